@@ -62,6 +62,9 @@ var (
 	protojsonPackage     goImportPath = protogen.GoImportPath("google.golang.org/protobuf/encoding/protojson")
 	protoreflectPackage  goImportPath = protogen.GoImportPath("google.golang.org/protobuf/reflect/protoreflect")
 	protoregistryPackage goImportPath = protogen.GoImportPath("google.golang.org/protobuf/reflect/protoregistry")
+
+	// fieldmaskPackage is only used by the well-known types within this module.
+	fieldmaskPackage goImportPath = protogen.GoImportPath("google.golang.org/protobuf/internal/fieldmask")
 )
 
 type goImportPath interface {
@@ -249,6 +252,13 @@ func genEnum(g *protogen.GeneratedFile, f *fileInfo, e *enumInfo) {
 			value.GoIdent, " ", e.GoIdent, " = ", value.Desc.Number(),
 			trailingComment(value.Comments.Trailing))
 	}
+	for _, value := range e.Values {
+		if value.PrefixedAlias.GoName != "" {
+			g.AnnotateSymbol(value.PrefixedAlias.GoName, protogen.Annotation{Location: value.Location})
+			g.P("// ", value.PrefixedAlias.GoName, " is an alias for ", value.GoIdent.GoName, ".")
+			g.P(value.PrefixedAlias, " ", e.GoIdent, " = ", value.GoIdent)
+		}
+	}
 	g.P(")")
 	g.P()
 
@@ -326,6 +326,19 @@ func genPackageKnownComment(f *fileInfo) protogen.Comments {
  IsValid needs to be passed the target message type as an input since the
  FieldMask message itself does not store the message type that the set of paths
  are for.
+
+
+ Applying a FieldMask
+
+ The Filter and Prune methods respectively keep or clear the fields of a
+ message that are selected by a FieldMask, while the Merge method updates
+ the selected fields of a message from another message:
+
+	var dst, src *descriptorpb.DescriptorProto
+	fm.Merge(dst, src) // updates the name and number of every field and the options
+
+ The Subtract function computes the paths of a FieldMask
+ that are not selected by other field masks.
 `
 	default:
 		return ""
@@ -1050,6 +1063,340 @@ func genMessageKnownFunctions(g *protogen.GeneratedFile, f *fileInfo, m *message
 		g.P("}")
 		g.P()
 
+		g.P("// Filter clears all fields of m that are not selected by the mask.")
+		g.P("// A path that traverses a repeated message field applies to every element")
+		g.P("// of the list and a path that traverses a map field applies to the entry")
+		g.P("// with the given key, which is formatted as in the text format and quoted")
+		g.P("// in backticks if it contains a dot or if it is the wildcard itself.")
+		g.P("// An extension field is named by its full name in parentheses.")
+		g.P("// The wildcard \"*\" selects every field or map entry at its position,")
+		g.P("// including those that other paths also traverse.")
+		g.P("// Unknown fields are cleared unless the mask contains the wildcard path.")
+		g.P("func (x *FieldMask) Filter(m ", protoPackage.Ident("Message"), ") {")
+		g.P("	filterMessage(m.ProtoReflect(), ", fieldmaskPackage.Ident("Parse"), "(x.GetPaths()))")
+		g.P("}")
+		g.P()
+
+		g.P("func filterMessage(m ", protoreflectPackage.Ident("Message"), ", t *", fieldmaskPackage.Ident("Mask"), ") {")
+		g.P("	m.Range(func(fd ", protoreflectPackage.Ident("FieldDescriptor"), ", v ", protoreflectPackage.Ident("Value"), ") bool {")
+		g.P("		switch sub, ok := t.Field(fd); {")
+		g.P("		case !ok:")
+		g.P("			m.Clear(fd)")
+		g.P("		case sub != nil:")
+		g.P("			filterValue(fd, v, sub)")
+		g.P("		}")
+		g.P("		return true")
+		g.P("	})")
+		g.P("	if sub, ok := t.Get(", fieldmaskPackage.Ident("Name"), "{Text: ", fieldmaskPackage.Ident("Wildcard"), ", IsWildcard: true}); (!ok || sub != nil) && len(m.GetUnknown()) > 0 {")
+		g.P("		m.SetUnknown(nil)")
+		g.P("	}")
+		g.P("}")
+		g.P()
+
+		g.P("func filterValue(fd ", protoreflectPackage.Ident("FieldDescriptor"), ", v ", protoreflectPackage.Ident("Value"), ", t *", fieldmaskPackage.Ident("Mask"), ") {")
+		g.P("	switch {")
+		g.P("	case fd.IsMap():")
+		g.P("		mm := v.Map()")
+		g.P("		mm.Range(func(k ", protoreflectPackage.Ident("MapKey"), ", v ", protoreflectPackage.Ident("Value"), ") bool {")
+		g.P("			switch sub, ok := t.MapKey(k); {")
+		g.P("			case !ok:")
+		g.P("				mm.Clear(k)")
+		g.P("			case sub != nil && fd.MapValue().Message() != nil:")
+		g.P("				filterMessage(v.Message(), sub)")
+		g.P("			}")
+		g.P("			return true")
+		g.P("		})")
+		g.P("	case fd.IsList():")
+		g.P("		if fd.Message() != nil {")
+		g.P("			l := v.List()")
+		g.P("			for i := 0; i < l.Len(); i++ {")
+		g.P("				filterMessage(l.Get(i).Message(), t)")
+		g.P("			}")
+		g.P("		}")
+		g.P("	case fd.Message() != nil:")
+		g.P("		filterMessage(v.Message(), t)")
+		g.P("	}")
+		g.P("}")
+		g.P()
+
+		g.P("// Prune clears all fields of m that are selected by the mask.")
+		g.P("// It is the complement of Filter and interprets the paths in the same way.")
+		g.P("func (x *FieldMask) Prune(m ", protoPackage.Ident("Message"), ") {")
+		g.P("	pruneMessage(m.ProtoReflect(), ", fieldmaskPackage.Ident("Parse"), "(x.GetPaths()))")
+		g.P("}")
+		g.P()
+
+		g.P("func pruneMessage(m ", protoreflectPackage.Ident("Message"), ", t *", fieldmaskPackage.Ident("Mask"), ") {")
+		g.P("	m.Range(func(fd ", protoreflectPackage.Ident("FieldDescriptor"), ", v ", protoreflectPackage.Ident("Value"), ") bool {")
+		g.P("		switch sub, ok := t.Field(fd); {")
+		g.P("		case ok && sub == nil:")
+		g.P("			m.Clear(fd)")
+		g.P("		case ok:")
+		g.P("			pruneValue(fd, v, sub)")
+		g.P("		}")
+		g.P("		return true")
+		g.P("	})")
+		g.P("}")
+		g.P()
+
+		g.P("func pruneValue(fd ", protoreflectPackage.Ident("FieldDescriptor"), ", v ", protoreflectPackage.Ident("Value"), ", t *", fieldmaskPackage.Ident("Mask"), ") {")
+		g.P("	switch {")
+		g.P("	case fd.IsMap():")
+		g.P("		mm := v.Map()")
+		g.P("		mm.Range(func(k ", protoreflectPackage.Ident("MapKey"), ", v ", protoreflectPackage.Ident("Value"), ") bool {")
+		g.P("			switch sub, ok := t.MapKey(k); {")
+		g.P("			case ok && sub == nil:")
+		g.P("				mm.Clear(k)")
+		g.P("			case ok && fd.MapValue().Message() != nil:")
+		g.P("				pruneMessage(v.Message(), sub)")
+		g.P("			}")
+		g.P("			return true")
+		g.P("		})")
+		g.P("	case fd.IsList():")
+		g.P("		if fd.Message() != nil {")
+		g.P("			l := v.List()")
+		g.P("			for i := 0; i < l.Len(); i++ {")
+		g.P("				pruneMessage(l.Get(i).Message(), t)")
+		g.P("			}")
+		g.P("		}")
+		g.P("	case fd.Message() != nil:")
+		g.P("		pruneMessage(v.Message(), t)")
+		g.P("	}")
+		g.P("}")
+		g.P()
+
+		g.P("// Merge merges the fields of src that are selected by the mask into dst")
+		g.P("// following the update semantics of https://google.aip.dev/134.")
+		g.P("//")
+		g.P("// Every selected field in dst is replaced by a copy of its value in src,")
+		g.P("// such that a selected field that is not populated in src is cleared in dst.")
+		g.P("// In particular, repeated and map fields are replaced as a whole")
+		g.P("// rather than appended to or merged.")
+		g.P("// A path that traverses a singular message field updates only the selected")
+		g.P("// fields of the message in dst.")
+		g.P("// A path that traverses a map field replaces or updates the entry with the")
+		g.P("// given key, which is deleted from dst if it is not present in src.")
+		g.P("// A path that traverses a repeated message field resizes the list in dst to")
+		g.P("// the length of the list in src and updates the selected fields of every")
+		g.P("// element. The wildcard path \"*\" replaces every field of dst.")
+		g.P("//")
+		g.P("// It panics if dst and src do not have the same message descriptor.")
+		g.P("func (x *FieldMask) Merge(dst, src ", protoPackage.Ident("Message"), ") {")
+		g.P("	dstMsg, srcMsg := dst.ProtoReflect(), src.ProtoReflect()")
+		g.P("	if dstMsg.Descriptor().FullName() != srcMsg.Descriptor().FullName() {")
+		g.P("		panic(\"descriptor mismatch\")")
+		g.P("	}")
+		g.P("	mergeMessage(dstMsg, srcMsg, ", fieldmaskPackage.Ident("Parse"), "(x.GetPaths()))")
+		g.P("}")
+		g.P()
+
+		g.P("func mergeMessage(dst, src ", protoreflectPackage.Ident("Message"), ", t *", fieldmaskPackage.Ident("Mask"), ") {")
+		g.P("	fds := dst.Descriptor().Fields()")
+		g.P("	for i := 0; i < fds.Len(); i++ {")
+		g.P("		mergeField(dst, src, fds.Get(i), t)")
+		g.P("	}")
+		g.P()
+		g.P("	// Extension fields are not declared by the message descriptor,")
+		g.P("	// so merge those that are populated in either message.")
+		g.P("	xds := make(map[", protoreflectPackage.Ident("FieldNumber"), "]", protoreflectPackage.Ident("FieldDescriptor"), ")")
+		g.P("	addExtension := func(fd ", protoreflectPackage.Ident("FieldDescriptor"), ", _ ", protoreflectPackage.Ident("Value"), ") bool {")
+		g.P("		if fd.IsExtension() {")
+		g.P("			xds[fd.Number()] = fd")
+		g.P("		}")
+		g.P("		return true")
+		g.P("	}")
+		g.P("	dst.Range(addExtension)")
+		g.P("	src.Range(addExtension)")
+		g.P("	for _, xd := range xds {")
+		g.P("		mergeField(dst, src, xd, t)")
+		g.P("	}")
+		g.P("}")
+		g.P()
+
+		g.P("func mergeField(dst, src ", protoreflectPackage.Ident("Message"), ", fd ", protoreflectPackage.Ident("FieldDescriptor"), ", t *", fieldmaskPackage.Ident("Mask"), ") {")
+		g.P("	sub, ok := t.Field(fd)")
+		g.P("	switch {")
+		g.P("	case !ok:")
+		g.P("	case sub == nil || (!fd.IsMap() && fd.Message() == nil):")
+		g.P("		replaceField(dst, src, fd)")
+		g.P("	case fd.IsMap():")
+		g.P("		if !dst.Has(fd) && !src.Has(fd) {")
+		g.P("			return")
+		g.P("		}")
+		g.P("		dm, sm := dst.Mutable(fd).Map(), src.Get(fd).Map()")
+		g.P("		isMessage := fd.MapValue().Message() != nil")
+		g.P("		dm.Range(func(k ", protoreflectPackage.Ident("MapKey"), ", _ ", protoreflectPackage.Ident("Value"), ") bool {")
+		g.P("			switch sub, ok := sub.MapKey(k); {")
+		g.P("			case !ok || sm.Has(k):")
+		g.P("			case sub == nil || !isMessage:")
+		g.P("				dm.Clear(k)")
+		g.P("			default:")
+		g.P("				mergeMessage(dm.Mutable(k).Message(), dm.NewValue().Message(), sub)")
+		g.P("			}")
+		g.P("			return true")
+		g.P("		})")
+		g.P("		sm.Range(func(k ", protoreflectPackage.Ident("MapKey"), ", v ", protoreflectPackage.Ident("Value"), ") bool {")
+		g.P("			switch sub, ok := sub.MapKey(k); {")
+		g.P("			case !ok:")
+		g.P("			case sub == nil || !isMessage:")
+		g.P("				dm.Set(k, cloneValue(dm.NewValue, fd.MapValue(), v))")
+		g.P("			default:")
+		g.P("				mergeMessage(dm.Mutable(k).Message(), v.Message(), sub)")
+		g.P("			}")
+		g.P("			return true")
+		g.P("		})")
+		g.P("		if dm.Len() == 0 {")
+		g.P("			dst.Clear(fd)")
+		g.P("		}")
+		g.P("	case fd.IsList():")
+		g.P("		sl := src.Get(fd).List()")
+		g.P("		if sl.Len() == 0 {")
+		g.P("			dst.Clear(fd)")
+		g.P("			return")
+		g.P("		}")
+		g.P("		dl := dst.Mutable(fd).List()")
+		g.P("		if dl.Len() > sl.Len() {")
+		g.P("			dl.Truncate(sl.Len())")
+		g.P("		}")
+		g.P("		for dl.Len() < sl.Len() {")
+		g.P("			dl.AppendMutable()")
+		g.P("		}")
+		g.P("		for i := 0; i < sl.Len(); i++ {")
+		g.P("			mergeMessage(dl.Get(i).Message(), sl.Get(i).Message(), sub)")
+		g.P("		}")
+		g.P("	case dst.Has(fd) || src.Has(fd):")
+		g.P("		mergeMessage(dst.Mutable(fd).Message(), src.Get(fd).Message(), sub)")
+		g.P("	}")
+		g.P("}")
+		g.P()
+
+		g.P("// replaceField replaces the value of the field fd in dst with a copy of")
+		g.P("// its value in src.")
+		g.P("func replaceField(dst, src ", protoreflectPackage.Ident("Message"), ", fd ", protoreflectPackage.Ident("FieldDescriptor"), ") {")
+		g.P("	dst.Clear(fd)")
+		g.P("	if !src.Has(fd) {")
+		g.P("		return")
+		g.P("	}")
+		g.P("	switch v := src.Get(fd); {")
+		g.P("	case fd.IsMap():")
+		g.P("		dm := dst.Mutable(fd).Map()")
+		g.P("		v.Map().Range(func(k ", protoreflectPackage.Ident("MapKey"), ", v ", protoreflectPackage.Ident("Value"), ") bool {")
+		g.P("			dm.Set(k, cloneValue(dm.NewValue, fd.MapValue(), v))")
+		g.P("			return true")
+		g.P("		})")
+		g.P("	case fd.IsList():")
+		g.P("		dl, sl := dst.Mutable(fd).List(), v.List()")
+		g.P("		for i := 0; i < sl.Len(); i++ {")
+		g.P("			dl.Append(cloneValue(dl.NewElement, fd, sl.Get(i)))")
+		g.P("		}")
+		g.P("	default:")
+		g.P("		dst.Set(fd, cloneValue(func() ", protoreflectPackage.Ident("Value"), " { return dst.NewField(fd) }, fd, v))")
+		g.P("	}")
+		g.P("}")
+		g.P()
+
+		g.P("// cloneValue returns a copy of the value v of the field fd, where newValue")
+		g.P("// returns an empty value to copy a message into.")
+		g.P("func cloneValue(newValue func() ", protoreflectPackage.Ident("Value"), ", fd ", protoreflectPackage.Ident("FieldDescriptor"), ", v ", protoreflectPackage.Ident("Value"), ") ", protoreflectPackage.Ident("Value"), " {")
+		g.P("	switch {")
+		g.P("	case fd.Message() != nil:")
+		g.P("		nv := newValue()")
+		g.P("		", protoPackage.Ident("Merge"), "(nv.Message().Interface(), v.Message().Interface())")
+		g.P("		return nv")
+		g.P("	case fd.Kind() == ", protoreflectPackage.Ident("BytesKind"), ":")
+		g.P("		return ", protoreflectPackage.Ident("ValueOfBytes"), "(append([]byte{}, v.Bytes()...))")
+		g.P("	default:")
+		g.P("		return v")
+		g.P("	}")
+		g.P("}")
+		g.P()
+
+		g.P("// Subtract returns the paths of mx that are not selected by any of the other")
+		g.P("// input field masks. A path of mx that is only partially subtracted is kept")
+		g.P("// as is; use SubtractOptions to expand it into the remaining fields.")
+		g.P("func Subtract(mx *FieldMask, my *FieldMask, ms ...*FieldMask) *FieldMask {")
+		g.P("	return SubtractOptions{}.Subtract(mx, my, ms...)")
+		g.P("}")
+		g.P()
+
+		g.P("// SubtractOptions configures the subtraction of field masks.")
+		g.P("type SubtractOptions struct {")
+		g.P("	// Message is a message of the type the field masks are for.")
+		g.P("	// If set, a path of mx that is only partially subtracted is expanded")
+		g.P("	// into the fields of its message that remain, provided that the path")
+		g.P("	// refers to a singular message field of Message.")
+		g.P("	Message ", protoPackage.Ident("Message"))
+		g.P("}")
+		g.P()
+
+		g.P("// Subtract returns the paths of mx that are not selected by any of the other")
+		g.P("// input field masks.")
+		g.P("func (o SubtractOptions) Subtract(mx *FieldMask, my *FieldMask, ms ...*FieldMask) *FieldMask {")
+		g.P("	var paths []string")
+		g.P("	paths = append(paths, my.GetPaths()...)")
+		g.P("	for _, m := range ms {")
+		g.P("		paths = append(paths, m.GetPaths()...)")
+		g.P("	}")
+		g.P("	t := ", fieldmaskPackage.Ident("Parse"), "(paths)")
+		g.P()
+		g.P("	var md0 ", protoreflectPackage.Ident("MessageDescriptor"))
+		g.P("	if o.Message != nil {")
+		g.P("		md0 = o.Message.ProtoReflect().Descriptor()")
+		g.P("	}")
+		g.P("	var out []string")
+		g.P("	for _, path := range mx.GetPaths() {")
+		g.P("		md, st, keep := md0, t, false")
+		g.P("		for _, name := range ", fieldmaskPackage.Ident("SplitPath"), "(path) {")
+		g.P("			var fd ", protoreflectPackage.Ident("FieldDescriptor"))
+		g.P("			if md != nil && !name.IsWildcard {")
+		g.P("				fd = md.Fields().ByTextName(name.Text)")
+		g.P("			}")
+		g.P("			md = nil")
+		g.P("			if fd != nil && !fd.IsList() && !fd.IsMap() {")
+		g.P("				md = fd.Message()")
+		g.P("			}")
+		g.P("			var ok bool")
+		g.P("			st, ok = st.Get(name)")
+		g.P("			if keep = !ok; keep || st == nil {")
+		g.P("				break")
+		g.P("			}")
+		g.P("		}")
+		g.P("		switch {")
+		g.P("		case keep:")
+		g.P("			out = append(out, path)")
+		g.P("		case st == nil:")
+		g.P("			// The path is entirely subtracted.")
+		g.P("		case md == nil:")
+		g.P("			out = append(out, path)")
+		g.P("		default:")
+		g.P("			out = subtractFields(out, path, md, st)")
+		g.P("		}")
+		g.P("	}")
+		g.P("	return &FieldMask{Paths: normalizePaths(out)}")
+		g.P("}")
+		g.P()
+
+		g.P("// subtractFields appends the paths of the fields of md that are not entirely")
+		g.P("// selected by t, where prefix is the path of the message.")
+		g.P("func subtractFields(out []string, prefix string, md ", protoreflectPackage.Ident("MessageDescriptor"), ", t *", fieldmaskPackage.Ident("Mask"), ") []string {")
+		g.P("	fds := md.Fields()")
+		g.P("	for i := 0; i < fds.Len(); i++ {")
+		g.P("		fd := fds.Get(i)")
+		g.P("		path := prefix + \".\" + fd.TextName()")
+		g.P("		switch sub, ok := t.Field(fd); {")
+		g.P("		case !ok:")
+		g.P("			out = append(out, path)")
+		g.P("		case sub == nil:")
+		g.P("		case fd.IsList() || fd.IsMap() || fd.Message() == nil:")
+		g.P("			out = append(out, path)")
+		g.P("		default:")
+		g.P("			out = subtractFields(out, path, fd.Message(), sub)")
+		g.P("		}")
+		g.P("	}")
+		g.P("	return out")
+		g.P("}")
+		g.P()
+
 	case genid.BoolValue_message_fullname,
 		genid.Int32Value_message_fullname,
 		genid.Int64Value_message_fullname,
@@ -0,0 +1,472 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: cmd/protoc-gen-go/testdata/protoeditions/go_names.proto
+
+package protoeditions
+
+import (
+	_ "google.golang.org/protobuf/internal/gonamespb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	_ "google.golang.org/protobuf/types/gofeaturespb"
+	reflect "reflect"
+	sync "sync"
+)
+
+type StrippedColor int32
+
+const (
+	StrippedColor_UNSPECIFIED StrippedColor = 0
+	StrippedColor_RED         StrippedColor = 1
+	// Values without the prefix keep their names.
+	StrippedColor_BLUE StrippedColor = 2
+)
+
+// Enum value maps for StrippedColor.
+var (
+	StrippedColor_name = map[int32]string{
+		0: "STRIPPED_COLOR_UNSPECIFIED",
+		1: "STRIPPED_COLOR_RED",
+		2: "BLUE",
+	}
+	StrippedColor_value = map[string]int32{
+		"STRIPPED_COLOR_UNSPECIFIED": 0,
+		"STRIPPED_COLOR_RED":         1,
+		"BLUE":                       2,
+	}
+)
+
+func (x StrippedColor) Enum() *StrippedColor {
+	p := new(StrippedColor)
+	*p = x
+	return p
+}
+
+func (x StrippedColor) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StrippedColor) Descriptor() protoreflect.EnumDescriptor {
+	return file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_enumTypes[0].Descriptor()
+}
+
+func (StrippedColor) Type() protoreflect.EnumType {
+	return &file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_enumTypes[0]
+}
+
+func (x StrippedColor) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StrippedColor.Descriptor instead.
+func (StrippedColor) EnumDescriptor() ([]byte, []int) {
+	return file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDescGZIP(), []int{0}
+}
+
+type RenamedShape int32
+
+const (
+	RenamedShape_UNSPECIFIED          RenamedShape = 0
+	RenamedShape_CIRCLE               RenamedShape = 1
+	RenamedShape_RENAMED_SHAPE_SQUARE RenamedShape = 2
+	// RenamedShape_RENAMED_SHAPE_UNSPECIFIED is an alias for RenamedShape_UNSPECIFIED.
+	RenamedShape_RENAMED_SHAPE_UNSPECIFIED RenamedShape = RenamedShape_UNSPECIFIED
+	// RenamedShape_RENAMED_SHAPE_CIRCLE is an alias for RenamedShape_CIRCLE.
+	RenamedShape_RENAMED_SHAPE_CIRCLE RenamedShape = RenamedShape_CIRCLE
+)
+
+// Enum value maps for RenamedShape.
+var (
+	RenamedShape_name = map[int32]string{
+		0: "RENAMED_SHAPE_UNSPECIFIED",
+		1: "RENAMED_SHAPE_CIRCLE",
+		2: "RENAMED_SHAPE_SQUARE",
+	}
+	RenamedShape_value = map[string]int32{
+		"RENAMED_SHAPE_UNSPECIFIED": 0,
+		"RENAMED_SHAPE_CIRCLE":      1,
+		"RENAMED_SHAPE_SQUARE":      2,
+	}
+)
+
+func (x RenamedShape) Enum() *RenamedShape {
+	p := new(RenamedShape)
+	*p = x
+	return p
+}
+
+func (x RenamedShape) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RenamedShape) Descriptor() protoreflect.EnumDescriptor {
+	return file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_enumTypes[1].Descriptor()
+}
+
+func (RenamedShape) Type() protoreflect.EnumType {
+	return &file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_enumTypes[1]
+}
+
+func (x RenamedShape) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RenamedShape.Descriptor instead.
+func (RenamedShape) EnumDescriptor() ([]byte, []int) {
+	return file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDescGZIP(), []int{1}
+}
+
+type GoNames_Kind int32
+
+const (
+	GoNames_UNSPECIFIED GoNames_Kind = 0
+	GoNames_SIMPLE      GoNames_Kind = 1
+)
+
+// Enum value maps for GoNames_Kind.
+var (
+	GoNames_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_SIMPLE",
+	}
+	GoNames_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"KIND_SIMPLE":      1,
+	}
+)
+
+func (x GoNames_Kind) Enum() *GoNames_Kind {
+	p := new(GoNames_Kind)
+	*p = x
+	return p
+}
+
+func (x GoNames_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GoNames_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_enumTypes[2].Descriptor()
+}
+
+func (GoNames_Kind) Type() protoreflect.EnumType {
+	return &file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_enumTypes[2]
+}
+
+func (x GoNames_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GoNames_Kind.Descriptor instead.
+func (GoNames_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type GoNames struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	URL *string `protobuf:"bytes,1,opt,name=url" json:"url,omitempty"`
+	// Fields without a Go name option are renamed to avoid the names set with it.
+	URL_   *string         `protobuf:"bytes,2,opt,name=u_r_l,json=uRL" json:"u_r_l,omitempty"`
+	Kind   *GoNames_Kind   `protobuf:"varint,3,opt,name=kind,enum=goproto.protoc.protoeditions.GoNamesMessage_Kind" json:"kind,omitempty"`
+	Nested *GoNames_Nested `protobuf:"bytes,4,opt,name=nested" json:"nested,omitempty"`
+	// Types that are assignable to Choice:
+	//
+	//	*GoNames_UserID
+	//	*GoNames_Name
+	Choice isGoNames_Choice `protobuf_oneof:"choice"`
+}
+
+func (x *GoNames) Reset() {
+	*x = GoNames{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GoNames) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoNames) ProtoMessage() {}
+
+func (x *GoNames) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoNames.ProtoReflect.Descriptor instead.
+func (*GoNames) Descriptor() ([]byte, []int) {
+	return file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GoNames) GetURL() string {
+	if x != nil && x.URL != nil {
+		return *x.URL
+	}
+	return ""
+}
+
+func (x *GoNames) GetURL_() string {
+	if x != nil && x.URL_ != nil {
+		return *x.URL_
+	}
+	return ""
+}
+
+func (x *GoNames) GetKind() GoNames_Kind {
+	if x != nil && x.Kind != nil {
+		return *x.Kind
+	}
+	return GoNames_UNSPECIFIED
+}
+
+func (x *GoNames) GetNested() *GoNames_Nested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (m *GoNames) GetChoice() isGoNames_Choice {
+	if m != nil {
+		return m.Choice
+	}
+	return nil
+}
+
+func (x *GoNames) GetUserID() int64 {
+	if x, ok := x.GetChoice().(*GoNames_UserID); ok {
+		return x.UserID
+	}
+	return 0
+}
+
+func (x *GoNames) GetName() string {
+	if x, ok := x.GetChoice().(*GoNames_Name); ok {
+		return x.Name
+	}
+	return ""
+}
+
+type isGoNames_Choice interface {
+	isGoNames_Choice()
+}
+
+type GoNames_UserID struct {
+	UserID int64 `protobuf:"varint,5,opt,name=user_id,json=userId,oneof"`
+}
+
+type GoNames_Name struct {
+	Name string `protobuf:"bytes,6,opt,name=name,oneof"`
+}
+
+func (*GoNames_UserID) isGoNames_Choice() {}
+
+func (*GoNames_Name) isGoNames_Choice() {}
+
+type GoNames_Nested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID *int32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (x *GoNames_Nested) Reset() {
+	*x = GoNames_Nested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GoNames_Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoNames_Nested) ProtoMessage() {}
+
+func (x *GoNames_Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoNames_Nested.ProtoReflect.Descriptor instead.
+func (*GoNames_Nested) Descriptor() ([]byte, []int) {
+	return file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *GoNames_Nested) GetID() int32 {
+	if x != nil && x.ID != nil {
+		return *x.ID
+	}
+	return 0
+}
+
+var File_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto protoreflect.FileDescriptor
+
+var file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDesc = []byte{
+	0x0a, 0x37, 0x63, 0x6d, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e,
+	0x2d, 0x67, 0x6f, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x67, 0x6f, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1c, 0x67, 0x6f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x21, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x67, 0x6f, 0x5f, 0x66, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x21, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x62, 0x2f, 0x67,
+	0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x81, 0x03,
+	0x0a, 0x0e, 0x47, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x19, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0x82,
+	0xb5, 0x18, 0x03, 0x55, 0x52, 0x4c, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x12, 0x0a, 0x05, 0x75,
+	0x5f, 0x72, 0x5f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x52, 0x4c, 0x12,
+	0x45, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e,
+	0x67, 0x6f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x47, 0x6f, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x4b, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x47, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x06, 0x6e, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x42, 0x0a, 0x82, 0xb5, 0x18, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44,
+	0x48, 0x00, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x1a, 0x20, 0x0a, 0x06, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x06, 0x82, 0xb5, 0x18, 0x02, 0x49, 0x44, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x36, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x10, 0x4b, 0x49,
+	0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x0f, 0x0a, 0x0b, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x53, 0x49, 0x4d, 0x50, 0x4c, 0x45, 0x10,
+	0x01, 0x1a, 0x07, 0x3a, 0x05, 0xd2, 0x3e, 0x02, 0x10, 0x03, 0x3a, 0x0b, 0x82, 0xb5, 0x18, 0x07,
+	0x47, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x42, 0x08, 0x0a, 0x06, 0x63, 0x68, 0x6f, 0x69, 0x63,
+	0x65, 0x2a, 0x5a, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x69, 0x70, 0x70, 0x65, 0x64, 0x43, 0x6f, 0x6c,
+	0x6f, 0x72, 0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x54, 0x52, 0x49, 0x50, 0x50, 0x45, 0x44, 0x5f, 0x43,
+	0x4f, 0x4c, 0x4f, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x54, 0x52, 0x49, 0x50, 0x50, 0x45, 0x44, 0x5f, 0x43,
+	0x4f, 0x4c, 0x4f, 0x52, 0x5f, 0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4c,
+	0x55, 0x45, 0x10, 0x02, 0x1a, 0x07, 0x3a, 0x05, 0xd2, 0x3e, 0x02, 0x10, 0x03, 0x2a, 0x73, 0x0a,
+	0x0c, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x53, 0x68, 0x61, 0x70, 0x65, 0x12, 0x1d, 0x0a,
+	0x19, 0x52, 0x45, 0x4e, 0x41, 0x4d, 0x45, 0x44, 0x5f, 0x53, 0x48, 0x41, 0x50, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14,
+	0x52, 0x45, 0x4e, 0x41, 0x4d, 0x45, 0x44, 0x5f, 0x53, 0x48, 0x41, 0x50, 0x45, 0x5f, 0x43, 0x49,
+	0x52, 0x43, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x14, 0x52, 0x45, 0x4e, 0x41, 0x4d, 0x45,
+	0x44, 0x5f, 0x53, 0x48, 0x41, 0x50, 0x45, 0x5f, 0x53, 0x51, 0x55, 0x41, 0x52, 0x45, 0x10, 0x02,
+	0x1a, 0x07, 0x12, 0x05, 0xd2, 0x3e, 0x02, 0x10, 0x01, 0x1a, 0x07, 0x3a, 0x05, 0xd2, 0x3e, 0x02,
+	0x10, 0x02, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x67, 0x6f, 0x6c,
+	0x61, 0x6e, 0x67, 0x2e, 0x6f, 0x72, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x63, 0x6d, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d,
+	0x67, 0x6f, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x62, 0x08, 0x65, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x70, 0xe9, 0x07,
+}
+
+var (
+	file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDescOnce sync.Once
+	file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDescData = file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDesc
+)
+
+func file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDescGZIP() []byte {
+	file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDescOnce.Do(func() {
+		file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDescData = protoimpl.X.CompressGZIP(file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDescData)
+	})
+	return file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDescData
+}
+
+var file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_goTypes = []any{
+	(StrippedColor)(0),     // 0: goproto.protoc.protoeditions.StrippedColor
+	(RenamedShape)(0),      // 1: goproto.protoc.protoeditions.RenamedShape
+	(GoNames_Kind)(0),      // 2: goproto.protoc.protoeditions.GoNamesMessage.Kind
+	(*GoNames)(nil),        // 3: goproto.protoc.protoeditions.GoNamesMessage
+	(*GoNames_Nested)(nil), // 4: goproto.protoc.protoeditions.GoNamesMessage.Nested
+}
+var file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_depIdxs = []int32{
+	2, // 0: goproto.protoc.protoeditions.GoNamesMessage.kind:type_name -> goproto.protoc.protoeditions.GoNamesMessage.Kind
+	4, // 1: goproto.protoc.protoeditions.GoNamesMessage.nested:type_name -> goproto.protoc.protoeditions.GoNamesMessage.Nested
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_init() }
+func file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_init() {
+	if File_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GoNames); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*GoNames_Nested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_msgTypes[0].OneofWrappers = []any{
+		(*GoNames_UserID)(nil),
+		(*GoNames_Name)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_goTypes,
+		DependencyIndexes: file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_depIdxs,
+		EnumInfos:         file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_enumTypes,
+		MessageInfos:      file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_msgTypes,
+	}.Build()
+	File_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto = out.File
+	file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_rawDesc = nil
+	file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_goTypes = nil
+	file_cmd_protoc_gen_go_testdata_protoeditions_go_names_proto_depIdxs = nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The protoc-gen-lint binary is a protoc plugin that checks .proto files
+// for style issues with the rules of the protolint package.
+// It does not generate any files; the findings are reported as an error:
+//
+//	protoc --lint_out=. foo.proto
+//
+// The rules to run are configured with parameters, where rule names are
+// separated by '+':
+//
+//	rules=a+b    run only the rules a and b
+//	disable=a+b  run the default rules except for a and b
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/internal/editionssupport"
+	"google.golang.org/protobuf/internal/version"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protolint"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "--version" {
+		fmt.Fprintf(os.Stdout, "%v %v\n", filepath.Base(os.Args[0]), version.String())
+		os.Exit(0)
+	}
+	if len(os.Args) > 1 {
+		fmt.Fprintf(os.Stderr, "%s: unknown argument %q (this program should be run by protoc, not directly)\n", filepath.Base(os.Args[0]), os.Args[1])
+		os.Exit(1)
+	}
+	if err := run(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", filepath.Base(os.Args[0]), err)
+		os.Exit(1)
+	}
+}
+
+// run reads a CodeGeneratorRequest from r and writes the response to w.
+func run(r io.Reader, w io.Writer) error {
+	in, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err := proto.Unmarshal(in, req); err != nil {
+		return err
+	}
+	out, err := proto.Marshal(lint(req))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// lint lints the files of the request.
+func lint(req *pluginpb.CodeGeneratorRequest) *pluginpb.CodeGeneratorResponse {
+	var cfg config
+	gen, err := protogen.Options{ParamFunc: cfg.set}.New(withImportPaths(req))
+	if err != nil {
+		return &pluginpb.CodeGeneratorResponse{Error: proto.String(err.Error())}
+	}
+	if err := cfg.lint(gen); err != nil {
+		gen.Error(err)
+	}
+	return gen.Response()
+}
+
+// withImportPaths returns a copy of req with an M parameter for every file
+// that has no go_package option. No Go code is generated, so files need not
+// have one, but protogen requires an import path for every file.
+func withImportPaths(req *pluginpb.CodeGeneratorRequest) *pluginpb.CodeGeneratorRequest {
+	req = proto.Clone(req).(*pluginpb.CodeGeneratorRequest)
+	params := []string{req.GetParameter()}
+	if params[0] == "" {
+		params = nil
+	}
+	for _, fd := range req.ProtoFile {
+		if fd.GetOptions().GetGoPackage() == "" {
+			params = append(params, "M"+fd.GetName()+"="+strings.TrimSuffix(fd.GetName(), ".proto"))
+		}
+	}
+	req.Parameter = proto.String(strings.Join(params, ","))
+	return req
+}
+
+// config is the configuration of the linter, as set by the parameters.
+type config struct {
+	enable  []string
+	disable []string
+}
+
+func (c *config) set(name, value string) error {
+	switch name {
+	case "rules":
+		c.enable = append(c.enable, strings.Split(value, "+")...)
+	case "disable":
+		c.disable = append(c.disable, strings.Split(value, "+")...)
+	default:
+		return fmt.Errorf("unknown parameter %q", name)
+	}
+	for _, name := range strings.Split(value, "+") {
+		if protolint.LookupRule(name) == nil {
+			return fmt.Errorf("unknown rule %q", name)
+		}
+	}
+	return nil
+}
+
+// rules returns the rules to run.
+func (c *config) rules() []*protolint.Rule {
+	var rules []*protolint.Rule
+	if len(c.enable) > 0 {
+		for _, name := range c.enable {
+			rules = append(rules, protolint.LookupRule(name))
+		}
+	} else {
+		rules = protolint.DefaultRules()
+	}
+	// The result is not nil even if all rules are disabled,
+	// since protolint.Options runs the default rules for nil.
+	filtered := []*protolint.Rule{}
+	for _, rule := range rules {
+		disabled := false
+		for _, name := range c.disable {
+			disabled = disabled || rule.Name == name
+		}
+		if !disabled {
+			filtered = append(filtered, rule)
+		}
+	}
+	return filtered
+}
+
+// lint lints the files to generate and returns an error that lists
+// the findings, one per line, if there are any.
+func (c *config) lint(gen *protogen.Plugin) error {
+	gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL | pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)
+	gen.SupportedEditionsMinimum = editionssupport.Minimum
+	gen.SupportedEditionsMaximum = editionssupport.Maximum
+
+	opts := protolint.Options{Rules: c.rules()}
+	var lines []string
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
+		}
+		for _, finding := range opts.Lint(f.Desc) {
+			lines = append(lines, finding.String())
+		}
+	}
+	if len(lines) > 0 {
+		return errors.New(strings.Join(lines, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"google.golang.org/protobuf/compiler/protoparse"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestLint(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a/a.proto": &fstest.MapFile{Data: []byte(`syntax = "proto3";
+package a;
+import "b/b.proto";
+option go_package = "example.com/a";
+// M is a message.
+message M { wrong.n n = 1; int32 Count = 2; }
+`)},
+		"b/b.proto": &fstest.MapFile{Data: []byte(`syntax = "proto3"; package wrong; message n {}`)},
+	}
+	fds, err := protoparse.Parser{FS: fsys}.Parse("a/a.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		param string
+		want  string
+	}{{
+		param: "",
+		want:  `a/a.proto:6:28: field name "Count" should be lower_snake_case (field_names)`,
+	}, {
+		param: "disable=field_names",
+		want:  "",
+	}, {
+		param: "rules=go_package+package",
+		want:  "",
+	}, {
+		param: "rules=field_names,disable=field_names",
+		want:  "",
+	}, {
+		param: "rules=nope",
+		want:  `unknown rule "nope"`,
+	}, {
+		param: "foo=bar",
+		want:  `unknown parameter "foo"`,
+	}}
+	for _, tt := range tests {
+		req := &pluginpb.CodeGeneratorRequest{
+			FileToGenerate: []string{"a/a.proto"},
+			Parameter:      proto.String(tt.param),
+			ProtoFile:      fds,
+		}
+		if got := lint(req).GetError(); got != tt.want {
+			t.Errorf("param %q: got error %q, want %q", tt.param, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The protogo binary generates Go code from .proto source files
+// without the need for protoc.
+//
+// It parses the source files with the compiler/protoparse package and runs the
+// protoc-gen-go code generator in-process. Its command-line flags are a subset
+// of those of protoc:
+//
+//	protogo [-I=PATH]... --go_out=[PARAMS:]DIR [--go_opt=PARAM]... FILE...
+//
+// The parameters accepted by --go_out and --go_opt are the same as those
+// accepted by protoc-gen-go, such as M, paths, and module. For more
+// information, see https://protobuf.dev/reference/go/go-generated.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gengo "google.golang.org/protobuf/cmd/protoc-gen-go/internal_gengo"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/compiler/protoparse"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/importfs"
+	"google.golang.org/protobuf/internal/version"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	var (
+		flags       = flag.NewFlagSet("protogo", flag.ContinueOnError)
+		importPaths importfs.StringList
+		goOpts      importfs.StringList
+		goOut       = flags.String("go_out", "", "generate Go source files in `DIR`, optionally preceded by `PARAMS:`")
+		showVersion = flags.Bool("version", false, "print the version and exit")
+	)
+	flags.Var(&importPaths, "I", "search for imports in `PATH`; may be specified multiple times")
+	flags.Var(&importPaths, "proto_path", "same as -I")
+	flags.Var(&goOpts, "go_opt", "pass `PARAM` to the Go code generator; may be specified multiple times")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: protogo [-I=PATH]... --go_out=[PARAMS:]DIR [--go_opt=PARAM]... FILE...\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if *showVersion {
+		fmt.Fprintf(stdout, "protogo %v\n", version.String())
+		return nil
+	}
+	if flags.NArg() == 0 {
+		return errors.New("Missing input file.")
+	}
+	if *goOut == "" {
+		return errors.New("Missing output directives.")
+	}
+	fsys := importfs.New(importPaths)
+
+	names := make([]string, flags.NArg())
+	for i, file := range flags.Args() {
+		name, err := fsys.VirtualName(file)
+		if err != nil {
+			return err
+		}
+		names[i] = name
+	}
+	fds, err := protoparse.Parser{FS: fsys}.Parse(names...)
+	if err != nil {
+		return err
+	}
+
+	outDir := *goOut
+	params := []string(goOpts)
+	if i := strings.LastIndex(outDir, ":"); i >= 0 && !isVolumeName(outDir[:i]) {
+		params = append([]string{outDir[:i]}, params...)
+		outDir = outDir[i+1:]
+	}
+	resp, err := generate(fds, names, strings.Join(params, ","))
+	if err != nil {
+		return fmt.Errorf("--go_out: %v", err)
+	}
+	for _, f := range resp.File {
+		if f.GetInsertionPoint() != "" {
+			return fmt.Errorf("--go_out: insertion points are not supported: %s", f.GetName())
+		}
+		path := filepath.Join(outDir, filepath.FromSlash(f.GetName()))
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(f.GetContent()), 0666); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generate runs the Go code generator on the named files, whose descriptors,
+// along with those of all their dependencies, are in fds.
+func generate(fds []*descriptorpb.FileDescriptorProto, names []string, param string) (*pluginpb.CodeGeneratorResponse, error) {
+	// As protoc does, pass the files to generate in ProtoFile with their
+	// source-retention options stripped, and unchanged in
+	// SourceFileDescriptors.
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: fds})
+	if err != nil {
+		return nil, err
+	}
+	types := dynamicpb.NewTypes(files)
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: names,
+	}
+	if param != "" {
+		req.Parameter = &param
+	}
+	for _, fd := range fds {
+		generated := false
+		for _, name := range names {
+			if fd.GetName() == name {
+				generated = true
+			}
+		}
+		if !generated {
+			req.ProtoFile = append(req.ProtoFile, fd)
+			continue
+		}
+		stripped := proto.Clone(fd).(*descriptorpb.FileDescriptorProto)
+		if err := stripSourceRetentionOptions(stripped.ProtoReflect(), types); err != nil {
+			return nil, fmt.Errorf("%s: %v", fd.GetName(), err)
+		}
+		req.ProtoFile = append(req.ProtoFile, stripped)
+		req.SourceFileDescriptors = append(req.SourceFileDescriptors, fd)
+	}
+	for _, fd := range req.SourceFileDescriptors {
+		if fd.GetSyntax() != "editions" {
+			continue
+		}
+		if e := fd.GetEdition(); e < gengo.SupportedEditionsMinimum || e > gengo.SupportedEditionsMaximum {
+			return nil, fmt.Errorf("%s: is a file using edition %s, which isn't supported by this code generator", fd.GetName(), strings.TrimPrefix(e.String(), "EDITION_"))
+		}
+	}
+
+	// The parameters that are not handled by protogen itself are set as
+	// flags, so that unknown parameters are reported as they are by
+	// protoc-gen-go.
+	var flags flag.FlagSet
+	flags.SetOutput(io.Discard)
+	gen, err := protogen.Options{
+		ParamFunc: flags.Set,
+	}.New(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range gen.Files {
+		if f.Generate {
+			gengo.GenerateFile(gen, f)
+		}
+	}
+	gen.SupportedFeatures = gengo.SupportedFeatures
+	gen.SupportedEditionsMinimum = gengo.SupportedEditionsMinimum
+	gen.SupportedEditionsMaximum = gengo.SupportedEditionsMaximum
+	resp := gen.Response()
+	if resp.Error != nil {
+		return nil, errors.New(resp.GetError())
+	}
+	return resp, nil
+}
+
+// stripSourceRetentionOptions clears the fields of m and its submessages
+// that have the field option [retention = RETENTION_SOURCE], including
+// custom options that r resolves from unknown fields. Messages that are
+// left empty by this are cleared as well, as protoc does.
+func stripSourceRetentionOptions(m protoreflect.Message, r protoregistry.ExtensionTypeResolver) error {
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if isSourceRetention(fd) {
+			m.Clear(fd)
+			return true
+		}
+		if fd.Message() == nil || fd.IsMap() {
+			return true
+		}
+		if fd.IsList() {
+			for i, l := 0, v.List(); i < l.Len() && err == nil; i++ {
+				err = stripSourceRetentionOptions(l.Get(i).Message(), r)
+			}
+			return err == nil
+		}
+		m2 := v.Message()
+		empty := proto.Size(m2.Interface()) == 0
+		if err = stripSourceRetentionOptions(m2, r); err != nil {
+			return false
+		}
+		if !empty && proto.Size(m2.Interface()) == 0 {
+			m.Clear(fd)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	// Custom options are unknown fields of the options messages.
+	var unknown protoreflect.RawFields
+	for b := m.GetUnknown(); len(b) > 0; {
+		num, _, n := protowire.ConsumeField(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		xt, err := r.FindExtensionByNumber(m.Descriptor().FullName(), num)
+		if err != nil || !isSourceRetention(xt.TypeDescriptor()) {
+			unknown = append(unknown, b[:n]...)
+		}
+		b = b[n:]
+	}
+	if len(unknown) != len(m.GetUnknown()) {
+		m.SetUnknown(unknown)
+	}
+	return nil
+}
+
+// isSourceRetention reports whether fd has the field option
+// [retention = RETENTION_SOURCE].
+func isSourceRetention(fd protoreflect.FieldDescriptor) bool {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	return ok && opts.GetRetention() == descriptorpb.FieldOptions_RETENTION_SOURCE
+}
+
+// isVolumeName reports whether s is a Windows volume name, such as "C",
+// which precedes a colon in an output directory that has no parameters.
+func isVolumeName(s string) bool {
+	return len(s) == 1 && filepath.VolumeName(s+":") != ""
+}
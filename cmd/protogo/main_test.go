@@ -0,0 +1,159 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	gengo "google.golang.org/protobuf/cmd/protoc-gen-go/internal_gengo"
+)
+
+// TestGolden checks that the generated code for the protoc-gen-go test data
+// matches the code that protoc and protoc-gen-go generated for it.
+func TestGolden(t *testing.T) {
+	defer func(v bool) { gengo.GenerateVersionMarkers = v }(gengo.GenerateVersionMarkers)
+	gengo.GenerateVersionMarkers = false
+
+	files := []string{
+		"cmd/protoc-gen-go/testdata/proto2/enum.proto",
+		"cmd/protoc-gen-go/testdata/proto2/nested_messages.proto",
+		"cmd/protoc-gen-go/testdata/proto3/fields.proto",
+		"cmd/protoc-gen-go/testdata/protoeditions/maps_and_delimited.proto",
+		"cmd/protoc-gen-go/testdata/extensions/proto3/ext3.proto",
+		"cmd/protoc-gen-go/testdata/comments/deprecated.proto",
+		"cmd/protoc-gen-go/testdata/import_public/a.proto",
+		"cmd/protoc-gen-go/testdata/retention/options_message.proto",
+		"internal/testprotos/lazy/lazy_extension_normalized_wire_test.proto",
+	}
+	out := t.TempDir()
+	args := []string{"-I", "../..", "--go_out=module=google.golang.org/protobuf:" + out}
+	for _, f := range files {
+		args = append(args, filepath.Join("../..", f))
+	}
+	if err := run(args, io.Discard); err != nil {
+		t.Fatalf("run() error: %v", err)
+	}
+	for _, f := range files {
+		name := strings.TrimSuffix(f, ".proto") + ".pb.go"
+		want, err := os.ReadFile(filepath.Join("../..", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(filepath.Join(out, name))
+		if err != nil {
+			t.Errorf("%s was not generated: %v", name, err)
+			continue
+		}
+		if diff := cmp.Diff(string(want), string(got)); diff != "" {
+			t.Errorf("%s: unexpected diff (-want +got):\n%s", name, diff)
+		}
+	}
+}
+
+func TestParams(t *testing.T) {
+	dir := t.TempDir()
+	src := `syntax = "proto3"; package p; message M {}`
+	if err := os.MkdirAll(filepath.Join(dir, "src", "a"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "a", "a.proto"), []byte(src), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc string
+		args []string
+		want string
+	}{{
+		desc: "M",
+		args: []string{"--go_opt=Ma/a.proto=example.com/x/a"},
+		want: "example.com/x/a/a.pb.go",
+	}, {
+		desc: "M with module",
+		args: []string{"--go_opt=Ma/a.proto=example.com/x/a", "--go_opt=module=example.com/x"},
+		want: "a/a.pb.go",
+	}, {
+		desc: "paths=source_relative",
+		args: []string{"--go_opt=Ma/a.proto=example.com/x/a;pkg", "--go_opt=paths=source_relative"},
+		want: "a/a.pb.go",
+	}, {
+		desc: "parameters in --go_out",
+		args: []string{"--go_opt=Ma/a.proto=example.com/x/a", "--go_out=paths=source_relative:OUT"},
+		want: "a/a.pb.go",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			out := t.TempDir()
+			args := []string{"-I", filepath.Join(dir, "src"), "--go_out=" + out}
+			for _, arg := range tt.args {
+				args = append(args, strings.Replace(arg, "OUT", out, 1))
+			}
+			args = append(args, filepath.Join(dir, "src", "a", "a.proto"))
+			if err := run(args, io.Discard); err != nil {
+				t.Fatalf("run() error: %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(out, filepath.FromSlash(tt.want))); err != nil {
+				t.Errorf("%s was not generated: %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.proto"), []byte(`syntax = "proto3"; message M {}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.proto"), []byte(`syntax = "proto3"; message M { int32 a = 1 }`), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc string
+		args []string
+		want string
+	}{{
+		desc: "missing output",
+		args: []string{"-I", dir, filepath.Join(dir, "a.proto")},
+		want: "Missing output directives.",
+	}, {
+		desc: "missing input",
+		args: []string{"-I", dir, "--go_out=" + dir},
+		want: "Missing input file.",
+	}, {
+		desc: "not in import path",
+		args: []string{"-I", filepath.Join(dir, "sub"), "--go_out=" + dir, filepath.Join(dir, "a.proto")},
+		want: "File does not reside within any path specified using --proto_path (or -I).",
+	}, {
+		desc: "syntax error",
+		args: []string{"-I", dir, "--go_out=" + dir, filepath.Join(dir, "b.proto")},
+		want: `b.proto:1:44: Expected ";".`,
+	}, {
+		desc: "missing go_package",
+		args: []string{"-I", dir, "--go_out=" + dir, filepath.Join(dir, "a.proto")},
+		want: `--go_out: unable to determine Go import path for "a.proto"`,
+	}, {
+		desc: "unknown parameter",
+		args: []string{"-I", dir, "--go_out=" + dir, "--go_opt=Ma.proto=example.com/a,foo=bar", filepath.Join(dir, "a.proto")},
+		want: "--go_out: no such flag -foo",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := run(tt.args, io.Discard)
+			if err == nil {
+				t.Fatalf("run() succeeded, want error containing %q", tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("run() error = %q, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
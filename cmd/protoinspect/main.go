@@ -0,0 +1,268 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The protoinspect binary converts protobuf messages between the binary wire
+// format, the size-delimited binary format, the JSON format and the text format.
+//
+//	protoinspect [-descriptor_set_in=FILES] [-in=FORMAT] [-out=FORMAT] TYPE [FILE]...
+//
+// The message type is looked up by its full name in the files of the
+// descriptor sets given by -descriptor_set_in, as produced by
+// "protoc --descriptor_set_out", or else among the types that are linked
+// into the binary, such as the well-known types.
+// The input is read from the files, which are concatenated, or from the
+// standard input if there are none, and the output is written to the
+// standard output. The formats are:
+//
+//	binary     a single message in the wire format
+//	delimited  a stream of size-delimited messages in the wire format
+//	json       a single message in the JSON format
+//	text       a single message in the text format
+//
+// A stream of more than one message can only be written in the delimited format.
+//
+// With -fields, only the given comma-separated field paths are kept,
+// as with a google.protobuf.FieldMask. With -raw, the input is decoded
+// in the wire format without a type, like "protoc --decode_raw".
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protoraw"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/internal/version"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "protoinspect: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// resolver resolves the types of messages and extensions.
+type resolver interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	var (
+		flags       = flag.NewFlagSet("protoinspect", flag.ContinueOnError)
+		descSets    = flags.String("descriptor_set_in", "", "load types from the FileDescriptorSets in `FILES`, separated by '"+string(os.PathListSeparator)+"'")
+		inFormat    = flags.String("in", "binary", "read the input in `FORMAT`: binary, delimited, json or text")
+		outFormat   = flags.String("out", "text", "write the output in `FORMAT`: binary, delimited, json or text")
+		fields      = flags.String("fields", "", "keep only the comma-separated field `PATHS`")
+		pretty      = flags.Bool("pretty", false, "write the JSON and text formats on multiple lines")
+		raw         = flags.Bool("raw", false, "decode binary input without a type")
+		showVersion = flags.Bool("version", false, "print the version and exit")
+	)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: protoinspect [-descriptor_set_in=FILES] [-in=FORMAT] [-out=FORMAT] TYPE [FILE]...\n")
+		fmt.Fprintf(flags.Output(), "       protoinspect -raw [FILE]...\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if *showVersion {
+		fmt.Fprintf(stdout, "protoinspect %v\n", version.String())
+		return nil
+	}
+	for _, f := range []string{*inFormat, *outFormat} {
+		switch f {
+		case "binary", "delimited", "json", "text":
+		default:
+			return fmt.Errorf("unknown format %q", f)
+		}
+	}
+
+	files := flags.Args()
+	if !*raw {
+		if len(files) == 0 {
+			return errors.New("missing message type")
+		}
+		files = files[1:]
+	}
+	in, err := readInput(files, stdin)
+	if err != nil {
+		return err
+	}
+	if *raw {
+		m, err := protoraw.Decode(in)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(stdout, protoraw.FormatOptions{Annotate: *pretty}.Format(m))
+		return err
+	}
+
+	var types resolver = protoregistry.GlobalTypes
+	if *descSets != "" {
+		files, err := loadDescriptorSets(strings.Split(*descSets, string(os.PathListSeparator)))
+		if err != nil {
+			return err
+		}
+		types = dynamicpb.NewTypes(files)
+	}
+	mt, err := types.FindMessageByName(protoreflect.FullName(flags.Arg(0)))
+	if err != nil {
+		return fmt.Errorf("message type %q: %v", flags.Arg(0), err)
+	}
+	var mask *fieldmaskpb.FieldMask
+	if *fields != "" {
+		mask, err = fieldmaskpb.New(mt.Zero().Interface(), strings.Split(*fields, ",")...)
+		if err != nil {
+			return fmt.Errorf("-fields: %v", err)
+		}
+	}
+
+	msgs, err := decode(in, *inFormat, mt, types)
+	if err != nil {
+		return err
+	}
+	if len(msgs) > 1 && *outFormat != "delimited" {
+		return fmt.Errorf("input has %d messages, but -out=%s holds a single message; use -out=delimited", len(msgs), *outFormat)
+	}
+	var out []byte
+	for _, m := range msgs {
+		if mask != nil {
+			mask.Filter(m)
+		}
+		if out, err = encode(out, m, *outFormat, *pretty, types); err != nil {
+			return err
+		}
+	}
+	_, err = stdout.Write(out)
+	return err
+}
+
+// readInput returns the concatenated contents of the files,
+// or the contents of stdin if there are no files.
+func readInput(files []string, stdin io.Reader) ([]byte, error) {
+	if len(files) == 0 {
+		return io.ReadAll(stdin)
+	}
+	var in []byte
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		in = append(in, b...)
+	}
+	return in, nil
+}
+
+// loadDescriptorSets returns the files of the FileDescriptorSets
+// that are stored in the named files in the wire format.
+func loadDescriptorSets(names []string) (*protoregistry.Files, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	for _, name := range names {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		s := &descriptorpb.FileDescriptorSet{}
+		if err := proto.Unmarshal(b, s); err != nil {
+			return nil, fmt.Errorf("%s: %v", filepath.Base(name), err)
+		}
+		for _, fd := range s.GetFile() {
+			// The same file may be in more than one set.
+			if !seen[fd.GetName()] {
+				seen[fd.GetName()] = true
+				set.File = append(set.File, fd)
+			}
+		}
+	}
+	return protodesc.NewFiles(set)
+}
+
+// decode decodes the messages of type mt in the input.
+func decode(in []byte, format string, mt protoreflect.MessageType, types resolver) ([]proto.Message, error) {
+	if format == "delimited" {
+		var msgs []proto.Message
+		r := bufio.NewReader(bytes.NewReader(in))
+		for {
+			m := mt.New().Interface()
+			err := protodelim.UnmarshalOptions{
+				UnmarshalOptions: proto.UnmarshalOptions{Resolver: types},
+				MaxSize:          -1,
+			}.UnmarshalFrom(r, m)
+			if err == io.EOF {
+				return msgs, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("message %d: %v", len(msgs)+1, err)
+			}
+			msgs = append(msgs, m)
+		}
+	}
+
+	m := mt.New().Interface()
+	var err error
+	switch format {
+	case "binary":
+		err = proto.UnmarshalOptions{Resolver: types}.Unmarshal(in, m)
+	case "json":
+		err = protojson.UnmarshalOptions{Resolver: types}.Unmarshal(in, m)
+	case "text":
+		err = prototext.UnmarshalOptions{Resolver: types}.Unmarshal(in, m)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []proto.Message{m}, nil
+}
+
+// encode appends the encoding of m to b.
+func encode(b []byte, m proto.Message, format string, pretty bool, types resolver) ([]byte, error) {
+	var indent string
+	if pretty {
+		indent = "  "
+	}
+	var err error
+	switch format {
+	case "binary":
+		b, err = proto.MarshalOptions{}.MarshalAppend(b, m)
+	case "delimited":
+		var buf bytes.Buffer
+		_, err = protodelim.MarshalTo(&buf, m)
+		b = append(b, buf.Bytes()...)
+	case "json":
+		var out []byte
+		out, err = protojson.MarshalOptions{Multiline: pretty, Indent: indent, Resolver: types}.Marshal(m)
+		b = append(append(b, out...), '\n')
+	case "text":
+		var out []byte
+		out, err = prototext.MarshalOptions{Multiline: pretty, Indent: indent, Resolver: types}.Marshal(m)
+		if len(out) > 0 && out[len(out)-1] != '\n' {
+			out = append(out, '\n')
+		}
+		b = append(b, out...)
+	}
+	return b, err
+}
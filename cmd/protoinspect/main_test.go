@@ -0,0 +1,195 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	test3pb "google.golang.org/protobuf/internal/testprotos/test3"
+)
+
+// writeDescriptorSet writes the descriptor set of the file of m
+// and its dependencies, and returns the path of the written file.
+func writeDescriptorSet(t *testing.T, m proto.Message) string {
+	t.Helper()
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		for i := 0; i < fd.Imports().Len(); i++ {
+			add(fd.Imports().Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+	add(m.ProtoReflect().Descriptor().ParentFile())
+	b, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "set.pb")
+	if err := os.WriteFile(path, b, 0666); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestConvert(t *testing.T) {
+	descSet := writeDescriptorSet(t, &test3pb.TestAllTypes{})
+	m := &test3pb.TestAllTypes{
+		SingularInt32:         1,
+		SingularString:        "hello",
+		SingularNestedMessage: &test3pb.TestAllTypes_NestedMessage{A: 2},
+		RepeatedInt32:         []int32{3, 4},
+	}
+	binary, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var delimited bytes.Buffer
+	for i := 0; i < 2; i++ {
+		if _, err := protodelim.MarshalTo(&delimited, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	const typeName = "goproto.proto.test3.TestAllTypes"
+
+	unmarshalers := map[string]func([]byte) ([]proto.Message, error){
+		"binary": func(b []byte) ([]proto.Message, error) {
+			m := &test3pb.TestAllTypes{}
+			return []proto.Message{m}, proto.Unmarshal(b, m)
+		},
+		"delimited": func(b []byte) ([]proto.Message, error) {
+			var msgs []proto.Message
+			r := bufio.NewReader(bytes.NewReader(b))
+			for {
+				m := &test3pb.TestAllTypes{}
+				if err := protodelim.UnmarshalFrom(r, m); err == io.EOF {
+					return msgs, nil
+				} else if err != nil {
+					return nil, err
+				}
+				msgs = append(msgs, m)
+			}
+		},
+		"json": func(b []byte) ([]proto.Message, error) {
+			m := &test3pb.TestAllTypes{}
+			return []proto.Message{m}, protojson.Unmarshal(b, m)
+		},
+		"text": func(b []byte) ([]proto.Message, error) {
+			m := &test3pb.TestAllTypes{}
+			return []proto.Message{m}, prototext.Unmarshal(b, m)
+		},
+	}
+
+	tests := []struct {
+		desc   string
+		args   []string
+		input  []byte
+		format string
+		want   []proto.Message
+	}{{
+		desc:   "binary to text with linked types",
+		args:   []string{typeName},
+		input:  binary,
+		format: "text",
+		want:   []proto.Message{m},
+	}, {
+		desc:   "binary to json with descriptor set",
+		args:   []string{"-descriptor_set_in=" + descSet, "-out=json", "-pretty", typeName},
+		input:  binary,
+		format: "json",
+		want:   []proto.Message{m},
+	}, {
+		desc:   "delimited to delimited",
+		args:   []string{"-descriptor_set_in=" + descSet, "-in=delimited", "-out=delimited", typeName},
+		input:  delimited.Bytes(),
+		format: "delimited",
+		want:   []proto.Message{m, m},
+	}, {
+		desc:   "json to binary",
+		args:   []string{"-descriptor_set_in=" + descSet, "-in=json", "-out=binary", typeName},
+		input:  []byte(`{"singularInt32": 1, "singularString": "hello", "singularNestedMessage": {"a": 2}, "repeatedInt32": [3, 4]}`),
+		format: "binary",
+		want:   []proto.Message{m},
+	}, {
+		desc:   "text to binary with field mask",
+		args:   []string{"-in=text", "-out=binary", "-fields=singular_string,singular_nested_message.a", typeName},
+		input:  []byte(`singular_int32: 1 singular_string: "hello" singular_nested_message {a: 2}`),
+		format: "binary",
+		want: []proto.Message{&test3pb.TestAllTypes{
+			SingularString:        "hello",
+			SingularNestedMessage: &test3pb.TestAllTypes_NestedMessage{A: 2},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := run(tt.args, bytes.NewReader(tt.input), &out); err != nil {
+				t.Fatalf("run() error: %v", err)
+			}
+			got, err := unmarshalers[tt.format](out.Bytes())
+			if err != nil {
+				t.Fatalf("cannot unmarshal output %q: %v", out.Bytes(), err)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("output mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRaw(t *testing.T) {
+	in := []byte{0x08, 0x96, 0x01, 0x12, 0x02, 'h', 'i'}
+	var out bytes.Buffer
+	if err := run([]string{"-raw"}, bytes.NewReader(in), &out); err != nil {
+		t.Fatalf("run() error: %v", err)
+	}
+	if got, want := out.String(), "1: 150\n2: \"hi\"\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		args  []string
+		input string
+		want  string
+	}{
+		{[]string{}, "", "missing message type"},
+		{[]string{"-in=yaml", "foo.Bar"}, "", `unknown format "yaml"`},
+		{[]string{"foo.Bar"}, "", `message type "foo.Bar": `},
+		{[]string{"-fields=nope", "goproto.proto.test3.TestAllTypes"}, "", "-fields: "},
+		{[]string{"-descriptor_set_in=does_not_exist.pb", "google.protobuf.Empty"}, "", "does_not_exist.pb"},
+		{[]string{"-in=delimited", "-out=binary", "goproto.proto.test3.TestAllTypes"}, "\x00\x00", "input has 2 messages"},
+		{[]string{"-in=delimited", "-out=text", "goproto.proto.test3.TestAllTypes"}, "\x00\x00\x00", "input has 3 messages"},
+	}
+	for _, tt := range tests {
+		err := run(tt.args, strings.NewReader(tt.input), io.Discard)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("run(%q) error = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The protomigrate binary converts proto2 and proto3 source files to editions.
+//
+// It parses the source files with the compiler/protoparse package, converts
+// them with the compiler/protomigrate package and formats the result with the
+// compiler/protoprint package:
+//
+//	protomigrate [-I=PATH]... [-edition=EDITION] [-w] FILE...
+//
+// The migrated files are written to standard output, or back to the source
+// files if -w is set. Each migrated file is checked to be equivalent to its
+// source file; see the compiler/protomigrate package for details.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"google.golang.org/protobuf/compiler/protomigrate"
+	"google.golang.org/protobuf/compiler/protoparse"
+	"google.golang.org/protobuf/compiler/protoprint"
+	"google.golang.org/protobuf/internal/importfs"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/gofeaturespb"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	var (
+		flags       = flag.NewFlagSet("protomigrate", flag.ContinueOnError)
+		importPaths importfs.StringList
+		edition     = flags.String("edition", "2023", "migrate to `EDITION`")
+		write       = flags.Bool("w", false, "write the migrated files back to the source files instead of to stdout")
+	)
+	flags.Var(&importPaths, "I", "search for imports in `PATH`; may be specified multiple times")
+	flags.Var(&importPaths, "proto_path", "same as -I")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: protomigrate [-I=PATH]... [-edition=EDITION] [-w] FILE...\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New("Missing input file.")
+	}
+	ed, ok := descriptorpb.Edition_value["EDITION_"+*edition]
+	if !ok {
+		return fmt.Errorf("unknown edition %q", *edition)
+	}
+	fsys := importfs.New(importPaths)
+
+	names := make([]string, flags.NArg())
+	for i, file := range flags.Args() {
+		name, err := fsys.VirtualName(file)
+		if err != nil {
+			return err
+		}
+		names[i] = name
+	}
+	fds, err := protoparse.Parser{FS: fsys}.Parse(names...)
+	if err != nil {
+		return err
+	}
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: fds})
+	if err != nil {
+		return err
+	}
+	// The migrated files may import the Go features,
+	// which the source files need not depend on.
+	goFeatures := gofeaturespb.File_google_protobuf_go_features_proto
+	if _, err := files.FindFileByPath(goFeatures.Path()); err != nil {
+		if err := files.RegisterFile(goFeatures); err != nil {
+			return err
+		}
+	}
+
+	opts := protomigrate.Options{
+		Edition:  descriptorpb.Edition(ed),
+		Resolver: files,
+	}
+	for i, name := range names {
+		var fdp *descriptorpb.FileDescriptorProto
+		for _, fd := range fds {
+			if fd.GetName() == name {
+				fdp = fd
+			}
+		}
+		out, err := opts.Migrate(fdp)
+		if err != nil {
+			return err
+		}
+		fd, err := protodesc.NewFile(out, files)
+		if err != nil {
+			return err
+		}
+		src := protoprint.Format(fd)
+		if *write {
+			if err := os.WriteFile(flags.Arg(i), []byte(src), 0666); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := io.WriteString(stdout, src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	source = `syntax = "proto2";
+package p;
+enum E { A = 0; }
+message M { optional string s = 1; optional E e = 2; required int32 r = 3; }
+`
+	migrated = `edition = "2023";
+
+package p;
+
+import "google/protobuf/go_features.proto";
+
+enum E {
+  option features.enum_type = CLOSED;
+  option features.(pb.go).legacy_unmarshal_json_enum = true;
+
+  A = 0;
+}
+
+message M {
+  string s = 1 [features.utf8_validation = NONE];
+  .p.E e = 2;
+  int32 r = 3 [features.field_presence = LEGACY_REQUIRED];
+}
+`
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.proto")
+	if err := os.WriteFile(path, []byte(source), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	if err := run([]string{"-I", dir, path}, &stdout); err != nil {
+		t.Fatalf("run() error: %v", err)
+	}
+	if diff := cmp.Diff(migrated, stdout.String()); diff != "" {
+		t.Errorf("run() output mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := run([]string{"-I", dir, "-edition=2024", path}, io.Discard); err != nil {
+		t.Fatalf("run() with -edition=2024 error: %v", err)
+	}
+
+	if err := run([]string{"-I", dir, "-w", path}, io.Discard); err != nil {
+		t.Fatalf("run() with -w error: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(migrated, string(got)); diff != "" {
+		t.Errorf("run() with -w: file mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.proto"), []byte(`syntax = "proto3"; message M {}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.proto"), []byte(`edition = "2023"; message M {}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc string
+		args []string
+		want string
+	}{{
+		desc: "missing input",
+		args: []string{"-I", dir},
+		want: "Missing input file.",
+	}, {
+		desc: "unknown edition",
+		args: []string{"-I", dir, "-edition=2000", filepath.Join(dir, "a.proto")},
+		want: `unknown edition "2000"`,
+	}, {
+		desc: "unsupported edition",
+		args: []string{"-I", dir, "-edition=MAX", filepath.Join(dir, "a.proto")},
+		want: "unsupported edition EDITION_MAX",
+	}, {
+		desc: "not in import path",
+		args: []string{"-I", filepath.Join(dir, "sub"), filepath.Join(dir, "a.proto")},
+		want: "File does not reside within any path specified using --proto_path (or -I).",
+	}, {
+		desc: "editions file",
+		args: []string{"-I", dir, filepath.Join(dir, "b.proto")},
+		want: `cannot migrate a file with syntax "editions"`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := run(tt.args, io.Discard)
+			if err == nil {
+				t.Fatalf("run() succeeded, want error containing %q", tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("run() error = %q, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
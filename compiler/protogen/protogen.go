@@ -28,6 +28,7 @@ import (
 	"strings"
 
 	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/internal/genid"
 	"google.golang.org/protobuf/internal/strs"
 	"google.golang.org/protobuf/proto"
@@ -37,6 +38,7 @@ import (
 
 	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/gofeaturespb"
 	"google.golang.org/protobuf/types/pluginpb"
 )
 
@@ -327,6 +329,14 @@ func (opts Options) New(req *pluginpb.CodeGeneratorRequest) (*Plugin, error) {
 		}
 		f.Generate = true
 	}
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
+		}
+		if err := f.checkGoIdents(); err != nil {
+			return nil, err
+		}
+	}
 
 	// Create fully-linked descriptors if new extensions were found
 	if typeRegistry.hasNovelExtensions() {
@@ -468,10 +478,18 @@ func newFile(gen *Plugin, p *descriptorpb.FileDescriptorProto, packageName GoPac
 	f.GeneratedFilenamePrefix = prefix
 
 	for i, eds := 0, desc.Enums(); i < eds.Len(); i++ {
-		f.Enums = append(f.Enums, newEnum(gen, f, nil, eds.Get(i)))
+		enum, err := newEnum(gen, f, nil, eds.Get(i))
+		if err != nil {
+			return nil, err
+		}
+		f.Enums = append(f.Enums, enum)
 	}
 	for i, mds := 0, desc.Messages(); i < mds.Len(); i++ {
-		f.Messages = append(f.Messages, newMessage(gen, f, nil, mds.Get(i)))
+		message, err := newMessage(gen, f, nil, mds.Get(i))
+		if err != nil {
+			return nil, err
+		}
+		f.Messages = append(f.Messages, message)
 	}
 	for i, xds := 0, desc.Extensions(); i < xds.Len(); i++ {
 		f.Extensions = append(f.Extensions, newField(gen, f, nil, xds.Get(i)))
@@ -499,6 +517,97 @@ func newFile(gen *Plugin, p *descriptorpb.FileDescriptorProto, packageName GoPac
 	return f, nil
 }
 
+// checkGoIdents reports an error if a Go name set with an option is not an
+// exported Go identifier or conflicts with another name in its message, or if
+// two of the top-level Go declarations for the file have the same name.
+// It is only called for the files to generate, so that plugins do not fail
+// on names in the files that they do not generate code for.
+func (f *File) checkGoIdents() error {
+	declared := make(map[string]protoreflect.Descriptor)
+	declare := func(ident GoIdent, d protoreflect.Descriptor) error {
+		if d2, ok := declared[ident.GoName]; ok {
+			return fmt.Errorf("%v: Go name %v conflicts with %v", d.FullName(), ident.GoName, d2.FullName())
+		}
+		declared[ident.GoName] = d
+		return nil
+	}
+	checkField := func(field *Field) error {
+		if field.hasGoNameOption && (!token.IsIdentifier(field.GoName) || !token.IsExported(field.GoName)) {
+			return fmt.Errorf("field %v: invalid Go name %q", field.Desc.FullName(), field.GoName)
+		}
+		if field.hasNameConflict {
+			return fmt.Errorf("field %v: Go name %q conflicts with another name in message %v", field.Desc.FullName(), field.GoName, field.Parent.Desc.FullName())
+		}
+		if field.Oneof != nil {
+			return declare(field.GoIdent, field.Desc)
+		}
+		return nil
+	}
+	checkEnum := func(enum *Enum) error {
+		if err := declare(enum.GoIdent, enum.Desc); err != nil {
+			return err
+		}
+		for _, value := range enum.Values {
+			if err := declare(value.GoIdent, value.Desc); err != nil {
+				return err
+			}
+			if value.PrefixedAlias.GoName != "" {
+				if err := declare(value.PrefixedAlias, value.Desc); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	var checkMessage func(*Message) error
+	checkMessage = func(message *Message) error {
+		if name := message.GoIdent.GoName; message.hasGoNameOption && (!token.IsIdentifier(name) || !token.IsExported(name)) {
+			return fmt.Errorf("message %v: invalid Go name %q", message.Desc.FullName(), name)
+		}
+		if err := declare(message.GoIdent, message.Desc); err != nil {
+			return err
+		}
+		for _, field := range message.Fields {
+			if err := checkField(field); err != nil {
+				return err
+			}
+		}
+		for _, extension := range message.Extensions {
+			if err := checkField(extension); err != nil {
+				return err
+			}
+		}
+		for _, enum := range message.Enums {
+			if err := checkEnum(enum); err != nil {
+				return err
+			}
+		}
+		for _, nested := range message.Messages {
+			if err := checkMessage(nested); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, enum := range f.Enums {
+		if err := checkEnum(enum); err != nil {
+			return err
+		}
+	}
+	for _, message := range f.Messages {
+		if err := checkMessage(message); err != nil {
+			return err
+		}
+	}
+	for _, extension := range f.Extensions {
+		if err := checkField(extension); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // splitImportPathAndPackageName splits off the optional Go package name
 // from the Go import path when separated by a ';' delimiter.
 func splitImportPathAndPackageName(s string) (GoImportPath, GoPackageName) {
@@ -520,7 +629,7 @@ type Enum struct {
 	Comments CommentSet // comments associated with this enum
 }
 
-func newEnum(gen *Plugin, f *File, parent *Message, desc protoreflect.EnumDescriptor) *Enum {
+func newEnum(gen *Plugin, f *File, parent *Message, desc protoreflect.EnumDescriptor) (*Enum, error) {
 	var loc Location
 	if parent != nil {
 		loc = parent.Location.appendPath(genid.DescriptorProto_EnumType_field_number, desc.Index())
@@ -529,15 +638,19 @@ func newEnum(gen *Plugin, f *File, parent *Message, desc protoreflect.EnumDescri
 	}
 	enum := &Enum{
 		Desc:     desc,
-		GoIdent:  newGoIdent(f, desc),
+		GoIdent:  newNestedGoIdent(f, parent, desc),
 		Location: loc,
 		Comments: makeCommentSet(f.Desc.SourceLocations().ByDescriptor(desc)),
 	}
 	gen.enumsByName[desc.FullName()] = enum
 	for i, vds := 0, enum.Desc.Values(); i < vds.Len(); i++ {
-		enum.Values = append(enum.Values, newEnumValue(gen, f, parent, enum, vds.Get(i)))
+		value, err := newEnumValue(gen, f, parent, enum, vds.Get(i))
+		if err != nil {
+			return nil, err
+		}
+		enum.Values = append(enum.Values, value)
 	}
-	return enum
+	return enum, nil
 }
 
 // An EnumValue describes an enum value.
@@ -546,13 +659,18 @@ type EnumValue struct {
 
 	GoIdent GoIdent // name of the generated Go declaration
 
+	// PrefixedAlias is the name of an alias for the generated Go declaration
+	// that keeps the enum name prefix, which is set if the strip_enum_prefix
+	// Go feature of the value is GENERATE_BOTH and the prefix was stripped.
+	PrefixedAlias GoIdent
+
 	Parent *Enum // enum in which this value is declared
 
 	Location Location   // location of this enum value
 	Comments CommentSet // comments associated with this enum value
 }
 
-func newEnumValue(gen *Plugin, f *File, message *Message, enum *Enum, desc protoreflect.EnumValueDescriptor) *EnumValue {
+func newEnumValue(gen *Plugin, f *File, message *Message, enum *Enum, desc protoreflect.EnumValueDescriptor) (*EnumValue, error) {
 	// A top-level enum value's name is: EnumName_ValueName
 	// An enum value contained in a message is: MessageName_ValueName
 	//
@@ -562,13 +680,45 @@ func newEnumValue(gen *Plugin, f *File, message *Message, enum *Enum, desc proto
 		parentIdent = message.GoIdent
 	}
 	name := parentIdent.GoName + "_" + string(desc.Name())
+	var prefixedAlias GoIdent
+	strip, err := stripEnumPrefix(desc)
+	if err != nil {
+		return nil, err
+	}
+	if strip != gofeaturespb.GoFeatures_STRIP_ENUM_PREFIX_KEEP {
+		// The prefix is the enum name, compared case-insensitively
+		// and ignoring underscores.
+		prefix := strings.ReplaceAll(strings.ToLower(string(enum.Desc.Name())), "_", "")
+		if trimmed := strs.TrimEnumPrefix(string(desc.Name()), prefix); trimmed != string(desc.Name()) {
+			if strip == gofeaturespb.GoFeatures_STRIP_ENUM_PREFIX_GENERATE_BOTH {
+				prefixedAlias = f.GoImportPath.Ident(name)
+			}
+			name = parentIdent.GoName + "_" + trimmed
+		}
+	}
 	loc := enum.Location.appendPath(genid.EnumDescriptorProto_Value_field_number, desc.Index())
 	return &EnumValue{
-		Desc:     desc,
-		GoIdent:  f.GoImportPath.Ident(name),
-		Parent:   enum,
-		Location: loc,
-		Comments: makeCommentSet(f.Desc.SourceLocations().ByDescriptor(desc)),
+		Desc:          desc,
+		GoIdent:       f.GoImportPath.Ident(name),
+		PrefixedAlias: prefixedAlias,
+		Parent:        enum,
+		Location:      loc,
+		Comments:      makeCommentSet(f.Desc.SourceLocations().ByDescriptor(desc)),
+	}, nil
+}
+
+// stripEnumPrefix returns the value of the strip_enum_prefix Go feature
+// for the enum value desc.
+func stripEnumPrefix(desc protoreflect.EnumValueDescriptor) (gofeaturespb.GoFeatures_StripEnumPrefix, error) {
+	gf, err := protodesc.ResolveFeatures(desc, gofeaturespb.E_Go)
+	if err != nil {
+		return 0, fmt.Errorf("enum value %v: %v", desc.FullName(), err)
+	}
+	switch v := gf.(*gofeaturespb.GoFeatures).GetStripEnumPrefix(); v {
+	case gofeaturespb.GoFeatures_STRIP_ENUM_PREFIX_GENERATE_BOTH, gofeaturespb.GoFeatures_STRIP_ENUM_PREFIX_STRIP:
+		return v, nil
+	default:
+		return gofeaturespb.GoFeatures_STRIP_ENUM_PREFIX_KEEP, nil
 	}
 }
 
@@ -587,9 +737,11 @@ type Message struct {
 
 	Location Location   // location of this message
 	Comments CommentSet // comments associated with this message
+
+	hasGoNameOption bool // whether GoIdent.GoName is set with the go_message_name option
 }
 
-func newMessage(gen *Plugin, f *File, parent *Message, desc protoreflect.MessageDescriptor) *Message {
+func newMessage(gen *Plugin, f *File, parent *Message, desc protoreflect.MessageDescriptor) (*Message, error) {
 	var loc Location
 	if parent != nil {
 		loc = parent.Location.appendPath(genid.DescriptorProto_NestedType_field_number, desc.Index())
@@ -598,16 +750,28 @@ func newMessage(gen *Plugin, f *File, parent *Message, desc protoreflect.Message
 	}
 	message := &Message{
 		Desc:     desc,
-		GoIdent:  newGoIdent(f, desc),
+		GoIdent:  newNestedGoIdent(f, parent, desc),
 		Location: loc,
 		Comments: makeCommentSet(f.Desc.SourceLocations().ByDescriptor(desc)),
 	}
+	if name, ok := gen.goNameOption(desc.Options(), goMessageNameOption); ok {
+		message.GoIdent.GoName = name
+		message.hasGoNameOption = true
+	}
 	gen.messagesByName[desc.FullName()] = message
 	for i, eds := 0, desc.Enums(); i < eds.Len(); i++ {
-		message.Enums = append(message.Enums, newEnum(gen, f, message, eds.Get(i)))
+		enum, err := newEnum(gen, f, message, eds.Get(i))
+		if err != nil {
+			return nil, err
+		}
+		message.Enums = append(message.Enums, enum)
 	}
 	for i, mds := 0, desc.Messages(); i < mds.Len(); i++ {
-		message.Messages = append(message.Messages, newMessage(gen, f, message, mds.Get(i)))
+		nested, err := newMessage(gen, f, message, mds.Get(i))
+		if err != nil {
+			return nil, err
+		}
+		message.Messages = append(message.Messages, nested)
 	}
 	for i, fds := 0, desc.Fields(); i < fds.Len(); i++ {
 		message.Fields = append(message.Fields, newField(gen, f, message, fds.Get(i)))
@@ -637,12 +801,15 @@ func newMessage(gen *Plugin, f *File, parent *Message, desc protoreflect.Message
 	// Any change to the following set of method names is a potential
 	// incompatible API change because it may change generated field names.
 	//
-	// TODO: If we ever support a 'go_name' option to set the Go name of a
-	// field, we should consider dropping this entirely. The conflict
-	// resolution algorithm is subtle and surprising (changing the order
-	// in which fields appear in the .proto source file can change the
-	// names of fields in generated code), and does not adapt well to
-	// adding new per-field methods such as setters.
+	// Fields whose Go name is set with the go_field_name option keep
+	// that name, and the other fields are renamed around them instead.
+	// It is an error for such a name to conflict with any other name.
+	//
+	// TODO: Consider dropping the renaming entirely now that go_field_name
+	// is supported. The conflict resolution algorithm is subtle and
+	// surprising (changing the order in which fields appear in the .proto
+	// source file can change the names of fields in generated code), and
+	// does not adapt well to adding new per-field methods such as setters.
 	usedNames := map[string]bool{
 		"Reset":               true,
 		"String":              true,
@@ -662,7 +829,17 @@ func newMessage(gen *Plugin, f *File, parent *Message, desc protoreflect.Message
 		return name
 	}
 	for _, field := range message.Fields {
-		field.GoName = makeNameUnique(field.GoName, true)
+		if !field.hasGoNameOption {
+			continue
+		}
+		field.hasNameConflict = usedNames[field.GoName] || usedNames["Get"+field.GoName]
+		usedNames[field.GoName] = true
+		usedNames["Get"+field.GoName] = true
+	}
+	for _, field := range message.Fields {
+		if !field.hasGoNameOption {
+			field.GoName = makeNameUnique(field.GoName, true)
+		}
 		field.GoIdent.GoName = message.GoIdent.GoName + "_" + field.GoName
 		if field.Oneof != nil && field.Oneof.Fields[0] == field {
 			// Make the name for a oneof unique as well. For historical reasons,
@@ -698,7 +875,7 @@ func newMessage(gen *Plugin, f *File, parent *Message, desc protoreflect.Message
 		}
 	}
 
-	return message
+	return message, nil
 }
 
 func (message *Message) resolveDependencies(gen *Plugin) error {
@@ -744,6 +921,9 @@ type Field struct {
 
 	Location Location   // location of this field
 	Comments CommentSet // comments associated with this field
+
+	hasGoNameOption bool // whether GoName is set with the go_field_name option
+	hasNameConflict bool // whether GoName set with the option conflicts with another name
 }
 
 func newField(gen *Plugin, f *File, message *Message, desc protoreflect.FieldDescriptor) *Field {
@@ -757,6 +937,10 @@ func newField(gen *Plugin, f *File, message *Message, desc protoreflect.FieldDes
 		loc = message.Location.appendPath(genid.DescriptorProto_Field_field_number, desc.Index())
 	}
 	camelCased := strs.GoCamelCase(string(desc.Name()))
+	name, hasGoNameOption := gen.goNameOption(desc.Options(), goFieldNameOption)
+	if hasGoNameOption {
+		camelCased = name
+	}
 	var parentPrefix string
 	if message != nil {
 		parentPrefix = message.GoIdent.GoName + "_"
@@ -768,9 +952,10 @@ func newField(gen *Plugin, f *File, message *Message, desc protoreflect.FieldDes
 			GoImportPath: f.GoImportPath,
 			GoName:       parentPrefix + camelCased,
 		},
-		Parent:   message,
-		Location: loc,
-		Comments: makeCommentSet(f.Desc.SourceLocations().ByDescriptor(desc)),
+		Parent:          message,
+		Location:        loc,
+		Comments:        makeCommentSet(f.Desc.SourceLocations().ByDescriptor(desc)),
+		hasGoNameOption: hasGoNameOption,
 	}
 	return field
 }
@@ -1241,6 +1426,76 @@ func newGoIdent(f *File, d protoreflect.Descriptor) GoIdent {
 	}
 }
 
+// newNestedGoIdent returns the GoIdent for a message or enum declared in
+// parent, which is nil for top-level declarations. If the Go name of parent
+// was set with the go_message_name option, it replaces the prefix of the name.
+func newNestedGoIdent(f *File, parent *Message, d protoreflect.Descriptor) GoIdent {
+	ident := newGoIdent(f, d)
+	if parent != nil {
+		prefix := newGoIdent(f, parent.Desc).GoName
+		if parent.GoIdent.GoName != prefix && strings.HasPrefix(ident.GoName, prefix) {
+			ident.GoName = parent.GoIdent.GoName + strings.TrimPrefix(ident.GoName, prefix)
+		}
+	}
+	return ident
+}
+
+// The full names of the options that set Go names. They are declared in
+// internal/gonamespb/go_names.proto, and are looked up by name in the files
+// of the request rather than linked in, as their extension numbers are not
+// allocated yet.
+const (
+	goMessageNameOption protoreflect.FullName = "pb.go_message_name"
+	goFieldNameOption   protoreflect.FullName = "pb.go_field_name"
+)
+
+// goNameOption returns the value of the Go name option with the given full
+// name in opts, and reports whether it is set. The option is only recognized
+// if its declaration is in one of the files of the request.
+func (gen *Plugin) goNameOption(opts protoreflect.ProtoMessage, name protoreflect.FullName) (value string, ok bool) {
+	if opts == nil {
+		return "", false
+	}
+	m := opts.ProtoReflect()
+	d, err := gen.fileReg.FindDescriptorByName(name)
+	if err != nil {
+		return "", false
+	}
+	xd, isExt := d.(protoreflect.ExtensionDescriptor)
+	if !isExt || xd.Kind() != protoreflect.StringKind || xd.ContainingMessage().FullName() != m.Descriptor().FullName() {
+		return "", false
+	}
+
+	// The option is an unknown field, unless the program that unmarshaled
+	// the options links in its declaration.
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.FullName() == name {
+			value, ok = v.String(), true
+		}
+		return !ok
+	})
+	for b := m.GetUnknown(); len(b) > 0; {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			break
+		}
+		b = b[n:]
+		if num == xd.Number() && typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(b)
+			if n >= 0 {
+				// As with any singular scalar field, the last value wins.
+				value, ok = string(v), true
+			}
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			break
+		}
+		b = b[n:]
+	}
+	return value, ok
+}
+
 // A GoImportPath is the import path of a Go package.
 // For example: "google.golang.org/protobuf/compiler/protogen"
 type GoImportPath string
@@ -7,10 +7,13 @@ package protogen
 import (
 	"flag"
 	"fmt"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/google/go-cmp/cmp"
 
+	"google.golang.org/protobuf/compiler/protoparse"
 	"google.golang.org/protobuf/internal/genid"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -18,6 +21,10 @@ import (
 
 	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/pluginpb"
+
+	// The Go name options are only linked in here, so that test files can
+	// import their declaration.
+	_ "google.golang.org/protobuf/internal/gonamespb"
 )
 
 func TestPluginParameters(t *testing.T) {
@@ -416,3 +423,206 @@ func TestAnnotations(t *testing.T) {
 		t.Fatalf("GeneratedCodeInfo mismatch (-want +got):\n%s", diff)
 	}
 }
+
+// newGoNamesPlugin returns a plugin for the file test.proto with the given
+// source, which may import go_features.proto and go_names.proto.
+func newGoNamesPlugin(src string) (*Plugin, error) {
+	return newGoNamesPluginFS(fstest.MapFS{"test.proto": {Data: []byte(src)}}, "test.proto")
+}
+
+// newGoNamesPluginFS returns a plugin for the named files in fsys.
+func newGoNamesPluginFS(fsys fstest.MapFS, names ...string) (*Plugin, error) {
+	fds, err := protoparse.Parser{FS: fsys}.Parse(names...)
+	if err != nil {
+		return nil, err
+	}
+	return Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: names,
+		ProtoFile:      fds,
+	})
+}
+
+func TestGoNames(t *testing.T) {
+	gen, err := newGoNamesPlugin(`edition = "2024";
+package test;
+import "google/protobuf/go_features.proto";
+import "internal/gonamespb/go_names.proto";
+option go_package = "example.com/test";
+
+enum Color {
+  option features.(pb.go).strip_enum_prefix = STRIP_ENUM_PREFIX_STRIP;
+  COLOR_UNSPECIFIED = 0;
+  color_red = 1;
+  BLUE = 2;
+  COLOR_GREEN = 3 [features.(pb.go).strip_enum_prefix = STRIP_ENUM_PREFIX_KEEP];
+}
+enum Shape {
+  option features.(pb.go).strip_enum_prefix = STRIP_ENUM_PREFIX_GENERATE_BOTH;
+  SHAPE_UNSPECIFIED = 0;
+  SQUARE = 1;
+}
+enum Size {
+  SIZE_UNSPECIFIED = 0;
+}
+message Message {
+  option (pb.go_message_name) = "Msg";
+  message Nested {
+    int32 id = 1 [(pb.go_field_name) = "ID"];
+  }
+  enum Kind {
+    KIND_UNSPECIFIED = 0;
+  }
+  string url = 1 [(pb.go_field_name) = "URL"];
+  string u_r_l = 2;
+  oneof o {
+    int32 user_id = 3 [(pb.go_field_name) = "UserID"];
+  }
+  extend Message {
+    int32 ext = 100 [(pb.go_field_name) = "Extension"];
+  }
+  extensions 100 to 200;
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := gen.FilesByPath["test.proto"]
+	var got []string
+	for _, enum := range f.Enums {
+		for _, value := range enum.Values {
+			s := value.GoIdent.GoName
+			if value.PrefixedAlias.GoName != "" {
+				s += " alias " + value.PrefixedAlias.GoName
+			}
+			got = append(got, s)
+		}
+	}
+	message := f.Messages[0]
+	got = append(got, message.GoIdent.GoName, message.Messages[0].GoIdent.GoName, message.Messages[0].Fields[0].GoName)
+	got = append(got, message.Enums[0].GoIdent.GoName, message.Enums[0].Values[0].GoIdent.GoName)
+	for _, field := range message.Fields {
+		got = append(got, field.GoName+" "+field.GoIdent.GoName)
+	}
+	got = append(got, message.Extensions[0].GoIdent.GoName)
+	want := []string{
+		"Color_UNSPECIFIED",
+		"Color_red",
+		"Color_BLUE",
+		"Color_COLOR_GREEN",
+		"Shape_UNSPECIFIED alias Shape_SHAPE_UNSPECIFIED",
+		"Shape_SQUARE",
+		"Size_SIZE_UNSPECIFIED",
+		"Msg",
+		"Msg_Nested",
+		"ID",
+		"Msg_Kind",
+		"Msg_KIND_UNSPECIFIED",
+		"URL Msg_URL",
+		"URL_ Msg_URL_",
+		"UserID Msg_UserID",
+		"Msg_Extension",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Go names mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGoNameErrors(t *testing.T) {
+	const header = `edition = "2024";
+package test;
+import "google/protobuf/go_features.proto";
+import "internal/gonamespb/go_names.proto";
+option go_package = "example.com/test";
+`
+	for _, tt := range []struct {
+		desc string
+		src  string
+		want string
+	}{{
+		desc: "invalid message name",
+		src:  `message M { option (pb.go_message_name) = "not valid"; }`,
+		want: `message test.M: invalid Go name "not valid"`,
+	}, {
+		desc: "unexported message name",
+		src:  `message M { option (pb.go_message_name) = "m"; }`,
+		want: `message test.M: invalid Go name "m"`,
+	}, {
+		desc: "invalid field name",
+		src:  `message M { int32 a = 1 [(pb.go_field_name) = "1A"]; }`,
+		want: `field test.M.a: invalid Go name "1A"`,
+	}, {
+		desc: "invalid extension name",
+		src:  `message M { extensions 1 to 2; } extend M { int32 a = 1 [(pb.go_field_name) = "a"]; }`,
+		want: `field test.a: invalid Go name "a"`,
+	}, {
+		desc: "field names",
+		src:  `message M { int32 a = 1 [(pb.go_field_name) = "B"]; int32 b = 2 [(pb.go_field_name) = "B"]; }`,
+		want: `field test.M.b: Go name "B" conflicts with another name in message test.M`,
+	}, {
+		desc: "field name and method",
+		src:  `message M { int32 a = 1 [(pb.go_field_name) = "Reset"]; }`,
+		want: `field test.M.a: Go name "Reset" conflicts with another name in message test.M`,
+	}, {
+		desc: "field name and getter",
+		src:  `message M { int32 a = 1 [(pb.go_field_name) = "B"]; int32 b = 2 [(pb.go_field_name) = "GetB"]; }`,
+		want: `field test.M.b: Go name "GetB" conflicts with another name in message test.M`,
+	}, {
+		desc: "message names",
+		src:  `message M { option (pb.go_message_name) = "N"; } message N {}`,
+		want: "test.N: Go name N conflicts with test.M",
+	}, {
+		desc: "stripped enum value names",
+		src: `enum E {
+  option features.enum_type = CLOSED;
+  option features.(pb.go).strip_enum_prefix = STRIP_ENUM_PREFIX_STRIP;
+  E_A = 0;
+  A = 1;
+}`,
+		want: "test.A: Go name E_A conflicts with test.E_A",
+	}, {
+		desc: "enum value alias",
+		src: `enum E {
+  option features.enum_type = CLOSED;
+  option features.(pb.go).strip_enum_prefix = STRIP_ENUM_PREFIX_GENERATE_BOTH;
+  A = 0;
+  E_A = 1;
+}`,
+		want: "test.E_A: Go name E_A conflicts with test.A",
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := newGoNamesPlugin(header + tt.src)
+			if err == nil {
+				t.Fatalf("New() succeeded, want error containing %q", tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New() error = %q, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestGoNameErrorsInDependency checks that Go names are only checked in the
+// files to generate.
+func TestGoNameErrorsInDependency(t *testing.T) {
+	fsys := fstest.MapFS{
+		"dep.proto": {Data: []byte(`edition = "2024";
+package dep;
+import "internal/gonamespb/go_names.proto";
+option go_package = "example.com/dep";
+message M { option (pb.go_message_name) = "N"; int32 a = 1 [(pb.go_field_name) = "Reset"]; }
+message N {}
+`)},
+		"test.proto": {Data: []byte(`edition = "2024";
+package test;
+import "dep.proto";
+option go_package = "example.com/test";
+message M { dep.M m = 1; }
+`)},
+	}
+	if _, err := newGoNamesPluginFS(fsys, "test.proto"); err != nil {
+		t.Errorf("New() for test.proto: %v", err)
+	}
+	if _, err := newGoNamesPluginFS(fsys, "dep.proto"); err == nil {
+		t.Errorf("New() for dep.proto succeeded, want error")
+	}
+}
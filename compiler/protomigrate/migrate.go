@@ -0,0 +1,454 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protomigrate converts proto2 and proto3 files to editions.
+//
+// A migrated file declares the same types as the original file, and its
+// features are set such that the types behave identically in the wire
+// format, the JSON format and the generated Go code. Each feature is set
+// on the file if that takes fewer options than setting it on each of the
+// declarations that need it, and is otherwise set on those declarations.
+// The migrated features are:
+//
+//   - field_presence, for proto3 fields without presence,
+//     proto3 optional fields and proto2 required fields;
+//   - repeated_field_encoding, for packed proto2 fields and
+//     unpacked proto3 fields;
+//   - enum_type, for proto2 enums, which are closed;
+//   - utf8_validation, for proto2 string fields;
+//   - message_encoding, for proto2 group fields;
+//   - (pb.go).legacy_unmarshal_json_enum, for proto2 enums.
+//
+// The json_format feature is left to its default, so protoc may report JSON
+// name conflicts in migrated proto2 files that were ignored in the originals.
+package protomigrate
+
+import (
+	"google.golang.org/protobuf/internal/editiondefaults"
+	"google.golang.org/protobuf/internal/editionssupport"
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/gofeaturespb"
+)
+
+// goFeaturesPath is the path of the file that declares the Go features.
+const goFeaturesPath = "google/protobuf/go_features.proto"
+
+// Migrate converts fd to edition 2023 using default options.
+func Migrate(fd *descriptorpb.FileDescriptorProto) (*descriptorpb.FileDescriptorProto, error) {
+	return Options{}.Migrate(fd)
+}
+
+// Options configures the migration of files to editions.
+type Options struct {
+	// Edition is the edition of the migrated files.
+	// If zero, EDITION_2023 is used.
+	Edition descriptorpb.Edition
+
+	// Resolver resolves the dependencies of the files.
+	// If nil, protoregistry.GlobalFiles is used.
+	// The file that declares the Go features is loaded from
+	// protoregistry.GlobalFiles if Resolver does not have it.
+	Resolver protodesc.Resolver
+}
+
+// Migrate returns a copy of fd, which must be a proto2 or proto3 file,
+// converted to an editions file. The source code info of fd, if any,
+// is preserved, so that its comments are kept.
+//
+// The migrated file is checked with Verify to be equivalent to fd.
+func (o Options) Migrate(fd *descriptorpb.FileDescriptorProto) (*descriptorpb.FileDescriptorProto, error) {
+	if o.Edition == 0 {
+		o.Edition = descriptorpb.Edition_EDITION_2023
+	}
+	if o.Edition < descriptorpb.Edition_EDITION_2023 || o.Edition > editionssupport.Maximum {
+		return nil, errors.New("cannot migrate to unsupported edition %v", o.Edition)
+	}
+	if o.Resolver == nil {
+		o.Resolver = protoregistry.GlobalFiles
+	}
+	switch fd.GetSyntax() {
+	case "", "proto2", "proto3":
+	default:
+		return nil, errors.New("%q: cannot migrate a file with syntax %q", fd.GetName(), fd.GetSyntax())
+	}
+	orig, err := protodesc.NewFile(fd, o.Resolver)
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := editionDefaults(o.Edition)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &migrator{
+		file:     proto.Clone(fd).(*descriptorpb.FileDescriptorProto),
+		defaults: defaults,
+	}
+	m.file.Syntax = proto.String("editions")
+	m.file.Edition = o.Edition.Enum()
+	m.migrateFile(orig)
+	m.setFeatures()
+
+	r := resolver{o.Resolver}
+	migrated, err := protodesc.NewFile(m.file, r)
+	if err != nil {
+		return nil, errors.Wrap(err, "%q: invalid migrated file", fd.GetName())
+	}
+	if err := Verify(orig, migrated); err != nil {
+		return nil, err
+	}
+	return m.file, nil
+}
+
+// resolver resolves the file that declares the Go features from
+// protoregistry.GlobalFiles if the underlying resolver does not have it.
+type resolver struct {
+	protodesc.Resolver
+}
+
+func (r resolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	fd, err := r.Resolver.FindFileByPath(path)
+	if err != nil && path == goFeaturesPath {
+		return protoregistry.GlobalFiles.FindFileByPath(path)
+	}
+	return fd, err
+}
+
+// editionDefaults returns the default features of the given edition.
+func editionDefaults(edition descriptorpb.Edition) (*descriptorpb.FeatureSet, error) {
+	defaults := new(descriptorpb.FeatureSetDefaults)
+	if err := proto.Unmarshal(editiondefaults.Defaults, defaults); err != nil {
+		return nil, err
+	}
+	var fs *descriptorpb.FeatureSet
+	for _, d := range defaults.GetDefaults() {
+		if d.GetEdition() <= edition {
+			fs = proto.Clone(d.GetFixedFeatures()).(*descriptorpb.FeatureSet)
+			proto.Merge(fs, d.GetOverridableFeatures())
+		}
+	}
+	if fs == nil {
+		return nil, errors.New("no feature defaults for edition %v", edition)
+	}
+	return fs, nil
+}
+
+// A migrator records the features that the declarations of a file need
+// while converting the file, and then sets them in the file.
+type migrator struct {
+	file     *descriptorpb.FileDescriptorProto
+	defaults *descriptorpb.FeatureSet
+
+	fieldPresence   uses
+	fieldEncoding   uses
+	enumType        uses
+	utf8Validation  uses
+	messageEncoding uses
+	legacyJSONEnum  uses
+}
+
+// uses is the list of declarations that need a given value of a feature.
+type uses []use
+
+type use struct {
+	value int32
+	// features returns the feature set of the declaration, creating it if
+	// needed. It is nil if the feature cannot be set on the declaration,
+	// in which case the value must be inherited from the file.
+	features func() *descriptorpb.FeatureSet
+}
+
+func (u *uses) add(value int32, features func() *descriptorpb.FeatureSet) {
+	*u = append(*u, use{value, features})
+}
+
+// set sets the feature on the file and its declarations, where def is its
+// value in the edition of the file and set sets its value in a feature set.
+// The values in exclude are never set on the file.
+func (u uses) set(file func() *descriptorpb.FeatureSet, def int32, exclude map[int32]bool, set func(*descriptorpb.FeatureSet, int32)) {
+	// Choose the value for the file that takes the fewest options to set,
+	// unless some declaration must inherit its value from the file.
+	cost := func(v int32) int {
+		n := 0
+		if v != def {
+			n++
+		}
+		for _, use := range u {
+			if use.value != v {
+				n++
+			}
+		}
+		return n
+	}
+	fileValue := def
+	for _, use := range u {
+		if use.features == nil {
+			fileValue = use.value
+			break
+		}
+		if exclude[use.value] {
+			continue
+		}
+		if cost(use.value) < cost(fileValue) {
+			fileValue = use.value
+		}
+	}
+	if fileValue != def {
+		set(file(), fileValue)
+	}
+	for _, use := range u {
+		if use.value != fileValue && use.features != nil {
+			set(use.features(), use.value)
+		}
+	}
+}
+
+func (m *migrator) migrateFile(fd protoreflect.FileDescriptor) {
+	for i, md := range m.file.GetMessageType() {
+		m.migrateMessage(md, fd.Messages().Get(i), []int32{int32(genid.FileDescriptorProto_MessageType_field_number), int32(i)})
+	}
+	for i, ed := range m.file.GetEnumType() {
+		m.migrateEnum(ed, fd.Enums().Get(i))
+	}
+	for i, xd := range m.file.GetExtension() {
+		m.migrateField(xd, fd.Extensions().Get(i))
+	}
+}
+
+func (m *migrator) migrateMessage(md *descriptorpb.DescriptorProto, desc protoreflect.MessageDescriptor, path []int32) {
+	for i, fd := range md.GetField() {
+		m.migrateField(fd, desc.Fields().Get(i))
+	}
+	for i, nd := range md.GetNestedType() {
+		m.migrateMessage(nd, desc.Messages().Get(i), append(path[:len(path):len(path)], int32(genid.DescriptorProto_NestedType_field_number), int32(i)))
+	}
+	for i, ed := range md.GetEnumType() {
+		m.migrateEnum(ed, desc.Enums().Get(i))
+	}
+	for i, xd := range md.GetExtension() {
+		m.migrateField(xd, desc.Extensions().Get(i))
+	}
+
+	// Remove the synthetic oneofs of proto3 optional fields.
+	var oneofs []*descriptorpb.OneofDescriptorProto
+	indexes := make(map[int32]int32)
+	for i, od := range md.GetOneofDecl() {
+		if !desc.Oneofs().Get(i).IsSynthetic() {
+			indexes[int32(i)] = int32(len(oneofs))
+			oneofs = append(oneofs, od)
+		}
+	}
+	if len(oneofs) == len(md.GetOneofDecl()) {
+		return
+	}
+	md.OneofDecl = oneofs
+	for _, fd := range md.GetField() {
+		if fd.OneofIndex == nil {
+			continue
+		}
+		if i, ok := indexes[fd.GetOneofIndex()]; ok {
+			fd.OneofIndex = proto.Int32(i)
+		} else {
+			fd.OneofIndex = nil
+		}
+	}
+	m.remapOneofLocations(path, indexes)
+}
+
+// remapOneofLocations updates the source locations of the oneofs of the
+// message at path, whose old indexes are mapped to their new indexes.
+// The locations of the removed oneofs are dropped.
+func (m *migrator) remapOneofLocations(path []int32, indexes map[int32]int32) {
+	info := m.file.GetSourceCodeInfo()
+	if info == nil {
+		return
+	}
+	var locs []*descriptorpb.SourceCodeInfo_Location
+	for _, loc := range info.GetLocation() {
+		p := loc.GetPath()
+		if len(p) > len(path)+1 && equalPath(p[:len(path)], path) && p[len(path)] == int32(genid.DescriptorProto_OneofDecl_field_number) {
+			i, ok := indexes[p[len(path)+1]]
+			if !ok {
+				continue
+			}
+			loc.Path = append(append([]int32(nil), p[:len(path)+1]...), append([]int32{i}, p[len(path)+2:]...)...)
+		}
+		locs = append(locs, loc)
+	}
+	info.Location = locs
+}
+
+func equalPath(x, y []int32) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *migrator) migrateField(fd *descriptorpb.FieldDescriptorProto, desc protoreflect.FieldDescriptor) {
+	features := func() *descriptorpb.FeatureSet {
+		if fd.Options == nil {
+			fd.Options = new(descriptorpb.FieldOptions)
+		}
+		if fd.Options.Features == nil {
+			fd.Options.Features = new(descriptorpb.FeatureSet)
+		}
+		return fd.Options.Features
+	}
+	// The fields of map entries are declared implicitly,
+	// so their features can only be inherited.
+	inMapEntry := !desc.IsExtension() && desc.ContainingMessage().IsMapEntry()
+	if inMapEntry {
+		features = nil
+	}
+
+	switch {
+	case desc.Cardinality() == protoreflect.Required:
+		fd.Label = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+		m.fieldPresence.add(int32(descriptorpb.FeatureSet_LEGACY_REQUIRED), features)
+	case desc.IsList() || desc.IsExtension() || inMapEntry || desc.Message() != nil:
+	case desc.ContainingOneof() != nil && !desc.ContainingOneof().IsSynthetic():
+	case desc.HasPresence():
+		m.fieldPresence.add(int32(descriptorpb.FeatureSet_EXPLICIT), features)
+	default:
+		m.fieldPresence.add(int32(descriptorpb.FeatureSet_IMPLICIT), features)
+	}
+	fd.Proto3Optional = nil
+
+	if desc.IsList() && isPackable(desc) {
+		if desc.IsPacked() {
+			m.fieldEncoding.add(int32(descriptorpb.FeatureSet_PACKED), features)
+		} else {
+			m.fieldEncoding.add(int32(descriptorpb.FeatureSet_EXPANDED), features)
+		}
+	}
+	if fd.GetOptions() != nil && fd.Options.Packed != nil {
+		fd.Options.Packed = nil
+		if proto.Size(fd.Options) == 0 {
+			fd.Options = nil
+		}
+	}
+
+	if desc.Kind() == protoreflect.StringKind {
+		if utf8Validated(desc) {
+			m.utf8Validation.add(int32(descriptorpb.FeatureSet_VERIFY), features)
+		} else {
+			m.utf8Validation.add(int32(descriptorpb.FeatureSet_NONE), features)
+		}
+	}
+
+	if desc.Kind() == protoreflect.GroupKind {
+		fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		m.messageEncoding.add(int32(descriptorpb.FeatureSet_DELIMITED), features)
+	}
+}
+
+func (m *migrator) migrateEnum(ed *descriptorpb.EnumDescriptorProto, desc protoreflect.EnumDescriptor) {
+	features := func() *descriptorpb.FeatureSet {
+		if ed.Options == nil {
+			ed.Options = new(descriptorpb.EnumOptions)
+		}
+		if ed.Options.Features == nil {
+			ed.Options.Features = new(descriptorpb.FeatureSet)
+		}
+		return ed.Options.Features
+	}
+	if desc.IsClosed() {
+		m.enumType.add(int32(descriptorpb.FeatureSet_CLOSED), features)
+	} else {
+		m.enumType.add(int32(descriptorpb.FeatureSet_OPEN), features)
+	}
+	if legacyUnmarshalJSONEnum(desc) {
+		m.legacyJSONEnum.add(1, features)
+	} else {
+		m.legacyJSONEnum.add(0, features)
+	}
+}
+
+// setFeatures sets the recorded features in the file.
+func (m *migrator) setFeatures() {
+	file := func() *descriptorpb.FeatureSet {
+		if m.file.Options == nil {
+			m.file.Options = new(descriptorpb.FileOptions)
+		}
+		if m.file.Options.Features == nil {
+			m.file.Options.Features = new(descriptorpb.FeatureSet)
+		}
+		return m.file.Options.Features
+	}
+	d := m.defaults
+	// Setting LEGACY_REQUIRED on the file would also apply it to map entry
+	// fields, so it is only set on required fields.
+	m.fieldPresence.set(file, int32(d.GetFieldPresence()), map[int32]bool{
+		int32(descriptorpb.FeatureSet_LEGACY_REQUIRED): true,
+	}, func(fs *descriptorpb.FeatureSet, v int32) {
+		fs.FieldPresence = descriptorpb.FeatureSet_FieldPresence(v).Enum()
+	})
+	m.fieldEncoding.set(file, int32(d.GetRepeatedFieldEncoding()), nil, func(fs *descriptorpb.FeatureSet, v int32) {
+		fs.RepeatedFieldEncoding = descriptorpb.FeatureSet_RepeatedFieldEncoding(v).Enum()
+	})
+	m.enumType.set(file, int32(d.GetEnumType()), nil, func(fs *descriptorpb.FeatureSet, v int32) {
+		fs.EnumType = descriptorpb.FeatureSet_EnumType(v).Enum()
+	})
+	m.utf8Validation.set(file, int32(d.GetUtf8Validation()), nil, func(fs *descriptorpb.FeatureSet, v int32) {
+		fs.Utf8Validation = descriptorpb.FeatureSet_Utf8Validation(v).Enum()
+	})
+	// Setting DELIMITED on the file would also apply it to map fields,
+	// so it is only set on group fields.
+	m.messageEncoding.set(file, int32(d.GetMessageEncoding()), map[int32]bool{
+		int32(descriptorpb.FeatureSet_DELIMITED): true,
+	}, func(fs *descriptorpb.FeatureSet, v int32) {
+		fs.MessageEncoding = descriptorpb.FeatureSet_MessageEncoding(v).Enum()
+	})
+
+	var goDefault int32
+	if gf, ok := proto.GetExtension(d, gofeaturespb.E_Go).(*gofeaturespb.GoFeatures); ok && gf.GetLegacyUnmarshalJsonEnum() {
+		goDefault = 1
+	}
+	m.legacyJSONEnum.set(file, goDefault, nil, func(fs *descriptorpb.FeatureSet, v int32) {
+		gf, _ := proto.GetExtension(fs, gofeaturespb.E_Go).(*gofeaturespb.GoFeatures)
+		if gf == nil {
+			gf = new(gofeaturespb.GoFeatures)
+		}
+		gf.LegacyUnmarshalJsonEnum = proto.Bool(v == 1)
+		proto.SetExtension(fs, gofeaturespb.E_Go, gf)
+		m.addImport(goFeaturesPath)
+	})
+}
+
+// addImport adds an import of the file at path, if it is neither the migrated
+// file itself, which uses its own declarations without importing them,
+// nor imported yet.
+func (m *migrator) addImport(path string) {
+	if path == m.file.GetName() {
+		return
+	}
+	for _, dep := range m.file.GetDependency() {
+		if dep == path {
+			return
+		}
+	}
+	m.file.Dependency = append(m.file.Dependency, path)
+}
+
+// isPackable reports whether fd can use packed encoding.
+func isPackable(fd protoreflect.FieldDescriptor) bool {
+	switch fd.Kind() {
+	case protoreflect.StringKind, protoreflect.BytesKind, protoreflect.MessageKind, protoreflect.GroupKind:
+		return false
+	}
+	return true
+}
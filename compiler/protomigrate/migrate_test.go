@@ -0,0 +1,371 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protomigrate_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/compiler/protomigrate"
+	"google.golang.org/protobuf/compiler/protoparse"
+	"google.golang.org/protobuf/compiler/protoprint"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/gofeaturespb"
+
+	proto2pb "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/proto2"
+	proto3pb "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/proto3"
+	testfuzzpb "google.golang.org/protobuf/internal/testprotos/editionsfuzztest"
+	enumspb "google.golang.org/protobuf/internal/testprotos/enums"
+	test3pb "google.golang.org/protobuf/internal/testprotos/test3"
+)
+
+// parse parses the source of the file test.proto.
+func parse(t *testing.T, src string) *descriptorpb.FileDescriptorProto {
+	t.Helper()
+	fds, err := protoparse.Parser{FS: fstest.MapFS{"test.proto": {Data: []byte(src)}}}.Parse("test.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fds[len(fds)-1]
+}
+
+func TestMigrate(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		edition descriptorpb.Edition
+		src     string
+		want    string
+	}{{
+		desc: "proto2",
+		src: `syntax = "proto2";
+package test;
+
+// Leading comment for M.
+message M {
+  optional int32 a = 1;
+  required string b = 2; // Trailing comment for b.
+  repeated int32 c = 3;
+  repeated int32 d = 4 [packed = true, deprecated = true];
+  optional group G = 5 {
+    optional int32 x = 1;
+  }
+  map<string, E> m = 6;
+  oneof o {
+    int32 e = 7;
+  }
+  extensions 100 to 200;
+}
+
+enum E {
+  E_ZERO = 0;
+}
+
+extend M {
+  repeated int64 ext = 100 [packed = true];
+}
+`,
+		want: `edition = "2023";
+
+package test;
+
+import "google/protobuf/go_features.proto";
+
+option features.utf8_validation = NONE;
+
+enum E {
+  option features.enum_type = CLOSED;
+  option features.(pb.go).legacy_unmarshal_json_enum = true;
+
+  E_ZERO = 0;
+}
+
+// Leading comment for M.
+message M {
+  message G {
+    int32 x = 1;
+  }
+
+  int32 a = 1;
+  string b = 2 [features.field_presence = LEGACY_REQUIRED]; // Trailing comment for b.
+  repeated int32 c = 3 [features.repeated_field_encoding = EXPANDED];
+  repeated int32 d = 4 [deprecated = true];
+  .test.M.G g = 5 [features.message_encoding = DELIMITED];
+  map<string, .test.E> m = 6;
+
+  oneof o {
+    int32 e = 7;
+  }
+
+  extensions 100 to 200;
+}
+
+extend .test.M {
+  repeated int64 ext = 100;
+}
+`,
+	}, {
+		desc: "proto3",
+		src: `syntax = "proto3";
+package test;
+
+message M {
+  int32 a = 1;
+  optional int32 b = 2;
+  repeated int32 c = 3;
+  repeated int32 d = 4 [packed = false];
+  string s = 5;
+  M m = 6;
+  map<string, string> ms = 7;
+  oneof o {
+    int32 e = 8;
+  }
+  E en = 9;
+}
+
+enum E {
+  E_ZERO = 0;
+}
+`,
+		want: `edition = "2023";
+
+package test;
+
+option features.field_presence = IMPLICIT;
+
+enum E {
+  E_ZERO = 0;
+}
+
+message M {
+  int32 a = 1;
+  int32 b = 2 [features.field_presence = EXPLICIT];
+  repeated int32 c = 3;
+  repeated int32 d = 4 [features.repeated_field_encoding = EXPANDED];
+  string s = 5;
+  .test.M m = 6;
+  map<string, string> ms = 7;
+
+  oneof o {
+    int32 e = 8;
+  }
+
+  .test.E en = 9;
+}
+`,
+	}, {
+		desc: "tie keeps the default",
+		src: `syntax = "proto3";
+package test;
+
+message M {
+  int32 a = 1;
+  int32 b = 2;
+  optional int32 c = 3;
+}
+`,
+		want: `edition = "2023";
+
+package test;
+
+message M {
+  int32 a = 1 [features.field_presence = IMPLICIT];
+  int32 b = 2 [features.field_presence = IMPLICIT];
+  int32 c = 3;
+}
+`,
+	}, {
+		desc:    "edition 2024",
+		edition: descriptorpb.Edition_EDITION_2024,
+		src: `syntax = "proto3";
+package test;
+
+message M {
+  int32 a = 1;
+}
+`,
+		want: `edition = "2024";
+
+package test;
+
+message M {
+  int32 a = 1 [features.field_presence = IMPLICIT];
+}
+`,
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			out, err := protomigrate.Options{Edition: tt.edition}.Migrate(parse(t, tt.src))
+			if err != nil {
+				t.Fatalf("Migrate() error: %v", err)
+			}
+			fd, err := protodesc.NewFile(out, protoregistry.GlobalFiles)
+			if err != nil {
+				t.Fatalf("NewFile() error: %v", err)
+			}
+			got := protoprint.Format(fd)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Migrate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestMigrateFiles checks that the files of the test protos migrate to
+// equivalent files.
+func TestMigrateFiles(t *testing.T) {
+	for _, fd := range []protoreflect.FileDescriptor{
+		proto2pb.File_cmd_protoc_gen_go_testdata_proto2_fields_proto,
+		proto2pb.File_cmd_protoc_gen_go_testdata_proto2_nested_messages_proto,
+		proto3pb.File_cmd_protoc_gen_go_testdata_proto3_fields_proto,
+		enumspb.File_internal_testprotos_enums_enums_proto,
+		testfuzzpb.File_internal_testprotos_editionsfuzztest_test2_proto,
+		testfuzzpb.File_internal_testprotos_editionsfuzztest_test3_proto,
+		test3pb.File_internal_testprotos_test3_test_proto,
+		test3pb.File_internal_testprotos_test3_test_extension_proto,
+		descriptorpb.File_google_protobuf_descriptor_proto,
+		gofeaturespb.File_google_protobuf_go_features_proto,
+	} {
+		t.Run(fd.Path(), func(t *testing.T) {
+			for _, edition := range []descriptorpb.Edition{
+				descriptorpb.Edition_EDITION_2023,
+				descriptorpb.Edition_EDITION_2024,
+			} {
+				out, err := protomigrate.Options{Edition: edition}.Migrate(protodesc.ToFileDescriptorProto(fd))
+				if err != nil {
+					t.Fatalf("Migrate() to %v error: %v", edition, err)
+				}
+				if got := out.GetEdition(); got != edition {
+					t.Errorf("Migrate() to %v: edition = %v", edition, got)
+				}
+			}
+		})
+	}
+}
+
+func TestMigrateErrors(t *testing.T) {
+	proto3 := parse(t, `syntax = "proto3"; message M {}`)
+	for _, tt := range []struct {
+		desc string
+		opts protomigrate.Options
+		fd   *descriptorpb.FileDescriptorProto
+		want string
+	}{{
+		desc: "editions file",
+		fd:   parse(t, `edition = "2023"; message M {}`),
+		want: `cannot migrate a file with syntax "editions"`,
+	}, {
+		desc: "edition before 2023",
+		opts: protomigrate.Options{Edition: descriptorpb.Edition_EDITION_PROTO3},
+		fd:   proto3,
+		want: "unsupported edition EDITION_PROTO3",
+	}, {
+		desc: "unsupported edition",
+		opts: protomigrate.Options{Edition: descriptorpb.Edition_EDITION_MAX},
+		fd:   proto3,
+		want: "unsupported edition EDITION_MAX",
+	}, {
+		desc: "unresolvable dependency",
+		fd: &descriptorpb.FileDescriptorProto{
+			Name:       proto.String("test.proto"),
+			Dependency: []string{"missing.proto"},
+		},
+		want: "missing.proto",
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := tt.opts.Migrate(tt.fd)
+			if err == nil {
+				t.Fatalf("Migrate() succeeded, want error containing %q", tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Migrate() error = %q, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	src := parse(t, `syntax = "proto2";
+package test;
+message M {
+  required int32 a = 1;
+  repeated int32 b = 2;
+  optional string c = 3;
+  optional group G = 4 {}
+}
+enum E {
+  E_ZERO = 0;
+}
+`)
+	orig, err := protodesc.NewFile(src, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := protomigrate.Migrate(src)
+	if err != nil {
+		t.Fatalf("Migrate() error: %v", err)
+	}
+
+	for _, tt := range []struct {
+		desc   string
+		modify func(*descriptorpb.FileDescriptorProto)
+		want   string
+	}{{
+		desc:   "unmodified",
+		modify: func(*descriptorpb.FileDescriptorProto) {},
+	}, {
+		desc: "required field",
+		modify: func(fd *descriptorpb.FileDescriptorProto) {
+			fd.MessageType[0].Field[0].Options = nil
+		},
+		want: "test.M.a: Cardinality() = optional, want required",
+	}, {
+		desc: "expanded field",
+		modify: func(fd *descriptorpb.FileDescriptorProto) {
+			fd.MessageType[0].Field[1].Options = nil
+		},
+		want: "test.M.b: IsPacked() = true, want false",
+	}, {
+		desc: "string field",
+		modify: func(fd *descriptorpb.FileDescriptorProto) {
+			fd.MessageType[0].Field[2].Options = nil
+		},
+		want: "test.M.c: utf8_validation = true, want false",
+	}, {
+		desc: "group field",
+		modify: func(fd *descriptorpb.FileDescriptorProto) {
+			fd.MessageType[0].Field[3].Options = nil
+		},
+		want: "test.M.g: Kind() = message, want group",
+	}, {
+		desc: "closed enum",
+		modify: func(fd *descriptorpb.FileDescriptorProto) {
+			fd.EnumType[0].Options = nil
+		},
+		want: "test.E: IsClosed() = false, want true",
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			fdp := proto.Clone(out).(*descriptorpb.FileDescriptorProto)
+			tt.modify(fdp)
+			migrated, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = protomigrate.Verify(orig, migrated)
+			switch {
+			case err == nil && tt.want != "":
+				t.Errorf("Verify() succeeded, want error containing %q", tt.want)
+			case err != nil && tt.want == "":
+				t.Errorf("Verify() error: %v", err)
+			case err != nil && !strings.Contains(err.Error(), tt.want):
+				t.Errorf("Verify() error = %q, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protomigrate
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/filedesc"
+	"google.golang.org/protobuf/internal/strs"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"google.golang.org/protobuf/types/gofeaturespb"
+)
+
+// Verify reports whether the declarations of the files original and migrated
+// are equivalent, such that they behave identically in the wire format,
+// the JSON format and the generated Go code, regardless of the syntax or
+// edition of each file. Both files must declare the same types.
+func Verify(original, migrated protoreflect.FileDescriptor) error {
+	v := verifier{}
+	v.verifyMessages(original.Messages(), migrated.Messages())
+	v.verifyEnums(original.Enums(), migrated.Enums())
+	v.verifyFields(original.Extensions(), migrated.Extensions())
+	if len(v.errs) > 0 {
+		return errors.New("%q: migrated file is not equivalent: %v", original.Path(), v.errs[0])
+	}
+	return nil
+}
+
+type verifier struct {
+	errs []string
+}
+
+func (v *verifier) errorf(d protoreflect.Descriptor, f string, x ...any) {
+	v.errs = append(v.errs, fmt.Sprintf("%v: ", d.FullName())+fmt.Sprintf(f, x...))
+}
+
+// check records an error for d if the value got of the named property
+// differs from the value want.
+func (v *verifier) check(d protoreflect.Descriptor, name string, got, want any) {
+	if got != want {
+		v.errorf(d, "%s = %v, want %v", name, got, want)
+	}
+}
+
+func (v *verifier) verifyMessages(want, got protoreflect.MessageDescriptors) {
+	if want.Len() != got.Len() {
+		v.errs = append(v.errs, fmt.Sprintf("got %d messages, want %d", got.Len(), want.Len()))
+		return
+	}
+	for i := 0; i < want.Len(); i++ {
+		wmd, gmd := want.Get(i), got.Get(i)
+		v.check(wmd, "FullName()", gmd.FullName(), wmd.FullName())
+		v.check(wmd, "IsMapEntry()", gmd.IsMapEntry(), wmd.IsMapEntry())
+		v.check(wmd, "ExtensionRanges().Len()", gmd.ExtensionRanges().Len(), wmd.ExtensionRanges().Len())
+		if wmd.FullName() != gmd.FullName() {
+			continue
+		}
+		v.verifyFields(wmd.Fields(), gmd.Fields())
+		v.verifyMessages(wmd.Messages(), gmd.Messages())
+		v.verifyEnums(wmd.Enums(), gmd.Enums())
+		v.verifyFields(wmd.Extensions(), gmd.Extensions())
+	}
+}
+
+// fieldList is a list of fields or extensions.
+type fieldList interface {
+	Len() int
+	Get(i int) protoreflect.FieldDescriptor
+}
+
+func (v *verifier) verifyFields(want, got fieldList) {
+	if want.Len() != got.Len() {
+		v.errs = append(v.errs, fmt.Sprintf("got %d fields, want %d", got.Len(), want.Len()))
+		return
+	}
+	for i := 0; i < want.Len(); i++ {
+		wfd, gfd := want.Get(i), got.Get(i)
+		v.check(wfd, "FullName()", gfd.FullName(), wfd.FullName())
+		v.check(wfd, "Number()", gfd.Number(), wfd.Number())
+		v.check(wfd, "Kind()", gfd.Kind(), wfd.Kind())
+		v.check(wfd, "Cardinality()", gfd.Cardinality(), wfd.Cardinality())
+		v.check(wfd, "IsPacked()", gfd.IsPacked(), wfd.IsPacked())
+		v.check(wfd, "IsMap()", gfd.IsMap(), wfd.IsMap())
+		v.check(wfd, "JSONName()", gfd.JSONName(), wfd.JSONName())
+		v.check(wfd, "TextName()", gfd.TextName(), wfd.TextName())
+		v.check(wfd, "HasDefault()", gfd.HasDefault(), wfd.HasDefault())
+		if wfd.HasDefault() && gfd.HasDefault() {
+			v.check(wfd, "Default()", fmt.Sprint(gfd.Default()), fmt.Sprint(wfd.Default()))
+		}
+		v.check(wfd, "ContainingOneof()", realOneofName(gfd), realOneofName(wfd))
+		// The presence of the fields of map entries is irrelevant,
+		// since a map entry always has both a key and a value.
+		if wfd.IsExtension() || !wfd.ContainingMessage().IsMapEntry() {
+			v.check(wfd, "HasPresence()", gfd.HasPresence(), wfd.HasPresence())
+		}
+		if wfd.Kind() == protoreflect.StringKind {
+			v.check(wfd, "utf8_validation", utf8Validated(gfd), utf8Validated(wfd))
+		}
+		if wfd.Enum() != nil && gfd.Enum() != nil {
+			v.check(wfd, "Enum()", gfd.Enum().FullName(), wfd.Enum().FullName())
+			v.check(wfd, "Enum().IsClosed()", gfd.Enum().IsClosed(), wfd.Enum().IsClosed())
+		}
+		if wfd.Message() != nil && gfd.Message() != nil {
+			v.check(wfd, "Message()", gfd.Message().FullName(), wfd.Message().FullName())
+		}
+		if wfd.IsExtension() && gfd.IsExtension() {
+			v.check(wfd, "ContainingMessage()", gfd.ContainingMessage().FullName(), wfd.ContainingMessage().FullName())
+		}
+	}
+}
+
+func (v *verifier) verifyEnums(want, got protoreflect.EnumDescriptors) {
+	if want.Len() != got.Len() {
+		v.errs = append(v.errs, fmt.Sprintf("got %d enums, want %d", got.Len(), want.Len()))
+		return
+	}
+	for i := 0; i < want.Len(); i++ {
+		wed, ged := want.Get(i), got.Get(i)
+		v.check(wed, "FullName()", ged.FullName(), wed.FullName())
+		v.check(wed, "IsClosed()", ged.IsClosed(), wed.IsClosed())
+		v.check(wed, "Values().Len()", ged.Values().Len(), wed.Values().Len())
+		v.check(wed, "legacy_unmarshal_json_enum", legacyUnmarshalJSONEnum(ged), legacyUnmarshalJSONEnum(wed))
+	}
+}
+
+// realOneofName returns the name of the oneof that contains fd,
+// or the empty string if fd is not in a oneof or is a proto3 optional field.
+func realOneofName(fd protoreflect.FieldDescriptor) protoreflect.Name {
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		return od.Name()
+	}
+	return ""
+}
+
+// utf8Validated reports whether the utf8_validation feature of fd is VERIFY.
+//
+// The feature is compared rather than the validation done by the runtime,
+// which does not validate the string extensions of editions files.
+func utf8Validated(fd protoreflect.FieldDescriptor) bool {
+	switch fd := fd.(type) {
+	case *filedesc.Field:
+		return fd.L1.EditionFeatures.IsUTF8Validated
+	case *filedesc.Extension:
+		return fd.L1.EditionFeatures.IsUTF8Validated
+	}
+	return strs.EnforceUTF8(fd)
+}
+
+// legacyUnmarshalJSONEnum reports whether the deprecated UnmarshalJSON
+// method is generated for ed.
+func legacyUnmarshalJSONEnum(ed protoreflect.EnumDescriptor) bool {
+	gf, err := protodesc.ResolveFeatures(ed, gofeaturespb.E_Go)
+	return err == nil && gf.(*gofeaturespb.GoFeatures).GetLegacyUnmarshalJsonEnum()
+}
@@ -0,0 +1,559 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int8
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenSymbol
+)
+
+// pos is a position in a source file, where the line and column start at 0.
+// Columns are counted in bytes, except that tabs advance the column to the
+// next multiple of 8, matching protoc.
+type pos struct {
+	line, col int
+}
+
+type token struct {
+	kind tokenKind
+	text string // raw text of the token
+	str  string // decoded value of a string token
+
+	start, end pos
+
+	// leading is the comment directly preceding the token,
+	// or nil if there is none.
+	leading *string
+	// detached are the comments preceding the token that are separated
+	// from it by a blank line.
+	detached []string
+	// trailing is the comment following the token,
+	// or nil if there is none.
+	trailing *string
+}
+
+// lexer splits the input into tokens and attributes comments to tokens
+// following the rules of protoc.
+type lexer struct {
+	filename string
+	in       string
+	off      int
+	line     int
+	col      int
+}
+
+func (l *lexer) pos() pos { return pos{l.line, l.col} }
+
+func (l *lexer) peekByte(i int) byte {
+	if l.off+i < len(l.in) {
+		return l.in[l.off+i]
+	}
+	return 0
+}
+
+// advance consumes n bytes of input, updating the line and column.
+func (l *lexer) advance(n int) {
+	for ; n > 0; n-- {
+		switch l.in[l.off] {
+		case '\n':
+			l.line++
+			l.col = 0
+		case '\t':
+			l.col += 8 - l.col%8
+		default:
+			l.col++
+		}
+		l.off++
+	}
+}
+
+func (l *lexer) errorf(p pos, f string, x ...any) error {
+	return &Error{Filename: l.filename, Line: p.line + 1, Column: p.col + 1, Message: fmt.Sprintf(f, x...)}
+}
+
+// tokenize splits the entire input into tokens, ending with an EOF token.
+func (l *lexer) tokenize() ([]*token, error) {
+	if strings.HasPrefix(l.in, "\uFEFF") {
+		l.off = len("\uFEFF") // ignore a leading byte order mark
+	}
+	var toks []*token
+	var prev *token
+	for {
+		c := commentCollector{canAttachToPrev: prev != nil}
+		tok, err := l.nextWithComments(prev, &c)
+		if err != nil {
+			return nil, err
+		}
+		if prev != nil && c.hasTrailing {
+			prev.trailing = &c.prevTrailing
+		}
+		tok.detached = c.detached
+		if c.hasComment {
+			leading := c.buf.String()
+			tok.leading = &leading
+		}
+		toks = append(toks, tok)
+		if tok.kind == tokenEOF {
+			return toks, nil
+		}
+		prev = tok
+	}
+}
+
+// commentCollector collects comments between two tokens.
+// See protoc: src/google/protobuf/io/tokenizer.cc (CommentCollector).
+type commentCollector struct {
+	prevTrailing string
+	hasTrailing  bool
+	detached     []string
+
+	buf             strings.Builder
+	hasComment      bool
+	isLineComment   bool
+	canAttachToPrev bool
+	numComments     int
+}
+
+func (c *commentCollector) startLineComment() {
+	// Consecutive line comments are combined, but not block comments.
+	if c.hasComment && !c.isLineComment {
+		c.flush()
+	}
+	c.hasComment = true
+	c.isLineComment = true
+}
+
+func (c *commentCollector) startBlockComment() {
+	if c.hasComment {
+		c.flush()
+	}
+	c.hasComment = true
+	c.isLineComment = false
+}
+
+func (c *commentCollector) clearBuffer() {
+	c.buf.Reset()
+	c.hasComment = false
+}
+
+// flush is called once the buffered comment is known to be complete and
+// not attached to the next token.
+func (c *commentCollector) flush() {
+	if !c.hasComment {
+		return
+	}
+	if c.canAttachToPrev {
+		c.prevTrailing += c.buf.String()
+		c.hasTrailing = true
+		c.canAttachToPrev = false
+	} else {
+		c.detached = append(c.detached, c.buf.String())
+	}
+	c.clearBuffer()
+	c.numComments++
+}
+
+func (c *commentCollector) maybeDetachComment() {
+	count := c.numComments
+	if c.hasComment {
+		count++
+	}
+	// If there is only one comment, it is ambiguous whether it belongs to
+	// the previous or the next token, so it is detached.
+	if count == 1 {
+		if c.hasTrailing {
+			c.detached = append([]string{c.prevTrailing}, c.detached...)
+			c.prevTrailing = ""
+			c.hasTrailing = false
+		}
+		c.canAttachToPrev = false
+		c.flush()
+	}
+}
+
+type commentStart int8
+
+const (
+	noComment commentStart = iota
+	lineComment
+	blockComment
+)
+
+func (l *lexer) tryCommentStart() commentStart {
+	if l.peekByte(0) == '/' {
+		switch l.peekByte(1) {
+		case '/':
+			l.advance(2)
+			return lineComment
+		case '*':
+			l.advance(2)
+			return blockComment
+		}
+	}
+	return noComment
+}
+
+func (l *lexer) skipSpaceNoNewline() {
+	for {
+		switch l.peekByte(0) {
+		case ' ', '\t', '\r', '\v', '\f':
+			l.advance(1)
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) tryNewline() bool {
+	if l.peekByte(0) == '\n' {
+		l.advance(1)
+		return true
+	}
+	return false
+}
+
+// consumeLineComment consumes the rest of a line comment, including the
+// trailing newline.
+func (l *lexer) consumeLineComment(c *commentCollector) {
+	i := strings.IndexByte(l.in[l.off:], '\n')
+	if i < 0 {
+		i = len(l.in) - l.off
+	} else {
+		i++
+	}
+	c.buf.WriteString(l.in[l.off : l.off+i])
+	l.advance(i)
+}
+
+// consumeBlockComment consumes the rest of a block comment. Continuation
+// lines have their leading whitespace and '*' removed, as in protoc.
+func (l *lexer) consumeBlockComment(c *commentCollector) error {
+	i := strings.Index(l.in[l.off:], "*/")
+	if i < 0 {
+		l.advance(len(l.in) - l.off)
+		return l.errorf(l.pos(), "End-of-file inside block comment.")
+	}
+	text := l.in[l.off : l.off+i]
+	l.advance(i + 2)
+	lines := strings.Split(text, "\n")
+	for j, line := range lines {
+		if j > 0 {
+			line = strings.TrimLeft(line, " \t\r\v\f")
+			if strings.HasPrefix(line, "*") {
+				line = line[1:]
+			}
+			c.buf.WriteByte('\n')
+		}
+		c.buf.WriteString(line)
+	}
+	return nil
+}
+
+// nextWithComments reads the next token and collects the comments before it.
+// See protoc: src/google/protobuf/io/tokenizer.cc (NextWithComments).
+func (l *lexer) nextWithComments(prev *token, c *commentCollector) (*token, error) {
+	prevLine := l.line
+	trailingCommentEndLine := -1
+	if prev != nil {
+		// A comment on the same line is attached to the previous token.
+		l.skipSpaceNoNewline()
+		switch l.tryCommentStart() {
+		case lineComment:
+			trailingCommentEndLine = l.line
+			c.startLineComment()
+			l.consumeLineComment(c)
+			c.flush()
+		case blockComment:
+			c.startBlockComment()
+			if err := l.consumeBlockComment(c); err != nil {
+				return nil, err
+			}
+			trailingCommentEndLine = l.line
+			l.skipSpaceNoNewline()
+			if !l.tryNewline() {
+				// The next token is on the same line,
+				// so the comment cannot be attributed.
+				c.clearBuffer()
+				return l.next()
+			}
+			c.flush()
+		case noComment:
+			if !l.tryNewline() {
+				return l.next()
+			}
+		}
+	} else {
+		c.canAttachToPrev = false
+	}
+
+	for {
+		l.skipSpaceNoNewline()
+		switch l.tryCommentStart() {
+		case lineComment:
+			c.startLineComment()
+			l.consumeLineComment(c)
+		case blockComment:
+			c.startBlockComment()
+			if err := l.consumeBlockComment(c); err != nil {
+				return nil, err
+			}
+			l.skipSpaceNoNewline()
+			l.tryNewline()
+		case noComment:
+			if l.tryNewline() {
+				// A blank line.
+				c.flush()
+				c.canAttachToPrev = false
+				continue
+			}
+			tok, err := l.next()
+			if err != nil {
+				return nil, err
+			}
+			if tok.kind == tokenEOF || tok.text == "}" || tok.text == "]" || tok.text == ")" {
+				// At the end of a scope, comments are not attached
+				// to the following token.
+				c.flush()
+			}
+			if tok.kind != tokenEOF && (prevLine == tok.start.line || trailingCommentEndLine == tok.start.line) {
+				c.maybeDetachComment()
+			}
+			return tok, nil
+		}
+	}
+}
+
+// next reads the next token, skipping any whitespace and comments.
+func (l *lexer) next() (*token, error) {
+	for {
+		for l.off < len(l.in) {
+			if c := l.in[l.off]; c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\v' || c == '\f' {
+				l.advance(1)
+				continue
+			}
+			break
+		}
+		var discard commentCollector
+		switch l.tryCommentStart() {
+		case lineComment:
+			l.consumeLineComment(&discard)
+			continue
+		case blockComment:
+			if err := l.consumeBlockComment(&discard); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		break
+	}
+
+	start := l.pos()
+	startOff := l.off
+	tok := &token{start: start}
+	if l.off >= len(l.in) {
+		tok.kind = tokenEOF
+		tok.end = start
+		return tok, nil
+	}
+
+	c := l.in[l.off]
+	switch {
+	case isLetter(c):
+		n := 1
+		for isLetter(l.peekByte(n)) || isDigit(l.peekByte(n)) {
+			n++
+		}
+		tok.kind = tokenIdent
+		l.advance(n)
+	case isDigit(c) || (c == '.' && isDigit(l.peekByte(1))):
+		kind, err := l.consumeNumber()
+		if err != nil {
+			return nil, err
+		}
+		tok.kind = kind
+	case c == '"' || c == '\'':
+		s, err := l.consumeString()
+		if err != nil {
+			return nil, err
+		}
+		tok.kind = tokenString
+		tok.str = s
+	default:
+		r, size := utf8.DecodeRuneInString(l.in[l.off:])
+		if r == utf8.RuneError || c < ' ' || c >= utf8.RuneSelf {
+			return nil, l.errorf(start, "Invalid control characters encountered in text.")
+		}
+		tok.kind = tokenSymbol
+		l.advance(size)
+	}
+	tok.text = l.in[startOff:l.off]
+	tok.end = l.pos()
+	return tok, nil
+}
+
+func (l *lexer) consumeNumber() (tokenKind, error) {
+	start := l.pos()
+	kind := tokenInt
+	n := 0
+	if l.peekByte(0) == '0' && (l.peekByte(1) == 'x' || l.peekByte(1) == 'X') {
+		n = 2
+		for isHexDigit(l.peekByte(n)) {
+			n++
+		}
+		if n == 2 {
+			return 0, l.errorf(start, "\"0x\" must be followed by hex digits.")
+		}
+	} else {
+		for isDigit(l.peekByte(n)) {
+			n++
+		}
+		if l.peekByte(n) == '.' {
+			kind = tokenFloat
+			n++
+			for isDigit(l.peekByte(n)) {
+				n++
+			}
+		}
+		if c := l.peekByte(n); c == 'e' || c == 'E' {
+			kind = tokenFloat
+			n++
+			if c := l.peekByte(n); c == '+' || c == '-' {
+				n++
+			}
+			if !isDigit(l.peekByte(n)) {
+				l.advance(n)
+				return 0, l.errorf(l.pos(), "\"e\" must be followed by exponent.")
+			}
+			for isDigit(l.peekByte(n)) {
+				n++
+			}
+		}
+		if kind == tokenInt && n > 1 && l.peekByte(0) == '0' {
+			for i := 1; i < n; i++ {
+				if c := l.peekByte(i); c == '8' || c == '9' {
+					return 0, l.errorf(start, "Numbers starting with leading zero must be in octal.")
+				}
+			}
+		}
+	}
+	if c := l.peekByte(n); isLetter(c) || c == '.' {
+		l.advance(n)
+		return 0, l.errorf(l.pos(), "Need space between number and identifier.")
+	}
+	l.advance(n)
+	return kind, nil
+}
+
+// consumeString consumes a quoted string and returns its decoded value.
+func (l *lexer) consumeString() (string, error) {
+	quote := l.in[l.off]
+	l.advance(1)
+	var b []byte
+	for {
+		if l.off >= len(l.in) || l.in[l.off] == '\n' {
+			return "", l.errorf(l.pos(), "Unexpected end of string.")
+		}
+		c := l.in[l.off]
+		switch {
+		case c == quote:
+			l.advance(1)
+			return string(b), nil
+		case c == '\\':
+			start := l.pos()
+			n, r, err := unescape(l.in[l.off:])
+			if err != nil {
+				return "", l.errorf(start, "%v", err)
+			}
+			b = append(b, r...)
+			l.advance(n)
+		default:
+			b = append(b, c)
+			l.advance(1)
+		}
+	}
+}
+
+// unescape decodes the escape sequence at the start of s, returning its
+// length and the bytes it represents.
+func unescape(s string) (int, []byte, error) {
+	if len(s) < 2 {
+		return 0, nil, fmt.Errorf("Invalid escape sequence in string literal.")
+	}
+	switch c := s[1]; c {
+	case 'a':
+		return 2, []byte{'\a'}, nil
+	case 'b':
+		return 2, []byte{'\b'}, nil
+	case 'f':
+		return 2, []byte{'\f'}, nil
+	case 'n':
+		return 2, []byte{'\n'}, nil
+	case 'r':
+		return 2, []byte{'\r'}, nil
+	case 't':
+		return 2, []byte{'\t'}, nil
+	case 'v':
+		return 2, []byte{'\v'}, nil
+	case '\\', '\'', '"', '?':
+		return 2, []byte{c}, nil
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		n := 2
+		for n < 4 && n < len(s) && '0' <= s[n] && s[n] <= '7' {
+			n++
+		}
+		v, _ := strconv.ParseUint(s[1:n], 8, 32)
+		return n, []byte{byte(v)}, nil
+	case 'x', 'X':
+		n := 2
+		for n < 4 && n < len(s) && isHexDigit(s[n]) {
+			n++
+		}
+		if n == 2 {
+			return 0, nil, fmt.Errorf("Expected hex digits for escape sequence.")
+		}
+		v, _ := strconv.ParseUint(s[2:n], 16, 8)
+		return n, []byte{byte(v)}, nil
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if len(s) < 2+size {
+			return 0, nil, fmt.Errorf("Expected four hex digits for \\u escape sequence.")
+		}
+		v, err := strconv.ParseUint(s[2:2+size], 16, 32)
+		if err != nil || v > utf8.MaxRune {
+			return 0, nil, fmt.Errorf("Expected four hex digits for \\u escape sequence.")
+		}
+		return 2 + size, utf8.AppendRune(nil, rune(v)), nil
+	default:
+		return 0, nil, fmt.Errorf("Invalid escape sequence in string literal.")
+	}
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
@@ -0,0 +1,649 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoparse
+
+import (
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+type symbolKind int8
+
+const (
+	packageSymbol symbolKind = iota
+	messageSymbol
+	enumSymbol
+	enumValueSymbol
+	fieldSymbol
+	oneofSymbol
+	serviceSymbol
+	methodSymbol
+)
+
+// symbol is a named element of a file.
+type symbol struct {
+	kind symbolKind
+	file string // the file that declares the symbol
+
+	msg   *messageProto // for messages
+	enum  *enumProto    // for enums
+	field *fieldProto   // for fields and extensions
+}
+
+func (s *symbol) isType() bool {
+	return s.kind == messageSymbol || s.kind == enumSymbol
+}
+
+// isAggregate reports whether the symbol may contain other symbols.
+func (s *symbol) isAggregate() bool {
+	return s.isType() || s.kind == packageSymbol || s.kind == serviceSymbol
+}
+
+// extensionKey identifies an extension by its extendee and field number.
+type extensionKey struct {
+	extendee string
+	number   int32
+}
+
+// extension is the declaration of an extension.
+type extension struct {
+	name string // the fully-qualified name of the extension
+	file string // the file that declares the extension
+}
+
+// addSymbols adds the symbols declared by the file to the symbol table,
+// reporting an error if any of them is already defined.
+func (c *compiler) addSymbols(f *parsedFile) error {
+	fd := f.fd
+	name := fd.GetName()
+	if pkg := fd.GetPackage(); pkg != "" {
+		for i := 0; i <= len(pkg); i++ {
+			if i < len(pkg) && pkg[i] != '.' {
+				continue
+			}
+			s := c.symbols[pkg[:i]]
+			if s == nil {
+				c.symbols[pkg[:i]] = &symbol{kind: packageSymbol, file: name}
+			} else if s.kind != packageSymbol {
+				return f.errorAt(join(nil, genid.FileDescriptorProto_Package_field_number),
+					"\"%s\" is already defined (as something other than a package) in file \"%s\".", pkg[:i], s.file)
+			}
+		}
+	}
+	w := &symbolAdder{c: c, f: f}
+	scope := fd.GetPackage()
+	for i, m := range fd.MessageType {
+		if err := w.addMessage(m, scope, join(nil, genid.FileDescriptorProto_MessageType_field_number, i)); err != nil {
+			return err
+		}
+	}
+	for i, e := range fd.EnumType {
+		if err := w.addEnum(e, scope, join(nil, genid.FileDescriptorProto_EnumType_field_number, i)); err != nil {
+			return err
+		}
+	}
+	for i, x := range fd.Extension {
+		path := join(nil, genid.FileDescriptorProto_Extension_field_number, i)
+		if err := w.add(scope, x.GetName(), &symbol{kind: fieldSymbol, field: x}, path); err != nil {
+			return err
+		}
+	}
+	for i, s := range fd.Service {
+		path := join(nil, genid.FileDescriptorProto_Service_field_number, i)
+		if err := w.add(scope, s.GetName(), &symbol{kind: serviceSymbol}, path); err != nil {
+			return err
+		}
+		for j, m := range s.Method {
+			path := join(path, genid.ServiceDescriptorProto_Method_field_number, j)
+			if err := w.add(qualify(scope, s.GetName()), m.GetName(), &symbol{kind: methodSymbol}, path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type symbolAdder struct {
+	c *compiler
+	f *parsedFile
+}
+
+// add adds the symbol named name within scope. The path is the path of the
+// element that declares it.
+func (w *symbolAdder) add(scope, name string, s *symbol, path []int32) error {
+	full := qualify(scope, name)
+	s.file = w.f.fd.GetName()
+	if prev := w.c.symbols[full]; prev != nil {
+		// The name of every element is field 1 of its descriptor.
+		path = join(path, genid.DescriptorProto_Name_field_number)
+		switch {
+		case prev.file != s.file:
+			return w.f.errorAt(path, "\"%s\" is already defined in file \"%s\".", full, prev.file)
+		case scope == "":
+			return w.f.errorAt(path, "\"%s\" is already defined.", full)
+		case s.kind == enumValueSymbol && prev.kind == enumValueSymbol:
+			return w.f.errorAt(path, "\"%s\" is already defined in \"%s\".  "+
+				"Note that enum values use C++ scoping rules, meaning that enum values are siblings of their type, not members of it.  "+
+				"Therefore, \"%s\" must be unique within \"%s\", not just within the enum.", name, scope, name, scope)
+		default:
+			return w.f.errorAt(path, "\"%s\" is already defined in \"%s\".", name, scope)
+		}
+	}
+	w.c.symbols[full] = s
+	if x := s.field; x != nil && w.f.desc != nil && x.GetExtendee() != "" {
+		// The extension belongs to a file that has already been linked.
+		key := extensionKey{x.GetExtendee()[1:], x.GetNumber()}
+		w.c.exts[key] = extension{full, s.file}
+	}
+	return nil
+}
+
+func (w *symbolAdder) addMessage(m *messageProto, scope string, path []int32) error {
+	if err := w.add(scope, m.GetName(), &symbol{kind: messageSymbol, msg: m}, path); err != nil {
+		return err
+	}
+	scope = qualify(scope, m.GetName())
+	for i, f := range m.Field {
+		if err := w.add(scope, f.GetName(), &symbol{kind: fieldSymbol, field: f}, join(path, genid.DescriptorProto_Field_field_number, i)); err != nil {
+			return err
+		}
+	}
+	for i, o := range m.OneofDecl {
+		if err := w.add(scope, o.GetName(), &symbol{kind: oneofSymbol}, join(path, genid.DescriptorProto_OneofDecl_field_number, i)); err != nil {
+			return err
+		}
+	}
+	for i, n := range m.NestedType {
+		if err := w.addMessage(n, scope, join(path, genid.DescriptorProto_NestedType_field_number, i)); err != nil {
+			return err
+		}
+	}
+	for i, e := range m.EnumType {
+		if err := w.addEnum(e, scope, join(path, genid.DescriptorProto_EnumType_field_number, i)); err != nil {
+			return err
+		}
+	}
+	for i, x := range m.Extension {
+		if err := w.add(scope, x.GetName(), &symbol{kind: fieldSymbol, field: x}, join(path, genid.DescriptorProto_Extension_field_number, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *symbolAdder) addEnum(e *enumProto, scope string, path []int32) error {
+	if err := w.add(scope, e.GetName(), &symbol{kind: enumSymbol, enum: e}, path); err != nil {
+		return err
+	}
+	// Enum values are siblings of their enum rather than children of it.
+	for i, v := range e.Value {
+		if err := w.add(scope, v.GetName(), &symbol{kind: enumValueSymbol}, join(path, genid.EnumDescriptorProto_Value_field_number, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func qualify(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// resolver resolves names referenced by a file.
+type resolver struct {
+	c *compiler
+	f *parsedFile
+
+	// visible is the set of files whose symbols may be referenced,
+	// which are the file itself, its imports, and their public imports.
+	visible map[string]bool
+	// packages is the set of packages of the visible files.
+	packages []string
+}
+
+func (c *compiler) newResolver(f *parsedFile) *resolver {
+	r := &resolver{c: c, f: f, visible: make(map[string]bool)}
+	var visit func(fd *descriptorpb.FileDescriptorProto, direct bool)
+	visit = func(fd *descriptorpb.FileDescriptorProto, direct bool) {
+		if r.visible[fd.GetName()] {
+			return
+		}
+		r.visible[fd.GetName()] = true
+		r.packages = append(r.packages, fd.GetPackage())
+		for i, dep := range fd.Dependency {
+			isPublic := false
+			for _, j := range fd.PublicDependency {
+				isPublic = isPublic || int(j) == i
+			}
+			if direct || isPublic {
+				visit(c.results[dep].fd, false)
+			}
+		}
+	}
+	visit(f.fd, true)
+	return r
+}
+
+// resolution is the result of resolving a name.
+type resolution struct {
+	name string // the fully-qualified name of the symbol
+	sym  *symbol
+
+	// notImported is the file that declares the symbol if it is not visible.
+	notImported string
+	// undefined is the name that a partially-qualified name resolved to
+	// when it does not exist.
+	undefined string
+}
+
+// find looks up a fully-qualified name among the visible symbols.
+func (r *resolver) find(name string, res *resolution) *symbol {
+	s := r.c.symbols[name]
+	switch {
+	case s == nil:
+		return nil
+	case s.kind == packageSymbol:
+		// A package is visible if any visible file declares it
+		// or one of its sub-packages.
+		for _, pkg := range r.packages {
+			if pkg == name || strings.HasPrefix(pkg, name+".") {
+				return s
+			}
+		}
+	case r.visible[s.file]:
+		return s
+	}
+	if res.notImported == "" {
+		res.notImported = s.file
+	}
+	return nil
+}
+
+// resolve resolves name relative to the scope of the element named
+// relativeTo, searching the innermost scope first as protoc does.
+// If onlyTypes is set, symbols other than messages and enums are skipped
+// when the name matches them exactly.
+func (r *resolver) resolve(name, relativeTo string, onlyTypes bool) resolution {
+	var res resolution
+	if strings.HasPrefix(name, ".") {
+		res.name = name[1:]
+		res.sym = r.find(res.name, &res)
+		return res
+	}
+	firstPart, _, hasDot := strings.Cut(name, ".")
+	scope := relativeTo
+	for {
+		i := strings.LastIndexByte(scope, '.')
+		if i < 0 {
+			res.name = name
+			res.sym = r.find(name, &res)
+			return res
+		}
+		scope = scope[:i]
+		s := r.find(scope+"."+firstPart, &res)
+		if s == nil {
+			continue
+		}
+		if hasDot {
+			if s.isAggregate() {
+				// The first part of the name determines the scope
+				// of the rest, even if the rest is not found.
+				res.name = scope + "." + name
+				res.sym = r.find(res.name, &res)
+				if res.sym == nil {
+					res.undefined = res.name
+				}
+				return res
+			}
+		} else if !onlyTypes || s.isType() {
+			res.name = scope + "." + name
+			res.sym = s
+			return res
+		}
+	}
+}
+
+// undefinedError returns the error for a name that could not be resolved.
+func (r *resolver) undefinedError(path []int32, name string, res resolution) error {
+	switch {
+	case res.undefined != "":
+		return r.f.errorAt(path, "\"%s\" is resolved to \"%s\", which is not defined. "+
+			"The innermost scope is searched first in name resolution. "+
+			"Consider using a leading '.'(i.e., \".%s\") to start from the outermost scope.", name, res.undefined, name)
+	case res.notImported != "":
+		return r.f.errorAt(path, "\"%s\" seems to be defined in \"%s\", which is not imported by \"%s\".  "+
+			"To use it here, please add the necessary import.", name, res.notImported, r.f.fd.GetName())
+	default:
+		return r.f.errorAt(path, "\"%s\" is not defined.", name)
+	}
+}
+
+// link adds the symbols of the file to the symbol table, resolves the names
+// that it references, and checks the field numbers that it declares.
+func (c *compiler) link(f *parsedFile) error {
+	if err := c.addSymbols(f); err != nil {
+		return err
+	}
+	l := &linker{resolver: c.newResolver(f)}
+	fd := f.fd
+	scope := fd.GetPackage()
+	for i, m := range fd.MessageType {
+		if err := l.linkMessage(m, scope, join(nil, genid.FileDescriptorProto_MessageType_field_number, i)); err != nil {
+			return err
+		}
+	}
+	for i, x := range fd.Extension {
+		if err := l.linkExtension(x, scope, join(nil, genid.FileDescriptorProto_Extension_field_number, i)); err != nil {
+			return err
+		}
+	}
+	for i, s := range fd.Service {
+		if err := l.linkService(s, scope, join(nil, genid.FileDescriptorProto_Service_field_number, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type linker struct {
+	*resolver
+}
+
+func (l *linker) linkMessage(m *messageProto, scope string, path []int32) error {
+	scope = qualify(scope, m.GetName())
+	for i, f := range m.Field {
+		if err := l.linkField(f, scope, join(path, genid.DescriptorProto_Field_field_number, i)); err != nil {
+			return err
+		}
+	}
+	for i, x := range m.Extension {
+		if err := l.linkExtension(x, scope, join(path, genid.DescriptorProto_Extension_field_number, i)); err != nil {
+			return err
+		}
+	}
+	for i, n := range m.NestedType {
+		if err := l.linkMessage(n, scope, join(path, genid.DescriptorProto_NestedType_field_number, i)); err != nil {
+			return err
+		}
+	}
+	return l.checkMessageNumbers(m, scope, path)
+}
+
+// linkField resolves the type of the field f, which is declared in scope.
+func (l *linker) linkField(f *fieldProto, scope string, path []int32) error {
+	relativeTo := qualify(scope, f.GetName())
+	if f.TypeName != nil {
+		name := f.GetTypeName()
+		typePath := join(path, genid.FieldDescriptorProto_TypeName_field_number)
+		res := l.resolve(name, relativeTo, true)
+		switch {
+		case res.sym == nil:
+			return l.undefinedError(typePath, name, res)
+		case !res.sym.isType():
+			return l.f.errorAt(typePath, "\"%s\" is not a type.", name)
+		}
+		f.TypeName = proto.String("." + res.name)
+		switch {
+		case f.Type == nil && res.sym.kind == messageSymbol:
+			f.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		case f.Type == nil:
+			f.Type = descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum()
+		case res.sym.kind != messageSymbol:
+			return l.f.errorAt(typePath, "\"%s\" is not a message type.", name)
+		}
+	}
+	if f.DefaultValue != nil {
+		defaultPath := join(path, genid.FieldDescriptorProto_DefaultValue_field_number)
+		switch f.GetType() {
+		case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+			return l.f.errorAt(defaultPath, "Messages can't have default values.")
+		case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+			e := l.c.symbols[f.GetTypeName()[1:]].enum
+			found := false
+			for _, v := range e.Value {
+				found = found || v.GetName() == f.GetDefaultValue()
+			}
+			if !found {
+				return l.f.errorAt(defaultPath, "Enum type \"%s\" has no value named \"%s\".", f.GetTypeName()[1:], f.GetDefaultValue())
+			}
+		}
+	}
+	if f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED && f.DefaultValue != nil {
+		return l.f.errorAt(join(path, genid.FieldDescriptorProto_DefaultValue_field_number), "Repeated fields can't have default values.")
+	}
+	return nil
+}
+
+// linkExtension resolves the extendee and type of the extension x,
+// which is declared in scope, and checks its field number.
+func (l *linker) linkExtension(x *fieldProto, scope string, path []int32) error {
+	name := x.GetExtendee()
+	extendeePath := join(path, genid.FieldDescriptorProto_Extendee_field_number)
+	res := l.resolve(name, qualify(scope, x.GetName()), false)
+	switch {
+	case res.sym == nil:
+		return l.undefinedError(extendeePath, name, res)
+	case res.sym.kind != messageSymbol:
+		return l.f.errorAt(extendeePath, "\"%s\" is not a message type.", name)
+	}
+	x.Extendee = proto.String("." + res.name)
+	if err := l.linkField(x, scope, path); err != nil {
+		return err
+	}
+
+	if l.f.fd.GetSyntax() == "proto3" && !isOptionsMessage(res.name) {
+		return l.f.errorAt(extendeePath, "Extensions in proto3 are only allowed for defining options.")
+	}
+	numberPath := join(path, genid.FieldDescriptorProto_Number_field_number)
+	if err := l.checkFieldNumber(x, res.sym.msg, numberPath); err != nil {
+		return err
+	}
+	n := x.GetNumber()
+	inRange := false
+	for _, r := range res.sym.msg.ExtensionRange {
+		inRange = inRange || (r.GetStart() <= n && n < r.GetEnd())
+	}
+	if !inRange {
+		return l.f.errorAt(numberPath, "\"%s\" does not declare %d as an extension number.", res.name, n)
+	}
+	key := extensionKey{res.name, n}
+	if prev, ok := l.c.exts[key]; ok {
+		if prev.file == l.f.fd.GetName() {
+			return l.f.errorAt(numberPath, "Extension number %d has already been used in \"%s\" by extension \"%s\".", n, res.name, prev.name)
+		}
+		return l.f.errorAt(numberPath, "Extension number %d has already been used in \"%s\" by extension \"%s\" defined in %s.", n, res.name, prev.name, prev.file)
+	}
+	l.c.exts[key] = extension{qualify(scope, x.GetName()), l.f.fd.GetName()}
+	return nil
+}
+
+// isOptionsMessage reports whether the named message is one of the options
+// messages of descriptor.proto.
+func isOptionsMessage(name string) bool {
+	pkg, name, _ := strings.Cut(name, ".")
+	return pkg == "google" && strings.HasPrefix(name, "protobuf.") && strings.HasSuffix(name, "Options")
+}
+
+func (l *linker) linkService(s *serviceProto, scope string, path []int32) error {
+	scope = qualify(scope, s.GetName())
+	for i, m := range s.Method {
+		methodPath := join(path, genid.ServiceDescriptorProto_Method_field_number, i)
+		relativeTo := qualify(scope, m.GetName())
+		for _, t := range []struct {
+			name  *string
+			field protoreflect.FieldNumber
+		}{
+			{m.InputType, genid.MethodDescriptorProto_InputType_field_number},
+			{m.OutputType, genid.MethodDescriptorProto_OutputType_field_number},
+		} {
+			typePath := join(methodPath, t.field)
+			res := l.resolve(*t.name, relativeTo, false)
+			switch {
+			case res.sym == nil:
+				return l.undefinedError(typePath, *t.name, res)
+			case res.sym.kind != messageSymbol:
+				return l.f.errorAt(typePath, "\"%s\" is not a message type.", *t.name)
+			}
+			*t.name = "." + res.name
+		}
+	}
+	return nil
+}
+
+// checkFieldNumber checks that the number of the field f is valid
+// and not reserved by the message m.
+func (l *linker) checkFieldNumber(f *fieldProto, m *messageProto, path []int32) error {
+	n := f.GetNumber()
+	switch {
+	case n <= 0:
+		return l.f.errorAt(path, "Field numbers must be positive integers.")
+	case n > protowireMaxNumber && !(f.Extendee != nil && isMessageSetProto(m)):
+		return l.f.errorAt(path, "Field numbers cannot be greater than %d.", protowireMaxNumber)
+	case 19000 <= n && n <= 19999:
+		return l.f.errorAt(path, "Field numbers 19000 through 19999 are reserved for the protocol buffer library implementation.")
+	}
+	return nil
+}
+
+// isMessageSetProto reports whether m uses the message set wire format,
+// whether or not its options have been interpreted.
+func isMessageSetProto(m *messageProto) bool {
+	return m.GetOptions().GetMessageSetWireFormat() || isMessageSet(m)
+}
+
+// checkMessageNumbers checks the field numbers and ranges of the message m.
+func (l *linker) checkMessageNumbers(m *messageProto, name string, path []int32) error {
+	used := make(map[int32]*fieldProto)
+	for i, f := range m.Field {
+		fieldPath := join(path, genid.DescriptorProto_Field_field_number, i)
+		numberPath := join(fieldPath, genid.FieldDescriptorProto_Number_field_number)
+		if err := l.checkFieldNumber(f, m, numberPath); err != nil {
+			return err
+		}
+		n := f.GetNumber()
+		if prev := used[n]; prev != nil {
+			return l.f.errorAt(numberPath, "Field number %d has already been used in \"%s\" by field \"%s\".", n, name, prev.GetName())
+		}
+		used[n] = f
+		for _, r := range m.ReservedRange {
+			if r.GetStart() <= n && n < r.GetEnd() {
+				return l.f.errorAt(numberPath, "Field \"%s\" uses reserved number %d.", f.GetName(), n)
+			}
+		}
+		for _, r := range m.ExtensionRange {
+			if r.GetStart() <= n && n < r.GetEnd() {
+				return l.f.errorAt(numberPath, "Extension range %d to %d includes field \"%s\" (%d).", r.GetStart(), r.GetEnd()-1, f.GetName(), n)
+			}
+		}
+		for _, s := range m.ReservedName {
+			if s == f.GetName() {
+				return l.f.errorAt(join(fieldPath, genid.FieldDescriptorProto_Name_field_number), "Field name \"%s\" is reserved.", s)
+			}
+		}
+	}
+
+	type numberRange struct {
+		start, end int32 // end is exclusive
+		path       []int32
+		reserved   bool
+	}
+	var ranges []numberRange
+	for i, r := range m.ExtensionRange {
+		rangePath := join(path, genid.DescriptorProto_ExtensionRange_field_number, i)
+		if r.GetStart() <= 0 {
+			return l.f.errorAt(join(rangePath, genid.DescriptorProto_ExtensionRange_Start_field_number), "Extension numbers must be positive integers.")
+		}
+		if r.GetEnd() <= r.GetStart() {
+			return l.f.errorAt(join(rangePath, genid.DescriptorProto_ExtensionRange_End_field_number), "Extension range end number must be greater than start number.")
+		}
+		ranges = append(ranges, numberRange{r.GetStart(), r.GetEnd(), rangePath, false})
+	}
+	for i, r := range m.ReservedRange {
+		rangePath := join(path, genid.DescriptorProto_ReservedRange_field_number, i)
+		if r.GetEnd() <= r.GetStart() {
+			return l.f.errorAt(join(rangePath, genid.DescriptorProto_ReservedRange_End_field_number), "Reserved range end number must be greater than start number.")
+		}
+		ranges = append(ranges, numberRange{r.GetStart(), r.GetEnd(), rangePath, true})
+	}
+	for i, r := range ranges {
+		for _, prev := range ranges[:i] {
+			if r.start >= prev.end || prev.start >= r.end {
+				continue
+			}
+			switch {
+			case r.reserved && prev.reserved:
+				return l.f.errorAt(r.path, "Reserved range %d to %d overlaps with already-defined range %d to %d.", r.start, r.end-1, prev.start, prev.end-1)
+			case r.reserved:
+				return l.f.errorAt(prev.path, "Extension range %d to %d overlaps with reserved range %d to %d.", prev.start, prev.end-1, r.start, r.end-1)
+			default:
+				return l.f.errorAt(r.path, "Extension range %d to %d overlaps with already-defined range %d to %d.", r.start, r.end-1, prev.start, prev.end-1)
+			}
+		}
+	}
+	names := make(map[string]bool)
+	for i, s := range m.ReservedName {
+		if names[s] {
+			return l.f.errorAt(join(path, genid.DescriptorProto_ReservedName_field_number, i), "Field name \"%s\" is reserved multiple times.", s)
+		}
+		names[s] = true
+	}
+	return nil
+}
+
+// checkEnum checks the values of the enum e after its options have been
+// interpreted.
+func checkEnum(f *parsedFile, e *enumProto, scope string, path []int32, open bool) error {
+	if open && len(e.Value) > 0 && e.Value[0].GetNumber() != 0 {
+		return f.errorAt(join(path, genid.EnumDescriptorProto_Value_field_number, 0, int(genid.EnumValueDescriptorProto_Number_field_number)),
+			"The first enum value must be zero for open enums.")
+	}
+	used := make(map[int32]string)
+	aliased := false
+	for i, v := range e.Value {
+		valuePath := join(path, genid.EnumDescriptorProto_Value_field_number, i)
+		n := v.GetNumber()
+		if prev, ok := used[n]; ok {
+			aliased = true
+			if !e.GetOptions().GetAllowAlias() {
+				return f.errorAt(join(valuePath, genid.EnumValueDescriptorProto_Number_field_number),
+					"\"%s\" uses the same enum value as \"%s\". If this is intended, set 'option allow_alias = true;' to the enum definition.",
+					qualify(scope, v.GetName()), prev)
+			}
+		} else {
+			used[n] = v.GetName()
+		}
+		for _, r := range e.ReservedRange {
+			if r.GetStart() <= n && n <= r.GetEnd() {
+				return f.errorAt(join(valuePath, genid.EnumValueDescriptorProto_Number_field_number), "Enum value \"%s\" uses reserved number %d.", v.GetName(), n)
+			}
+		}
+		for _, s := range e.ReservedName {
+			if s == v.GetName() {
+				return f.errorAt(join(valuePath, genid.EnumValueDescriptorProto_Name_field_number), "Enum value \"%s\" is reserved.", s)
+			}
+		}
+	}
+	if e.GetOptions().GetAllowAlias() && !aliased {
+		return f.errorAt(path, "\"%s\" declares support for enum aliases but no enum values share field numbers. "+
+			"Please remove the unnecessary 'option allow_alias = true;' declaration.", qualify(scope, e.GetName()))
+	}
+	ranges := append([]*descriptorpb.EnumDescriptorProto_EnumReservedRange{}, e.ReservedRange...)
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].GetStart() < ranges[j].GetStart() })
+	for i := 1; i < len(ranges); i++ {
+		if prev, r := ranges[i-1], ranges[i]; r.GetStart() <= prev.GetEnd() {
+			return f.errorAt(join(path, genid.EnumDescriptorProto_ReservedRange_field_number),
+				"Reserved range %d to %d overlaps with already-defined range %d to %d.", r.GetStart(), r.GetEnd(), prev.GetStart(), prev.GetEnd())
+		}
+	}
+	return nil
+}
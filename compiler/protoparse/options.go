@@ -0,0 +1,467 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoparse
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// optionsTarget is the options message of an element of a file.
+type optionsTarget struct {
+	opts proto.Message // e.g., *descriptorpb.FieldOptions
+	path []int32       // the path of the options message
+	// scope is the name relative to which the names of custom options
+	// are resolved.
+	scope string
+}
+
+// interpretOptions interprets the uninterpreted options of the file,
+// setting the fields of each options message that they refer to.
+//
+// Options are interpreted in two passes. The first pass interprets the
+// options declared by descriptor.proto. This is enough to build the
+// descriptor of the file itself, which provides the types of the custom
+// options it declares for the second pass.
+func (c *compiler) interpretOptions(f *parsedFile) error {
+	targets := collectOptions(f.fd)
+	files := c.depFiles(f.fd)
+	in := &interpreter{
+		resolver: c.newResolver(f),
+		files:    files,
+		types:    dynamicpb.NewTypes(files),
+	}
+	for _, t := range targets {
+		if err := in.interpretAll(t, false); err != nil {
+			return err
+		}
+	}
+	if err := checkEnums(f); err != nil {
+		return err
+	}
+
+	skeleton := proto.Clone(f.fd).(*descriptorpb.FileDescriptorProto)
+	for _, t := range collectOptions(skeleton) {
+		m := t.opts.ProtoReflect()
+		m.Clear(m.Descriptor().Fields().ByName("uninterpreted_option"))
+	}
+	desc, err := protodesc.NewFile(skeleton, files)
+	if err != nil {
+		return &Error{Filename: f.fd.GetName(), Message: trimPrefix(err.Error())}
+	}
+	if err := files.RegisterFile(desc); err != nil {
+		return &Error{Filename: f.fd.GetName(), Message: trimPrefix(err.Error())}
+	}
+	in.types = dynamicpb.NewTypes(files)
+	for _, t := range targets {
+		if err := in.interpretAll(t, true); err != nil {
+			return err
+		}
+		if err := normalizeOptions(t.opts); err != nil {
+			return &Error{Filename: f.fd.GetName(), Message: trimPrefix(err.Error())}
+		}
+	}
+	return nil
+}
+
+// depFiles returns a registry of the transitive dependencies of the file.
+func (c *compiler) depFiles(fd *descriptorpb.FileDescriptorProto) *protoregistry.Files {
+	files := new(protoregistry.Files)
+	seen := make(map[string]bool)
+	var visit func(desc protoreflect.FileDescriptor)
+	visit = func(desc protoreflect.FileDescriptor) {
+		if seen[desc.Path()] {
+			return
+		}
+		seen[desc.Path()] = true
+		for i := 0; i < desc.Imports().Len(); i++ {
+			visit(c.results[desc.Imports().Get(i).Path()].desc)
+		}
+		files.RegisterFile(desc)
+	}
+	for _, dep := range fd.Dependency {
+		visit(c.results[dep].desc)
+	}
+	return files
+}
+
+// normalizeOptions replaces the values of custom options, which have dynamic
+// types, with their encoded form. If the custom option is registered with
+// protoregistry.GlobalTypes, it is decoded using that type.
+func normalizeOptions(opts proto.Message) error {
+	b, err := proto.MarshalOptions{AllowPartial: true, Deterministic: true}.Marshal(opts)
+	if err != nil {
+		return err
+	}
+	proto.Reset(opts)
+	return proto.UnmarshalOptions{AllowPartial: true}.Unmarshal(b, opts)
+}
+
+// collectOptions returns the options messages of the file that have
+// uninterpreted options.
+func collectOptions(fd *descriptorpb.FileDescriptorProto) []optionsTarget {
+	var ts []optionsTarget
+	add := func(opts interface {
+		proto.Message
+		GetUninterpretedOption() []*descriptorpb.UninterpretedOption
+	}, path []int32, scope string) {
+		if len(opts.GetUninterpretedOption()) > 0 {
+			ts = append(ts, optionsTarget{opts, path, scope})
+		}
+	}
+	pkg := fd.GetPackage()
+	// Options of the file are resolved relative to its package.
+	add(fd.Options, join(nil, genid.FileDescriptorProto_Options_field_number), qualify(pkg, "_"))
+	var addEnums func(es []*enumProto, scope string, path []int32)
+	addEnums = func(es []*enumProto, scope string, path []int32) {
+		for i, e := range es {
+			enumPath := joinIndex(path, i)
+			name := qualify(scope, e.GetName())
+			add(e.Options, join(enumPath, genid.EnumDescriptorProto_Options_field_number), name)
+			for j, v := range e.Value {
+				valuePath := join(enumPath, genid.EnumDescriptorProto_Value_field_number, j)
+				add(v.Options, join(valuePath, genid.EnumValueDescriptorProto_Options_field_number), qualify(scope, v.GetName()))
+			}
+		}
+	}
+	var addMessages func(ms []*messageProto, scope string, path []int32)
+	addMessages = func(ms []*messageProto, scope string, path []int32) {
+		for i, m := range ms {
+			msgPath := joinIndex(path, i)
+			name := qualify(scope, m.GetName())
+			add(m.Options, join(msgPath, genid.DescriptorProto_Options_field_number), name)
+			for j, f := range m.Field {
+				fieldPath := join(msgPath, genid.DescriptorProto_Field_field_number, j)
+				add(f.Options, join(fieldPath, genid.FieldDescriptorProto_Options_field_number), qualify(name, f.GetName()))
+			}
+			for j, x := range m.Extension {
+				fieldPath := join(msgPath, genid.DescriptorProto_Extension_field_number, j)
+				add(x.Options, join(fieldPath, genid.FieldDescriptorProto_Options_field_number), qualify(name, x.GetName()))
+			}
+			for j, o := range m.OneofDecl {
+				oneofPath := join(msgPath, genid.DescriptorProto_OneofDecl_field_number, j)
+				add(o.Options, join(oneofPath, genid.OneofDescriptorProto_Options_field_number), qualify(name, o.GetName()))
+			}
+			for j, r := range m.ExtensionRange {
+				rangePath := join(msgPath, genid.DescriptorProto_ExtensionRange_field_number, j)
+				add(r.Options, join(rangePath, genid.DescriptorProto_ExtensionRange_Options_field_number), name)
+			}
+			addMessages(m.NestedType, name, join(msgPath, genid.DescriptorProto_NestedType_field_number))
+			addEnums(m.EnumType, name, join(msgPath, genid.DescriptorProto_EnumType_field_number))
+		}
+	}
+	addMessages(fd.MessageType, pkg, join(nil, genid.FileDescriptorProto_MessageType_field_number))
+	addEnums(fd.EnumType, pkg, join(nil, genid.FileDescriptorProto_EnumType_field_number))
+	for i, x := range fd.Extension {
+		fieldPath := join(nil, genid.FileDescriptorProto_Extension_field_number, i)
+		add(x.Options, join(fieldPath, genid.FieldDescriptorProto_Options_field_number), qualify(pkg, x.GetName()))
+	}
+	for i, s := range fd.Service {
+		servicePath := join(nil, genid.FileDescriptorProto_Service_field_number, i)
+		name := qualify(pkg, s.GetName())
+		add(s.Options, join(servicePath, genid.ServiceDescriptorProto_Options_field_number), name)
+		for j, m := range s.Method {
+			methodPath := join(servicePath, genid.ServiceDescriptorProto_Method_field_number, j)
+			add(m.Options, join(methodPath, genid.MethodDescriptorProto_Options_field_number), qualify(name, m.GetName()))
+		}
+	}
+	return ts
+}
+
+// interpreter interprets the options of a file.
+type interpreter struct {
+	*resolver
+	files *protoregistry.Files
+	types *dynamicpb.Types
+}
+
+// interpretAll interprets the options of t that do or do not
+// refer to custom options, depending on custom.
+func (in *interpreter) interpretAll(t optionsTarget, custom bool) error {
+	m := t.opts.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName("uninterpreted_option")
+	if !m.Has(fd) {
+		return nil
+	}
+	list := m.Mutable(fd).List()
+	var remaining []*descriptorpb.UninterpretedOption
+	for i := 0; i < list.Len(); i++ {
+		opt := list.Get(i).Message().Interface().(*descriptorpb.UninterpretedOption)
+		if isCustom(opt) != custom {
+			remaining = append(remaining, opt)
+			continue
+		}
+		if err := in.interpret(t, opt); err != nil {
+			return err
+		}
+	}
+	list.Truncate(0)
+	for _, opt := range remaining {
+		list.Append(protoreflect.ValueOfMessage(opt.ProtoReflect()))
+	}
+	if len(remaining) == 0 {
+		m.Clear(fd)
+	}
+	return nil
+}
+
+func isCustom(opt *descriptorpb.UninterpretedOption) bool {
+	for _, part := range opt.Name {
+		if part.GetIsExtension() {
+			return true
+		}
+	}
+	return false
+}
+
+// interpret sets the field of t.opts that the option refers to.
+func (in *interpreter) interpret(t optionsTarget, opt *descriptorpb.UninterpretedOption) error {
+	info := in.f.options[opt]
+	m := t.opts.ProtoReflect()
+	var path []int32
+	var name string // the name of the option as written, for errors
+	for i, part := range opt.Name {
+		if i > 0 {
+			name += "."
+		}
+		var fd protoreflect.FieldDescriptor
+		if part.GetIsExtension() {
+			name += "(" + part.GetNamePart() + ")"
+			res := in.resolve(part.GetNamePart(), t.scope, false)
+			if res.sym == nil || res.sym.kind != fieldSymbol || res.sym.field.Extendee == nil {
+				if res.undefined != "" {
+					return in.f.errorAtPos(info.name, "Option \"%s\" is resolved to \"(%s)\", which is not defined. "+
+						"The innermost scope is searched first in name resolution. "+
+						"Consider using a leading '.'(i.e., \"(.%s\") to start from the outermost scope.", name, res.undefined, name[1:])
+				}
+				return in.f.errorAtPos(info.name, "Option \"%s\" unknown. Ensure that your proto definition file imports the proto which defines the option.", name)
+			}
+			d, err := in.files.FindDescriptorByName(protoreflect.FullName(res.name))
+			if err != nil {
+				return in.f.errorAtPos(info.name, "Option \"%s\" unknown. Ensure that your proto definition file imports the proto which defines the option.", name)
+			}
+			fd = dynamicpb.NewExtensionType(d.(protoreflect.ExtensionDescriptor)).TypeDescriptor()
+			if fd.ContainingMessage().FullName() != m.Descriptor().FullName() {
+				return in.f.errorAtPos(info.name, "Option field \"%s\" is not a field or extension of message \"%s\".", name, m.Descriptor().Name())
+			}
+		} else {
+			name += part.GetNamePart()
+			fd = m.Descriptor().Fields().ByName(protoreflect.Name(part.GetNamePart()))
+			if fd == nil {
+				return in.f.errorAtPos(info.name, "Option \"%s\" unknown. Ensure that your proto definition file imports the proto which defines the option.", name)
+			}
+			if fd.Name() == "uninterpreted_option" {
+				return in.f.errorAtPos(info.name, "Option must not use reserved name \"uninterpreted_option\".")
+			}
+		}
+		path = append(path, int32(fd.Number()))
+		if i < len(opt.Name)-1 {
+			switch {
+			case fd.Message() == nil:
+				return in.f.errorAtPos(info.name, "Option \"%s\" is an atomic type, not a message.", name)
+			case fd.IsList():
+				return in.f.errorAtPos(info.name, "Option field \"%s\" is a repeated message. Repeated message options must be initialized using an aggregate value.", name)
+			}
+			m = m.Mutable(fd).Message()
+			continue
+		}
+
+		if fd.FullName() == genid.MessageOptions_MapEntry_field_fullname {
+			return in.f.errorAtPos(info.name, "map_entry should not be set explicitly. Use map<KeyType, ValueType> instead.")
+		}
+		if !fd.IsList() && m.Has(fd) {
+			return in.f.errorAtPos(info.name, "Option \"%s\" was already set.", name)
+		}
+		var v protoreflect.Value
+		if fd.IsList() {
+			v = m.Mutable(fd).List().NewElement()
+		} else {
+			v = m.NewField(fd)
+		}
+		v, err := in.value(fd, v, opt, info.value)
+		if err != nil {
+			return err
+		}
+		if fd.IsList() {
+			list := m.Mutable(fd).List()
+			path = append(path, int32(list.Len()))
+			list.Append(v)
+		} else {
+			m.Set(fd, v)
+		}
+	}
+	info.loc.Path = append(append([]int32{}, t.path...), path...)
+	return nil
+}
+
+// value converts the value of the option to a value of the field fd.
+// For message fields, v is a new message into which the value is parsed.
+func (in *interpreter) value(fd protoreflect.FieldDescriptor, v protoreflect.Value, opt *descriptorpb.UninterpretedOption, pos pos) (protoreflect.Value, error) {
+	rangeError := func(typ string) (protoreflect.Value, error) {
+		return protoreflect.Value{}, in.f.errorAtPos(pos, "Value out of range for %s option \"%s\".", typ, fd.FullName())
+	}
+	typeError := func(msg, typ string) (protoreflect.Value, error) {
+		return protoreflect.Value{}, in.f.errorAtPos(pos, "Value must be %s for %s option \"%s\".", msg, typ, fd.FullName())
+	}
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		switch {
+		case opt.PositiveIntValue != nil:
+			if opt.GetPositiveIntValue() > math.MaxInt32 {
+				return rangeError("int32")
+			}
+			return protoreflect.ValueOfInt32(int32(opt.GetPositiveIntValue())), nil
+		case opt.NegativeIntValue != nil:
+			if opt.GetNegativeIntValue() < math.MinInt32 {
+				return rangeError("int32")
+			}
+			return protoreflect.ValueOfInt32(int32(opt.GetNegativeIntValue())), nil
+		}
+		return typeError("integer", "int32")
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		switch {
+		case opt.PositiveIntValue != nil:
+			if opt.GetPositiveIntValue() > math.MaxInt64 {
+				return rangeError("int64")
+			}
+			return protoreflect.ValueOfInt64(int64(opt.GetPositiveIntValue())), nil
+		case opt.NegativeIntValue != nil:
+			return protoreflect.ValueOfInt64(opt.GetNegativeIntValue()), nil
+		}
+		return typeError("integer", "int64")
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if opt.PositiveIntValue != nil {
+			if opt.GetPositiveIntValue() > math.MaxUint32 {
+				return rangeError("uint32")
+			}
+			return protoreflect.ValueOfUint32(uint32(opt.GetPositiveIntValue())), nil
+		}
+		return typeError("non-negative integer", "uint32")
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if opt.PositiveIntValue != nil {
+			return protoreflect.ValueOfUint64(opt.GetPositiveIntValue()), nil
+		}
+		return typeError("non-negative integer", "uint64")
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		var x float64
+		switch {
+		case opt.DoubleValue != nil:
+			x = opt.GetDoubleValue()
+		case opt.PositiveIntValue != nil:
+			x = float64(opt.GetPositiveIntValue())
+		case opt.NegativeIntValue != nil:
+			x = float64(opt.GetNegativeIntValue())
+		case opt.GetIdentifierValue() == "inf":
+			x = math.Inf(+1)
+		case opt.GetIdentifierValue() == "nan":
+			x = math.NaN()
+		default:
+			if fd.Kind() == protoreflect.FloatKind {
+				return typeError("number", "float")
+			}
+			return typeError("number", "double")
+		}
+		if fd.Kind() == protoreflect.FloatKind {
+			return protoreflect.ValueOfFloat32(float32(x)), nil
+		}
+		return protoreflect.ValueOfFloat64(x), nil
+	case protoreflect.BoolKind:
+		switch opt.GetIdentifierValue() {
+		case "true":
+			return protoreflect.ValueOfBool(true), nil
+		case "false":
+			return protoreflect.ValueOfBool(false), nil
+		}
+		return typeError("\"true\" or \"false\"", "boolean")
+	case protoreflect.EnumKind:
+		if opt.IdentifierValue == nil {
+			return typeError("identifier", "enum-valued")
+		}
+		ev := fd.Enum().Values().ByName(protoreflect.Name(opt.GetIdentifierValue()))
+		if ev == nil {
+			return protoreflect.Value{}, in.f.errorAtPos(pos, "Enum type \"%s\" has no value named \"%s\" for option \"%s\".",
+				fd.Enum().FullName(), opt.GetIdentifierValue(), fd.FullName())
+		}
+		return protoreflect.ValueOfEnum(ev.Number()), nil
+	case protoreflect.StringKind:
+		if opt.StringValue == nil {
+			return typeError("quoted string", "string")
+		}
+		return protoreflect.ValueOfString(string(opt.StringValue)), nil
+	case protoreflect.BytesKind:
+		if opt.StringValue == nil {
+			return typeError("quoted string", "string")
+		}
+		return protoreflect.ValueOfBytes(opt.StringValue), nil
+	default: // message or group
+		if opt.AggregateValue == nil {
+			return protoreflect.Value{}, in.f.errorAtPos(pos, "Option \"%s\" is a message. "+
+				"To set the entire message, use syntax like \"%s = { <proto text format> }\". "+
+				"To set fields within it, use syntax like \"%s.foo = value\".", fd.FullName(), fd.Name(), fd.Name())
+		}
+		u := prototext.UnmarshalOptions{AllowPartial: true, Resolver: in.types}
+		if err := u.Unmarshal([]byte(opt.GetAggregateValue()), v.Message().Interface()); err != nil {
+			return protoreflect.Value{}, in.f.errorAtPos(pos, "Error while parsing option value for \"%s\": %s", fd.Name(), trimPrefix(err.Error()))
+		}
+		return v, nil
+	}
+}
+
+// checkEnums checks the values of the enums of the file,
+// which depend on the options declared by descriptor.proto.
+func checkEnums(f *parsedFile) error {
+	fd := f.fd
+	closed := fd.GetSyntax() == "" || fd.GetSyntax() == "proto2"
+	closed = isClosed(fd.GetOptions().GetFeatures(), closed)
+	var checkMessages func(ms []*messageProto, scope string, path []int32, closed bool) error
+	checkEnums := func(es []*enumProto, scope string, path []int32, closed bool) error {
+		for i, e := range es {
+			open := !isClosed(e.GetOptions().GetFeatures(), closed)
+			if err := checkEnum(f, e, scope, joinIndex(path, i), open); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	checkMessages = func(ms []*messageProto, scope string, path []int32, closed bool) error {
+		for i, m := range ms {
+			msgPath := joinIndex(path, i)
+			name := qualify(scope, m.GetName())
+			closed := isClosed(m.GetOptions().GetFeatures(), closed)
+			if err := checkEnums(m.EnumType, name, join(msgPath, genid.DescriptorProto_EnumType_field_number), closed); err != nil {
+				return err
+			}
+			if err := checkMessages(m.NestedType, name, join(msgPath, genid.DescriptorProto_NestedType_field_number), closed); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := checkEnums(fd.EnumType, fd.GetPackage(), join(nil, genid.FileDescriptorProto_EnumType_field_number), closed); err != nil {
+		return err
+	}
+	return checkMessages(fd.MessageType, fd.GetPackage(), join(nil, genid.FileDescriptorProto_MessageType_field_number), closed)
+}
+
+// isClosed reports whether enums are closed given the features of an
+// element, where closed is the value inherited from its parent.
+func isClosed(features *descriptorpb.FeatureSet, closed bool) bool {
+	switch features.GetEnumType() {
+	case descriptorpb.FeatureSet_OPEN:
+		return false
+	case descriptorpb.FeatureSet_CLOSED:
+		return true
+	}
+	return closed
+}
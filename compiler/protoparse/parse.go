@@ -0,0 +1,1693 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoparse
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/internal/strs"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+type (
+	fileProto      = descriptorpb.FileDescriptorProto
+	messageProto   = descriptorpb.DescriptorProto
+	fieldProto     = descriptorpb.FieldDescriptorProto
+	oneofProto     = descriptorpb.OneofDescriptorProto
+	enumProto      = descriptorpb.EnumDescriptorProto
+	enumValueProto = descriptorpb.EnumValueDescriptorProto
+	serviceProto   = descriptorpb.ServiceDescriptorProto
+	methodProto    = descriptorpb.MethodDescriptorProto
+	location       = descriptorpb.SourceCodeInfo_Location
+)
+
+// maxRangeSentinel is the end of a message range declared with "max" until
+// it is known whether the message uses the message set wire format.
+const maxRangeSentinel = -1
+
+// parsedFile is the result of parsing a single source file.
+type parsedFile struct {
+	fd      *fileProto
+	options map[*descriptorpb.UninterpretedOption]*optionInfo
+
+	// desc is the descriptor of a file that was not parsed from source,
+	// but loaded from the global registry.
+	desc protoreflect.FileDescriptor
+}
+
+// optionInfo is the position of an uninterpreted option in a file.
+type optionInfo struct {
+	// loc is the location of the option, which is updated with the path
+	// of the option once it has been interpreted.
+	loc *location
+	// name and value are the positions of the name and value of the option.
+	name, value pos
+}
+
+// parser converts the tokens of a source file into a FileDescriptorProto,
+// following the grammar and producing the same source locations as protoc.
+type parser struct {
+	filename string
+	toks     []*token
+	i        int    // index of the next token
+	prev     *token // the last consumed token
+
+	file    *fileProto
+	syntax  protoreflect.Syntax
+	locs    []*location
+	options map[*descriptorpb.UninterpretedOption]*optionInfo
+}
+
+func parse(filename string, src []byte) (*parsedFile, error) {
+	l := &lexer{filename: filename, in: string(src)}
+	toks, err := l.tokenize()
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{
+		filename: filename,
+		toks:     toks,
+		options:  make(map[*descriptorpb.UninterpretedOption]*optionInfo),
+	}
+	if err := p.parseFile(); err != nil {
+		return nil, err
+	}
+	return &parsedFile{fd: p.file, options: p.options}, nil
+}
+
+func (p *parser) peek() *token {
+	return p.toks[p.i]
+}
+
+func (p *parser) next() *token {
+	t := p.toks[p.i]
+	if t.kind != tokenEOF {
+		p.i++
+	}
+	p.prev = t
+	return t
+}
+
+func (p *parser) atEOF() bool {
+	return p.peek().kind == tokenEOF
+}
+
+func (p *parser) lookingAt(s string) bool {
+	t := p.peek()
+	return (t.kind == tokenIdent || t.kind == tokenSymbol) && t.text == s
+}
+
+func (p *parser) tryConsume(s string) bool {
+	if p.lookingAt(s) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) consume(s string) error {
+	return p.consumeOrError(s, "Expected \""+s+"\".")
+}
+
+func (p *parser) consumeOrError(s, msg string) error {
+	if !p.tryConsume(s) {
+		return p.error(p.peek(), msg)
+	}
+	return nil
+}
+
+func (p *parser) error(t *token, msg string) error {
+	return &Error{Filename: p.filename, Line: t.start.line + 1, Column: t.start.col + 1, Message: msg}
+}
+
+func (p *parser) errorf(t *token, f string, x ...any) error {
+	return p.error(t, fmt.Sprintf(f, x...))
+}
+
+func (p *parser) consumeIdent(msg string) (*token, error) {
+	if p.peek().kind != tokenIdent {
+		return nil, p.error(p.peek(), msg)
+	}
+	return p.next(), nil
+}
+
+// consumeInt consumes a non-negative integer that is at most max.
+func (p *parser) consumeInt(max uint64, msg string) (uint64, error) {
+	t := p.peek()
+	if t.kind != tokenInt {
+		return 0, p.error(t, msg)
+	}
+	v, err := strconv.ParseUint(t.text, 0, 64)
+	if err != nil || v > max {
+		return 0, p.error(t, "Integer out of range.")
+	}
+	p.next()
+	return v, nil
+}
+
+func (p *parser) consumeInt32(msg string) (int32, error) {
+	v, err := p.consumeInt(math.MaxInt32, msg)
+	return int32(v), err
+}
+
+func (p *parser) consumeSignedInt32(msg string) (int32, error) {
+	neg := p.tryConsume("-")
+	max := uint64(math.MaxInt32)
+	if neg {
+		max++ // two's complement has one more negative value
+	}
+	v, err := p.consumeInt(max, msg)
+	if neg {
+		return int32(-int64(v)), err
+	}
+	return int32(v), err
+}
+
+// consumeNumber consumes a floating-point number, an integer,
+// or one of the identifiers "inf" and "nan".
+func (p *parser) consumeNumber(msg string) (float64, error) {
+	t := p.peek()
+	var v float64
+	switch {
+	case t.kind == tokenFloat:
+		// Values that are out of range are parsed as an infinity.
+		v, _ = strconv.ParseFloat(t.text, 64)
+	case t.kind == tokenInt:
+		if n, err := strconv.ParseUint(t.text, 0, 64); err == nil {
+			v = float64(n)
+		} else if f, err := strconv.ParseFloat(t.text, 64); err == nil && t.text[0] != '0' {
+			v = f
+		} else {
+			return 0, p.error(t, "Integer out of range.")
+		}
+	case t.kind == tokenIdent && t.text == "inf":
+		v = math.Inf(1)
+	case t.kind == tokenIdent && t.text == "nan":
+		v = math.NaN()
+	default:
+		return 0, p.error(t, msg)
+	}
+	p.next()
+	return v, nil
+}
+
+// consumeString consumes one or more adjacent string literals,
+// returning their concatenated value.
+func (p *parser) consumeString(msg string) (string, error) {
+	if p.peek().kind != tokenString {
+		return "", p.error(p.peek(), msg)
+	}
+	var b strings.Builder
+	for p.peek().kind == tokenString {
+		b.WriteString(p.next().str)
+	}
+	return b.String(), nil
+}
+
+// consumeEndOfDecl consumes the token that ends a declaration and attaches
+// the comments of the declaration, which starts at first, to loc.
+func (p *parser) consumeEndOfDecl(s string, loc *location, first *token) error {
+	if err := p.consume(s); err != nil {
+		return err
+	}
+	if first.leading != nil && *first.leading != "" {
+		loc.LeadingComments = proto.String(*first.leading)
+	}
+	if last := p.prev; last.trailing != nil && *last.trailing != "" {
+		loc.TrailingComments = proto.String(*last.trailing)
+	}
+	loc.LeadingDetachedComments = first.detached
+	return nil
+}
+
+// newLoc records a new location for path, which starts at the token start.
+// Its span is completed by endLoc.
+func (p *parser) newLoc(path []int32, start *token) *location {
+	loc := &location{
+		Path: append([]int32{}, path...),
+		Span: []int32{int32(start.start.line), int32(start.start.col)},
+	}
+	p.locs = append(p.locs, loc)
+	return loc
+}
+
+// endLoc ends the span of loc at the end of the last consumed token.
+func (p *parser) endLoc(loc *location) {
+	t := p.prev
+	if t == nil {
+		t = p.peek()
+	}
+	endLocAt(loc, t)
+}
+
+func endLocAt(loc *location, t *token) {
+	loc.Span = loc.Span[:2]
+	if line := int32(t.end.line); line != loc.Span[0] {
+		loc.Span = append(loc.Span, line)
+	}
+	loc.Span = append(loc.Span, int32(t.end.col))
+}
+
+// spanLoc records a location for path spanning the tokens start to end.
+func (p *parser) spanLoc(path []int32, start, end *token) *location {
+	loc := p.newLoc(path, start)
+	endLocAt(loc, end)
+	return loc
+}
+
+// join returns a new path consisting of path followed by the field number n
+// and, for repeated fields, the index of an element.
+func join(path []int32, n protoreflect.FieldNumber, index ...int) []int32 {
+	out := make([]int32, len(path), len(path)+1+len(index))
+	copy(out, path)
+	out = append(out, int32(n))
+	for _, i := range index {
+		out = append(out, int32(i))
+	}
+	return out
+}
+
+// joinIndex returns a new path consisting of path, which is the path of a
+// repeated field, followed by the index i.
+func joinIndex(path []int32, i int) []int32 {
+	out := make([]int32, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, int32(i))
+}
+
+func (p *parser) parseFile() error {
+	p.file = &fileProto{Name: proto.String(p.filename)}
+	p.syntax = protoreflect.Proto2
+	root := p.newLoc(nil, p.peek())
+	if p.lookingAt("syntax") || p.lookingAt("edition") {
+		if err := p.parseSyntax(); err != nil {
+			return err
+		}
+	}
+	for !p.atEOF() {
+		if err := p.parseTopLevelStatement(); err != nil {
+			return err
+		}
+	}
+	p.endLoc(root)
+	p.file.SourceCodeInfo = &descriptorpb.SourceCodeInfo{Location: p.locs}
+	return nil
+}
+
+func (p *parser) parseSyntax() error {
+	first := p.peek()
+	loc := p.newLoc(join(nil, genid.FileDescriptorProto_Syntax_field_number), first)
+	defer p.endLoc(loc)
+	isEdition := p.tryConsume("edition")
+	if !isEdition {
+		if err := p.consumeOrError("syntax", "File must begin with a syntax statement, e.g. 'syntax = \"proto2\";'."); err != nil {
+			return err
+		}
+	}
+	if err := p.consume("="); err != nil {
+		return err
+	}
+	t := p.peek()
+	s, err := p.consumeString("Expected syntax identifier.")
+	if err != nil {
+		return err
+	}
+	if err := p.consumeEndOfDecl(";", loc, first); err != nil {
+		return err
+	}
+	if isEdition {
+		e, ok := descriptorpb.Edition_value["EDITION_"+s]
+		if !ok || e < int32(descriptorpb.Edition_EDITION_2023) {
+			return p.errorf(t, "Unknown edition \"%s\".", s)
+		}
+		p.syntax = protoreflect.Editions
+		p.file.Syntax = proto.String("editions")
+		p.file.Edition = descriptorpb.Edition(e).Enum()
+		return nil
+	}
+	switch s {
+	case "proto2":
+		// As in the output of protoc, the syntax of proto2 files is unset.
+	case "proto3":
+		p.syntax = protoreflect.Proto3
+		p.file.Syntax = proto.String(s)
+	default:
+		return p.errorf(t, "Unrecognized syntax identifier \"%s\".  This parser only recognizes \"proto2\" and \"proto3\".", s)
+	}
+	return nil
+}
+
+func (p *parser) parseTopLevelStatement() error {
+	f := p.file
+	switch {
+	case p.tryConsume(";"):
+		return nil // empty statement
+	case p.lookingAt("message"):
+		path := join(nil, genid.FileDescriptorProto_MessageType_field_number, len(f.MessageType))
+		m := new(messageProto)
+		f.MessageType = append(f.MessageType, m)
+		return p.parseMessage(m, path)
+	case p.lookingAt("enum"):
+		path := join(nil, genid.FileDescriptorProto_EnumType_field_number, len(f.EnumType))
+		e := new(enumProto)
+		f.EnumType = append(f.EnumType, e)
+		return p.parseEnum(e, path)
+	case p.lookingAt("service"):
+		path := join(nil, genid.FileDescriptorProto_Service_field_number, len(f.Service))
+		s := new(serviceProto)
+		f.Service = append(f.Service, s)
+		return p.parseService(s, path)
+	case p.lookingAt("extend"):
+		return p.parseExtend(&f.Extension, &f.MessageType,
+			join(nil, genid.FileDescriptorProto_MessageType_field_number),
+			join(nil, genid.FileDescriptorProto_Extension_field_number))
+	case p.lookingAt("import"):
+		return p.parseImport()
+	case p.lookingAt("package"):
+		return p.parsePackage()
+	case p.lookingAt("option"):
+		loc := p.newLoc(join(nil, genid.FileDescriptorProto_Options_field_number), p.peek())
+		defer p.endLoc(loc)
+		return p.parseOption(loc.Path, func() proto.Message {
+			if f.Options == nil {
+				f.Options = new(descriptorpb.FileOptions)
+			}
+			return f.Options
+		}, true)
+	default:
+		return p.error(p.peek(), "Expected top-level statement (e.g. \"message\").")
+	}
+}
+
+func (p *parser) parseImport() error {
+	f := p.file
+	first := p.peek()
+	loc := p.newLoc(join(nil, genid.FileDescriptorProto_Dependency_field_number, len(f.Dependency)), first)
+	defer p.endLoc(loc)
+	p.next() // import
+	switch {
+	case p.lookingAt("public"):
+		p.spanLoc(join(nil, genid.FileDescriptorProto_PublicDependency_field_number, len(f.PublicDependency)), p.peek(), p.peek())
+		p.next()
+		f.PublicDependency = append(f.PublicDependency, int32(len(f.Dependency)))
+	case p.lookingAt("weak"):
+		p.spanLoc(join(nil, genid.FileDescriptorProto_WeakDependency_field_number, len(f.WeakDependency)), p.peek(), p.peek())
+		p.next()
+		f.WeakDependency = append(f.WeakDependency, int32(len(f.Dependency)))
+	}
+	s, err := p.consumeString("Expected a string naming the file to import.")
+	if err != nil {
+		return err
+	}
+	f.Dependency = append(f.Dependency, s)
+	return p.consumeEndOfDecl(";", loc, first)
+}
+
+func (p *parser) parsePackage() error {
+	first := p.peek()
+	if p.file.Package != nil {
+		return p.error(first, "Multiple package definitions.")
+	}
+	loc := p.newLoc(join(nil, genid.FileDescriptorProto_Package_field_number), first)
+	defer p.endLoc(loc)
+	p.next() // package
+	var b strings.Builder
+	for {
+		t, err := p.consumeIdent("Expected identifier.")
+		if err != nil {
+			return err
+		}
+		b.WriteString(t.text)
+		if !p.tryConsume(".") {
+			break
+		}
+		b.WriteByte('.')
+	}
+	p.file.Package = proto.String(b.String())
+	return p.consumeEndOfDecl(";", loc, first)
+}
+
+// parseOption parses an option assignment, either as a statement or within
+// a bracketed list, and adds it to the uninterpreted options of the options
+// message returned by opts. The path of the options message is optsPath.
+func (p *parser) parseOption(optsPath []int32, opts func() proto.Message, statement bool) error {
+	m := opts().ProtoReflect()
+	list := m.Mutable(m.Descriptor().Fields().ByName("uninterpreted_option")).List()
+	first := p.peek()
+	loc := p.newLoc(join(optsPath, genid.FileOptions_UninterpretedOption_field_number, list.Len()), first)
+	defer p.endLoc(loc)
+	if statement {
+		p.next() // option
+	}
+	opt := new(descriptorpb.UninterpretedOption)
+	list.Append(protoreflect.ValueOfMessage(opt.ProtoReflect()))
+	info := &optionInfo{loc: loc, name: p.peek().start}
+	p.options[opt] = info
+	for {
+		if err := p.parseOptionNamePart(opt); err != nil {
+			return err
+		}
+		if !p.tryConsume(".") {
+			break
+		}
+	}
+	if err := p.consume("="); err != nil {
+		return err
+	}
+	info.value = p.peek().start
+	if err := p.parseOptionValue(opt); err != nil {
+		return err
+	}
+	if statement {
+		return p.consumeEndOfDecl(";", loc, first)
+	}
+	return nil
+}
+
+func (p *parser) parseOptionNamePart(opt *descriptorpb.UninterpretedOption) error {
+	var b strings.Builder
+	isExtension := p.tryConsume("(")
+	if isExtension {
+		// An extension name consists of dot-separated identifiers,
+		// and may begin with a dot.
+		if p.peek().kind == tokenIdent {
+			b.WriteString(p.next().text)
+		}
+		for p.tryConsume(".") {
+			t, err := p.consumeIdent("Expected identifier.")
+			if err != nil {
+				return err
+			}
+			b.WriteByte('.')
+			b.WriteString(t.text)
+		}
+		if err := p.consume(")"); err != nil {
+			return err
+		}
+	} else {
+		t, err := p.consumeIdent("Expected identifier.")
+		if err != nil {
+			return err
+		}
+		b.WriteString(t.text)
+	}
+	opt.Name = append(opt.Name, &descriptorpb.UninterpretedOption_NamePart{
+		NamePart:    proto.String(b.String()),
+		IsExtension: proto.Bool(isExtension),
+	})
+	return nil
+}
+
+func (p *parser) parseOptionValue(opt *descriptorpb.UninterpretedOption) error {
+	// All values are a single token, except for negative numbers,
+	// which consist of a '-' symbol followed by a positive number.
+	neg := p.tryConsume("-")
+	t := p.peek()
+	switch t.kind {
+	case tokenEOF:
+		return p.error(t, "Unexpected end of stream while parsing option value.")
+	case tokenIdent:
+		p.next()
+		if !neg {
+			opt.IdentifierValue = proto.String(t.text)
+			return nil
+		}
+		switch t.text {
+		case "inf":
+			opt.DoubleValue = proto.Float64(math.Inf(-1))
+		case "nan":
+			opt.DoubleValue = proto.Float64(math.NaN())
+		default:
+			return p.error(t, "Identifier after '-' symbol must be inf or nan.")
+		}
+		return nil
+	case tokenInt:
+		max := uint64(math.MaxUint64)
+		if neg {
+			max = -math.MinInt64
+		}
+		if v, err := strconv.ParseUint(t.text, 0, 64); err == nil && v <= max {
+			p.next()
+			if neg {
+				opt.NegativeIntValue = proto.Int64(int64(-v))
+			} else {
+				opt.PositiveIntValue = proto.Uint64(v)
+			}
+			return nil
+		}
+		// The value is too large for an integer, so treat it as a float.
+		fallthrough
+	case tokenFloat:
+		v, err := p.consumeNumber("Expected number.")
+		if err != nil {
+			return err
+		}
+		if neg {
+			v = -v
+		}
+		opt.DoubleValue = proto.Float64(v)
+		return nil
+	case tokenString:
+		if neg {
+			return p.error(t, "Invalid '-' symbol before string.")
+		}
+		s, err := p.consumeString("Expected string.")
+		if err != nil {
+			return err
+		}
+		opt.StringValue = []byte(s)
+		return nil
+	default:
+		if !p.lookingAt("{") {
+			return p.error(t, "Expected option value.")
+		}
+		s, err := p.parseAggregate()
+		if err != nil {
+			return err
+		}
+		opt.AggregateValue = proto.String(s)
+		return nil
+	}
+}
+
+// parseAggregate parses a message value in the text format, which is
+// delimited by braces. As with protoc, it returns the tokens within the
+// braces separated by single spaces.
+func (p *parser) parseAggregate() (string, error) {
+	p.next() // {
+	var b strings.Builder
+	depth := 1
+	for !p.atEOF() {
+		t := p.next()
+		if t.kind == tokenSymbol {
+			switch t.text {
+			case "{":
+				depth++
+			case "}":
+				if depth--; depth == 0 {
+					return b.String(), nil
+				}
+			}
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(t.text)
+	}
+	return "", p.error(p.peek(), "Unexpected end of stream while parsing aggregate value.")
+}
+
+// parseOptionList parses a bracketed list of options, which is recorded as a
+// location for path, the path of the options message.
+func (p *parser) parseOptionList(path []int32, opts func() proto.Message) error {
+	if !p.lookingAt("[") {
+		return nil
+	}
+	loc := p.newLoc(path, p.peek())
+	defer p.endLoc(loc)
+	p.next() // [
+	for {
+		if err := p.parseOption(path, opts, false); err != nil {
+			return err
+		}
+		if !p.tryConsume(",") {
+			break
+		}
+	}
+	return p.consume("]")
+}
+
+func (p *parser) parseMessage(m *messageProto, path []int32) error {
+	first := p.peek()
+	loc := p.newLoc(path, first)
+	defer p.endLoc(loc)
+	p.next() // message
+	t, err := p.consumeIdent("Expected message name.")
+	if err != nil {
+		return err
+	}
+	p.spanLoc(join(path, genid.DescriptorProto_Name_field_number), t, t)
+	m.Name = proto.String(t.text)
+	if err := p.parseMessageBlock(m, path, loc, first); err != nil {
+		return err
+	}
+	if p.syntax == protoreflect.Proto3 {
+		addSyntheticOneofs(m)
+	}
+	return nil
+}
+
+func (p *parser) parseMessageBlock(m *messageProto, path []int32, loc *location, first *token) error {
+	if err := p.consumeEndOfDecl("{", loc, first); err != nil {
+		return err
+	}
+	for !p.tryConsume("}") {
+		if p.atEOF() {
+			return p.error(p.peek(), "Reached end of input in message definition (missing '}').")
+		}
+		if err := p.parseMessageStatement(m, path); err != nil {
+			return err
+		}
+	}
+
+	// Resolve the end of ranges declared with "max".
+	max := int32(protowireMaxNumber + 1)
+	if isMessageSet(m) {
+		max = math.MaxInt32
+	}
+	for _, r := range m.ExtensionRange {
+		if r.GetEnd() == maxRangeSentinel {
+			r.End = proto.Int32(max)
+		}
+	}
+	for _, r := range m.ReservedRange {
+		if r.GetEnd() == maxRangeSentinel {
+			r.End = proto.Int32(max)
+		}
+	}
+	return nil
+}
+
+// protowireMaxNumber is the maximum field number.
+const protowireMaxNumber = 1<<29 - 1
+
+// isMessageSet reports whether the message m, whose options have not yet
+// been interpreted, uses the message set wire format.
+func isMessageSet(m *messageProto) bool {
+	for _, opt := range m.GetOptions().GetUninterpretedOption() {
+		if len(opt.Name) == 1 && opt.Name[0].GetNamePart() == "message_set_wire_format" && opt.GetIdentifierValue() == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseMessageStatement(m *messageProto, path []int32) error {
+	switch {
+	case p.tryConsume(";"):
+		return nil // empty statement
+	case p.lookingAt("message"):
+		nested := new(messageProto)
+		nestedPath := join(path, genid.DescriptorProto_NestedType_field_number, len(m.NestedType))
+		m.NestedType = append(m.NestedType, nested)
+		return p.parseMessage(nested, nestedPath)
+	case p.lookingAt("enum"):
+		e := new(enumProto)
+		enumPath := join(path, genid.DescriptorProto_EnumType_field_number, len(m.EnumType))
+		m.EnumType = append(m.EnumType, e)
+		return p.parseEnum(e, enumPath)
+	case p.lookingAt("extensions"):
+		return p.parseExtensionRanges(m, join(path, genid.DescriptorProto_ExtensionRange_field_number))
+	case p.lookingAt("reserved"):
+		return p.parseReserved(path, &m.ReservedName, func(start, end int32) {
+			m.ReservedRange = append(m.ReservedRange, &descriptorpb.DescriptorProto_ReservedRange{
+				Start: proto.Int32(start),
+				End:   proto.Int32(end),
+			})
+		}, len(m.ReservedRange), false)
+	case p.lookingAt("extend"):
+		return p.parseExtend(&m.Extension, &m.NestedType,
+			join(path, genid.DescriptorProto_NestedType_field_number),
+			join(path, genid.DescriptorProto_Extension_field_number))
+	case p.lookingAt("option"):
+		loc := p.newLoc(join(path, genid.DescriptorProto_Options_field_number), p.peek())
+		defer p.endLoc(loc)
+		return p.parseOption(loc.Path, func() proto.Message {
+			if m.Options == nil {
+				m.Options = new(descriptorpb.MessageOptions)
+			}
+			return m.Options
+		}, true)
+	case p.lookingAt("oneof"):
+		return p.parseOneof(m, path)
+	default:
+		f := new(fieldProto)
+		fieldPath := join(path, genid.DescriptorProto_Field_field_number, len(m.Field))
+		m.Field = append(m.Field, f)
+		first := p.peek()
+		loc := p.newLoc(fieldPath, first)
+		defer p.endLoc(loc)
+		if err := p.parseLabel(f, fieldPath); err != nil {
+			return err
+		}
+		return p.parseFieldNoLabel(f, &m.NestedType, join(path, genid.DescriptorProto_NestedType_field_number), fieldPath, loc, first)
+	}
+}
+
+// addSyntheticOneofs adds a oneof for each proto3 optional field.
+func addSyntheticOneofs(m *messageProto) {
+	names := make(map[string]bool)
+	for _, f := range m.Field {
+		names[f.GetName()] = true
+	}
+	for _, o := range m.OneofDecl {
+		names[o.GetName()] = true
+	}
+	for _, f := range m.Field {
+		if !f.GetProto3Optional() {
+			continue
+		}
+		name := f.GetName()
+		if !strings.HasPrefix(name, "_") {
+			name = "_" + name
+		}
+		for names[name] {
+			name = "X" + name
+		}
+		names[name] = true
+		f.OneofIndex = proto.Int32(int32(len(m.OneofDecl)))
+		m.OneofDecl = append(m.OneofDecl, &oneofProto{Name: proto.String(name)})
+	}
+}
+
+var fieldTypes = map[string]descriptorpb.FieldDescriptorProto_Type{
+	"double":   descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+	"float":    descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+	"int64":    descriptorpb.FieldDescriptorProto_TYPE_INT64,
+	"uint64":   descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	"int32":    descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	"fixed64":  descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
+	"fixed32":  descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+	"bool":     descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+	"string":   descriptorpb.FieldDescriptorProto_TYPE_STRING,
+	"group":    descriptorpb.FieldDescriptorProto_TYPE_GROUP,
+	"bytes":    descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	"uint32":   descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	"sfixed32": descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
+	"sfixed64": descriptorpb.FieldDescriptorProto_TYPE_SFIXED64,
+	"sint32":   descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+	"sint64":   descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+}
+
+func (p *parser) parseLabel(f *fieldProto, path []int32) error {
+	t := p.peek()
+	var label descriptorpb.FieldDescriptorProto_Label
+	switch {
+	case p.lookingAt("optional"):
+		label = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	case p.lookingAt("repeated"):
+		label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	case p.lookingAt("required"):
+		label = descriptorpb.FieldDescriptorProto_LABEL_REQUIRED
+	default:
+		return nil
+	}
+	p.next()
+	p.spanLoc(join(path, genid.FieldDescriptorProto_Label_field_number), t, t)
+	switch {
+	case p.syntax == protoreflect.Editions && label == descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL:
+		return p.error(t, "Label \"optional\" is not supported in editions. By default, all singular fields have presence unless features.field_presence is set.")
+	case p.syntax == protoreflect.Editions && label == descriptorpb.FieldDescriptorProto_LABEL_REQUIRED:
+		return p.error(t, "Required label is not allowed under editions.  Use the feature field_presence = LEGACY_REQUIRED to control this behavior.")
+	case p.syntax == protoreflect.Proto3 && label == descriptorpb.FieldDescriptorProto_LABEL_REQUIRED:
+		return p.error(t, "Required fields are not allowed in proto3.")
+	}
+	f.Label = label.Enum()
+	if p.syntax == protoreflect.Proto3 && label == descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL {
+		f.Proto3Optional = proto.Bool(true)
+	}
+	return nil
+}
+
+// parseFieldNoLabel parses a field declaration following its label.
+// Groups and map fields also declare a nested message, which is appended to
+// msgs, the path of which is msgsPath. The declaration starts at first.
+func (p *parser) parseFieldNoLabel(f *fieldProto, msgs *[]*messageProto, msgsPath, path []int32, loc *location, first *token) error {
+	typeStart := p.peek()
+	var mapKey, mapValue *fieldProto
+	if p.lookingAt("map") && p.toks[p.i+1].text == "<" {
+		p.next() // map
+		switch {
+		case f.OneofIndex != nil:
+			return p.error(p.peek(), "Map fields are not allowed in oneofs.")
+		case f.Label != nil:
+			return p.error(p.peek(), "Field labels (required/optional/repeated) are not allowed on map fields.")
+		case f.Extendee != nil:
+			return p.error(p.peek(), "Map fields are not allowed to be extensions.")
+		}
+		p.next() // <
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		mapKey = &fieldProto{Name: proto.String("key"), Number: proto.Int32(1)}
+		mapValue = &fieldProto{Name: proto.String("value"), Number: proto.Int32(2)}
+		if err := p.parseType(mapKey); err != nil {
+			return err
+		}
+		if err := p.consume(","); err != nil {
+			return err
+		}
+		if err := p.parseType(mapValue); err != nil {
+			return err
+		}
+		if err := p.consume(">"); err != nil {
+			return err
+		}
+		p.spanLoc(join(path, genid.FieldDescriptorProto_TypeName_field_number), typeStart, p.prev)
+	} else {
+		if f.Label == nil {
+			if p.syntax == protoreflect.Proto2 {
+				return p.error(typeStart, "Expected \"required\", \"optional\", or \"repeated\".")
+			}
+			f.Label = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+		}
+		if err := p.parseType(f); err != nil {
+			return err
+		}
+		if f.Type != nil {
+			p.spanLoc(join(path, genid.FieldDescriptorProto_Type_field_number), typeStart, p.prev)
+		} else {
+			p.spanLoc(join(path, genid.FieldDescriptorProto_TypeName_field_number), typeStart, p.prev)
+		}
+		if f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+			switch p.syntax {
+			case protoreflect.Proto3:
+				return p.error(typeStart, "Groups are not supported in proto3 syntax.")
+			case protoreflect.Editions:
+				return p.error(typeStart, "Group syntax is no longer supported in editions. To get group behavior you can specify features.message_encoding = DELIMITED on a message field.")
+			}
+		}
+	}
+
+	name, err := p.consumeIdent("Expected field name.")
+	if err != nil {
+		return err
+	}
+	p.spanLoc(join(path, genid.FieldDescriptorProto_Name_field_number), name, name)
+	f.Name = proto.String(name.text)
+	if err := p.consumeOrError("=", "Missing field number."); err != nil {
+		return err
+	}
+	numTok := p.peek()
+	n, err := p.consumeInt32("Expected field number.")
+	if err != nil {
+		return err
+	}
+	p.spanLoc(join(path, genid.FieldDescriptorProto_Number_field_number), numTok, numTok)
+	f.Number = proto.Int32(n)
+	if err := p.parseFieldOptions(f, path); err != nil {
+		return err
+	}
+
+	if f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+		// A group declares both a message and a field,
+		// so their locations overlap.
+		groupPath := joinIndex(msgsPath, len(*msgs))
+		group := &messageProto{Name: proto.String(name.text)}
+		*msgs = append(*msgs, group)
+		groupLoc := p.newLoc(groupPath, first)
+		p.spanLoc(join(groupPath, genid.DescriptorProto_Name_field_number), name, name)
+		p.spanLoc(join(path, genid.FieldDescriptorProto_TypeName_field_number), name, name)
+		if c := name.text[0]; c < 'A' || 'Z' < c {
+			return p.error(name, "Group names must start with a capital letter.")
+		}
+		f.Name = proto.String(strings.ToLower(name.text))
+		f.TypeName = proto.String(name.text)
+		if !p.lookingAt("{") {
+			return p.error(p.peek(), "Missing group body.")
+		}
+		if err := p.parseMessageBlock(group, groupPath, groupLoc, first); err != nil {
+			return err
+		}
+		p.endLoc(groupLoc)
+	} else if err := p.consumeEndOfDecl(";", loc, first); err != nil {
+		return err
+	}
+
+	if mapKey != nil {
+		entry := &messageProto{
+			Name:    proto.String(strs.MapEntryName(f.GetName())),
+			Field:   []*fieldProto{mapKey, mapValue},
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		}
+		for _, kv := range entry.Field {
+			kv.Label = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+			kv.JsonName = kv.Name
+		}
+		*msgs = append(*msgs, entry)
+		f.TypeName = entry.Name
+	}
+	if f.JsonName == nil {
+		f.JsonName = proto.String(strs.JSONCamelCase(f.GetName()))
+	}
+	return nil
+}
+
+// parseType parses the type of the field f, which is either a scalar type,
+// a group, or the name of a message or enum.
+func (p *parser) parseType(f *fieldProto) error {
+	if t := p.peek(); t.kind == tokenIdent {
+		if typ, ok := fieldTypes[t.text]; ok {
+			p.next()
+			f.Type = typ.Enum()
+			return nil
+		}
+	}
+	name, err := p.parseTypeName()
+	if err != nil {
+		return err
+	}
+	f.TypeName = proto.String(name)
+	return nil
+}
+
+// parseTypeName parses the possibly qualified name of a message or enum.
+func (p *parser) parseTypeName() (string, error) {
+	if t := p.peek(); t.kind == tokenIdent {
+		if _, ok := fieldTypes[t.text]; ok {
+			return "", p.error(t, "Expected message type.")
+		}
+	}
+	var b strings.Builder
+	if p.tryConsume(".") {
+		b.WriteByte('.') // fully-qualified name
+	}
+	t, err := p.consumeIdent("Expected type name.")
+	if err != nil {
+		return "", err
+	}
+	b.WriteString(t.text)
+	for p.tryConsume(".") {
+		t, err := p.consumeIdent("Expected identifier.")
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte('.')
+		b.WriteString(t.text)
+	}
+	return b.String(), nil
+}
+
+func (p *parser) parseFieldOptions(f *fieldProto, path []int32) error {
+	if !p.lookingAt("[") {
+		return nil
+	}
+	optsPath := join(path, genid.FieldDescriptorProto_Options_field_number)
+	loc := p.newLoc(optsPath, p.peek())
+	defer p.endLoc(loc)
+	p.next() // [
+	for {
+		var err error
+		switch {
+		case p.lookingAt("default"):
+			// The default value and JSON name are not actually options,
+			// so their locations are recorded under the field.
+			err = p.parseDefault(f, path)
+		case p.lookingAt("json_name"):
+			err = p.parseJSONName(f, path)
+		default:
+			err = p.parseOption(optsPath, func() proto.Message {
+				if f.Options == nil {
+					f.Options = new(descriptorpb.FieldOptions)
+				}
+				return f.Options
+			}, false)
+		}
+		if err != nil {
+			return err
+		}
+		if !p.tryConsume(",") {
+			break
+		}
+	}
+	return p.consume("]")
+}
+
+func (p *parser) parseDefault(f *fieldProto, path []int32) error {
+	if f.DefaultValue != nil {
+		return p.error(p.peek(), "Already set option \"default\".")
+	}
+	p.next() // default
+	if err := p.consume("="); err != nil {
+		return err
+	}
+	start := p.peek()
+	loc := p.newLoc(join(path, genid.FieldDescriptorProto_DefaultValue_field_number), start)
+	defer p.endLoc(loc)
+	if p.syntax == protoreflect.Proto3 {
+		return p.error(start, "Explicit default values are not allowed in proto3.")
+	}
+	if f.Type == nil {
+		// The field refers to a message or enum, which is not yet known.
+		// Since enum default values are identifiers, parse an identifier;
+		// a default value for a message is rejected later.
+		t, err := p.consumeIdent("Expected enum identifier.")
+		if err != nil {
+			return err
+		}
+		f.DefaultValue = proto.String(t.text)
+		return nil
+	}
+	var s string
+	switch f.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		max := uint64(math.MaxInt64)
+		switch f.GetType() {
+		case descriptorpb.FieldDescriptorProto_TYPE_INT32,
+			descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+			descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+			max = math.MaxInt32
+		}
+		if p.tryConsume("-") {
+			s = "-"
+			max++ // two's complement has one more negative value
+		}
+		v, err := p.consumeInt(max, "Expected integer for field default value.")
+		if err != nil {
+			return err
+		}
+		s += strconv.FormatUint(v, 10)
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		max := uint64(math.MaxUint64)
+		switch f.GetType() {
+		case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+			descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+			max = math.MaxUint32
+		}
+		if p.lookingAt("-") {
+			return p.error(p.peek(), "Unsigned field can't have negative default value.")
+		}
+		v, err := p.consumeInt(max, "Expected integer for field default value.")
+		if err != nil {
+			return err
+		}
+		s = strconv.FormatUint(v, 10)
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+		descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		if p.tryConsume("-") {
+			s = "-"
+		}
+		v, err := p.consumeNumber("Expected number.")
+		if err != nil {
+			return err
+		}
+		s += formatDouble(v)
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		switch {
+		case p.tryConsume("true"):
+			s = "true"
+		case p.tryConsume("false"):
+			s = "false"
+		default:
+			return p.error(p.peek(), "Expected \"true\" or \"false\".")
+		}
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		v, err := p.consumeString("Expected string for field default value.")
+		if err != nil {
+			return err
+		}
+		s = v
+	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		v, err := p.consumeString("Expected string.")
+		if err != nil {
+			return err
+		}
+		s = cEscape(v)
+	default:
+		return p.error(start, "Messages can't have default values.")
+	}
+	f.DefaultValue = proto.String(s)
+	return nil
+}
+
+// formatDouble formats v in the same way as protoc formats default values.
+func formatDouble(v float64) string {
+	switch {
+	case math.IsInf(v, +1):
+		return "inf"
+	case math.IsInf(v, -1):
+		return "-inf"
+	case math.IsNaN(v):
+		return "nan"
+	}
+	s := strconv.FormatFloat(v, 'g', 15, 64)
+	if f, _ := strconv.ParseFloat(s, 64); f != v {
+		s = strconv.FormatFloat(v, 'g', 17, 64)
+	}
+	return s
+}
+
+// cEscape escapes s using C escape sequences, which is how protoc
+// represents the default value of bytes fields.
+func cEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\'':
+			b.WriteString(`\'`)
+		case '\\':
+			b.WriteString(`\\`)
+		default:
+			if c < ' ' || c >= 0x7f {
+				fmt.Fprintf(&b, `\%03o`, c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	return b.String()
+}
+
+func (p *parser) parseJSONName(f *fieldProto, path []int32) error {
+	t := p.peek()
+	if f.JsonName != nil {
+		return p.error(t, "Already set option \"json_name\".")
+	}
+	if f.Extendee != nil {
+		return p.error(t, "option json_name is not allowed on extension fields.")
+	}
+	loc := p.newLoc(join(path, genid.FieldDescriptorProto_JsonName_field_number), t)
+	defer p.endLoc(loc)
+	p.next() // json_name
+	if err := p.consume("="); err != nil {
+		return err
+	}
+	s, err := p.consumeString("Expected string for JSON name.")
+	if err != nil {
+		return err
+	}
+	f.JsonName = proto.String(s)
+	return nil
+}
+
+func (p *parser) parseOneof(m *messageProto, msgPath []int32) error {
+	index := len(m.OneofDecl)
+	o := new(oneofProto)
+	m.OneofDecl = append(m.OneofDecl, o)
+	path := join(msgPath, genid.DescriptorProto_OneofDecl_field_number, index)
+	first := p.peek()
+	loc := p.newLoc(path, first)
+	defer p.endLoc(loc)
+	p.next() // oneof
+	t, err := p.consumeIdent("Expected oneof name.")
+	if err != nil {
+		return err
+	}
+	p.spanLoc(join(path, genid.OneofDescriptorProto_Name_field_number), t, t)
+	o.Name = proto.String(t.text)
+	if err := p.consumeEndOfDecl("{", loc, first); err != nil {
+		return err
+	}
+	for {
+		if err := p.parseOneofStatement(m, msgPath, o, path, index); err != nil {
+			return err
+		}
+		if p.tryConsume("}") {
+			return nil
+		}
+	}
+}
+
+func (p *parser) parseOneofStatement(m *messageProto, msgPath []int32, o *oneofProto, path []int32, index int) error {
+	if p.atEOF() {
+		return p.error(p.peek(), "Reached end of input in oneof definition (missing '}').")
+	}
+	if p.lookingAt("option") {
+		loc := p.newLoc(join(path, genid.OneofDescriptorProto_Options_field_number), p.peek())
+		defer p.endLoc(loc)
+		return p.parseOption(loc.Path, func() proto.Message {
+			if o.Options == nil {
+				o.Options = new(descriptorpb.OneofOptions)
+			}
+			return o.Options
+		}, true)
+	}
+	if p.lookingAt("required") || p.lookingAt("optional") || p.lookingAt("repeated") {
+		return p.error(p.peek(), "Fields in oneofs must not have labels (required / optional / repeated).")
+	}
+	f := &fieldProto{
+		Label:      descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		OneofIndex: proto.Int32(int32(index)),
+	}
+	fieldPath := join(msgPath, genid.DescriptorProto_Field_field_number, len(m.Field))
+	m.Field = append(m.Field, f)
+	first := p.peek()
+	loc := p.newLoc(fieldPath, first)
+	defer p.endLoc(loc)
+	return p.parseFieldNoLabel(f, &m.NestedType, join(msgPath, genid.DescriptorProto_NestedType_field_number), fieldPath, loc, first)
+}
+
+// parseExtend parses an extend block, appending the extensions it declares
+// to exts. The path of the block is path.
+func (p *parser) parseExtend(exts *[]*fieldProto, msgs *[]*messageProto, msgsPath, path []int32) error {
+	first := p.peek()
+	loc := p.newLoc(path, first)
+	defer p.endLoc(loc)
+	p.next() // extend
+	extendeeStart := p.peek()
+	extendee, err := p.parseTypeName()
+	if err != nil {
+		return err
+	}
+	extendeeEnd := p.prev
+	if err := p.consumeEndOfDecl("{", loc, first); err != nil {
+		return err
+	}
+	for {
+		if p.atEOF() {
+			return p.error(p.peek(), "Reached end of input in extend definition (missing '}').")
+		}
+		f := &fieldProto{Extendee: proto.String(extendee)}
+		fieldPath := joinIndex(path, len(*exts))
+		*exts = append(*exts, f)
+		fieldFirst := p.peek()
+		fieldLoc := p.newLoc(fieldPath, fieldFirst)
+		p.spanLoc(join(fieldPath, genid.FieldDescriptorProto_Extendee_field_number), extendeeStart, extendeeEnd)
+		if err := p.parseLabel(f, fieldPath); err != nil {
+			return err
+		}
+		if err := p.parseFieldNoLabel(f, msgs, msgsPath, fieldPath, fieldLoc, fieldFirst); err != nil {
+			return err
+		}
+		p.endLoc(fieldLoc)
+		if p.tryConsume("}") {
+			return nil
+		}
+	}
+}
+
+// parseExtensionRanges parses an extensions statement.
+// The path of the extension ranges of the message m is path.
+func (p *parser) parseExtensionRanges(m *messageProto, path []int32) error {
+	first := p.peek()
+	if p.syntax == protoreflect.Proto3 {
+		return p.error(first, "Extension ranges are not allowed in proto3.")
+	}
+	loc := p.newLoc(path, first)
+	defer p.endLoc(loc)
+	p.next() // extensions
+	start := len(m.ExtensionRange)
+	for {
+		rangePath := joinIndex(path, len(m.ExtensionRange))
+		rangeLoc := p.newLoc(rangePath, p.peek())
+		lo, hi, err := p.parseRange(rangePath, "Expected field number range.", false)
+		if err != nil {
+			return err
+		}
+		p.endLoc(rangeLoc)
+		m.ExtensionRange = append(m.ExtensionRange, &descriptorpb.DescriptorProto_ExtensionRange{
+			Start: proto.Int32(lo),
+			End:   proto.Int32(hi),
+		})
+		if !p.tryConsume(",") {
+			break
+		}
+	}
+
+	if p.lookingAt("[") {
+		// The options apply to every range in the statement. They are parsed
+		// into the first range and copied to the others, along with their
+		// source locations.
+		opts := new(descriptorpb.ExtensionRangeOptions)
+		locs := p.locs
+		p.locs = nil
+		optsPath := join(joinIndex(path, start), genid.DescriptorProto_ExtensionRange_Options_field_number)
+		if err := p.parseOptionList(optsPath, func() proto.Message { return opts }); err != nil {
+			return err
+		}
+		optLocs := p.locs
+		p.locs = locs
+		optInfos := make([]*optionInfo, len(opts.UninterpretedOption))
+		for j, opt := range opts.UninterpretedOption {
+			optInfos[j] = p.options[opt]
+		}
+		for i := start; i < len(m.ExtensionRange); i++ {
+			rangeOpts := opts
+			if i > start {
+				rangeOpts = proto.Clone(opts).(*descriptorpb.ExtensionRangeOptions)
+			}
+			m.ExtensionRange[i].Options = rangeOpts
+			for j, loc := range optLocs {
+				if i > start {
+					loc = proto.Clone(loc).(*location)
+					loc.Path[len(path)] = int32(i)
+					if j > 0 {
+						// The first location is that of the option list.
+						info := *optInfos[j-1]
+						info.loc = loc
+						p.options[rangeOpts.UninterpretedOption[j-1]] = &info
+					}
+				}
+				p.locs = append(p.locs, loc)
+			}
+		}
+	}
+	return p.consumeEndOfDecl(";", loc, first)
+}
+
+// parseRange parses a range of numbers such as "5", "5 to 10", or "5 to max",
+// recording the locations of its start and end under path.
+// For enums, the range may be negative and its end is inclusive.
+func (p *parser) parseRange(path []int32, msg string, isEnum bool) (start, end int32, err error) {
+	startTok := p.peek()
+	if isEnum {
+		start, err = p.consumeSignedInt32(msg)
+	} else {
+		start, err = p.consumeInt32(msg)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	p.spanLoc(join(path, genid.DescriptorProto_ReservedRange_Start_field_number), startTok, p.prev)
+	endPath := join(path, genid.DescriptorProto_ReservedRange_End_field_number)
+	if !p.tryConsume("to") {
+		p.spanLoc(endPath, startTok, startTok)
+		if isEnum {
+			return start, start, nil
+		}
+		return start, start + 1, nil
+	}
+	endTok := p.peek()
+	switch {
+	case p.tryConsume("max"):
+		if isEnum {
+			end = math.MaxInt32
+		} else {
+			end = maxRangeSentinel
+		}
+	case isEnum:
+		end, err = p.consumeSignedInt32("Expected integer.")
+	default:
+		end, err = p.consumeInt32("Expected integer.")
+		end++ // the end of a message range is exclusive
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	p.spanLoc(endPath, endTok, p.prev)
+	return start, end, nil
+}
+
+// parseReserved parses a reserved statement within a message or enum, the
+// path of which is path. Reserved names are appended to names and reserved
+// ranges are passed to addRange, where numRanges is the current number of
+// reserved ranges.
+func (p *parser) parseReserved(path []int32, names *[]string, addRange func(start, end int32), numRanges int, isEnum bool) error {
+	first := p.next() // reserved
+	t := p.peek()
+	switch {
+	case t.kind == tokenString || t.kind == tokenIdent:
+		if t.kind == tokenString && p.syntax == protoreflect.Editions {
+			return p.error(t, "Reserved names must be identifiers in editions, not string literals.")
+		}
+		if t.kind == tokenIdent && p.syntax != protoreflect.Editions {
+			return p.error(t, "Reserved names must be string literals. (Only editions supports identifiers.)")
+		}
+		namesPath := join(path, genid.DescriptorProto_ReservedName_field_number)
+		if isEnum {
+			namesPath = join(path, genid.EnumDescriptorProto_ReservedName_field_number)
+		}
+		loc := p.newLoc(namesPath, first)
+		defer p.endLoc(loc)
+		for {
+			t := p.peek()
+			var name string
+			if t.kind == tokenIdent {
+				p.next()
+				name = t.text
+			} else {
+				s, err := p.consumeString("Expected field name.")
+				if err != nil {
+					return err
+				}
+				name = s
+			}
+			p.spanLoc(joinIndex(namesPath, len(*names)), t, p.prev)
+			*names = append(*names, name)
+			if !p.tryConsume(",") {
+				break
+			}
+			if p.peek().kind != t.kind {
+				if t.kind == tokenIdent {
+					return p.error(p.peek(), "Expected field name identifier.")
+				}
+				return p.error(p.peek(), "Expected field name.")
+			}
+		}
+		return p.consumeEndOfDecl(";", loc, first)
+	default:
+		rangesPath := join(path, genid.DescriptorProto_ReservedRange_field_number)
+		if isEnum {
+			rangesPath = join(path, genid.EnumDescriptorProto_ReservedRange_field_number)
+		}
+		loc := p.newLoc(rangesPath, first)
+		defer p.endLoc(loc)
+		msg := "Expected field name or number range."
+		if isEnum {
+			msg = "Expected enum value or number range."
+		}
+		for {
+			rangePath := joinIndex(rangesPath, numRanges)
+			rangeLoc := p.newLoc(rangePath, p.peek())
+			start, end, err := p.parseRange(rangePath, msg, isEnum)
+			if err != nil {
+				return err
+			}
+			p.endLoc(rangeLoc)
+			addRange(start, end)
+			numRanges++
+			if !p.tryConsume(",") {
+				break
+			}
+			msg = "Expected field number range."
+			if isEnum {
+				msg = "Expected enum number range."
+			}
+		}
+		return p.consumeEndOfDecl(";", loc, first)
+	}
+}
+
+func (p *parser) parseEnum(e *enumProto, path []int32) error {
+	first := p.peek()
+	loc := p.newLoc(path, first)
+	defer p.endLoc(loc)
+	p.next() // enum
+	t, err := p.consumeIdent("Expected enum name.")
+	if err != nil {
+		return err
+	}
+	p.spanLoc(join(path, genid.EnumDescriptorProto_Name_field_number), t, t)
+	e.Name = proto.String(t.text)
+	if err := p.consumeEndOfDecl("{", loc, first); err != nil {
+		return err
+	}
+	for !p.tryConsume("}") {
+		if p.atEOF() {
+			return p.error(p.peek(), "Reached end of input in enum definition (missing '}').")
+		}
+		if err := p.parseEnumStatement(e, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseEnumStatement(e *enumProto, path []int32) error {
+	switch {
+	case p.tryConsume(";"):
+		return nil // empty statement
+	case p.lookingAt("option"):
+		loc := p.newLoc(join(path, genid.EnumDescriptorProto_Options_field_number), p.peek())
+		defer p.endLoc(loc)
+		return p.parseOption(loc.Path, func() proto.Message {
+			if e.Options == nil {
+				e.Options = new(descriptorpb.EnumOptions)
+			}
+			return e.Options
+		}, true)
+	case p.lookingAt("reserved"):
+		return p.parseReserved(path, &e.ReservedName, func(start, end int32) {
+			e.ReservedRange = append(e.ReservedRange, &descriptorpb.EnumDescriptorProto_EnumReservedRange{
+				Start: proto.Int32(start),
+				End:   proto.Int32(end),
+			})
+		}, len(e.ReservedRange), true)
+	default:
+		v := new(enumValueProto)
+		valuePath := join(path, genid.EnumDescriptorProto_Value_field_number, len(e.Value))
+		e.Value = append(e.Value, v)
+		return p.parseEnumValue(v, valuePath)
+	}
+}
+
+func (p *parser) parseEnumValue(v *enumValueProto, path []int32) error {
+	first := p.peek()
+	loc := p.newLoc(path, first)
+	defer p.endLoc(loc)
+	t, err := p.consumeIdent("Expected enum constant name.")
+	if err != nil {
+		return err
+	}
+	p.spanLoc(join(path, genid.EnumValueDescriptorProto_Name_field_number), t, t)
+	v.Name = proto.String(t.text)
+	if err := p.consumeOrError("=", "Missing numeric value for enum constant."); err != nil {
+		return err
+	}
+	numTok := p.peek()
+	n, err := p.consumeSignedInt32("Expected integer.")
+	if err != nil {
+		return err
+	}
+	p.spanLoc(join(path, genid.EnumValueDescriptorProto_Number_field_number), numTok, p.prev)
+	v.Number = proto.Int32(n)
+	if err := p.parseOptionList(join(path, genid.EnumValueDescriptorProto_Options_field_number), func() proto.Message {
+		if v.Options == nil {
+			v.Options = new(descriptorpb.EnumValueOptions)
+		}
+		return v.Options
+	}); err != nil {
+		return err
+	}
+	return p.consumeEndOfDecl(";", loc, first)
+}
+
+func (p *parser) parseService(s *serviceProto, path []int32) error {
+	first := p.peek()
+	loc := p.newLoc(path, first)
+	defer p.endLoc(loc)
+	p.next() // service
+	t, err := p.consumeIdent("Expected service name.")
+	if err != nil {
+		return err
+	}
+	p.spanLoc(join(path, genid.ServiceDescriptorProto_Name_field_number), t, t)
+	s.Name = proto.String(t.text)
+	if err := p.consumeEndOfDecl("{", loc, first); err != nil {
+		return err
+	}
+	for !p.tryConsume("}") {
+		if p.atEOF() {
+			return p.error(p.peek(), "Reached end of input in service definition (missing '}').")
+		}
+		if err := p.parseServiceStatement(s, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseServiceStatement(s *serviceProto, path []int32) error {
+	switch {
+	case p.tryConsume(";"):
+		return nil // empty statement
+	case p.lookingAt("option"):
+		loc := p.newLoc(join(path, genid.ServiceDescriptorProto_Options_field_number), p.peek())
+		defer p.endLoc(loc)
+		return p.parseOption(loc.Path, func() proto.Message {
+			if s.Options == nil {
+				s.Options = new(descriptorpb.ServiceOptions)
+			}
+			return s.Options
+		}, true)
+	default:
+		m := new(methodProto)
+		methodPath := join(path, genid.ServiceDescriptorProto_Method_field_number, len(s.Method))
+		s.Method = append(s.Method, m)
+		return p.parseMethod(m, methodPath)
+	}
+}
+
+func (p *parser) parseMethod(m *methodProto, path []int32) error {
+	first := p.peek()
+	loc := p.newLoc(path, first)
+	defer p.endLoc(loc)
+	if err := p.consume("rpc"); err != nil {
+		return err
+	}
+	t, err := p.consumeIdent("Expected method name.")
+	if err != nil {
+		return err
+	}
+	p.spanLoc(join(path, genid.MethodDescriptorProto_Name_field_number), t, t)
+	m.Name = proto.String(t.text)
+
+	parseType := func(streamingField, typeField protoreflect.FieldNumber) (string, bool, error) {
+		if err := p.consume("("); err != nil {
+			return "", false, err
+		}
+		streaming := p.lookingAt("stream")
+		if streaming {
+			p.spanLoc(join(path, streamingField), p.peek(), p.peek())
+			p.next()
+		}
+		start := p.peek()
+		name, err := p.parseTypeName()
+		if err != nil {
+			return "", false, err
+		}
+		p.spanLoc(join(path, typeField), start, p.prev)
+		return name, streaming, p.consume(")")
+	}
+	input, streaming, err := parseType(genid.MethodDescriptorProto_ClientStreaming_field_number, genid.MethodDescriptorProto_InputType_field_number)
+	if err != nil {
+		return err
+	}
+	m.InputType = proto.String(input)
+	if streaming {
+		m.ClientStreaming = proto.Bool(true)
+	}
+	if err := p.consume("returns"); err != nil {
+		return err
+	}
+	output, streaming, err := parseType(genid.MethodDescriptorProto_ServerStreaming_field_number, genid.MethodDescriptorProto_OutputType_field_number)
+	if err != nil {
+		return err
+	}
+	m.OutputType = proto.String(output)
+	if streaming {
+		m.ServerStreaming = proto.Bool(true)
+	}
+
+	if !p.lookingAt("{") {
+		return p.consumeEndOfDecl(";", loc, first)
+	}
+	if err := p.consumeEndOfDecl("{", loc, first); err != nil {
+		return err
+	}
+	for !p.tryConsume("}") {
+		if p.atEOF() {
+			return p.error(p.peek(), "Reached end of input in method options (missing '}').")
+		}
+		if p.tryConsume(";") {
+			continue // empty statement
+		}
+		if !p.lookingAt("option") {
+			return p.error(p.peek(), "Expected \"option\".")
+		}
+		optLoc := p.newLoc(join(path, genid.MethodDescriptorProto_Options_field_number), p.peek())
+		if err := p.parseOption(optLoc.Path, func() proto.Message {
+			if m.Options == nil {
+				m.Options = new(descriptorpb.MethodOptions)
+			}
+			return m.Options
+		}, true); err != nil {
+			return err
+		}
+		p.endLoc(optLoc)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protoparse parses .proto source files into descriptors.
+//
+// It is a pure Go implementation of the parser and linker of protoc,
+// the Protocol Buffer compiler. The resulting descriptors,
+// including their source code info, and the error messages for invalid
+// source files match those of protoc as closely as practical.
+package protoparse
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	// Files that are not found in the file system are loaded from the global
+	// registry, so that the well-known types are always importable.
+	_ "google.golang.org/protobuf/types/gofeaturespb"
+	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/apipb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/fieldmaskpb"
+	_ "google.golang.org/protobuf/types/known/sourcecontextpb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/typepb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+	_ "google.golang.org/protobuf/types/pluginpb"
+)
+
+// Parser parses .proto source files.
+type Parser struct {
+	// FS is the file system from which source files are read.
+	// The name of each file, as used in import statements,
+	// is its path within FS.
+	//
+	// Files that are not present in FS are looked up in
+	// protoregistry.GlobalFiles, which always contains
+	// google/protobuf/descriptor.proto and the well-known types.
+	// If FS is nil, only files in protoregistry.GlobalFiles can be loaded.
+	FS fs.FS
+}
+
+// Parse parses the named files and the files that they import.
+// It returns the descriptors of all these files, where each file appears
+// after the files that it imports.
+//
+// If a file is invalid, the returned error is an *Error.
+func (p Parser) Parse(names ...string) ([]*descriptorpb.FileDescriptorProto, error) {
+	c, err := p.compile(names)
+	if err != nil {
+		return nil, err
+	}
+	fds := make([]*descriptorpb.FileDescriptorProto, len(c.order))
+	for i, f := range c.order {
+		fds[i] = f.fd
+	}
+	return fds, nil
+}
+
+// ParseFiles parses the named files and the files that they import,
+// returning a registry that contains all of them.
+//
+// If a file is invalid, the returned error is an *Error.
+func (p Parser) ParseFiles(names ...string) (*protoregistry.Files, error) {
+	c, err := p.compile(names)
+	if err != nil {
+		return nil, err
+	}
+	return c.files, nil
+}
+
+func (p Parser) compile(names []string) (*compiler, error) {
+	c := &compiler{
+		fs:      p.FS,
+		files:   new(protoregistry.Files),
+		results: make(map[string]*result),
+		symbols: make(map[string]*symbol),
+		exts:    make(map[extensionKey]extension),
+	}
+	for _, name := range names {
+		if _, err := c.load(name, nil); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil, &Error{Filename: name, Message: "File not found."}
+			}
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Error is an error in a source file, such as a syntax error or a reference
+// to an undefined type.
+type Error struct {
+	Filename string
+	// Line and Column are the 1-based position of the error in the file.
+	// They are zero if the error does not have a position.
+	Line, Column int
+	Message      string
+}
+
+// Error formats the error as protoc does, e.g., "foo.proto:3:5: Expected ";".".
+func (e *Error) Error() string {
+	if e.Line == 0 {
+		return e.Filename + ": " + e.Message
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.Filename, e.Line, e.Column, e.Message)
+}
+
+// result is a file that has been loaded.
+type result struct {
+	fd   *descriptorpb.FileDescriptorProto
+	desc protoreflect.FileDescriptor
+}
+
+// compiler loads and links a set of files.
+type compiler struct {
+	fs      fs.FS
+	files   *protoregistry.Files
+	results map[string]*result
+	order   []*result
+
+	symbols map[string]*symbol
+	exts    map[extensionKey]extension
+}
+
+// load loads the named file, which is imported by the files in stack.
+// It reports an error wrapping fs.ErrNotExist if the file does not exist.
+func (c *compiler) load(name string, stack []string) (*result, error) {
+	if r := c.results[name]; r != nil {
+		return r, nil
+	}
+	pf, err := c.read(name)
+	if err != nil {
+		return nil, err
+	}
+	if pf.desc != nil {
+		// The file was loaded from the global registry,
+		// so it has already been linked.
+		for i := 0; i < pf.desc.Imports().Len(); i++ {
+			if _, err := c.load(pf.desc.Imports().Get(i).Path(), append(stack, name)); err != nil {
+				return nil, err
+			}
+		}
+		if err := c.addSymbols(pf); err != nil {
+			return nil, err
+		}
+		return c.add(pf.fd, pf.desc)
+	}
+
+	stack = append(stack, name)
+	seen := make(map[string]bool)
+	for i, dep := range pf.fd.Dependency {
+		if seen[dep] {
+			return nil, pf.errorAt(join(nil, genid.FileDescriptorProto_Dependency_field_number, i), "Import \"%s\" was listed twice.", dep)
+		}
+		seen[dep] = true
+		for j, s := range stack {
+			if s == dep {
+				cycle := strings.Join(append(stack[j:], dep), " -> ")
+				return nil, pf.errorAt(join(nil, genid.FileDescriptorProto_Dependency_field_number, i), "File recursively imports itself: %s", cycle)
+			}
+		}
+		if _, err := c.load(dep, stack); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil, pf.errorAt(join(nil, genid.FileDescriptorProto_Dependency_field_number, i), "Import \"%s\" was not found or had errors.", dep)
+			}
+			return nil, err
+		}
+	}
+	if err := c.link(pf); err != nil {
+		return nil, err
+	}
+	if err := c.interpretOptions(pf); err != nil {
+		return nil, err
+	}
+	desc, err := protodesc.NewFile(pf.fd, c.files)
+	if err != nil {
+		return nil, &Error{Filename: name, Message: trimPrefix(err.Error())}
+	}
+	return c.add(pf.fd, desc)
+}
+
+func (c *compiler) add(fd *descriptorpb.FileDescriptorProto, desc protoreflect.FileDescriptor) (*result, error) {
+	if err := c.files.RegisterFile(desc); err != nil {
+		return nil, &Error{Filename: fd.GetName(), Message: trimPrefix(err.Error())}
+	}
+	r := &result{fd: fd, desc: desc}
+	c.results[fd.GetName()] = r
+	c.order = append(c.order, r)
+	return r, nil
+}
+
+// read reads and parses the named file. If the file is not in the file
+// system, it is loaded from protoregistry.GlobalFiles.
+func (c *compiler) read(name string) (*parsedFile, error) {
+	var err error = fs.ErrNotExist
+	var src []byte
+	if c.fs != nil {
+		src, err = fs.ReadFile(c.fs, name)
+	}
+	switch {
+	case err == nil:
+		return parse(name, src)
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	desc, err := protoregistry.GlobalFiles.FindFileByPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, fs.ErrNotExist)
+	}
+	return &parsedFile{fd: protodesc.ToFileDescriptorProto(desc), desc: desc}, nil
+}
+
+// trimPrefix removes the "proto:" prefix from an error message
+// produced by another package of this module.
+func trimPrefix(s string) string {
+	if t, ok := strings.CutPrefix(s, "proto:"); ok {
+		t, _ = strings.CutPrefix(t, " ")
+		t, _ = strings.CutPrefix(t, " ")
+		return t
+	}
+	return s
+}
+
+// errorAt returns an error at the location of the element with the given
+// path within the file. If the element has no location, the error is at the
+// location of its innermost ancestor that does.
+func (f *parsedFile) errorAt(path []int32, format string, args ...any) error {
+	err := &Error{Filename: f.fd.GetName(), Message: fmt.Sprintf(format, args...)}
+	if loc := f.location(path); loc != nil {
+		err.Line = int(loc.Span[0]) + 1
+		err.Column = int(loc.Span[1]) + 1
+	}
+	return err
+}
+
+// errorAtPos returns an error at the position p within the file.
+func (f *parsedFile) errorAtPos(p pos, format string, args ...any) error {
+	return &Error{Filename: f.fd.GetName(), Line: p.line + 1, Column: p.col + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+func (f *parsedFile) location(path []int32) *location {
+	var best *location
+	for _, loc := range f.fd.GetSourceCodeInfo().GetLocation() {
+		if len(loc.Path) > len(path) || (best != nil && len(loc.Path) <= len(best.Path)) {
+			continue
+		}
+		if isPrefix(loc.Path, path) {
+			best = loc
+			if len(loc.Path) == len(path) {
+				break
+			}
+		}
+	}
+	return best
+}
+
+func isPrefix(prefix, path []int32) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i, x := range prefix {
+		if path[i] != x {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,481 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoparse_test
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/compiler/protoparse"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	proto2pb "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/proto2"
+	proto3pb "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/proto3"
+	protoeditionspb "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/protoeditions"
+	enumspb "google.golang.org/protobuf/internal/testprotos/enums"
+	test3pb "google.golang.org/protobuf/internal/testprotos/test3"
+	testeditionspb "google.golang.org/protobuf/internal/testprotos/testeditions"
+)
+
+// TestGolden parses the sources of generated files and checks that the
+// result matches the descriptors that protoc produced for them.
+func TestGolden(t *testing.T) {
+	for _, fd := range []protoreflect.FileDescriptor{
+		proto2pb.File_cmd_protoc_gen_go_testdata_proto2_fields_proto,
+		proto2pb.File_cmd_protoc_gen_go_testdata_proto2_nested_messages_proto,
+		proto3pb.File_cmd_protoc_gen_go_testdata_proto3_fields_proto,
+		protoeditionspb.File_cmd_protoc_gen_go_testdata_protoeditions_fields_proto,
+		protoeditionspb.File_cmd_protoc_gen_go_testdata_protoeditions_maps_and_delimited_proto,
+		enumspb.File_internal_testprotos_enums_enums_proto,
+		test3pb.File_internal_testprotos_test3_test_proto,
+		test3pb.File_internal_testprotos_test3_test_extension_proto,
+		testeditionspb.File_internal_testprotos_testeditions_test_proto,
+		testeditionspb.File_internal_testprotos_testeditions_test_extension_proto,
+	} {
+		t.Run(fd.Path(), func(t *testing.T) {
+			fds, err := protoparse.Parser{FS: os.DirFS("../..")}.Parse(fd.Path())
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			got := fds[len(fds)-1]
+			if got.SourceCodeInfo == nil {
+				t.Errorf("Parse() did not produce source code info")
+			}
+			got.SourceCodeInfo = nil
+			want := protodesc.ToFileDescriptorProto(fd)
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	fsys := fstest.MapFS{
+		"opts.proto": {Data: []byte(`
+syntax = "proto2";
+package test.opts;
+import "google/protobuf/descriptor.proto";
+message Rule {
+  optional string name = 1;
+  repeated int32 values = 2;
+}
+extend google.protobuf.MessageOptions {
+  optional Rule rule = 50000;
+}
+extend google.protobuf.FieldOptions {
+  repeated string tags = 50001;
+}
+`)},
+		"test.proto": {Data: []byte(`
+syntax = "proto2";
+package test;
+import public "opts.proto";
+option go_package = "example.com/test";
+message Message {
+  option (test.opts.rule) = { name: "message" values: [1, 2] };
+  option (opts.rule).values = 3;
+  required int32 a = 1 [default = -0x10];
+  optional bytes b = 2 [default = "\x00\n\xff"];
+  optional double c = 3 [default = -inf, (opts.tags) = "x", (opts.tags) = "y"];
+  repeated group G = 4 { optional Enum e = 1 [default = TWO]; }
+  map<string, Message> m = 5;
+  extensions 100 to max [verification = UNVERIFIED];
+  reserved 10, 20 to 30;
+  reserved "d";
+}
+enum Enum {
+  option allow_alias = true;
+  ONE = 1;
+  TWO = 2;
+  DOS = 2;
+  reserved -5 to -1;
+}
+extend Message {
+  optional string ext = 100;
+}
+extend .test.Message {
+  optional string qualified_ext = 101;
+}
+`)},
+	}
+	fds, err := protoparse.Parser{FS: fsys}.Parse("test.proto")
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	var paths []string
+	for _, fd := range fds {
+		paths = append(paths, fd.GetName())
+	}
+	if diff := cmp.Diff([]string{"google/protobuf/descriptor.proto", "opts.proto", "test.proto"}, paths); diff != "" {
+		t.Errorf("Parse() files mismatch (-want +got):\n%s", diff)
+	}
+
+	got := fds[2]
+	got.SourceCodeInfo = nil
+	// Custom options are compared separately below.
+	msgOpts := got.MessageType[0].Options
+	fieldOpts := got.MessageType[0].Field[2].Options
+	got.MessageType[0].Options = nil
+	got.MessageType[0].Field[2].Options = nil
+	want := &descriptorpb.FileDescriptorProto{
+		Name:             proto.String("test.proto"),
+		Package:          proto.String("test"),
+		Dependency:       []string{"opts.proto"},
+		PublicDependency: []int32{0},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Message"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:         proto.String("a"),
+				Number:       proto.Int32(1),
+				Label:        descriptorpb.FieldDescriptorProto_LABEL_REQUIRED.Enum(),
+				Type:         descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(),
+				DefaultValue: proto.String("-16"),
+				JsonName:     proto.String("a"),
+			}, {
+				Name:         proto.String("b"),
+				Number:       proto.Int32(2),
+				Label:        descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:         descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum(),
+				DefaultValue: proto.String(`\000\n\377`),
+				JsonName:     proto.String("b"),
+			}, {
+				Name:         proto.String("c"),
+				Number:       proto.Int32(3),
+				Label:        descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:         descriptorpb.FieldDescriptorProto_TYPE_DOUBLE.Enum(),
+				DefaultValue: proto.String("-inf"),
+				JsonName:     proto.String("c"),
+			}, {
+				Name:     proto.String("g"),
+				Number:   proto.Int32(4),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_GROUP.Enum(),
+				TypeName: proto.String(".test.Message.G"),
+				JsonName: proto.String("g"),
+			}, {
+				Name:     proto.String("m"),
+				Number:   proto.Int32(5),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String(".test.Message.MEntry"),
+				JsonName: proto.String("m"),
+			}},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("G"),
+				Field: []*descriptorpb.FieldDescriptorProto{{
+					Name:         proto.String("e"),
+					Number:       proto.Int32(1),
+					Label:        descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:         descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(),
+					TypeName:     proto.String(".test.Enum"),
+					DefaultValue: proto.String("TWO"),
+					JsonName:     proto.String("e"),
+				}},
+			}, {
+				Name: proto.String("MEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{{
+					Name:     proto.String("key"),
+					Number:   proto.Int32(1),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+					JsonName: proto.String("key"),
+				}, {
+					Name:     proto.String("value"),
+					Number:   proto.Int32(2),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+					TypeName: proto.String(".test.Message"),
+					JsonName: proto.String("value"),
+				}},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+			ExtensionRange: []*descriptorpb.DescriptorProto_ExtensionRange{{
+				Start: proto.Int32(100),
+				End:   proto.Int32(536870912),
+				Options: &descriptorpb.ExtensionRangeOptions{
+					Verification: descriptorpb.ExtensionRangeOptions_UNVERIFIED.Enum(),
+				},
+			}},
+			ReservedRange: []*descriptorpb.DescriptorProto_ReservedRange{
+				{Start: proto.Int32(10), End: proto.Int32(11)},
+				{Start: proto.Int32(20), End: proto.Int32(31)},
+			},
+			ReservedName: []string{"d"},
+		}},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Enum"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("ONE"), Number: proto.Int32(1)},
+				{Name: proto.String("TWO"), Number: proto.Int32(2)},
+				{Name: proto.String("DOS"), Number: proto.Int32(2)},
+			},
+			Options: &descriptorpb.EnumOptions{AllowAlias: proto.Bool(true)},
+			ReservedRange: []*descriptorpb.EnumDescriptorProto_EnumReservedRange{
+				{Start: proto.Int32(-5), End: proto.Int32(-1)},
+			},
+		}},
+		Extension: []*descriptorpb.FieldDescriptorProto{{
+			Name:     proto.String("ext"),
+			Number:   proto.Int32(100),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			Extendee: proto.String(".test.Message"),
+			JsonName: proto.String("ext"),
+		}, {
+			Name:     proto.String("qualified_ext"),
+			Number:   proto.Int32(101),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			Extendee: proto.String(".test.Message"),
+			JsonName: proto.String("qualifiedExt"),
+		}},
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/test")},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The custom options are only known to the registry of the parsed files.
+	files, err := protoparse.Parser{FS: fsys}.ParseFiles("test.proto")
+	if err != nil {
+		t.Fatalf("ParseFiles() error: %v", err)
+	}
+	types := dynamicpb.NewTypes(files)
+	for _, test := range []struct {
+		got  proto.Message
+		want string
+	}{
+		{msgOpts, `[test.opts.rule]: {name: "message" values: [1, 2, 3]}`},
+		{fieldOpts, `[test.opts.tags]: ["x", "y"]`},
+	} {
+		b, err := proto.Marshal(test.got)
+		if err != nil {
+			t.Fatalf("Marshal() error: %v", err)
+		}
+		got := test.got.ProtoReflect().Type().New().Interface()
+		if err := (proto.UnmarshalOptions{Resolver: types}).Unmarshal(b, got); err != nil {
+			t.Fatalf("Unmarshal() error: %v", err)
+		}
+		want := test.got.ProtoReflect().Type().New().Interface()
+		if err := (prototext.UnmarshalOptions{Resolver: types}).Unmarshal([]byte(test.want), want); err != nil {
+			t.Fatalf("prototext.Unmarshal() error: %v", err)
+		}
+		if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+			t.Errorf("%v options mismatch (-want +got):\n%s", got.ProtoReflect().Descriptor().FullName(), diff)
+		}
+	}
+}
+
+func TestSourceCodeInfo(t *testing.T) {
+	const src = `// Detached comment.
+
+// Leading comment for syntax.
+syntax = "proto3";
+
+package test; // Trailing comment for package.
+
+/* Leading comment for M.
+ * Second line. */
+message M {
+  int32 a = 1 [deprecated = true];
+  // Leading comment for b.
+  optional string b = 2;
+}
+`
+	fds, err := protoparse.Parser{FS: fstest.MapFS{"test.proto": {Data: []byte(src)}}}.Parse("test.proto")
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	got := fds[0].SourceCodeInfo
+	loc := func(path []int32, span []int32, comments ...string) *descriptorpb.SourceCodeInfo_Location {
+		l := &descriptorpb.SourceCodeInfo_Location{Path: path, Span: span}
+		if len(comments) > 0 && comments[0] != "" {
+			l.LeadingComments = proto.String(comments[0])
+		}
+		if len(comments) > 1 && comments[1] != "" {
+			l.TrailingComments = proto.String(comments[1])
+		}
+		if len(comments) > 2 {
+			l.LeadingDetachedComments = comments[2:]
+		}
+		return l
+	}
+	want := &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{
+		loc([]int32{}, []int32{3, 0, 13, 1}),
+		loc([]int32{12}, []int32{3, 0, 18}, " Leading comment for syntax.\n", "", " Detached comment.\n"),
+		loc([]int32{2}, []int32{5, 0, 13}, "", " Trailing comment for package.\n"),
+		loc([]int32{4, 0}, []int32{9, 0, 13, 1}, " Leading comment for M.\n Second line. "),
+		loc([]int32{4, 0, 1}, []int32{9, 8, 9}),
+		loc([]int32{4, 0, 2, 0}, []int32{10, 2, 34}),
+		loc([]int32{4, 0, 2, 0, 5}, []int32{10, 2, 7}),
+		loc([]int32{4, 0, 2, 0, 1}, []int32{10, 8, 9}),
+		loc([]int32{4, 0, 2, 0, 3}, []int32{10, 12, 13}),
+		loc([]int32{4, 0, 2, 0, 8}, []int32{10, 14, 33}),
+		loc([]int32{4, 0, 2, 0, 8, 3}, []int32{10, 15, 32}),
+		loc([]int32{4, 0, 2, 1}, []int32{12, 2, 24}, " Leading comment for b.\n"),
+		loc([]int32{4, 0, 2, 1, 4}, []int32{12, 2, 10}),
+		loc([]int32{4, 0, 2, 1, 5}, []int32{12, 11, 17}),
+		loc([]int32{4, 0, 2, 1, 1}, []int32{12, 18, 19}),
+		loc([]int32{4, 0, 2, 1, 3}, []int32{12, 22, 23}),
+	}}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Parse() source code info mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		desc  string
+		files map[string]string
+		want  string
+	}{{
+		desc:  "unknown syntax",
+		files: map[string]string{"test.proto": `syntax = "proto4";`},
+		want:  `test.proto:1:10: Unrecognized syntax identifier "proto4".  This parser only recognizes "proto2" and "proto3".`,
+	}, {
+		desc:  "unknown edition",
+		files: map[string]string{"test.proto": `edition = "2022";`},
+		want:  `test.proto:1:11: Unknown edition "2022".`,
+	}, {
+		desc:  "missing semicolon",
+		files: map[string]string{"test.proto": `syntax = "proto3"; message M { int32 a = 1 }`},
+		want:  `test.proto:1:44: Expected ";".`,
+	}, {
+		desc:  "unterminated comment",
+		files: map[string]string{"test.proto": `syntax = "proto2"; /* comment`},
+		want:  `test.proto:1:30: End-of-file inside block comment.`,
+	}, {
+		desc:  "bad hex literal",
+		files: map[string]string{"test.proto": `syntax = "proto2"; message M { optional int32 a = 0x; }`},
+		want:  `test.proto:1:51: "0x" must be followed by hex digits.`,
+	}, {
+		desc:  "missing label",
+		files: map[string]string{"test.proto": `message M { int32 a = 1; }`},
+		want:  `test.proto:1:13: Expected "required", "optional", or "repeated".`,
+	}, {
+		desc:  "required in proto3",
+		files: map[string]string{"test.proto": `syntax = "proto3"; message M { required int32 a = 1; }`},
+		want:  `test.proto:1:32: Required fields are not allowed in proto3.`,
+	}, {
+		desc:  "optional in editions",
+		files: map[string]string{"test.proto": `edition = "2023"; message M { optional int32 a = 1; }`},
+		want:  `test.proto:1:31: Label "optional" is not supported in editions. By default, all singular fields have presence unless features.field_presence is set.`,
+	}, {
+		desc:  "undefined type",
+		files: map[string]string{"test.proto": `syntax = "proto3"; message M { Foo a = 1; }`},
+		want:  `test.proto:1:32: "Foo" is not defined.`,
+	}, {
+		desc: "inner scope shadows outer scope",
+		files: map[string]string{"test.proto": `syntax = "proto3"; package a.b;
+message M { b.X x = 1; message b {} }
+message X {}`},
+		want: `test.proto:2:13: "b.X" is resolved to "a.b.M.b.X", which is not defined. The innermost scope is searched first in name resolution. Consider using a leading '.'(i.e., ".b.X") to start from the outermost scope.`,
+	}, {
+		desc: "type not imported",
+		files: map[string]string{
+			"a.proto":    `syntax = "proto3"; package a; message A {}`,
+			"b.proto":    `syntax = "proto3"; import "a.proto";`,
+			"test.proto": `syntax = "proto3"; import "b.proto"; message M { a.A a = 1; }`,
+		},
+		want: `test.proto:1:50: "a.A" seems to be defined in "a.proto", which is not imported by "test.proto".  To use it here, please add the necessary import.`,
+	}, {
+		desc:  "duplicate name",
+		files: map[string]string{"test.proto": `syntax = "proto3"; package p; message M {} enum M { A = 0; }`},
+		want:  `test.proto:1:49: "M" is already defined in "p".`,
+	}, {
+		desc: "duplicate name in another file",
+		files: map[string]string{
+			"a.proto":    `syntax = "proto3"; message M {}`,
+			"test.proto": `syntax = "proto3"; import "a.proto"; message M {}`,
+		},
+		want: `test.proto:1:46: "M" is already defined in file "a.proto".`,
+	}, {
+		desc:  "duplicate field number",
+		files: map[string]string{"test.proto": `syntax = "proto3"; message M { int32 a = 1; string b = 1; }`},
+		want:  `test.proto:1:56: Field number 1 has already been used in "M" by field "a".`,
+	}, {
+		desc:  "reserved field number",
+		files: map[string]string{"test.proto": `syntax = "proto2"; message M { optional int32 a = 1; reserved 1; }`},
+		want:  `test.proto:1:51: Field "a" uses reserved number 1.`,
+	}, {
+		desc:  "implementation-reserved field number",
+		files: map[string]string{"test.proto": `syntax = "proto3"; message M { int32 a = 19001; }`},
+		want:  `test.proto:1:42: Field numbers 19000 through 19999 are reserved for the protocol buffer library implementation.`,
+	}, {
+		desc:  "overlapping extension ranges",
+		files: map[string]string{"test.proto": `syntax = "proto2"; message M { extensions 1 to 10; extensions 5 to 20; }`},
+		want:  `test.proto:1:63: Extension range 5 to 20 overlaps with already-defined range 1 to 10.`,
+	}, {
+		desc:  "extension outside range",
+		files: map[string]string{"test.proto": `syntax = "proto2"; message M { extensions 1 to 10; } extend M { optional int32 x = 11; }`},
+		want:  `test.proto:1:84: "M" does not declare 11 as an extension number.`,
+	}, {
+		desc:  "first enum value",
+		files: map[string]string{"test.proto": `syntax = "proto3"; enum E { A = 1; }`},
+		want:  `test.proto:1:33: The first enum value must be zero for open enums.`,
+	}, {
+		desc:  "enum alias",
+		files: map[string]string{"test.proto": `syntax = "proto3"; enum E { A = 0; B = 0; }`},
+		want:  `test.proto:1:40: "B" uses the same enum value as "A". If this is intended, set 'option allow_alias = true;' to the enum definition.`,
+	}, {
+		desc:  "bad default value",
+		files: map[string]string{"test.proto": `syntax = "proto2"; enum E { A = 0; } message M { optional E e = 1 [default = B]; }`},
+		want:  `test.proto:1:78: Enum type "E" has no value named "B".`,
+	}, {
+		desc:  "bad option value",
+		files: map[string]string{"test.proto": `syntax = "proto3"; message M { int32 a = 1 [deprecated = 5]; }`},
+		want:  `test.proto:1:58: Value must be "true" or "false" for boolean option "google.protobuf.FieldOptions.deprecated".`,
+	}, {
+		desc:  "unknown option",
+		files: map[string]string{"test.proto": `syntax = "proto3"; option (foo.bar) = 1;`},
+		want:  `test.proto:1:27: Option "(foo.bar)" unknown. Ensure that your proto definition file imports the proto which defines the option.`,
+	}, {
+		desc:  "option set twice",
+		files: map[string]string{"test.proto": `syntax = "proto3"; option java_package = "a"; option java_package = "b";`},
+		want:  `test.proto:1:54: Option "java_package" was already set.`,
+	}, {
+		desc:  "missing file",
+		files: map[string]string{},
+		want:  `test.proto: File not found.`,
+	}, {
+		desc:  "missing import",
+		files: map[string]string{"test.proto": `syntax = "proto3"; import "nope.proto";`},
+		want:  `test.proto:1:20: Import "nope.proto" was not found or had errors.`,
+	}, {
+		desc: "import cycle",
+		files: map[string]string{
+			"a.proto":    `syntax = "proto3"; import "test.proto";`,
+			"test.proto": `syntax = "proto3"; import "a.proto";`,
+		},
+		want: `a.proto:1:20: File recursively imports itself: test.proto -> a.proto -> test.proto`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for name, src := range tt.files {
+				fsys[name] = &fstest.MapFile{Data: []byte(src)}
+			}
+			_, err := protoparse.Parser{FS: fsys}.Parse("test.proto")
+			if err == nil {
+				t.Fatalf("Parse() succeeded, want error %q", tt.want)
+			}
+			if _, ok := err.(*protoparse.Error); !ok {
+				t.Errorf("Parse() error is %T, want *protoparse.Error", err)
+			}
+			if got := err.Error(); got != tt.want {
+				t.Errorf("Parse() error:\ngot  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoprint
+
+import (
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// option is an option setting, such as "deprecated = true".
+type option struct {
+	name, value string
+}
+
+// optionList returns the options that are set in an options message,
+// in field number order.
+func (p *printer) optionList(m proto.Message) []option {
+	if m == nil {
+		return nil
+	}
+	rm := m.ProtoReflect()
+	if !rm.IsValid() {
+		return nil
+	}
+	if len(rm.GetUnknown()) > 0 {
+		// Custom options that were unknown when the descriptor was built
+		// are resolved with the types available now.
+		b, err := proto.MarshalOptions{AllowPartial: true, Deterministic: true}.Marshal(m)
+		if err == nil {
+			m2 := rm.Type().New()
+			err = proto.UnmarshalOptions{AllowPartial: true, Resolver: p.opts.Resolver}.Unmarshal(b, m2.Interface())
+			if err == nil {
+				rm = m2
+			}
+		}
+	}
+	var opts []option
+	p.appendOptions(&opts, "", rm)
+	return opts
+}
+
+func (p *printer) appendOptions(opts *[]option, prefix string, m protoreflect.Message) {
+	for _, fd := range sortedFields(m) {
+		v := m.Get(fd)
+		name := prefix + optionName(fd)
+		switch {
+		case fd.FullName() == genid.MessageOptions_MapEntry_field_fullname:
+			// Map entries are implied by the map syntax.
+		case fd.IsList():
+			for i, l := 0, v.List(); i < l.Len(); i++ {
+				*opts = append(*opts, option{name, p.formatValue(fd, l.Get(i))})
+			}
+		case fd.Message() != nil && (fd.Message().FullName() == genid.FeatureSet_message_fullname || strings.HasPrefix(prefix, "features.")) && !fd.IsMap():
+			// Features are set individually, as in "features.field_presence = IMPLICIT".
+			p.appendOptions(opts, name+".", v.Message())
+		default:
+			*opts = append(*opts, option{name, p.formatValue(fd, v)})
+		}
+	}
+}
+
+func optionName(fd protoreflect.FieldDescriptor) string {
+	if fd.IsExtension() {
+		return "(" + string(fd.FullName()) + ")"
+	}
+	return string(fd.Name())
+}
+
+// sortedFields returns the populated fields of m in field number order.
+func sortedFields(m protoreflect.Message) []protoreflect.FieldDescriptor {
+	var fds []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fds = append(fds, fd)
+		return true
+	})
+	sort.Slice(fds, func(i, j int) bool { return fds[i].Number() < fds[j].Number() })
+	return fds
+}
+
+// printOptions prints option statements,
+// which are separated from the following elements by a blank line.
+func (p *printer) printOptions(opts []option) {
+	for _, opt := range opts {
+		p.begin(elementLine)
+		p.printf("option %s = %s;\n", opt.name, opt.value)
+	}
+	if len(opts) > 0 {
+		p.prev = elementBlock
+	}
+}
+
+// formatFieldOptions formats a bracketed list of options, such as
+// " [deprecated = true]", or returns the empty string if there are none.
+func (p *printer) formatFieldOptions(opts []option) string {
+	if len(opts) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(" [")
+	for i, opt := range opts {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(opt.name + " = " + opt.value)
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// formatValue formats the value of an option. A message value is formatted
+// as an aggregate in the text format, indented relative to the current line.
+func (p *printer) formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if fd.Message() == nil {
+		return formatScalar(fd, v)
+	}
+	var b strings.Builder
+	p.formatMessage(&b, p.indent(), v.Message())
+	return b.String()
+}
+
+func (p *printer) formatMessage(b *strings.Builder, indent string, m protoreflect.Message) {
+	fds := sortedFields(m)
+	if len(fds) == 0 {
+		b.WriteString("{}")
+		return
+	}
+	b.WriteString("{\n")
+	inner := indent + p.opts.Indent
+	for _, fd := range fds {
+		name := fd.TextName()
+		if fd.IsExtension() {
+			name = "[" + string(fd.FullName()) + "]"
+		}
+		v := m.Get(fd)
+		switch {
+		case fd.IsList():
+			for i, l := 0, v.List(); i < l.Len(); i++ {
+				p.formatField(b, inner, name, fd, l.Get(i))
+			}
+		case fd.IsMap():
+			mapv := v.Map()
+			var keys []protoreflect.MapKey
+			mapv.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				keys = append(keys, k)
+				return true
+			})
+			sort.Slice(keys, func(i, j int) bool { return lessMapKey(keys[i], keys[j]) })
+			for _, k := range keys {
+				b.WriteString(inner + name + " {\n")
+				p.formatField(b, inner+p.opts.Indent, "key", fd.MapKey(), k.Value())
+				p.formatField(b, inner+p.opts.Indent, "value", fd.MapValue(), mapv.Get(k))
+				b.WriteString(inner + "}\n")
+			}
+		default:
+			p.formatField(b, inner, name, fd, v)
+		}
+	}
+	b.WriteString(indent + "}")
+}
+
+func (p *printer) formatField(b *strings.Builder, indent, name string, fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	b.WriteString(indent + name)
+	if fd.Message() != nil {
+		b.WriteString(" ")
+		p.formatMessage(b, indent, v.Message())
+	} else {
+		b.WriteString(": " + formatScalar(fd, v))
+	}
+	b.WriteString("\n")
+}
+
+func lessMapKey(x, y protoreflect.MapKey) bool {
+	switch x.Interface().(type) {
+	case bool:
+		return !x.Bool() && y.Bool()
+	case int32, int64:
+		return x.Int() < y.Int()
+	case uint32, uint64:
+		return x.Uint() < y.Uint()
+	default:
+		return x.String() < y.String()
+	}
+}
@@ -0,0 +1,641 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protoprint formats file descriptors as .proto source files.
+//
+// The output is a canonically formatted .proto file that, when compiled,
+// produces a file descriptor equivalent to the input. It is useful to
+// reconstruct the schema of files whose descriptors are embedded in binaries
+// or held in a registry.
+//
+// Declarations are printed in a fixed order, and types are always referred
+// to by their fully-qualified names. Comments are taken from the source
+// code info of the file, if any.
+package protoprint
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/internal/strs"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Format returns the .proto source of fd using default options.
+func Format(fd protoreflect.FileDescriptor) string {
+	return FormatOptions{}.Format(fd)
+}
+
+// FormatOptions is a configurable .proto source formatter.
+type FormatOptions struct {
+	// Indent is the string used to indent each level of nesting.
+	// If empty, two spaces are used.
+	Indent string
+
+	// OmitComments specifies whether to omit the comments recorded in
+	// the source code info of the file.
+	OmitComments bool
+
+	// Resolver is used to resolve custom options that are stored
+	// as unknown fields of the options messages.
+	// If nil, the extensions declared in the file and in the files that it
+	// transitively imports are used.
+	Resolver interface {
+		protoregistry.ExtensionTypeResolver
+		protoregistry.MessageTypeResolver
+	}
+}
+
+// Format returns the .proto source of fd.
+func (o FormatOptions) Format(fd protoreflect.FileDescriptor) string {
+	p := &printer{opts: o, file: fd}
+	if p.opts.Indent == "" {
+		p.opts.Indent = "  "
+	}
+	if p.opts.Resolver == nil {
+		files := new(protoregistry.Files)
+		registerFiles(files, fd)
+		p.opts.Resolver = dynamicpb.NewTypes(files)
+	}
+	p.printFile()
+	return p.buf.String()
+}
+
+// registerFiles registers fd and the files that it transitively imports.
+func registerFiles(files *protoregistry.Files, fd protoreflect.FileDescriptor) {
+	if _, err := files.FindFileByPath(fd.Path()); err == nil {
+		return
+	}
+	imports := fd.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		registerFiles(files, imports.Get(i).FileDescriptor)
+	}
+	// Conflicts and placeholder files are ignored;
+	// their options are left unresolved.
+	files.RegisterFile(fd)
+}
+
+type printer struct {
+	opts FormatOptions
+	file protoreflect.FileDescriptor
+	buf  bytes.Buffer
+
+	depth int
+	// prev is the kind of the last element printed at the current depth.
+	prev elementKind
+}
+
+type elementKind int
+
+const (
+	elementNone elementKind = iota
+	elementLine
+	elementBlock
+)
+
+// begin starts a new element at the current depth, separating it from the
+// previous one by a blank line if either of them spans multiple lines.
+func (p *printer) begin(kind elementKind) {
+	if p.prev == elementBlock || (p.prev != elementNone && kind == elementBlock) {
+		p.buf.WriteByte('\n')
+	}
+	p.prev = kind
+}
+
+func (p *printer) indent() string {
+	return strings.Repeat(p.opts.Indent, p.depth)
+}
+
+func (p *printer) printf(format string, args ...any) {
+	p.buf.WriteString(p.indent())
+	fmt.Fprintf(&p.buf, format, args...)
+}
+
+// open ends the current line with the opening brace of a block.
+func (p *printer) open(loc protoreflect.SourceLocation) {
+	p.buf.WriteString(" {")
+	p.trailing(loc)
+	p.depth++
+	p.prev = elementNone
+}
+
+func (p *printer) close() {
+	p.depth--
+	p.printf("}\n")
+	p.prev = elementBlock
+}
+
+// end ends the current line, which completes a declaration.
+func (p *printer) end(loc protoreflect.SourceLocation) {
+	p.buf.WriteString(";")
+	p.trailing(loc)
+}
+
+func (p *printer) location(d protoreflect.Descriptor) protoreflect.SourceLocation {
+	if p.opts.OmitComments {
+		return protoreflect.SourceLocation{}
+	}
+	return p.file.SourceLocations().ByDescriptor(d)
+}
+
+func (p *printer) locationByPath(path ...int32) protoreflect.SourceLocation {
+	if p.opts.OmitComments {
+		return protoreflect.SourceLocation{}
+	}
+	return p.file.SourceLocations().ByPath(path)
+}
+
+// leading prints the detached and leading comments of a location.
+func (p *printer) leading(loc protoreflect.SourceLocation) {
+	for _, c := range loc.LeadingDetachedComments {
+		p.comment(c)
+		p.buf.WriteByte('\n')
+	}
+	p.comment(loc.LeadingComments)
+}
+
+// trailing ends the current line with the trailing comment of a location.
+func (p *printer) trailing(loc protoreflect.SourceLocation) {
+	c := strings.TrimSuffix(loc.TrailingComments, "\n")
+	if c != "" && !strings.Contains(c, "\n") {
+		p.buf.WriteString(" //" + c)
+		c = ""
+	}
+	p.buf.WriteByte('\n')
+	if c != "" {
+		p.depth++
+		p.comment(c)
+		p.depth--
+	}
+}
+
+func (p *printer) comment(c string) {
+	if c == "" {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(c, "\n"), "\n") {
+		p.printf("//%s\n", strings.TrimRightFunc(line, unicode.IsSpace))
+	}
+}
+
+func (p *printer) printFile() {
+	fd := p.file
+	loc := p.locationByPath(int32(genid.FileDescriptorProto_Syntax_field_number))
+	p.leading(loc)
+	switch fd.Syntax() {
+	case protoreflect.Proto2:
+		p.printf(`syntax = "proto2"`)
+	case protoreflect.Proto3:
+		p.printf(`syntax = "proto3"`)
+	case protoreflect.Editions:
+		edition := protodesc.ToFileDescriptorProto(fd).GetEdition()
+		p.printf(`edition = "%s"`, strings.TrimPrefix(edition.String(), "EDITION_"))
+	}
+	p.end(loc)
+
+	if fd.Package() != "" {
+		p.buf.WriteByte('\n')
+		loc := p.locationByPath(int32(genid.FileDescriptorProto_Package_field_number))
+		p.leading(loc)
+		p.printf("package %s", fd.Package())
+		p.end(loc)
+	}
+
+	if imports := fd.Imports(); imports.Len() > 0 {
+		p.buf.WriteByte('\n')
+		for i := 0; i < imports.Len(); i++ {
+			imp := imports.Get(i)
+			loc := p.locationByPath(int32(genid.FileDescriptorProto_Dependency_field_number), int32(i))
+			p.leading(loc)
+			var modifier string
+			switch {
+			case imp.IsPublic:
+				modifier = "public "
+			case imp.IsWeak:
+				modifier = "weak "
+			}
+			p.printf("import %s%s", modifier, quote(imp.Path(), false))
+			p.end(loc)
+		}
+	}
+
+	if opts := p.optionList(fd.Options()); len(opts) > 0 {
+		p.buf.WriteByte('\n')
+		p.printOptions(opts)
+	}
+
+	p.prev = elementBlock
+	for i := 0; i < fd.Enums().Len(); i++ {
+		p.printEnum(fd.Enums().Get(i))
+	}
+	// The messages declared by group extensions are listed along with the
+	// other messages in the order of declaration, so each of the extensions
+	// that declare one is printed, along with the extensions before it,
+	// in its place.
+	groups := groupMessages(fd.Extensions())
+	var nextExt int
+	for i := 0; i < fd.Messages().Len(); i++ {
+		md := fd.Messages().Get(i)
+		if j, ok := groups[md]; ok {
+			p.printExtensions(fd.Extensions(), nextExt, j+1)
+			nextExt = j + 1
+			continue
+		}
+		p.printMessage(md)
+	}
+	for i := 0; i < fd.Services().Len(); i++ {
+		p.printService(fd.Services().Get(i))
+	}
+	p.printExtensions(fd.Extensions(), nextExt, fd.Extensions().Len())
+}
+
+func (p *printer) printMessage(md protoreflect.MessageDescriptor) {
+	p.begin(elementBlock)
+	loc := p.location(md)
+	p.leading(loc)
+	p.printf("message %s", md.Name())
+	p.open(loc)
+	p.printMessageBody(md)
+	p.close()
+}
+
+func (p *printer) printMessageBody(md protoreflect.MessageDescriptor) {
+	p.printOptions(p.optionList(md.Options()))
+
+	// The messages declared by group and map fields and by group extensions
+	// are listed along with the other nested messages in the order of
+	// declaration. Nested messages are printed in that order, and each of the
+	// fields or extensions that declare one is printed, along with the fields
+	// or extensions before it, in its place.
+	fields := md.Fields()
+	groups := groupMessages(fields)
+	for i := 0; i < fields.Len(); i++ {
+		if fd := fields.Get(i); fd.IsMap() {
+			groups[fd.Message()] = i
+		}
+	}
+	extensionGroups := groupMessages(md.Extensions())
+	var next, nextExt int
+	var enumsPrinted bool
+	printEnums := func() {
+		if !enumsPrinted {
+			for i := 0; i < md.Enums().Len(); i++ {
+				p.printEnum(md.Enums().Get(i))
+			}
+			enumsPrinted = true
+		}
+	}
+	printFields := func(end int) {
+		printEnums()
+		for ; next < end; next++ {
+			fd := fields.Get(next)
+			od := fd.ContainingOneof()
+			if od == nil || od.IsSynthetic() {
+				p.printField(fd)
+				continue
+			}
+			if od.Fields().Get(0) != fd {
+				continue
+			}
+			p.begin(elementBlock)
+			loc := p.location(od)
+			p.leading(loc)
+			p.printf("oneof %s", od.Name())
+			p.open(loc)
+			p.printOptions(p.optionList(od.Options()))
+			for j := 0; j < od.Fields().Len(); j++ {
+				p.printField(od.Fields().Get(j))
+			}
+			p.close()
+		}
+	}
+	for i := 0; i < md.Messages().Len(); i++ {
+		nested := md.Messages().Get(i)
+		if j, ok := groups[nested]; ok {
+			printFields(j + 1)
+		} else if j, ok := extensionGroups[nested]; ok {
+			p.printExtensions(md.Extensions(), nextExt, j+1)
+			nextExt = j + 1
+		} else {
+			p.printMessage(nested)
+		}
+	}
+	printFields(fields.Len())
+
+	p.printExtensionRanges(md)
+	p.printExtensions(md.Extensions(), nextExt, md.Extensions().Len())
+
+	var ranges []string
+	for i := 0; i < md.ReservedRanges().Len(); i++ {
+		r := md.ReservedRanges().Get(i)
+		ranges = append(ranges, formatRange(int64(r[0]), int64(r[1])-1, protowireMaxNumber))
+	}
+	p.printReserved(ranges, md.ReservedNames())
+}
+
+func (p *printer) printExtensionRanges(md protoreflect.MessageDescriptor) {
+	max := int64(protowireMaxNumber)
+	if md.Options().(*descriptorpb.MessageOptions).GetMessageSetWireFormat() {
+		max = math.MaxInt32 - 1
+	}
+	xrs := md.ExtensionRanges()
+	for i := 0; i < xrs.Len(); {
+		// Consecutive ranges without options are printed in one statement.
+		opts := p.optionList(md.ExtensionRangeOptions(i))
+		var ranges []string
+		for ; i < xrs.Len(); i++ {
+			if len(ranges) > 0 && (len(opts) > 0 || len(p.optionList(md.ExtensionRangeOptions(i))) > 0) {
+				break
+			}
+			r := xrs.Get(i)
+			ranges = append(ranges, formatRange(int64(r[0]), int64(r[1])-1, max))
+		}
+		p.begin(elementLine)
+		p.printf("extensions %s%s;\n", strings.Join(ranges, ", "), p.formatFieldOptions(opts))
+	}
+}
+
+func (p *printer) printReserved(ranges []string, names protoreflect.Names) {
+	if len(ranges) > 0 {
+		p.begin(elementLine)
+		p.printf("reserved %s;\n", strings.Join(ranges, ", "))
+	}
+	if names.Len() > 0 {
+		var s []string
+		for i := 0; i < names.Len(); i++ {
+			if p.file.Syntax() == protoreflect.Editions {
+				s = append(s, string(names.Get(i)))
+			} else {
+				s = append(s, quote(string(names.Get(i)), false))
+			}
+		}
+		p.begin(elementLine)
+		p.printf("reserved %s;\n", strings.Join(s, ", "))
+	}
+}
+
+func (p *printer) printField(fd protoreflect.FieldDescriptor) {
+	group := isGroup(fd)
+	if group {
+		p.begin(elementBlock)
+	} else {
+		p.begin(elementLine)
+	}
+	loc := p.location(fd)
+	p.leading(loc)
+
+	var label string
+	od := fd.ContainingOneof()
+	switch {
+	case fd.IsMap():
+	case fd.Cardinality() == protoreflect.Repeated:
+		label = "repeated "
+	case od != nil && !od.IsSynthetic():
+	case fd.Cardinality() == protoreflect.Required && p.file.Syntax() != protoreflect.Editions:
+		label = "required "
+	case fd.HasOptionalKeyword():
+		label = "optional "
+	case p.file.Syntax() == protoreflect.Proto2:
+		label = "optional "
+	}
+
+	var opts []option
+	if fd.HasDefault() {
+		opts = append(opts, option{"default", formatDefault(fd)})
+	}
+	if fd.HasJSONName() && fd.JSONName() != strs.JSONCamelCase(string(fd.Name())) && !fd.IsExtension() {
+		opts = append(opts, option{"json_name", quote(fd.JSONName(), false)})
+	}
+	opts = append(opts, p.optionList(fd.Options())...)
+
+	switch {
+	case group:
+		p.printf("%sgroup %s = %d%s", label, fd.Message().Name(), fd.Number(), p.formatFieldOptions(opts))
+		p.open(loc)
+		p.printMessageBody(fd.Message())
+		p.close()
+		return
+	case fd.IsMap():
+		p.printf("map<%s, %s> %s = %d%s", typeName(fd.MapKey()), typeName(fd.MapValue()), fd.Name(), fd.Number(), p.formatFieldOptions(opts))
+	default:
+		p.printf("%s%s %s = %d%s", label, typeName(fd), fd.Name(), fd.Number(), p.formatFieldOptions(opts))
+	}
+	p.end(loc)
+}
+
+// printExtensions prints the extension declarations in xds from start up to
+// end, grouping consecutive extensions of the same message into a single
+// extend block.
+func (p *printer) printExtensions(xds protoreflect.ExtensionDescriptors, start, end int) {
+	for i := start; i < end; {
+		extendee := xds.Get(i).ContainingMessage().FullName()
+		p.begin(elementBlock)
+		p.printf("extend .%s", extendee)
+		p.open(protoreflect.SourceLocation{})
+		for ; i < end && xds.Get(i).ContainingMessage().FullName() == extendee; i++ {
+			p.printField(xds.Get(i))
+		}
+		p.close()
+	}
+}
+
+func (p *printer) printEnum(ed protoreflect.EnumDescriptor) {
+	p.begin(elementBlock)
+	loc := p.location(ed)
+	p.leading(loc)
+	p.printf("enum %s", ed.Name())
+	p.open(loc)
+	p.printOptions(p.optionList(ed.Options()))
+	for i := 0; i < ed.Values().Len(); i++ {
+		vd := ed.Values().Get(i)
+		p.begin(elementLine)
+		loc := p.location(vd)
+		p.leading(loc)
+		p.printf("%s = %d%s", vd.Name(), vd.Number(), p.formatFieldOptions(p.optionList(vd.Options())))
+		p.end(loc)
+	}
+	var ranges []string
+	for i := 0; i < ed.ReservedRanges().Len(); i++ {
+		r := ed.ReservedRanges().Get(i)
+		ranges = append(ranges, formatRange(int64(r[0]), int64(r[1]), math.MaxInt32))
+	}
+	p.printReserved(ranges, ed.ReservedNames())
+	p.close()
+}
+
+func (p *printer) printService(sd protoreflect.ServiceDescriptor) {
+	p.begin(elementBlock)
+	loc := p.location(sd)
+	p.leading(loc)
+	p.printf("service %s", sd.Name())
+	p.open(loc)
+	p.printOptions(p.optionList(sd.Options()))
+	for i := 0; i < sd.Methods().Len(); i++ {
+		md := sd.Methods().Get(i)
+		opts := p.optionList(md.Options())
+		if len(opts) > 0 {
+			p.begin(elementBlock)
+		} else {
+			p.begin(elementLine)
+		}
+		loc := p.location(md)
+		p.leading(loc)
+		var in, out string
+		if md.IsStreamingClient() {
+			in = "stream "
+		}
+		if md.IsStreamingServer() {
+			out = "stream "
+		}
+		p.printf("rpc %s(%s.%s) returns (%s.%s)", md.Name(), in, md.Input().FullName(), out, md.Output().FullName())
+		if len(opts) == 0 {
+			p.end(loc)
+			continue
+		}
+		p.open(loc)
+		p.printOptions(opts)
+		p.close()
+	}
+	p.close()
+}
+
+// isGroup reports whether fd is declared with the proto2 group syntax,
+// in which case its message is declared along with the field.
+func isGroup(fd protoreflect.FieldDescriptor) bool {
+	if fd.Kind() != protoreflect.GroupKind || fd.ParentFile().Syntax() == protoreflect.Editions {
+		return false
+	}
+	md := fd.Message()
+	return md.Parent().FullName() == fd.Parent().FullName() &&
+		md.ParentFile().Path() == fd.ParentFile().Path() &&
+		strings.ToLower(string(md.Name())) == string(fd.Name())
+}
+
+// groupMessages returns the messages declared by the group fields in fds,
+// mapped to the index of the field that declares each.
+func groupMessages(fds interface {
+	Len() int
+	Get(int) protoreflect.FieldDescriptor
+}) map[protoreflect.Descriptor]int {
+	groups := make(map[protoreflect.Descriptor]int)
+	for i := 0; i < fds.Len(); i++ {
+		if fd := fds.Get(i); isGroup(fd) {
+			groups[fd.Message()] = i
+		}
+	}
+	return groups
+}
+
+const protowireMaxNumber = 1<<29 - 1
+
+// formatRange formats the inclusive range [start, end],
+// where an end of max is formatted as "max".
+func formatRange(start, end, max int64) string {
+	switch {
+	case start == end:
+		return strconv.FormatInt(start, 10)
+	case end == max:
+		return fmt.Sprintf("%d to max", start)
+	default:
+		return fmt.Sprintf("%d to %d", start, end)
+	}
+}
+
+func typeName(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return "." + string(fd.Message().FullName())
+	case protoreflect.EnumKind:
+		return "." + string(fd.Enum().FullName())
+	default:
+		return fd.Kind().String()
+	}
+}
+
+func formatDefault(fd protoreflect.FieldDescriptor) string {
+	if fd.Kind() == protoreflect.EnumKind {
+		return string(fd.DefaultEnumValue().Name())
+	}
+	return formatScalar(fd, fd.Default())
+}
+
+// formatScalar formats a value of a non-message field.
+func formatScalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(v.Bool())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return strconv.FormatInt(int64(v.Enum()), 10)
+	case protoreflect.StringKind:
+		return quote(v.String(), false)
+	case protoreflect.BytesKind:
+		return quote(string(v.Bytes()), true)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		f := v.Float()
+		switch {
+		case math.IsInf(f, +1):
+			return "inf"
+		case math.IsInf(f, -1):
+			return "-inf"
+		case math.IsNaN(f):
+			return "nan"
+		case fd.Kind() == protoreflect.FloatKind:
+			return strconv.FormatFloat(f, 'g', -1, 32)
+		default:
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(v.Int(), 10)
+	default:
+		return strconv.FormatUint(v.Uint(), 10)
+	}
+}
+
+// quote returns s as a quoted string literal with C-style escapes.
+// Printable non-ASCII characters are escaped only if bytes is set.
+func quote(s string, bytes bool) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\'':
+			b.WriteString(`\'`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r < utf8.RuneSelf && unicode.IsPrint(r),
+			!bytes && r != utf8.RuneError && unicode.IsPrint(r):
+			b.WriteString(s[i : i+n])
+		default:
+			for _, c := range []byte(s[i : i+n]) {
+				fmt.Fprintf(&b, `\%03o`, c)
+			}
+		}
+		i += n
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,246 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoprint_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/compiler/protoparse"
+	"google.golang.org/protobuf/compiler/protoprint"
+	"google.golang.org/protobuf/internal/flags"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/comments"
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/extensions/base"
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/extensions/ext"
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/fieldnames"
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/import_public"
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/proto2"
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/proto3"
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/protoeditions"
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go/testdata/retention"
+	_ "google.golang.org/protobuf/internal/testprotos/editionsfuzztest"
+	_ "google.golang.org/protobuf/internal/testprotos/enums"
+	_ "google.golang.org/protobuf/internal/testprotos/messageset/messagesetpb"
+	_ "google.golang.org/protobuf/internal/testprotos/required"
+	_ "google.golang.org/protobuf/internal/testprotos/test"
+	_ "google.golang.org/protobuf/internal/testprotos/test/weak1"
+	_ "google.golang.org/protobuf/internal/testprotos/test/weak2"
+	_ "google.golang.org/protobuf/internal/testprotos/test3"
+	_ "google.golang.org/protobuf/internal/testprotos/testeditions"
+	_ "google.golang.org/protobuf/internal/testprotos/textpb2"
+	_ "google.golang.org/protobuf/internal/testprotos/textpb3"
+	_ "google.golang.org/protobuf/internal/testprotos/textpbeditions"
+)
+
+// TestRoundTrip checks that compiling the formatted source of each registered
+// test file produces the same descriptor as the original.
+func TestRoundTrip(t *testing.T) {
+	var fds []protoreflect.FileDescriptor
+	protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		if path := fd.Path(); strings.HasPrefix(path, "cmd/protoc-gen-go/testdata/") || strings.HasPrefix(path, "internal/testprotos/") {
+			fds = append(fds, fd)
+		}
+		return true
+	})
+	sort.Slice(fds, func(i, j int) bool { return fds[i].Path() < fds[j].Path() })
+	fds = append(fds, descriptorpb.File_google_protobuf_descriptor_proto)
+
+	for _, fd := range fds {
+		t.Run(fd.Path(), func(t *testing.T) {
+			if !flags.ProtoLegacy && usesLegacyFeatures(fd.Messages()) {
+				t.Skip("requires the protolegacy build tag")
+			}
+			src := protoprint.Format(fd)
+			fsys := fstest.MapFS{fd.Path(): {Data: []byte(src)}}
+			fds, err := protoparse.Parser{FS: fsys}.Parse(fd.Path())
+			if err != nil {
+				t.Fatalf("Parse() error: %v\nsource:\n%s", err, src)
+			}
+			got := fds[len(fds)-1]
+			got.SourceCodeInfo = nil
+			want := protodesc.ToFileDescriptorProto(fd)
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):\n%s\nsource:\n%s", diff, src)
+			}
+		})
+	}
+}
+
+// usesLegacyFeatures reports whether any of mds or their nested messages
+// is a message set or has a weak field.
+func usesLegacyFeatures(mds protoreflect.MessageDescriptors) bool {
+	for i := 0; i < mds.Len(); i++ {
+		md := mds.Get(i)
+		if md.Options().(*descriptorpb.MessageOptions).GetMessageSetWireFormat() {
+			return true
+		}
+		for j := 0; j < md.Fields().Len(); j++ {
+			if md.Fields().Get(j).IsWeak() {
+				return true
+			}
+		}
+		if usesLegacyFeatures(md.Messages()) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestFormat checks that formatting a canonically formatted file
+// reproduces its source.
+func TestFormat(t *testing.T) {
+	for _, test := range []struct {
+		desc string
+		src  string
+	}{{
+		desc: "proto2",
+		src: `// Detached comment.
+
+// Leading comment for syntax.
+syntax = "proto2";
+
+package test; // Trailing comment for package.
+
+import "google/protobuf/descriptor.proto";
+
+option java_package = "com.example.test";
+option (test.file_opt) = {
+  name: "file"
+  values: 1
+  values: 2
+  nested {
+    name: "nested"
+  }
+};
+
+// Leading comment for Message.
+message Message { // Trailing comment for Message.
+  option deprecated = true;
+
+  message Nested {
+  }
+
+  message Options {
+    optional string name = 1;
+    repeated int32 values = 2;
+    optional .test.Message.Options nested = 3;
+  }
+
+  enum Enum {
+    // Leading comment for ZERO.
+    ZERO = 0;
+    ONE = 1 [deprecated = true];
+    reserved 5 to 10, 20, 100 to max;
+    reserved "TWO";
+  }
+
+  required int32 a = 1 [default = -1, json_name = "A"];
+  optional string b = 2 [default = "\"\n\001é"]; // Trailing comment for b.
+  repeated .test.Message.Nested c = 3;
+  map<string, .test.Message.Enum> d = 4;
+
+  optional group G = 5 {
+    optional double x = 1 [default = -inf];
+  }
+
+  oneof o {
+    option (test.oneof_opt) = "o";
+
+    float e = 6 [default = 1.5];
+    bytes f = 7 [default = "\000\377"];
+  }
+
+  extensions 100 to 199;
+  extensions 1000 to max [verification = UNVERIFIED];
+
+  extend .test.Message {
+    optional int32 ext = 100;
+  }
+
+  reserved 8, 10 to 12;
+  reserved "h", "i";
+}
+
+service Service {
+  rpc Unary(.test.Message) returns (.test.Message);
+  rpc Stream(stream .test.Message) returns (stream .test.Message);
+
+  rpc WithOptions(.test.Message) returns (.test.Message) {
+    option idempotency_level = NO_SIDE_EFFECTS;
+  }
+}
+
+extend .google.protobuf.FileOptions {
+  optional .test.Message.Options file_opt = 50000;
+}
+
+extend .google.protobuf.OneofOptions {
+  optional string oneof_opt = 50000;
+}
+`,
+	}, {
+		desc: "proto3",
+		src: `syntax = "proto3";
+
+package test;
+
+message Message {
+  int32 a = 1;
+  optional string b = 2;
+  repeated int64 c = 3 [packed = false];
+
+  oneof o {
+    string d = 4;
+  }
+}
+`,
+	}, {
+		desc: "editions",
+		src: `edition = "2023";
+
+package test;
+
+option features.field_presence = IMPLICIT;
+
+enum Enum {
+  option features.enum_type = CLOSED;
+
+  ONE = 1;
+}
+
+message Message {
+  int32 a = 1 [features.field_presence = LEGACY_REQUIRED];
+  .test.Message b = 2 [features.message_encoding = DELIMITED];
+  .test.Enum c = 3 [features.field_presence = EXPLICIT];
+  reserved foo, bar;
+}
+`,
+	}} {
+		t.Run(test.desc, func(t *testing.T) {
+			src := test.src
+			files, err := protoparse.Parser{FS: fstest.MapFS{"test.proto": {Data: []byte(src)}}}.ParseFiles("test.proto")
+			if err != nil {
+				t.Fatalf("ParseFiles() error: %v", err)
+			}
+			fd, err := files.FindFileByPath("test.proto")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := protoprint.Format(fd)
+			if diff := cmp.Diff(src, got); diff != "" {
+				t.Errorf("Format() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
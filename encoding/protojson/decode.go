@@ -7,6 +7,7 @@ package protojson
 import (
 	"encoding/base64"
 	"fmt"
+	"io"
 	"math"
 	"strconv"
 	"strings"
@@ -17,6 +18,7 @@ import (
 	"google.golang.org/protobuf/internal/errors"
 	"google.golang.org/protobuf/internal/flags"
 	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/internal/pathfmt"
 	"google.golang.org/protobuf/internal/pragma"
 	"google.golang.org/protobuf/internal/set"
 	"google.golang.org/protobuf/proto"
@@ -59,14 +61,29 @@ type UnmarshalOptions struct {
 // It will clear the message first before setting the fields.
 // If it returns an error, the given message may be partially set.
 // The provided message must be mutable (e.g., a non-nil pointer to a message).
+// Errors in the input are reported as a [*DecodeError].
 func (o UnmarshalOptions) Unmarshal(b []byte, m proto.Message) error {
-	return o.unmarshal(b, m)
+	return o.unmarshal(json.NewDecoder(b), m, true)
+}
+
+// UnmarshalFrom reads a JSON document from r and populates the given
+// [proto.Message] using options in the UnmarshalOptions object.
+// The input is read and decoded incrementally, such that the entire document
+// is never held in memory at once. The input must contain exactly one JSON
+// object, optionally surrounded by whitespace, up to the end of r.
+// It will clear the message first before setting the fields.
+// If it returns an error, the given message may be partially set.
+// The provided message must be mutable (e.g., a non-nil pointer to a message).
+func (o UnmarshalOptions) UnmarshalFrom(r io.Reader, m proto.Message) error {
+	return o.unmarshal(json.NewStreamDecoder(r), m, true)
 }
 
 // unmarshal is a centralized function that all unmarshal operations go through.
 // For profiling purposes, avoid changing the name of this function or
 // introducing other code paths for unmarshal that do not go through this.
-func (o UnmarshalOptions) unmarshal(b []byte, m proto.Message) error {
+//
+// If requireEOF is set, the input must end after the JSON object.
+func (o UnmarshalOptions) unmarshal(jdec *json.Decoder, m proto.Message, requireEOF bool) error {
 	proto.Reset(m)
 
 	if o.Resolver == nil {
@@ -76,18 +93,21 @@ func (o UnmarshalOptions) unmarshal(b []byte, m proto.Message) error {
 		o.RecursionLimit = protowire.DefaultRecursionLimit
 	}
 
-	dec := decoder{json.NewDecoder(b), o}
+	dec := decoder{jdec, o, new(pathfmt.Path)}
+	dec.path.Root(m.ProtoReflect().Descriptor())
 	if err := dec.unmarshalMessage(m.ProtoReflect(), false); err != nil {
-		return err
+		return dec.wrapError(err)
 	}
 
 	// Check for EOF.
-	tok, err := dec.Read()
-	if err != nil {
-		return err
-	}
-	if tok.Kind() != json.EOF {
-		return dec.unexpectedTokenError(tok)
+	if requireEOF {
+		tok, err := dec.Read()
+		if err != nil {
+			return dec.wrapError(err)
+		}
+		if tok.Kind() != json.EOF {
+			return dec.unexpectedTokenError(tok)
+		}
 	}
 
 	if o.AllowPartial {
@@ -96,16 +116,92 @@ func (o UnmarshalOptions) unmarshal(b []byte, m proto.Message) error {
 	return proto.CheckInitialized(m)
 }
 
+// DecodeError is the error returned by Unmarshal for input that is malformed
+// or does not match the message being unmarshaled.
+// It reports the position of the error in the input and the path to the
+// value that was being decoded.
+type DecodeError struct {
+	// Offset is the byte offset of the error in the input, starting at 0.
+	Offset int
+	// Line and Column are the line and column of the error in the input,
+	// starting at 1. The column is counted in characters.
+	Line, Column int
+
+	// Path is the path to the value being decoded in the syntax of
+	// protopath.Path, such as "(pkg.Message).field[2].nested".
+	// It can be converted to a protopath.Path with protopath.Parse.
+	// For an unknown field, it is the path to the message containing it.
+	Path string
+
+	// Category is the category of the error.
+	Category ErrorCategory
+
+	err error
+}
+
+func (e *DecodeError) Error() string { return e.err.Error() }
+
+// Unwrap returns the underlying error, which matches [proto.Error].
+func (e *DecodeError) Unwrap() error { return e.err }
+
+// ErrorCategory is the category of a [DecodeError].
+type ErrorCategory int
+
+const (
+	_ ErrorCategory = iota
+	// CategorySyntax is an error in the JSON syntax,
+	// including input that ends unexpectedly.
+	CategorySyntax
+	// CategoryUnknownField is a field name that does not identify a field
+	// of the message.
+	CategoryUnknownField
+	// CategoryDuplicateField is a field, oneof or map key that is set
+	// more than once.
+	CategoryDuplicateField
+	// CategoryInvalidValue is a value that is not valid for the type
+	// of the field.
+	CategoryInvalidValue
+	// CategoryUnresolvable is an extension or google.protobuf.Any type
+	// that cannot be resolved.
+	CategoryUnresolvable
+	// CategoryRecursionLimit is a value that is nested more deeply than
+	// UnmarshalOptions.RecursionLimit allows.
+	CategoryRecursionLimit
+)
+
+func (c ErrorCategory) String() string {
+	switch c {
+	case CategorySyntax:
+		return "syntax"
+	case CategoryUnknownField:
+		return "unknown field"
+	case CategoryDuplicateField:
+		return "duplicate field"
+	case CategoryInvalidValue:
+		return "invalid value"
+	case CategoryUnresolvable:
+		return "unresolvable"
+	case CategoryRecursionLimit:
+		return "recursion limit"
+	default:
+		return fmt.Sprintf("<unknown:%d>", int(c))
+	}
+}
+
 type decoder struct {
 	*json.Decoder
 	opts UnmarshalOptions
+	// path is the path to the value being decoded. It is only truncated
+	// after a value is successfully decoded, such that it points to the
+	// value that failed to decode when an error is returned.
+	path *pathfmt.Path
 }
 
 // newError returns an error object with position info.
-func (d decoder) newError(pos int, f string, x ...any) error {
+func (d decoder) newError(pos int, c ErrorCategory, f string, x ...any) error {
 	line, column := d.Position(pos)
 	head := fmt.Sprintf("(line %d:%d): ", line, column)
-	return errors.New(head+f, x...)
+	return d.newDecodeError(pos, c, errors.New(head+f, x...))
 }
 
 // unexpectedTokenError returns a syntax error for the given unexpected token.
@@ -117,14 +213,43 @@ func (d decoder) unexpectedTokenError(tok json.Token) error {
 func (d decoder) syntaxError(pos int, f string, x ...any) error {
 	line, column := d.Position(pos)
 	head := fmt.Sprintf("syntax error (line %d:%d): ", line, column)
-	return errors.New(head+f, x...)
+	return d.newDecodeError(pos, CategorySyntax, errors.New(head+f, x...))
+}
+
+func (d decoder) newDecodeError(pos int, c ErrorCategory, err error) *DecodeError {
+	line, column := d.Position(pos)
+	return &DecodeError{
+		Offset:   pos,
+		Line:     line,
+		Column:   column,
+		Path:     d.path.String(),
+		Category: c,
+		err:      err,
+	}
+}
+
+// wrapError converts errors from the underlying JSON decoder
+// into a *DecodeError.
+func (d decoder) wrapError(err error) error {
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		return d.newDecodeError(e.Pos, CategorySyntax, e.Unwrap())
+	}
+	if err == json.ErrUnexpectedEOF {
+		return d.newDecodeError(d.Offset(), CategorySyntax, err)
+	}
+	return err
 }
 
 // unmarshalMessage unmarshals a message into the given protoreflect.Message.
 func (d decoder) unmarshalMessage(m protoreflect.Message, skipTypeURL bool) error {
 	d.opts.RecursionLimit--
 	if d.opts.RecursionLimit < 0 {
-		return errors.New("exceeded max recursion depth")
+		tok, err := d.Peek()
+		if err != nil {
+			return err
+		}
+		return d.newError(tok.Pos(), CategoryRecursionLimit, "exceeded max recursion depth")
 	}
 	if unmarshal := wellKnownTypeUnmarshaler(m.Descriptor().FullName()); unmarshal != nil {
 		return unmarshal(d, m)
@@ -177,12 +302,12 @@ func (d decoder) unmarshalMessage(m protoreflect.Message, skipTypeURL bool) erro
 			extName := protoreflect.FullName(name[1 : len(name)-1])
 			extType, err := d.opts.Resolver.FindExtensionByName(extName)
 			if err != nil && err != protoregistry.NotFound {
-				return d.newError(tok.Pos(), "unable to resolve %s: %v", tok.RawString(), err)
+				return d.newError(tok.Pos(), CategoryUnresolvable, "unable to resolve %s: %v", tok.RawString(), err)
 			}
 			if extType != nil {
 				fd = extType.TypeDescriptor()
 				if !messageDesc.ExtensionRanges().Has(fd.Number()) || fd.ContainingMessage().FullName() != messageDesc.FullName() {
-					return d.newError(tok.Pos(), "message %v cannot be extended by %v", messageDesc.FullName(), fd.FullName())
+					return d.newError(tok.Pos(), CategoryUnknownField, "message %v cannot be extended by %v", messageDesc.FullName(), fd.FullName())
 				}
 			}
 		} else {
@@ -206,13 +331,16 @@ func (d decoder) unmarshalMessage(m protoreflect.Message, skipTypeURL bool) erro
 				}
 				continue
 			}
-			return d.newError(tok.Pos(), "unknown field %v", tok.RawString())
+			return d.newError(tok.Pos(), CategoryUnknownField, "unknown field %v", tok.RawString())
 		}
 
+		n := d.path.Len()
+		d.path.Field(fd)
+
 		// Do not allow duplicate fields.
 		num := uint64(fd.Number())
 		if seenNums.Has(num) {
-			return d.newError(tok.Pos(), "duplicate field %v", tok.RawString())
+			return d.newError(tok.Pos(), CategoryDuplicateField, "duplicate field %v", tok.RawString())
 		}
 		seenNums.Set(num)
 
@@ -220,6 +348,7 @@ func (d decoder) unmarshalMessage(m protoreflect.Message, skipTypeURL bool) erro
 		// google.protobuf.Value or google.protobuf.NullValue.
 		if tok, _ := d.Peek(); tok.Kind() == json.Null && !isKnownValue(fd) && !isNullValue(fd) {
 			d.Read()
+			d.path.Truncate(n)
 			continue
 		}
 
@@ -239,7 +368,7 @@ func (d decoder) unmarshalMessage(m protoreflect.Message, skipTypeURL bool) erro
 			if od := fd.ContainingOneof(); od != nil {
 				idx := uint64(od.Index())
 				if seenOneofs.Has(idx) {
-					return d.newError(tok.Pos(), "error parsing %s, oneof %v is already set", tok.RawString(), od.FullName())
+					return d.newError(tok.Pos(), CategoryDuplicateField, "error parsing %s, oneof %v is already set", tok.RawString(), od.FullName())
 				}
 				seenOneofs.Set(idx)
 			}
@@ -249,6 +378,7 @@ func (d decoder) unmarshalMessage(m protoreflect.Message, skipTypeURL bool) erro
 				return err
 			}
 		}
+		d.path.Truncate(n)
 	}
 }
 
@@ -351,7 +481,7 @@ func (d decoder) unmarshalScalar(fd protoreflect.FieldDescriptor) (protoreflect.
 		panic(fmt.Sprintf("unmarshalScalar: invalid scalar kind %v", kind))
 	}
 
-	return protoreflect.Value{}, d.newError(tok.Pos(), "invalid value for %v field %v: %v", kind, fd.JSONName(), tok.RawString())
+	return protoreflect.Value{}, d.newError(tok.Pos(), CategoryInvalidValue, "invalid value for %v field %v: %v", kind, fd.JSONName(), tok.RawString())
 }
 
 func unmarshalInt(tok json.Token, bitSize int) (protoreflect.Value, bool) {
@@ -524,47 +654,44 @@ func (d decoder) unmarshalList(list protoreflect.List, fd protoreflect.FieldDesc
 		return d.unexpectedTokenError(tok)
 	}
 
-	switch fd.Kind() {
-	case protoreflect.MessageKind, protoreflect.GroupKind:
-		for {
-			tok, err := d.Peek()
-			if err != nil {
-				return err
-			}
-
-			if tok.Kind() == json.ArrayClose {
-				d.Read()
-				return nil
-			}
-
-			val := list.NewElement()
-			if err := d.unmarshalMessage(val.Message(), false); err != nil {
-				return err
-			}
-			list.Append(val)
+	for {
+		tok, err := d.Peek()
+		if err != nil {
+			return err
 		}
-	default:
-		for {
-			tok, err := d.Peek()
-			if err != nil {
-				return err
-			}
 
-			if tok.Kind() == json.ArrayClose {
-				d.Read()
-				return nil
-			}
+		if tok.Kind() == json.ArrayClose {
+			d.Read()
+			return nil
+		}
 
-			val, err := d.unmarshalScalar(fd)
-			if err != nil {
-				return err
-			}
-			if val.IsValid() {
-				list.Append(val)
-			}
+		if err := d.unmarshalListElement(list, fd); err != nil {
+			return err
 		}
 	}
+}
 
+// unmarshalListElement unmarshals a single list element and appends it to
+// the given protoreflect.List.
+func (d decoder) unmarshalListElement(list protoreflect.List, fd protoreflect.FieldDescriptor) error {
+	n := d.path.Len()
+	d.path.ListIndex(list.Len())
+	var val protoreflect.Value
+	var err error
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		val = list.NewElement()
+		err = d.unmarshalMessage(val.Message(), false)
+	default:
+		val, err = d.unmarshalScalar(fd)
+	}
+	if err != nil {
+		return err
+	}
+	if val.IsValid() {
+		list.Append(val)
+	}
+	d.path.Truncate(n)
 	return nil
 }
 
@@ -620,10 +747,12 @@ Loop:
 
 		// Check for duplicate field name.
 		if mmap.Has(pkey) {
-			return d.newError(tok.Pos(), "duplicate map key %v", tok.RawString())
+			return d.newError(tok.Pos(), CategoryDuplicateField, "duplicate map key %v", tok.RawString())
 		}
 
 		// Read and unmarshal field value.
+		n := d.path.Len()
+		d.path.MapIndex(pkey)
 		pval, err := unmarshalMapValue()
 		if err != nil {
 			return err
@@ -631,6 +760,7 @@ Loop:
 		if pval.IsValid() {
 			mmap.Set(pkey, pval)
 		}
+		d.path.Truncate(n)
 	}
 
 	return nil
@@ -681,5 +811,5 @@ func (d decoder) unmarshalMapKey(tok json.Token, fd protoreflect.FieldDescriptor
 		panic(fmt.Sprintf("invalid kind for map key: %v", kind))
 	}
 
-	return protoreflect.MapKey{}, d.newError(tok.Pos(), "invalid value for %v key: %s", kind, tok.RawString())
+	return protoreflect.MapKey{}, d.newError(tok.Pos(), CategoryInvalidValue, "invalid value for %v key: %s", kind, tok.RawString())
 }
@@ -5,14 +5,19 @@
 package protojson_test
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"math"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/internal/errors"
+	protoerrors "google.golang.org/protobuf/internal/errors"
 	"google.golang.org/protobuf/internal/flags"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
 	"google.golang.org/protobuf/reflect/protoregistry"
 
 	testpb "google.golang.org/protobuf/internal/testprotos/test"
@@ -1164,7 +1169,7 @@ func TestUnmarshal(t *testing.T) {
 		desc:         "required fields not set",
 		inputMessage: &pb2.Requireds{},
 		inputText:    `{}`,
-		wantErr:      errors.RequiredNotSet("pb2.Requireds.req_bool").Error(),
+		wantErr:      protoerrors.RequiredNotSet("pb2.Requireds.req_bool").Error(),
 	}, {
 		desc:         "required field set",
 		inputMessage: &pb2.PartialRequired{},
@@ -1189,7 +1194,7 @@ func TestUnmarshal(t *testing.T) {
 			ReqString:   proto.String("hello"),
 			ReqEnum:     pb2.Enum_ONE.Enum(),
 		},
-		wantErr: errors.RequiredNotSet("pb2.Requireds.req_double").Error(),
+		wantErr: protoerrors.RequiredNotSet("pb2.Requireds.req_double").Error(),
 	}, {
 		desc:         "required fields partially set with AllowPartial",
 		umo:          protojson.UnmarshalOptions{AllowPartial: true},
@@ -1234,7 +1239,7 @@ func TestUnmarshal(t *testing.T) {
 		wantMessage: &pb2.IndirectRequired{
 			OptNested: &pb2.NestedWithRequired{},
 		},
-		wantErr: errors.RequiredNotSet("pb2.NestedWithRequired.req_string").Error(),
+		wantErr: protoerrors.RequiredNotSet("pb2.NestedWithRequired.req_string").Error(),
 	}, {
 		desc:         "indirect required field with AllowPartial",
 		umo:          protojson.UnmarshalOptions{AllowPartial: true},
@@ -1262,7 +1267,7 @@ func TestUnmarshal(t *testing.T) {
 				{},
 			},
 		},
-		wantErr: errors.RequiredNotSet("pb2.NestedWithRequired.req_string").Error(),
+		wantErr: protoerrors.RequiredNotSet("pb2.NestedWithRequired.req_string").Error(),
 	}, {
 		desc:         "indirect required field in repeated with AllowPartial",
 		umo:          protojson.UnmarshalOptions{AllowPartial: true},
@@ -1300,7 +1305,7 @@ func TestUnmarshal(t *testing.T) {
 				},
 			},
 		},
-		wantErr: errors.RequiredNotSet("pb2.NestedWithRequired.req_string").Error(),
+		wantErr: protoerrors.RequiredNotSet("pb2.NestedWithRequired.req_string").Error(),
 	}, {
 		desc:         "indirect required field in map with AllowPartial",
 		umo:          protojson.UnmarshalOptions{AllowPartial: true},
@@ -1332,7 +1337,7 @@ func TestUnmarshal(t *testing.T) {
 				OneofNested: &pb2.NestedWithRequired{},
 			},
 		},
-		wantErr: errors.RequiredNotSet("pb2.NestedWithRequired.req_string").Error(),
+		wantErr: protoerrors.RequiredNotSet("pb2.NestedWithRequired.req_string").Error(),
 	}, {
 		desc:         "indirect required field in oneof with AllowPartial",
 		umo:          protojson.UnmarshalOptions{AllowPartial: true},
@@ -2795,7 +2800,14 @@ func TestUnmarshal(t *testing.T) {
 			continue
 		}
 		t.Run(tt.desc, func(t *testing.T) {
+			streamMessage := proto.Clone(tt.inputMessage)
+			streamErr := tt.umo.UnmarshalFrom(iotest.OneByteReader(strings.NewReader(tt.inputText)), streamMessage)
 			err := tt.umo.Unmarshal([]byte(tt.inputText), tt.inputMessage)
+			if fmt.Sprint(streamErr) != fmt.Sprint(err) {
+				t.Errorf("UnmarshalFrom() error got %v, want %v", streamErr, err)
+			} else if !proto.Equal(streamMessage, tt.inputMessage) {
+				t.Errorf("UnmarshalFrom()\n<got>\n%v\n<want>\n%v\n", streamMessage, tt.inputMessage)
+			}
 			if err != nil {
 				if tt.wantErr == "" {
 					t.Errorf("Unmarshal() got unexpected error: %v", err)
@@ -2813,3 +2825,168 @@ func TestUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalFromLarge(t *testing.T) {
+	want := &testpb.TestAllTypes{}
+	for i := 0; i < 10000; i++ {
+		want.RepeatedString = append(want.RepeatedString, fmt.Sprintf("value %d", i))
+		want.RepeatedNestedMessage = append(want.RepeatedNestedMessage, &testpb.TestAllTypes_NestedMessage{A: proto.Int32(int32(i))})
+	}
+	b, err := protojson.MarshalOptions{Multiline: true}.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %v", err)
+	}
+
+	got := &testpb.TestAllTypes{}
+	if err := (protojson.UnmarshalOptions{}).UnmarshalFrom(iotest.HalfReader(bytes.NewReader(b)), got); err != nil {
+		t.Fatalf("UnmarshalFrom() returned error: %v", err)
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("UnmarshalFrom() did not round-trip a large message")
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	tests := []struct {
+		desc      string
+		umo       protojson.UnmarshalOptions
+		m         proto.Message
+		inputText string
+		want      protojson.DecodeError // Error is not compared
+	}{{
+		desc:      "invalid value",
+		m:         &pb2.Nests{},
+		inputText: "{\n  \"optNested\": {\"optString\": 1}\n}",
+		want: protojson.DecodeError{
+			Offset: 31, Line: 2, Column: 30,
+			Path:     "(pb2.Nests).opt_nested.opt_string",
+			Category: protojson.CategoryInvalidValue,
+		},
+	}, {
+		desc:      "unknown field in list element",
+		m:         &pb2.Nests{},
+		inputText: `{"rptNested": [{}, {"unknown": 1}]}`,
+		want: protojson.DecodeError{
+			Offset: 20, Line: 1, Column: 21,
+			Path:     "(pb2.Nests).rpt_nested[1]",
+			Category: protojson.CategoryUnknownField,
+		},
+	}, {
+		desc:      "invalid map value",
+		m:         &pb3.Maps{},
+		inputText: `{"strToNested": {"é": {"sString": true}}}`,
+		want: protojson.DecodeError{
+			Offset: 35, Line: 1, Column: 35,
+			Path:     `(pb3.Maps).str_to_nested["é"].s_string`,
+			Category: protojson.CategoryInvalidValue,
+		},
+	}, {
+		desc:      "duplicate field",
+		m:         &pb2.Nests{},
+		inputText: `{"optNested": {}, "opt_nested": {}}`,
+		want: protojson.DecodeError{
+			Offset: 18, Line: 1, Column: 19,
+			Path:     "(pb2.Nests).opt_nested",
+			Category: protojson.CategoryDuplicateField,
+		},
+	}, {
+		desc:      "Any",
+		m:         &pb2.KnownTypes{},
+		inputText: `{"optAny": {"@type": "type.googleapis.com/pb2.Nested", "optString": 1}}`,
+		want: protojson.DecodeError{
+			Offset: 68, Line: 1, Column: 69,
+			Path:     "(pb2.KnownTypes).opt_any.(pb2.Nested).opt_string",
+			Category: protojson.CategoryInvalidValue,
+		},
+	}, {
+		desc:      "unresolvable Any",
+		m:         &pb2.KnownTypes{},
+		inputText: `{"optAny": {"@type": "type.googleapis.com/pb2.Unknown"}}`,
+		want: protojson.DecodeError{
+			Offset: 21, Line: 1, Column: 22,
+			Path:     "(pb2.KnownTypes).opt_any",
+			Category: protojson.CategoryUnresolvable,
+		},
+	}, {
+		desc:      "lexical error",
+		m:         &pb2.Nests{},
+		inputText: "{\"optNested\": {\n  \"optString\": tru\n}}",
+		want: protojson.DecodeError{
+			Offset: 31, Line: 2, Column: 16,
+			Path:     "(pb2.Nests).opt_nested.opt_string",
+			Category: protojson.CategorySyntax,
+		},
+	}, {
+		desc:      "unexpected EOF",
+		m:         &pb2.Nests{},
+		inputText: `{"optNested": {`,
+		want: protojson.DecodeError{
+			Offset: 15, Line: 1, Column: 16,
+			Path:     "(pb2.Nests).opt_nested",
+			Category: protojson.CategorySyntax,
+		},
+	}, {
+		desc:      "recursion limit",
+		umo:       protojson.UnmarshalOptions{RecursionLimit: 2},
+		m:         &pb2.Nests{},
+		inputText: `{"optNested": {"optNested": {}}}`,
+		want: protojson.DecodeError{
+			Offset: 28, Line: 1, Column: 29,
+			Path:     "(pb2.Nests).opt_nested.opt_nested",
+			Category: protojson.CategoryRecursionLimit,
+		},
+	}, {
+		desc:      "recursion limit in discarded field",
+		umo:       protojson.UnmarshalOptions{RecursionLimit: 2, DiscardUnknown: true},
+		m:         &pb2.Nests{},
+		inputText: `{"unknown": [[[1]]]}`,
+		want: protojson.DecodeError{
+			Offset: 13, Line: 1, Column: 14,
+			Path:     "(pb2.Nests)",
+			Category: protojson.CategoryRecursionLimit,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.umo.Unmarshal([]byte(tt.inputText), tt.m)
+			var got *protojson.DecodeError
+			if !errors.As(err, &got) {
+				t.Fatalf("Unmarshal() error = %v, want *DecodeError", err)
+			}
+			if !errors.Is(err, proto.Error) {
+				t.Errorf("Unmarshal() error = %v, want proto.Error", err)
+			}
+			if got.Offset != tt.want.Offset || got.Line != tt.want.Line || got.Column != tt.want.Column {
+				t.Errorf("position = %d (%d:%d), want %d (%d:%d)", got.Offset, got.Line, got.Column, tt.want.Offset, tt.want.Line, tt.want.Column)
+			}
+			if got.Path != tt.want.Path {
+				t.Errorf("Path = %q, want %q", got.Path, tt.want.Path)
+			}
+			if got.Category != tt.want.Category {
+				t.Errorf("Category = %v, want %v", got.Category, tt.want.Category)
+			}
+			if _, err := protopath.Parse(tt.m.ProtoReflect().Descriptor(), got.Path); err != nil {
+				t.Errorf("protopath.Parse(%q) error: %v", got.Path, err)
+			}
+		})
+	}
+}
+
+func TestDecoderDecodeError(t *testing.T) {
+	dec := protojson.NewDecoder(strings.NewReader("{}\n{\"unknown\": 1}"))
+	if err := dec.Decode(&pb2.Nests{}); err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	err := dec.Decode(&pb2.Nests{})
+	got, ok := err.(*protojson.DecodeError)
+	if !ok {
+		t.Fatalf("Decode() error = %v, want *DecodeError", err)
+	}
+	if got.Line != 2 || got.Column != 2 || got.Category != protojson.CategoryUnknownField {
+		t.Errorf("Decode() error = %d:%d %v, want 2:2 %v", got.Line, got.Column, got.Category, protojson.CategoryUnknownField)
+	}
+	if want := "record 1: (line 2:2): unknown field \"unknown\""; !strings.Contains(err.Error(), want) {
+		t.Errorf("Decode() error = %q, want %q", err, want)
+	}
+}
@@ -7,15 +7,18 @@ package protojson
 import (
 	"encoding/base64"
 	"fmt"
+	"io"
 
 	"google.golang.org/protobuf/internal/encoding/json"
 	"google.golang.org/protobuf/internal/encoding/messageset"
 	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/fieldmask"
 	"google.golang.org/protobuf/internal/filedesc"
 	"google.golang.org/protobuf/internal/flags"
 	"google.golang.org/protobuf/internal/genid"
 	"google.golang.org/protobuf/internal/order"
 	"google.golang.org/protobuf/internal/pragma"
+	"google.golang.org/protobuf/internal/redact"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/reflect/protoregistry"
@@ -108,9 +111,29 @@ type MarshalOptions struct {
 		protoregistry.ExtensionTypeResolver
 		protoregistry.MessageTypeResolver
 	}
+
+	// FieldMask restricts the output to the fields selected by its paths,
+	// which is typically a *fieldmaskpb.FieldMask. Each path is a sequence of
+	// proto field names separated by dots, where a path through a repeated
+	// message field applies to every element, and the name of a map field may
+	// be followed by a map key to select map entries, where a key containing
+	// a dot is quoted in backticks. The wildcard "*" selects every field or
+	// map entry. Extension fields are named by their full name in parentheses.
+	// Well-known types with a special JSON representation are always
+	// marshaled in their entirety once selected.
+	// If FieldMask is nil or has no paths, all fields are marshaled.
+	FieldMask interface{ GetPaths() []string }
+
+	// Redact replaces the values of fields marked with the debug_redact option,
+	// including fields whose options are set to enum values marked with the
+	// debug_redact option, with a placeholder string. The unmarshaler is
+	// unable to parse the output of redacted fields.
+	// Format always redacts fields.
+	Redact bool
 }
 
 // Format formats the message as a string.
+// The values of fields marked with the debug_redact option are redacted.
 // This method is only intended for human consumption and ignores errors.
 // Do not depend on the output being stable. Its output will change across
 // different builds of your program, even when using the same version of the
@@ -120,6 +143,7 @@ func (o MarshalOptions) Format(m proto.Message) string {
 		return "<nil>" // invalid syntax, but okay since this is for debugging
 	}
 	o.AllowPartial = true
+	o.Redact = true
 	b, _ := o.Marshal(m)
 	return string(b)
 }
@@ -129,19 +153,34 @@ func (o MarshalOptions) Format(m proto.Message) string {
 // different builds of your program, even when using the same version of the
 // protobuf module.
 func (o MarshalOptions) Marshal(m proto.Message) ([]byte, error) {
-	return o.marshal(nil, m)
+	return o.marshal(nil, nil, m)
 }
 
 // MarshalAppend appends the JSON format encoding of m to b,
 // returning the result.
 func (o MarshalOptions) MarshalAppend(b []byte, m proto.Message) ([]byte, error) {
-	return o.marshal(b, m)
+	return o.marshal(b, nil, m)
+}
+
+// MarshalTo writes the JSON format encoding of m to w.
+// The output is written incrementally as the message is encoded,
+// such that the entire encoding is never held in memory at once.
+// The output is identical to that of Marshal using the same options.
+//
+// Unless AllowPartial is set, missing required fields are reported before
+// anything is written to w. If any other error occurs, a partial encoding
+// may have been written to w.
+func (o MarshalOptions) MarshalTo(w io.Writer, m proto.Message) error {
+	_, err := o.marshal(nil, w, m)
+	return err
 }
 
 // marshal is a centralized function that all marshal operations go through.
 // For profiling purposes, avoid changing the name of this function or
 // introducing other code paths for marshal that do not go through this.
-func (o MarshalOptions) marshal(b []byte, m proto.Message) ([]byte, error) {
+//
+// If w is non-nil, the output is written to w instead of being appended to b.
+func (o MarshalOptions) marshal(b []byte, w io.Writer, m proto.Message) ([]byte, error) {
 	if o.Multiline && o.Indent == "" {
 		o.Indent = defaultIndent
 	}
@@ -149,7 +188,13 @@ func (o MarshalOptions) marshal(b []byte, m proto.Message) ([]byte, error) {
 		o.Resolver = protoregistry.GlobalTypes
 	}
 
-	internalEnc, err := json.NewEncoder(b, o.Indent)
+	var internalEnc *json.Encoder
+	var err error
+	if w != nil {
+		internalEnc, err = json.NewStreamEncoder(w, o.Indent)
+	} else {
+		internalEnc, err = json.NewEncoder(b, o.Indent)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -157,10 +202,31 @@ func (o MarshalOptions) marshal(b []byte, m proto.Message) ([]byte, error) {
 	// Treat nil message interface as an empty message,
 	// in which case the output in an empty JSON object.
 	if m == nil {
+		if w != nil {
+			_, err := io.WriteString(w, "{}")
+			return nil, err
+		}
 		return append(b, '{', '}'), nil
 	}
 
-	enc := encoder{internalEnc, o}
+	var mask *fieldmask.Mask
+	if o.FieldMask != nil {
+		mask = fieldmask.New(o.FieldMask.GetPaths())
+	}
+	enc := encoder{internalEnc, o, mask}
+	if w != nil {
+		// Output written to w cannot be retracted, so check for missing
+		// required fields before writing anything.
+		if !o.AllowPartial {
+			if err := proto.CheckInitialized(m); err != nil {
+				return nil, err
+			}
+		}
+		if err := enc.marshalMessage(m.ProtoReflect(), ""); err != nil {
+			return nil, err
+		}
+		return nil, enc.Flush()
+	}
 	if err := enc.marshalMessage(m.ProtoReflect(), ""); err != nil {
 		return nil, err
 	}
@@ -173,6 +239,9 @@ func (o MarshalOptions) marshal(b []byte, m proto.Message) ([]byte, error) {
 type encoder struct {
 	*json.Encoder
 	opts MarshalOptions
+
+	// mask selects the fields of the message currently being marshaled.
+	mask *fieldmask.Mask
 }
 
 // typeFieldDesc is a synthetic field descriptor used for the "@type" field.
@@ -240,6 +309,7 @@ func (e encoder) marshalMessage(m protoreflect.Message, typeURL string) error {
 	}
 
 	if marshal := wellKnownTypeMarshaler(m.Descriptor().FullName()); marshal != nil {
+		e.mask = nil
 		return marshal(e, m)
 	}
 
@@ -253,6 +323,10 @@ func (e encoder) marshalMessage(m protoreflect.Message, typeURL string) error {
 	case e.opts.EmitDefaultValues:
 		fields = unpopulatedFieldRanger{Message: m, skipNull: true}
 	}
+	mask := e.mask
+	if mask != nil {
+		fields = fieldmask.FieldRanger{FieldRanger: fields, Mask: mask}
+	}
 	if typeURL != "" {
 		fields = typeURLFieldRanger{fields, typeURL}
 	}
@@ -267,6 +341,11 @@ func (e encoder) marshalMessage(m protoreflect.Message, typeURL string) error {
 		if err = e.WriteName(name); err != nil {
 			return false
 		}
+		if e.opts.Redact && redact.IsRedacted(fd) {
+			e.WriteString(redact.Placeholder)
+			return true
+		}
+		e.mask, _ = mask.Field(fd)
 		if err = e.marshalValue(v, fd); err != nil {
 			return false
 		}
@@ -368,11 +447,17 @@ func (e encoder) marshalMap(mmap protoreflect.Map, fd protoreflect.FieldDescript
 	e.StartObject()
 	defer e.EndObject()
 
+	mask := e.mask
 	var err error
 	order.RangeEntries(mmap, order.GenericKeyOrder, func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		sub, ok := mask.MapKey(k)
+		if !ok {
+			return true
+		}
 		if err = e.WriteName(k.String()); err != nil {
 			return false
 		}
+		e.mask = sub
 		if err = e.marshalSingular(v, fd.MapValue()); err != nil {
 			return false
 		}
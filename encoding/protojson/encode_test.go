@@ -8,15 +8,18 @@ import (
 	"bytes"
 	"math"
 	"testing"
+	"testing/fstest"
 
 	"github.com/google/go-cmp/cmp"
 
+	"google.golang.org/protobuf/compiler/protoparse"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/internal/detrand"
 	"google.golang.org/protobuf/internal/flags"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/testing/protopack"
+	"google.golang.org/protobuf/types/dynamicpb"
 
 	pb2 "google.golang.org/protobuf/internal/testprotos/textpb2"
 	pb3 "google.golang.org/protobuf/internal/testprotos/textpb3"
@@ -2523,6 +2526,15 @@ func TestMarshal(t *testing.T) {
 					t.Errorf("Marshal() diff -want +got\n%v\n", diff)
 				}
 			}
+
+			var buf bytes.Buffer
+			err = tt.mo.MarshalTo(&buf, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MarshalTo() returned error %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && buf.String() != tt.want {
+				t.Errorf("MarshalTo() diff -want +got\n%v\n", cmp.Diff(tt.want, buf.String()))
+			}
 		})
 	}
 }
@@ -2567,3 +2579,169 @@ func TestMarshalAppendAllocations(t *testing.T) {
 		t.Errorf("expect amortized allocs/op to be identical")
 	}
 }
+
+func TestMarshalToRequired(t *testing.T) {
+	var buf bytes.Buffer
+	err := protojson.MarshalOptions{}.MarshalTo(&buf, &pb2.Requireds{})
+	if err == nil {
+		t.Fatal("MarshalTo() got nil error, want missing required field error")
+	}
+	if buf.Len() > 0 {
+		t.Errorf("MarshalTo() wrote %q, want no output", buf.String())
+	}
+}
+
+func TestMarshalFieldMask(t *testing.T) {
+	input := &pb2.Nests{
+		OptNested: &pb2.Nested{
+			OptString: proto.String("nested"),
+			OptNested: &pb2.Nested{OptString: proto.String("inner")},
+		},
+		RptNested: []*pb2.Nested{
+			{OptString: proto.String("one"), OptNested: &pb2.Nested{}},
+			{OptString: proto.String("two")},
+		},
+	}
+	maps := &pb2.Maps{
+		Int32ToStr: map[int32]string{1: "one", 2: "two"},
+		StrToNested: map[string]*pb2.Nested{
+			"a": {OptString: proto.String("a"), OptNested: &pb2.Nested{}},
+			"b": {OptString: proto.String("b")},
+		},
+	}
+	ext := &pb2.Extensions{OptString: proto.String("string")}
+	proto.SetExtension(ext, pb2.E_OptExtString, "extension")
+	proto.SetExtension(ext, pb2.E_OptExtBool, true)
+	wantExt := &pb2.Extensions{}
+	proto.SetExtension(wantExt, pb2.E_OptExtString, "extension")
+
+	tests := []struct {
+		desc  string
+		mo    protojson.MarshalOptions
+		paths []string
+		input proto.Message
+		want  proto.Message
+		// wantJSON is the expected output if want is nil.
+		wantJSON string
+	}{{
+		desc:  "nested field",
+		paths: []string{"opt_nested.opt_nested.opt_string"},
+		input: input,
+		want:  &pb2.Nests{OptNested: &pb2.Nested{OptNested: &pb2.Nested{OptString: proto.String("inner")}}},
+	}, {
+		desc:  "repeated field elements",
+		paths: []string{"rpt_nested.opt_string"},
+		input: input,
+		want:  &pb2.Nests{RptNested: []*pb2.Nested{{OptString: proto.String("one")}, {OptString: proto.String("two")}}},
+	}, {
+		desc:     "emit unpopulated",
+		mo:       protojson.MarshalOptions{EmitUnpopulated: true},
+		paths:    []string{"opt_nested.opt_string", "OptGroup"},
+		input:    &pb2.Nests{OptNested: &pb2.Nested{}},
+		wantJSON: `{"optNested":{"optString":null},"optgroup":null}`,
+	}, {
+		desc:  "map entries",
+		paths: []string{"int32_to_str.2", "str_to_nested.a.opt_string"},
+		input: maps,
+		want: &pb2.Maps{
+			Int32ToStr:  map[int32]string{2: "two"},
+			StrToNested: map[string]*pb2.Nested{"a": {OptString: proto.String("a")}},
+		},
+	}, {
+		desc:  "extension",
+		paths: []string{"(pb2.opt_ext_string)"},
+		input: ext,
+		want:  wantExt,
+	}, {
+		desc:  "well-known type",
+		paths: []string{"value.seconds"},
+		input: &anypb.Any{TypeUrl: "type.googleapis.com/google.protobuf.Duration", Value: []byte{0x08, 0x01}},
+		want:  &anypb.Any{TypeUrl: "type.googleapis.com/google.protobuf.Duration", Value: []byte{0x08, 0x01}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mo := tt.mo
+			mo.FieldMask = &fieldmaskpb.FieldMask{Paths: tt.paths}
+			got, err := mo.Marshal(tt.input)
+			if err != nil {
+				t.Fatalf("Marshal() returned error: %v", err)
+			}
+			want := []byte(tt.wantJSON)
+			if tt.want != nil {
+				want, err = tt.mo.Marshal(tt.want)
+				if err != nil {
+					t.Fatalf("Marshal() returned error: %v", err)
+				}
+			}
+			if string(got) != string(want) {
+				t.Errorf("Marshal() with mask %v:\ngot:  %s\nwant: %s", tt.paths, got, want)
+			}
+		})
+	}
+}
+
+const redactSource = `syntax = "proto2";
+package redact.test;
+import "google/protobuf/any.proto";
+
+message Message {
+	optional string name = 1;
+	optional string password = 2 [debug_redact = true];
+	repeated string tokens = 3 [debug_redact = true];
+	map<string, Message> children = 4;
+	optional google.protobuf.Any any = 5;
+	extensions 100 to max;
+}
+extend Message {
+	optional string api_key = 100 [debug_redact = true];
+}
+`
+
+func TestMarshalRedact(t *testing.T) {
+	files, err := protoparse.Parser{
+		FS: fstest.MapFS{"redact.proto": {Data: []byte(redactSource)}},
+	}.ParseFiles("redact.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := dynamicpb.NewTypes(files)
+	mt, err := types.FindMessageByName("redact.test.Message")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := mt.New().Interface()
+	if err := (protojson.UnmarshalOptions{Resolver: types}).Unmarshal([]byte(`{
+		"name": "name",
+		"password": "hunter2",
+		"tokens": ["hunter2", "hunter2"],
+		"children": {"child": {"name": "child", "password": "hunter2"}},
+		"any": {"@type": "type.googleapis.com/redact.test.Message", "name": "any", "password": "hunter2"},
+		"[redact.test.api_key]": "hunter2"
+	}`), m); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"name":"name","password":"[REDACTED]","tokens":"[REDACTED]",` +
+		`"children":{"child":{"name":"child","password":"[REDACTED]"}},` +
+		`"any":{"@type":"type.googleapis.com/redact.test.Message","name":"any","password":"[REDACTED]"},` +
+		`"[redact.test.api_key]":"[REDACTED]"}`
+	got, err := protojson.MarshalOptions{Redact: true, Resolver: types}.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("Marshal() with Redact:\ngot:  %s\nwant: %s", got, want)
+	}
+	if got := (protojson.MarshalOptions{Resolver: types}).Format(m); got != want {
+		t.Errorf("Format():\ngot:  %s\nwant: %s", got, want)
+	}
+
+	got, err = protojson.MarshalOptions{Resolver: types}.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %v", err)
+	}
+	if bytes.Contains(got, []byte("REDACTED")) || bytes.Count(got, []byte("hunter2")) != 6 {
+		t.Errorf("Marshal() without Redact:\ngot:  %s\nwant every field", got)
+	}
+}
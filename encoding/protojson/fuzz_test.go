@@ -92,6 +92,6 @@ func FuzzEncodeDecodeRoundTrip(f *testing.F) {
 		// We cannot test proto2 because it does not have UTF-8 validation
 		// but the JSON spec requires valid UTF-8 and thus we might initialize
 		// proto2 messages with invalid UTF-8 and then fail marshalling it.
-		roundTripAndCompareProto(t, in, (*testfuzzpb.TestAllTypesProto3)(nil), (*testfuzzpb.TestAllTypesProto3Editions)(nil))
+		roundTripAndCompareProto(t, in, (*testfuzzpb.TestAllTypesProto3)(nil), (*testfuzzpb.TestAllTypesProto3Editions)(nil), (*testfuzzpb.TestAllTypesProto3Editions2024)(nil))
 	})
 }
@@ -0,0 +1,140 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protojson
+
+import (
+	"io"
+
+	"google.golang.org/protobuf/internal/encoding/json"
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/pathfmt"
+	"google.golang.org/protobuf/proto"
+)
+
+// Decoder reads a stream of JSON objects, each of which is the JSON
+// representation of a message. Objects may be concatenated or separated by
+// any whitespace, such as in the newline-delimited JSON (JSONL) format.
+type Decoder struct {
+	opts UnmarshalOptions
+	dec  *json.Decoder
+
+	// record is the number of records read so far.
+	record int
+	// offset is the offset in the input of the start of the last record.
+	offset int
+	// err is the sticky error that stopped decoding.
+	err error
+}
+
+// NewDecoder returns a Decoder that reads messages from r using the default
+// options.
+func NewDecoder(r io.Reader) *Decoder {
+	return UnmarshalOptions{}.NewDecoder(r)
+}
+
+// NewDecoder returns a Decoder that reads messages from r using options in the
+// UnmarshalOptions object.
+func (o UnmarshalOptions) NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{opts: o, dec: json.NewStreamDecoder(r)}
+}
+
+// Decode reads the next JSON object from the input and populates the given
+// [proto.Message] using the options of the Decoder.
+// It will clear the message first before setting the fields.
+// The provided message must be mutable (e.g., a non-nil pointer to a message).
+//
+// Decode returns [io.EOF] once the end of the input has been reached
+// without reading any further object. Errors in the JSON input report the
+// index of the record along with its line and column in the input, and
+// errors returned from the underlying reader are returned unchanged.
+// Once Decode returns an error, all further calls return the same error.
+func (d *Decoder) Decode(m proto.Message) error {
+	if d.err != nil {
+		return d.err
+	}
+	tok, err := d.dec.Peek()
+	if err != nil {
+		d.err = d.wrapError(decoder{d.dec, d.opts, new(pathfmt.Path)}.wrapError(err))
+		return d.err
+	}
+	if tok.Kind() == json.EOF {
+		d.err = io.EOF
+		return d.err
+	}
+
+	d.offset = tok.Pos()
+	if err := d.opts.unmarshal(d.dec, m, false); err != nil {
+		d.err = d.wrapError(err)
+		return d.err
+	}
+	d.record++
+	d.dec.NextValue()
+	return nil
+}
+
+// wrapError annotates errors in the JSON input with the current record.
+func (d *Decoder) wrapError(err error) error {
+	if !errors.Is(err, errors.Error) {
+		return err
+	}
+	if e, ok := err.(*DecodeError); ok {
+		// Annotate the underlying error such that the result is still
+		// a *DecodeError.
+		e2 := *e
+		e2.err = errors.Wrap(e.err, "record %d", d.record)
+		return &e2
+	}
+	return errors.Wrap(err, "record %d", d.record)
+}
+
+// Record returns the zero-based index of the next record to be read, which is
+// the number of records that have been successfully decoded.
+func (d *Decoder) Record() int {
+	return d.record
+}
+
+// RecordOffset returns the byte offset in the input of the start of the
+// record that was most recently read or attempted to be read.
+func (d *Decoder) RecordOffset() int64 {
+	return int64(d.offset)
+}
+
+// Encoder writes a stream of messages in the newline-delimited JSON (JSONL)
+// format, where each message is written as a single-line JSON object
+// terminated by a newline.
+type Encoder struct {
+	opts MarshalOptions
+	w    io.Writer
+	buf  []byte
+}
+
+// NewEncoder returns an Encoder that writes messages to w using the default
+// options.
+func NewEncoder(w io.Writer) *Encoder {
+	return MarshalOptions{}.NewEncoder(w)
+}
+
+// NewEncoder returns an Encoder that writes messages to w using options in the
+// MarshalOptions object. The Multiline and Indent options are ignored since
+// each message must be written on a single line.
+func (o MarshalOptions) NewEncoder(w io.Writer) *Encoder {
+	o.Multiline = false
+	o.Indent = ""
+	return &Encoder{opts: o, w: w}
+}
+
+// Encode writes the JSON representation of m followed by a newline to the
+// underlying writer. Each message is written with a single call to Write.
+// If the writer returns an error, Encode returns it unchanged.
+func (e *Encoder) Encode(m proto.Message) error {
+	b, err := e.opts.MarshalAppend(e.buf[:0], m)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	e.buf = b
+	_, err = e.w.Write(b)
+	return err
+}
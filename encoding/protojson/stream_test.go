@@ -0,0 +1,156 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protojson_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/internal/testprotos/test3"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestStreamRoundTrip(t *testing.T) {
+	msgs := []*test3.TestAllTypes{
+		{SingularInt32: 1},
+		{SingularString: "hello\nworld"},
+		{RepeatedDouble: []float64{1.2, 3.4}},
+		{},
+		{
+			SingularNestedMessage:  &test3.TestAllTypes_NestedMessage{A: 1},
+			RepeatedForeignMessage: []*test3.ForeignMessage{{C: 2}, {D: 3}},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	enc := protojson.MarshalOptions{Multiline: true}.NewEncoder(buf)
+	for _, m := range msgs {
+		if err := enc.Encode(m); err != nil {
+			t.Fatalf("Encode(%v) returned error: %v", m, err)
+		}
+	}
+	if got, want := strings.Count(buf.String(), "\n"), len(msgs); got != want {
+		t.Errorf("Encode() wrote %d lines, want %d:\n%s", got, want, buf.String())
+	}
+
+	for _, tc := range []struct {
+		name   string
+		reader io.Reader
+	}{
+		{name: "reader", reader: bytes.NewReader(buf.Bytes())},
+		{name: "onebyte", reader: iotest.OneByteReader(bytes.NewReader(buf.Bytes()))},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []*test3.TestAllTypes
+			dec := protojson.NewDecoder(tc.reader)
+			for {
+				m := &test3.TestAllTypes{}
+				err := dec.Decode(m)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Decode() returned error: %v", err)
+				}
+				got = append(got, m)
+			}
+			if diff := cmp.Diff(msgs, got, protocmp.Transform()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			if got, want := dec.Record(), len(msgs); got != want {
+				t.Errorf("Record() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestDecoderConcatenated(t *testing.T) {
+	dec := protojson.NewDecoder(strings.NewReader(`{"singularInt32":1}{"singularInt32":2} {"singularInt32":3}`))
+	for i := int32(1); i <= 3; i++ {
+		m := &test3.TestAllTypes{}
+		if err := dec.Decode(m); err != nil {
+			t.Fatalf("Decode() returned error: %v", err)
+		}
+		if m.SingularInt32 != i {
+			t.Errorf("Decode() got singularInt32 %d, want %d", m.SingularInt32, i)
+		}
+	}
+	if err := dec.Decode(&test3.TestAllTypes{}); err != io.EOF {
+		t.Errorf("Decode() error got %v, want io.EOF", err)
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	tests := []struct {
+		desc       string
+		input      string
+		wantRecord int
+		wantOffset int64
+		wantErr    string
+	}{{
+		desc:       "unknown field",
+		input:      "{\"singularInt32\":1}\n{\"singularInt32\":2}\n{\"unknown\":3}\n",
+		wantRecord: 2,
+		wantOffset: 40,
+		wantErr:    `record 2: (line 3:2): unknown field "unknown"`,
+	}, {
+		desc:       "syntax error",
+		input:      "{}\n{\"singularInt32\":1,}\n",
+		wantRecord: 1,
+		wantOffset: 3,
+		wantErr:    `record 1: syntax error (line 2:20): unexpected token }`,
+	}, {
+		desc:       "truncated record",
+		input:      "{}\n{\"singularInt32\":",
+		wantRecord: 1,
+		wantOffset: 3,
+		wantErr:    `record 1: unexpected EOF`,
+	}, {
+		desc:       "not an object",
+		input:      "{}\n[]\n",
+		wantRecord: 1,
+		wantOffset: 3,
+		wantErr:    `record 1: syntax error (line 2:1): unexpected token [`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dec := protojson.NewDecoder(strings.NewReader(tt.input))
+			var err error
+			for err == nil {
+				err = dec.Decode(&test3.TestAllTypes{})
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Decode() error got %q, want %q", err, tt.wantErr)
+			}
+			if got := dec.Record(); got != tt.wantRecord {
+				t.Errorf("Record() = %d, want %d", got, tt.wantRecord)
+			}
+			if got := dec.RecordOffset(); got != tt.wantOffset {
+				t.Errorf("RecordOffset() = %d, want %d", got, tt.wantOffset)
+			}
+			if err2 := dec.Decode(&test3.TestAllTypes{}); err2 != err {
+				t.Errorf("Decode() after error got %v, want %v", err2, err)
+			}
+		})
+	}
+}
+
+func TestDecoderReadError(t *testing.T) {
+	wantErr := errors.New("read error")
+	dec := protojson.NewDecoder(io.MultiReader(strings.NewReader("{}\n{"), iotest.ErrReader(wantErr)))
+	if err := dec.Decode(&test3.TestAllTypes{}); err != nil {
+		t.Fatalf("Decode() returned error: %v", err)
+	}
+	if err := dec.Decode(&test3.TestAllTypes{}); err != wantErr {
+		t.Errorf("Decode() error got %v, want %v", err, wantErr)
+	}
+}
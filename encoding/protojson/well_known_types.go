@@ -176,7 +176,7 @@ func (d decoder) unmarshalAny(m protoreflect.Message) error {
 	// Use another decoder to parse the unread bytes for @type field. This
 	// avoids advancing a read from current decoder because the current JSON
 	// object may contain the fields of the embedded type.
-	dec := decoder{d.Clone(), UnmarshalOptions{RecursionLimit: d.opts.RecursionLimit}}
+	dec := decoder{d.Clone(), UnmarshalOptions{RecursionLimit: d.opts.RecursionLimit}, d.path}
 	tok, err := findTypeURL(dec)
 	switch err {
 	case errEmptyObject:
@@ -191,7 +191,7 @@ func (d decoder) unmarshalAny(m protoreflect.Message) error {
 			return d.skipJSONValue()
 		}
 		// Use start.Pos() for line position.
-		return d.newError(start.Pos(), err.Error())
+		return d.newError(start.Pos(), CategoryInvalidValue, err.Error())
 
 	default:
 		if err != nil {
@@ -202,11 +202,13 @@ func (d decoder) unmarshalAny(m protoreflect.Message) error {
 	typeURL := tok.ParsedString()
 	emt, err := d.opts.Resolver.FindMessageByURL(typeURL)
 	if err != nil {
-		return d.newError(tok.Pos(), "unable to resolve %v: %q", tok.RawString(), err)
+		return d.newError(tok.Pos(), CategoryUnresolvable, "unable to resolve %v: %q", tok.RawString(), err)
 	}
 
 	// Create new message for the embedded message type and unmarshal into it.
 	em := emt.New()
+	n := d.path.Len()
+	d.path.AnyExpand(em.Descriptor())
 	if unmarshal := wellKnownTypeUnmarshaler(emt.Descriptor().FullName()); unmarshal != nil {
 		// If embedded message is a custom type,
 		// unmarshal the JSON "value" field into it.
@@ -219,6 +221,7 @@ func (d decoder) unmarshalAny(m protoreflect.Message) error {
 			return err
 		}
 	}
+	d.path.Truncate(n)
 	// Serialize the embedded message and assign the resulting bytes to the
 	// proto value field.
 	b, err := proto.MarshalOptions{
@@ -226,7 +229,7 @@ func (d decoder) unmarshalAny(m protoreflect.Message) error {
 		Deterministic: true,
 	}.Marshal(em.Interface())
 	if err != nil {
-		return d.newError(start.Pos(), "error in marshaling Any.value field: %v", err)
+		return d.newError(start.Pos(), CategoryInvalidValue, "error in marshaling Any.value field: %v", err)
 	}
 
 	fds := m.Descriptor().Fields()
@@ -283,7 +286,7 @@ Loop:
 
 			// Return error if this was previously set already.
 			if typeURL != "" {
-				return json.Token{}, d.newError(tok.Pos(), `duplicate "@type" field`)
+				return json.Token{}, d.newError(tok.Pos(), CategoryDuplicateField, `duplicate "@type" field`)
 			}
 			// Read field value.
 			tok, err := d.Read()
@@ -291,11 +294,11 @@ Loop:
 				return json.Token{}, err
 			}
 			if tok.Kind() != json.String {
-				return json.Token{}, d.newError(tok.Pos(), `@type field value is not a string: %v`, tok.RawString())
+				return json.Token{}, d.newError(tok.Pos(), CategoryInvalidValue, `@type field value is not a string: %v`, tok.RawString())
 			}
 			typeURL = tok.ParsedString()
 			if typeURL == "" {
-				return json.Token{}, d.newError(tok.Pos(), `@type field contains empty value`)
+				return json.Token{}, d.newError(tok.Pos(), CategoryInvalidValue, `@type field contains empty value`)
 			}
 			typeTok = tok
 		}
@@ -320,12 +323,12 @@ func (d decoder) skipJSONValue() error {
 		case json.ObjectOpen, json.ArrayOpen:
 			open++
 			if open > d.opts.RecursionLimit {
-				return errors.New("exceeded max recursion depth")
+				return d.newError(tok.Pos(), CategoryRecursionLimit, "exceeded max recursion depth")
 			}
 		case json.EOF:
 			// This can only happen if there's a bug in Decoder.Read.
 			// Avoid an infinite loop if this does happen.
-			return errors.New("unexpected EOF")
+			return d.newDecodeError(tok.Pos(), CategorySyntax, json.ErrUnexpectedEOF)
 		}
 		if open == 0 {
 			return nil
@@ -348,7 +351,7 @@ func (d decoder) unmarshalAnyValue(unmarshal unmarshalFunc, m protoreflect.Messa
 		switch tok.Kind() {
 		case json.ObjectClose:
 			if !found {
-				return d.newError(tok.Pos(), `missing "value" field`)
+				return d.newError(tok.Pos(), CategoryInvalidValue, `missing "value" field`)
 			}
 			return nil
 
@@ -360,7 +363,7 @@ func (d decoder) unmarshalAnyValue(unmarshal unmarshalFunc, m protoreflect.Messa
 
 			case "value":
 				if found {
-					return d.newError(tok.Pos(), `duplicate "value" field`)
+					return d.newError(tok.Pos(), CategoryDuplicateField, `duplicate "value" field`)
 				}
 				// Unmarshal the field value into the given message.
 				if err := unmarshal(d, m); err != nil {
@@ -375,7 +378,7 @@ func (d decoder) unmarshalAnyValue(unmarshal unmarshalFunc, m protoreflect.Messa
 					}
 					continue
 				}
-				return d.newError(tok.Pos(), "unknown field %v", tok.RawString())
+				return d.newError(tok.Pos(), CategoryUnknownField, "unknown field %v", tok.RawString())
 			}
 		}
 	}
@@ -432,7 +435,7 @@ func (d decoder) unmarshalEmpty(protoreflect.Message) error {
 				}
 				continue
 			}
-			return d.newError(tok.Pos(), "unknown field %v", tok.RawString())
+			return d.newError(tok.Pos(), CategoryUnknownField, "unknown field %v", tok.RawString())
 
 		default:
 			return d.unexpectedTokenError(tok)
@@ -516,7 +519,7 @@ func (d decoder) unmarshalKnownValue(m protoreflect.Message) error {
 		var ok bool
 		val, ok = unmarshalFloat(tok, 64)
 		if !ok {
-			return d.newError(tok.Pos(), "invalid %v: %v", genid.Value_message_fullname, tok.RawString())
+			return d.newError(tok.Pos(), CategoryInvalidValue, "invalid %v: %v", genid.Value_message_fullname, tok.RawString())
 		}
 
 	case json.String:
@@ -548,7 +551,7 @@ func (d decoder) unmarshalKnownValue(m protoreflect.Message) error {
 		}
 
 	default:
-		return d.newError(tok.Pos(), "invalid %v: %v", genid.Value_message_fullname, tok.RawString())
+		return d.newError(tok.Pos(), CategoryInvalidValue, "invalid %v: %v", genid.Value_message_fullname, tok.RawString())
 	}
 
 	m.Set(fd, val)
@@ -615,12 +618,12 @@ func (d decoder) unmarshalDuration(m protoreflect.Message) error {
 
 	secs, nanos, ok := parseDuration(tok.ParsedString())
 	if !ok {
-		return d.newError(tok.Pos(), "invalid %v value %v", genid.Duration_message_fullname, tok.RawString())
+		return d.newError(tok.Pos(), CategoryInvalidValue, "invalid %v value %v", genid.Duration_message_fullname, tok.RawString())
 	}
 	// Validate seconds. No need to validate nanos because parseDuration would
 	// have covered that already.
 	if secs < -maxSecondsInDuration || secs > maxSecondsInDuration {
-		return d.newError(tok.Pos(), "%v value out of range: %v", genid.Duration_message_fullname, tok.RawString())
+		return d.newError(tok.Pos(), CategoryInvalidValue, "%v value out of range: %v", genid.Duration_message_fullname, tok.RawString())
 	}
 
 	fds := m.Descriptor().Fields()
@@ -798,18 +801,18 @@ func (d decoder) unmarshalTimestamp(m protoreflect.Message) error {
 	s := tok.ParsedString()
 	t, err := time.Parse(time.RFC3339Nano, s)
 	if err != nil {
-		return d.newError(tok.Pos(), "invalid %v value %v", genid.Timestamp_message_fullname, tok.RawString())
+		return d.newError(tok.Pos(), CategoryInvalidValue, "invalid %v value %v", genid.Timestamp_message_fullname, tok.RawString())
 	}
 	// Validate seconds.
 	secs := t.Unix()
 	if secs < minTimestampSeconds || secs > maxTimestampSeconds {
-		return d.newError(tok.Pos(), "%v value out of range: %v", genid.Timestamp_message_fullname, tok.RawString())
+		return d.newError(tok.Pos(), CategoryInvalidValue, "%v value out of range: %v", genid.Timestamp_message_fullname, tok.RawString())
 	}
 	// Validate subseconds.
 	i := strings.LastIndexByte(s, '.')  // start of subsecond field
 	j := strings.LastIndexAny(s, "Z-+") // start of timezone field
 	if i >= 0 && j >= i && j-i > len(".999999999") {
-		return d.newError(tok.Pos(), "invalid %v value %v", genid.Timestamp_message_fullname, tok.RawString())
+		return d.newError(tok.Pos(), CategoryInvalidValue, "invalid %v value %v", genid.Timestamp_message_fullname, tok.RawString())
 	}
 
 	fds := m.Descriptor().Fields()
@@ -868,7 +871,7 @@ func (d decoder) unmarshalFieldMask(m protoreflect.Message) error {
 	for _, s0 := range paths {
 		s := strs.JSONSnakeCase(s0)
 		if strings.Contains(s0, "_") || !protoreflect.FullName(s).IsValid() {
-			return d.newError(tok.Pos(), "%v contains invalid path: %q", genid.FieldMask_Paths_field_fullname, s0)
+			return d.newError(tok.Pos(), CategoryInvalidValue, "%v contains invalid path: %q", genid.FieldMask_Paths_field_fullname, s0)
 		}
 		list.Append(protoreflect.ValueOfString(s))
 	}
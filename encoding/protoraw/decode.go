@@ -0,0 +1,288 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protoraw decodes messages in the protobuf binary wire format
+// without a schema.
+//
+// The wire format does not describe the types of fields, so the decoder
+// guesses how to interpret length-delimited fields: as a nested message,
+// a string, a packed list of varints, or opaque bytes.
+// The result is a tree of fields that can be formatted in a syntax
+// similar to the text format, as "protoc --decode_raw" does:
+//
+//	m, err := protoraw.Decode(b)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Print(m.Format())
+//
+// For decoding with a schema, use the [proto] package instead.
+//
+// [proto]: https://pkg.go.dev/google.golang.org/protobuf/proto
+package protoraw
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/errors"
+)
+
+// Kind is the interpretation of the value of a field.
+type Kind int8
+
+const (
+	// VarintKind is a field of the varint wire type.
+	VarintKind Kind = iota + 1
+	// Fixed32Kind is a field of the 32-bit wire type.
+	Fixed32Kind
+	// Fixed64Kind is a field of the 64-bit wire type.
+	Fixed64Kind
+	// GroupKind is a field of the start group wire type.
+	GroupKind
+
+	// MessageKind is a length-delimited field that holds a message.
+	MessageKind
+	// StringKind is a length-delimited field that holds printable UTF-8 text.
+	StringKind
+	// PackedVarintKind is a length-delimited field that holds a list of varints.
+	PackedVarintKind
+	// BytesKind is a length-delimited field that is not interpreted.
+	BytesKind
+)
+
+// String returns the name of k, such as "varint".
+func (k Kind) String() string {
+	switch k {
+	case VarintKind:
+		return "varint"
+	case Fixed32Kind:
+		return "fixed32"
+	case Fixed64Kind:
+		return "fixed64"
+	case GroupKind:
+		return "group"
+	case MessageKind:
+		return "message"
+	case StringKind:
+		return "string"
+	case PackedVarintKind:
+		return "packed varint"
+	case BytesKind:
+		return "bytes"
+	default:
+		return fmt.Sprintf("<unknown:%d>", k)
+	}
+}
+
+// Message is a decoded message, which is a list of fields in wire order.
+// A field number may appear more than once.
+type Message []Field
+
+// Field is a decoded field.
+type Field struct {
+	// Number is the field number.
+	Number protowire.Number
+	// Type is the wire type.
+	Type protowire.Type
+	// Kind is the interpretation of the value.
+	Kind Kind
+	// Offset is the offset of the tag of the field in the input.
+	Offset int
+	// Raw is the encoded value of the field, not including the tag.
+	// For a group, it is the encoded fields between the start and end tags.
+	Raw []byte
+
+	// Scalar is the value of a VarintKind, Fixed32Kind or Fixed64Kind field.
+	Scalar uint64
+	// Packed is the value of a PackedVarintKind field.
+	Packed []uint64
+	// Message is the value of a MessageKind or GroupKind field.
+	Message Message
+}
+
+// Decode decodes b, which must be a message in the wire format.
+// The returned fields alias b.
+func Decode(b []byte) (Message, error) {
+	d := decoder{buf: b}
+	m, _, err := d.message(0, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// maxDepth is the nesting depth beyond which length-delimited fields
+// are not interpreted as messages.
+const maxDepth = 100
+
+type decoder struct {
+	buf []byte
+}
+
+// message decodes the fields that start at offset off, until the end of
+// the input or until the end group tag of the group numbered group, if any.
+// It returns the fields and the offset of the end of the last field.
+func (d *decoder) message(off int, group protowire.Number, depth int) (Message, int, error) {
+	end := len(d.buf)
+	m := Message{}
+	for off < end {
+		num, typ, n := protowire.ConsumeTag(d.buf[off:end])
+		if n < 0 {
+			return nil, off, errors.New("offset %d: %v", off, protowire.ParseError(n))
+		}
+		f := Field{Number: num, Type: typ, Offset: off}
+		valOff := off + n
+		b := d.buf[valOff:end]
+		switch typ {
+		case protowire.VarintType:
+			f.Kind = VarintKind
+			f.Scalar, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed32Type:
+			var v uint32
+			f.Kind = Fixed32Kind
+			v, n = protowire.ConsumeFixed32(b)
+			f.Scalar = uint64(v)
+		case protowire.Fixed64Type:
+			f.Kind = Fixed64Kind
+			f.Scalar, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			var v []byte
+			v, n = protowire.ConsumeBytes(b)
+			if n >= 0 {
+				d.interpret(&f, v, valOff+n-len(v), depth)
+			}
+		case protowire.StartGroupType:
+			if depth >= maxDepth {
+				return nil, off, errors.New("offset %d: exceeded maximum recursion depth", off)
+			}
+			var err error
+			var groupEnd int
+			f.Kind = GroupKind
+			f.Message, groupEnd, err = d.message(valOff, num, depth+1)
+			if err != nil {
+				return nil, off, err
+			}
+			if groupEnd == len(d.buf) {
+				return nil, off, errors.New("offset %d: group %d is not terminated", off, num)
+			}
+			f.Raw = d.buf[valOff:groupEnd]
+			_, _, n = protowire.ConsumeTag(d.buf[groupEnd:])
+			n += groupEnd - valOff
+		case protowire.EndGroupType:
+			if num != group {
+				return nil, off, errors.New("offset %d: unexpected end group %d", off, num)
+			}
+			return m, off, nil
+		default:
+			return nil, off, errors.New("offset %d: invalid wire type %d", off, typ)
+		}
+		if n < 0 {
+			return nil, off, errors.New("offset %d: field %d: %v", off, num, protowire.ParseError(n))
+		}
+		if f.Kind <= Fixed64Kind {
+			f.Raw = d.buf[valOff : valOff+n]
+		}
+		m = append(m, f)
+		off = valOff + n
+	}
+	return m, off, nil
+}
+
+// interpret guesses the kind of the length-delimited field f with the value v,
+// which starts at offset off.
+func (d *decoder) interpret(f *Field, v []byte, off, depth int) {
+	f.Raw = v
+	if isText(v) {
+		f.Kind = StringKind
+		return
+	}
+	if depth < maxDepth && isMessage(v) {
+		// The value is decoded with the input truncated at its end,
+		// so that the offsets of its fields are relative to the whole input.
+		sub := decoder{buf: d.buf[:off+len(v)]}
+		if m, _, err := sub.message(off, 0, depth+1); err == nil {
+			f.Kind = MessageKind
+			f.Message = m
+			return
+		}
+	}
+	switch {
+	case isPackedVarint(v):
+		f.Kind = PackedVarintKind
+		for len(v) > 0 {
+			x, n := protowire.ConsumeVarint(v)
+			f.Packed = append(f.Packed, x)
+			v = v[n:]
+		}
+	default:
+		f.Kind = BytesKind
+	}
+}
+
+// isText reports whether b is valid UTF-8 that consists of printable
+// characters and whitespace. The empty string is considered text.
+func isText(b []byte) bool {
+	for len(b) > 0 {
+		r, n := utf8.DecodeRune(b)
+		if r == utf8.RuneError && n <= 1 {
+			return false
+		}
+		if !unicode.IsPrint(r) && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+		b = b[n:]
+	}
+	return true
+}
+
+// isMessage reports whether b is a valid message with at least one field,
+// where all groups are terminated and all field numbers are valid.
+func isMessage(b []byte) bool {
+	if len(b) == 0 {
+		return false
+	}
+	var groups []protowire.Number
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 || num > protowire.MaxValidNumber {
+			return false
+		}
+		b = b[n:]
+		switch typ {
+		case protowire.StartGroupType:
+			groups = append(groups, num)
+			continue
+		case protowire.EndGroupType:
+			if len(groups) == 0 || groups[len(groups)-1] != num {
+				return false
+			}
+			groups = groups[:len(groups)-1]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return false
+		}
+		b = b[n:]
+	}
+	return len(groups) == 0
+}
+
+// isPackedVarint reports whether b is a list of minimally encoded varints.
+func isPackedVarint(b []byte) bool {
+	if len(b) == 0 {
+		return false
+	}
+	for len(b) > 0 {
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 || n != protowire.SizeVarint(v) {
+			return false
+		}
+		b = b[n:]
+	}
+	return true
+}
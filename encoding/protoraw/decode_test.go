@@ -0,0 +1,196 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoraw_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"google.golang.org/protobuf/encoding/protoraw"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protopack"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+)
+
+func TestDecode(t *testing.T) {
+	in := protopack.Message{
+		protopack.Tag{1, protopack.VarintType}, protopack.Varint(150),
+		protopack.Tag{2, protopack.BytesType}, protopack.LengthPrefix{protopack.Message{
+			protopack.Tag{1, protopack.Fixed32Type}, protopack.Uint32(7),
+		}},
+		protopack.Tag{3, protopack.StartGroupType},
+		protopack.Tag{4, protopack.Fixed64Type}, protopack.Uint64(8),
+		protopack.Tag{3, protopack.EndGroupType},
+	}.Marshal()
+	got, err := protoraw.Decode(in)
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	want := protoraw.Message{{
+		Number: 1, Type: protowire.VarintType, Kind: protoraw.VarintKind,
+		Offset: 0, Raw: in[1:3], Scalar: 150,
+	}, {
+		Number: 2, Type: protowire.BytesType, Kind: protoraw.MessageKind,
+		Offset: 3, Raw: in[5:10],
+		Message: protoraw.Message{{
+			Number: 1, Type: protowire.Fixed32Type, Kind: protoraw.Fixed32Kind,
+			Offset: 5, Raw: in[6:10], Scalar: 7,
+		}},
+	}, {
+		Number: 3, Type: protowire.StartGroupType, Kind: protoraw.GroupKind,
+		Offset: 10, Raw: in[11:20],
+		Message: protoraw.Message{{
+			Number: 4, Type: protowire.Fixed64Type, Kind: protoraw.Fixed64Kind,
+			Offset: 11, Raw: in[12:20], Scalar: 8,
+		}},
+	}}
+	if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("Decode() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestKinds(t *testing.T) {
+	tests := []struct {
+		desc string
+		val  []byte
+		want protoraw.Kind
+	}{
+		{"empty", nil, protoraw.StringKind},
+		{"text", []byte("hello, 世界\n"), protoraw.StringKind},
+		{"message", protopack.Message{protopack.Tag{1, protopack.BytesType}, protopack.String("abc")}.Marshal(), protoraw.MessageKind},
+		{"group in message", protopack.Message{protopack.Tag{1, protopack.StartGroupType}, protopack.Tag{1, protopack.EndGroupType}}.Marshal(), protoraw.MessageKind},
+		{"packed", protopack.Message{protopack.Varint(300), protopack.Varint(0), protopack.Varint(1 << 40)}.Marshal(), protoraw.PackedVarintKind},
+		{"unterminated group", []byte{0x0b, 0x80}, protoraw.BytesKind},
+		{"invalid UTF-8", []byte{0xff, 0xfe, 0x80}, protoraw.BytesKind},
+		{"denormalized varint", []byte{0x80, 0x00}, protoraw.BytesKind},
+	}
+	for _, tt := range tests {
+		in := protowire.AppendBytes(protowire.AppendTag(nil, 1, protowire.BytesType), tt.val)
+		m, err := protoraw.Decode(in)
+		if err != nil {
+			t.Errorf("%s: Decode() error: %v", tt.desc, err)
+			continue
+		}
+		if got := m[0].Kind; got != tt.want {
+			t.Errorf("%s: kind = %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   []byte
+		want string
+	}{
+		{"truncated tag", []byte{0x80}, "offset 0: unexpected EOF"},
+		{"field number zero", []byte{0x00}, "offset 0: invalid field number"},
+		{"truncated value", []byte{0x08, 0x01, 0x0d, 0x01}, "offset 2: field 1: unexpected EOF"},
+		{"truncated bytes", []byte{0x12, 0x05, 'a'}, "offset 0: field 2: unexpected EOF"},
+		{"unterminated group", []byte{0x0b, 0x08, 0x01}, "offset 0: group 1 is not terminated"},
+		{"mismatched end group", []byte{0x0b, 0x14}, "offset 1: unexpected end group 2"},
+		{"end group at top level", []byte{0x0c}, "offset 0: unexpected end group 1"},
+		{"invalid wire type", []byte{0x0e}, "offset 0: invalid wire type 6"},
+	}
+	for _, tt := range tests {
+		_, err := protoraw.Decode(tt.in)
+		if err == nil || !strings.HasSuffix(err.Error(), tt.want) {
+			t.Errorf("%s: Decode() error = %v, want %q", tt.desc, err, tt.want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	b, err := proto.Marshal(&testpb.TestAllTypes{
+		OptionalInt32:   proto.Int32(-1),
+		OptionalSint32:  proto.Int32(-2),
+		OptionalFixed32: proto.Uint32(1),
+		OptionalFloat:   proto.Float32(1.5),
+		OptionalDouble:  proto.Float64(-2),
+		OptionalString:  proto.String("héllo \"world\""),
+		OptionalBytes:   []byte{0, 1, 0xff},
+		OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+			A: proto.Int32(1),
+		},
+		Optionalgroup: &testpb.TestAllTypes_OptionalGroup{
+			A: proto.Int32(2),
+		},
+		RepeatedInt32: []int32{1, 2, 300},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := protoraw.Decode(b)
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+
+	tests := []struct {
+		opts protoraw.FormatOptions
+		want string
+	}{{
+		opts: protoraw.FormatOptions{},
+		want: `1: 18446744073709551615
+5: 3
+7: 0x00000001
+11: 0x3fc00000
+12: 0xc000000000000000
+14: "héllo \"world\""
+15: "\x00\x01\xff"
+16 {
+  17: 2
+}
+18 {
+  1: 1
+}
+31: 1
+31: 2
+31: 300
+`,
+	}, {
+		opts: protoraw.FormatOptions{Indent: "\t", Annotate: true},
+		want: `1: 18446744073709551615  # int64: -1, sint: -9223372036854775808
+5: 3  # sint: -2
+7: 0x00000001  # fixed32: 1, float: 1e-45
+11: 0x3fc00000  # fixed32: 1069547520, float: 1.5
+12: 0xc000000000000000  # fixed64: 13835058055282163712, sfixed64: -4611686018427387904, double: -2
+14: "héllo \"world\""
+15: "\x00\x01\xff"  # 3 bytes
+16 {  # group
+	17: 2  # sint: 1
+}
+18 {  # 2 bytes
+	1: 1  # sint: -1
+}
+31: 1  # sint: -1
+31: 2  # sint: 1
+31: 300  # sint: 150
+`,
+	}}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, tt.opts.Format(m)); diff != "" {
+			t.Errorf("Format() mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestFormatPacked(t *testing.T) {
+	b, err := proto.Marshal(&testpb.TestPackedTypes{PackedInt32: []int32{1, 2, 300}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := protoraw.Decode(b)
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	if got, want := m.Format(), "90: [1, 2, 300]\n"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoraw
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/encoding/text"
+)
+
+// Format formats m with the default options.
+func (m Message) Format() string {
+	return FormatOptions{}.Format(m)
+}
+
+// FormatOptions configures the formatter.
+type FormatOptions struct {
+	// Indent is the indentation of nested messages.
+	// If empty, two spaces are used.
+	Indent string
+
+	// Annotate adds a comment to each field with other interpretations
+	// of its value, such as the signed and zigzag-decoded values of a varint.
+	Annotate bool
+}
+
+// Format formats m in a syntax similar to the text format,
+// where fields are named by their numbers:
+//
+//	1: 150
+//	2: "hello"
+//	3 {
+//	  1: 0x3f800000
+//	}
+//	4: [1, 2, 300]
+//
+// Strings and bytes are quoted, varints are written in decimal,
+// and 32-bit and 64-bit values in hexadecimal. Messages and groups are
+// written as blocks, and packed varints as lists.
+func (o FormatOptions) Format(m Message) string {
+	if o.Indent == "" {
+		o.Indent = "  "
+	}
+	var b strings.Builder
+	o.format(&b, "", m)
+	return b.String()
+}
+
+func (o FormatOptions) format(b *strings.Builder, indent string, m Message) {
+	for _, f := range m {
+		b.WriteString(indent)
+		b.WriteString(strconv.Itoa(int(f.Number)))
+		var comments []string
+		switch f.Kind {
+		case MessageKind, GroupKind:
+			b.WriteString(" {")
+			if f.Kind == GroupKind {
+				comments = append(comments, "group")
+			} else {
+				comments = append(comments, fmt.Sprintf("%d bytes", len(f.Raw)))
+			}
+			o.writeComments(b, comments)
+			b.WriteString("\n")
+			o.format(b, indent+o.Indent, f.Message)
+			b.WriteString(indent + "}\n")
+			continue
+		case VarintKind:
+			fmt.Fprintf(b, ": %d", f.Scalar)
+			if int64(f.Scalar) < 0 {
+				comments = append(comments, fmt.Sprintf("int64: %d", int64(f.Scalar)))
+			}
+			if f.Scalar != 0 {
+				comments = append(comments, fmt.Sprintf("sint: %d", protowire.DecodeZigZag(f.Scalar)))
+			}
+		case Fixed32Kind:
+			fmt.Fprintf(b, ": 0x%08x", f.Scalar)
+			comments = append(comments, fmt.Sprintf("fixed32: %d", f.Scalar))
+			if int32(f.Scalar) < 0 {
+				comments = append(comments, fmt.Sprintf("sfixed32: %d", int32(f.Scalar)))
+			}
+			comments = append(comments, "float: "+strconv.FormatFloat(float64(math.Float32frombits(uint32(f.Scalar))), 'g', -1, 32))
+		case Fixed64Kind:
+			fmt.Fprintf(b, ": 0x%016x", f.Scalar)
+			comments = append(comments, fmt.Sprintf("fixed64: %d", f.Scalar))
+			if int64(f.Scalar) < 0 {
+				comments = append(comments, fmt.Sprintf("sfixed64: %d", int64(f.Scalar)))
+			}
+			comments = append(comments, "double: "+strconv.FormatFloat(math.Float64frombits(f.Scalar), 'g', -1, 64))
+		case PackedVarintKind:
+			b.WriteString(": [")
+			for i, v := range f.Packed {
+				if i > 0 {
+					b.WriteString(", ")
+				}
+				b.WriteString(strconv.FormatUint(v, 10))
+			}
+			b.WriteString("]")
+			comments = append(comments, "packed varint")
+		case StringKind, BytesKind:
+			b.WriteString(": ")
+			b.Write(text.AppendString(nil, string(f.Raw)))
+			if f.Kind == BytesKind {
+				comments = append(comments, fmt.Sprintf("%d bytes", len(f.Raw)))
+			}
+		}
+		o.writeComments(b, comments)
+		b.WriteString("\n")
+	}
+}
+
+func (o FormatOptions) writeComments(b *strings.Builder, comments []string) {
+	if o.Annotate && len(comments) > 0 {
+		b.WriteString("  # " + strings.Join(comments, ", "))
+	}
+}
@@ -0,0 +1,429 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ast provides a syntax tree for the textproto format that preserves
+// the comments and formatting of the input.
+//
+// The syntax tree is parsed without a message descriptor, such that it
+// represents any syntactically valid textproto input. Fields are modified
+// using field descriptors, where [Message.Set] replaces the value of a field
+// while keeping its comments, and [Message.Format] re-emits the input with
+// only the modified values changed:
+//
+//	root, err := ast.Parse(b)
+//	if err != nil {
+//		return err
+//	}
+//	fd := md.Fields().ByName("timeout_seconds")
+//	root.Set(fd, protoreflect.ValueOfInt32(30))
+//	b = root.Format()
+//
+// Use [prototext.Unmarshal] on the formatted output to validate it against
+// a message type.
+package ast
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/internal/encoding/text"
+)
+
+// Position is a position in the textproto input.
+type Position struct {
+	// Offset is the byte offset, starting at 0.
+	Offset int
+	// Line is the line number, starting at 1.
+	Line int
+	// Column is the column number in characters, starting at 1.
+	Column int
+}
+
+// Value is the value of a field, which is one of [*Scalar], [*Message],
+// or [*List].
+type Value interface {
+	// Pos returns the position of the value in the input.
+	// It is the zero Position for values that were not parsed.
+	Pos() Position
+
+	appendText(b []byte) []byte
+	beforeText() string
+	setBefore(s string)
+}
+
+// Message is a message in the syntax tree. The root message of the input
+// has no delimiters; all other messages are enclosed in braces or angle
+// brackets.
+type Message struct {
+	// Fields are the fields of the message in the order that they appear.
+	Fields []*Field
+
+	pos    Position
+	before string // whitespace and comments before the message in a list
+	open   byte   // '{' or '<', or 0 for the root message
+
+	// openTrailing is the whitespace and comments after the opening
+	// delimiter on the same line.
+	openTrailing string
+	// closeLeading is the whitespace and comments before the closing
+	// delimiter, or before the end of the input for the root message.
+	closeLeading string
+	// indent is the indentation of new fields in the message.
+	indent string
+}
+
+// Pos returns the position of the opening delimiter of the message.
+func (m *Message) Pos() Position { return m.pos }
+
+// Field is a field in a message.
+type Field struct {
+	// Name is the name of the field, which is a field name, a field number,
+	// or an extension name or google.protobuf.Any type URL in brackets, such as
+	// "[pkg.ext]". Whitespace and comments within brackets are removed.
+	Name string
+	// Value is the value of the field.
+	Value Value
+
+	pos     Position
+	rawName string // the name as written in the input
+
+	// leading is the whitespace and comments before the field,
+	// starting from the end of the line of the preceding token.
+	leading string
+	// sep is the text between the field name and value,
+	// which includes the separator character ':' if present.
+	sep string
+	// trailing is the whitespace, separators and comments after the value
+	// on the same line.
+	trailing string
+}
+
+// Pos returns the position of the field name.
+func (f *Field) Pos() Position { return f.pos }
+
+// LeadingComments returns the lines of the comment block that directly
+// precedes the field, without the '#' character and a single subsequent space.
+func (f *Field) LeadingComments() []string {
+	lines := strings.Split(f.leading, "\n")
+	var out []string
+	for _, line := range lines[commentBlockStart(lines) : len(lines)-1] {
+		out = append(out, trimComment(line))
+	}
+	return out
+}
+
+// SetLeadingComments replaces the comment block that directly precedes the
+// field with the given lines, which must not contain newlines.
+func (f *Field) SetLeadingComments(comments []string) {
+	lines := strings.Split(f.leading, "\n")
+	i := commentBlockStart(lines)
+	indent := lines[len(lines)-1]
+	var b strings.Builder
+	b.WriteString(strings.Join(lines[:i], "\n"))
+	if i > 0 {
+		b.WriteString("\n")
+	}
+	for _, c := range comments {
+		b.WriteString(indent)
+		b.WriteString(strings.TrimRight("# "+c, " "))
+		b.WriteString("\n")
+	}
+	b.WriteString(indent)
+	f.leading = b.String()
+}
+
+// TrailingComment returns the comment that follows the field value on the
+// same line, without the '#' character and a single subsequent space.
+func (f *Field) TrailingComment() string {
+	if i := strings.IndexByte(f.trailing, '#'); i >= 0 {
+		return trimComment(f.trailing[i:])
+	}
+	return ""
+}
+
+// commentBlockStart returns the index of the first line of the comment block
+// that ends before the last line.
+func commentBlockStart(lines []string) int {
+	i := len(lines) - 1
+	for i > 0 && strings.HasPrefix(strings.TrimSpace(lines[i-1]), "#") {
+		i--
+	}
+	return i
+}
+
+func trimComment(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "#")
+	return strings.TrimPrefix(s, " ")
+}
+
+// List is a list of values in brackets.
+type List struct {
+	// Elems are the elements of the list, which are [*Scalar] or [*Message].
+	Elems []Value
+
+	pos          Position
+	closeLeading string // whitespace and comments before ']'
+}
+
+// Pos returns the position of the opening bracket of the list.
+func (l *List) Pos() Position { return l.pos }
+
+// Scalar is a scalar value, such as a number, a string or an enum value.
+type Scalar struct {
+	raw    string
+	pos    Position
+	before string // whitespace and comments before the value in a list
+}
+
+// Pos returns the position of the value.
+func (s *Scalar) Pos() Position { return s.pos }
+
+// Text returns the value as written in the textproto input.
+func (s *Scalar) Text() string { return s.raw }
+
+func (m *Message) beforeText() string { return m.before }
+func (l *List) beforeText() string    { return "" }
+func (s *Scalar) beforeText() string  { return s.before }
+
+func (m *Message) setBefore(s string) { m.before = s }
+func (l *List) setBefore(s string)    {}
+func (s *Scalar) setBefore(b string)  { s.before = b }
+
+// Parse parses the textproto input b into a syntax tree,
+// returning the root message.
+func Parse(b []byte) (*Message, error) {
+	p := &parser{d: text.NewDecoder(b), in: b}
+	m := &Message{pos: Position{Line: 1, Column: 1}}
+	if err := p.parseMessage(m, text.EOF); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type parser struct {
+	d   *text.Decoder
+	in  []byte
+	end int // end offset of the last token
+}
+
+// read reads the next token and returns it with the text between the
+// previous token and it.
+func (p *parser) read() (text.Token, string, error) {
+	tok, err := p.d.Read()
+	if err != nil {
+		return text.Token{}, "", err
+	}
+	gap := string(p.in[p.end:tok.Pos()])
+	p.end = tok.Pos() + len(tok.RawString())
+	return tok, gap, nil
+}
+
+func (p *parser) position(offset int) Position {
+	line, column := p.d.Position(offset)
+	return Position{Offset: offset, Line: line, Column: column}
+}
+
+func (p *parser) parseMessage(m *Message, closeKind text.Kind) error {
+	for {
+		tok, gap, err := p.read()
+		if err != nil {
+			return err
+		}
+		if tok.Kind() == closeKind {
+			switch {
+			case len(m.Fields) > 0:
+				last := m.Fields[len(m.Fields)-1]
+				last.trailing, m.closeLeading = splitTrailing(gap)
+			default:
+				m.closeLeading = gap
+			}
+			return nil
+		}
+
+		f := &Field{pos: p.position(tok.Pos()), rawName: tok.RawString()}
+		switch tok.NameKind() {
+		case text.TypeName:
+			f.Name = "[" + tok.TypeName() + "]"
+		default:
+			f.Name = tok.RawString()
+		}
+		switch {
+		case len(m.Fields) > 0:
+			last := m.Fields[len(m.Fields)-1]
+			last.trailing, f.leading = splitTrailing(gap)
+		case m.open != 0:
+			m.openTrailing, f.leading = splitTrailing(gap)
+		default:
+			f.leading = gap
+		}
+		m.Fields = append(m.Fields, f)
+		if strings.Contains(f.leading, "\n") {
+			m.indent = lastLine(f.leading)
+		}
+
+		if f.Value, f.sep, err = p.parseValue(m.indent); err != nil {
+			return err
+		}
+	}
+}
+
+// parseValue parses the value of a field or list element,
+// where indent is the indentation of the field.
+func (p *parser) parseValue(indent string) (Value, string, error) {
+	tok, gap, err := p.read()
+	if err != nil {
+		return nil, "", err
+	}
+	switch tok.Kind() {
+	case text.MessageOpen:
+		m := &Message{pos: p.position(tok.Pos()), open: tok.RawString()[0], indent: indent + "  "}
+		closeKind := text.MessageClose
+		return m, gap, p.parseMessage(m, closeKind)
+	case text.ListOpen:
+		l := &List{pos: p.position(tok.Pos())}
+		for {
+			tok, err := p.d.Peek()
+			if err != nil {
+				return nil, "", err
+			}
+			if tok.Kind() == text.ListClose {
+				_, l.closeLeading, err = p.read()
+				return l, gap, err
+			}
+			v, before, err := p.parseValue(indent)
+			if err != nil {
+				return nil, "", err
+			}
+			v.setBefore(before)
+			l.Elems = append(l.Elems, v)
+		}
+	default:
+		raw := tok.RawString()
+		if raw[0] == '"' || raw[0] == '\'' {
+			// The raw text of a string includes any whitespace and comments
+			// after it, since adjacent strings are concatenated.
+			raw = trimString(raw)
+			p.end = tok.Pos() + len(raw)
+		}
+		return &Scalar{raw: raw, pos: p.position(tok.Pos())}, gap, nil
+	}
+}
+
+// trimString trims the whitespace and comments after the last string
+// in the raw text of a string token.
+func trimString(s string) string {
+	var end int
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\'':
+			for i++; i < len(s) && s[i] != c; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+			end = i + 1
+		case '#':
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+		}
+	}
+	return s[:end]
+}
+
+// splitTrailing splits the text between two tokens into the part on the line
+// of the first token and the remainder, which starts with a newline.
+func splitTrailing(s string) (trailing, leading string) {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i], s[i:]
+	}
+	return s, ""
+}
+
+// lastLine returns the text after the last newline in s
+// if it consists of whitespace only.
+func lastLine(s string) string {
+	s = s[strings.LastIndexByte(s, '\n')+1:]
+	if strings.TrimLeft(s, " \t") != "" {
+		return ""
+	}
+	return s
+}
+
+// Format returns the textproto encoding of the message. The root message is
+// formatted without delimiters. Unmodified parts of the syntax tree are
+// formatted exactly as they were parsed, including comments.
+func (m *Message) Format() []byte {
+	return m.appendText(nil)
+}
+
+func (m *Message) appendText(b []byte) []byte {
+	if m.open != 0 {
+		b = append(b, m.open)
+		b = append(b, m.openTrailing...)
+	}
+	for _, f := range m.Fields {
+		b = append(b, f.leading...)
+		b = append(b, f.rawName...)
+		b = append(b, f.sep...)
+		b = f.Value.appendText(b)
+		b = append(b, f.trailing...)
+	}
+	b = append(b, m.closeLeading...)
+	if m.open != 0 {
+		b = append(b, closeDelim(m.open))
+	}
+	return b
+}
+
+func closeDelim(open byte) byte {
+	if open == '<' {
+		return '>'
+	}
+	return '}'
+}
+
+func (l *List) appendText(b []byte) []byte {
+	b = append(b, '[')
+	for i, v := range l.Elems {
+		// Ensure that elements are separated by exactly one comma,
+		// since elements may have been removed or added.
+		before := v.beforeText()
+		hasComma := strings.Contains(stripComments(before), ",")
+		switch {
+		case i == 0 && hasComma:
+			j := strings.IndexByte(stripComments(before), ',')
+			before = before[:j] + before[j+1:]
+		case i > 0 && !hasComma:
+			before = "," + before
+			if before == "," {
+				before = ", "
+			}
+		}
+		b = append(b, before...)
+		b = v.appendText(b)
+	}
+	b = append(b, l.closeLeading...)
+	return append(b, ']')
+}
+
+// stripComments replaces the comments in s with spaces of the same length.
+func stripComments(s string) string {
+	if !strings.Contains(s, "#") {
+		return s
+	}
+	b := []byte(s)
+	for i := 0; i < len(b); i++ {
+		if b[i] == '#' {
+			for ; i < len(b) && b[i] != '\n'; i++ {
+				b[i] = ' '
+			}
+		}
+	}
+	return string(b)
+}
+
+func (s *Scalar) appendText(b []byte) []byte {
+	return append(b, s.raw...)
+}
@@ -0,0 +1,394 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/encoding/prototext/ast"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	pb2 "google.golang.org/protobuf/internal/testprotos/textpb2"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"\n",
+		"# only a comment\n",
+		"opt_string: 'a'",
+		"opt_string:'a'opt_nested{}",
+		"# header\n\n# leading\nopt_string: \"a\" # trailing\n\n# footer\n",
+		"opt_nested <\n  opt_string: \"a\";  # x\n> # y\n",
+		"opt_nested: { # open\n  # inner\n  opt_nested {\n    opt_string: 'b'\n  }\n  # close\n}\n",
+		"rpt_int32: [1, 2 ,3] rpt_string: [\n  # first\n  'a',\n  'b' # last\n]\n",
+		"[pb2.opt_ext_bool]: true\n[ pb2 . opt_ext_string ]: 'x'\n",
+		"1: 1, 2: 2; 3: 3\n",
+		"str_to_nested [{key: 'a'}, {key: 'b' value {}}]",
+	} {
+		root, err := ast.Parse([]byte(in))
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", in, err)
+			continue
+		}
+		if got := string(root.Format()); got != in {
+			t.Errorf("Parse(%q).Format() = %q, want unchanged", in, got)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, in := range []string{
+		"opt_string",
+		"opt_string: ",
+		"opt_nested {",
+		"opt_nested }",
+		"rpt_int32: [1, 2",
+		"rpt_int32: [1,, 2]",
+		"rpt_int32: [",
+		"rpt_int32: [1, }",
+		"# comment\n:",
+	} {
+		if _, err := ast.Parse([]byte(in)); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestPositions(t *testing.T) {
+	root, err := ast.Parse([]byte("# c\nopt_string: 'a'\nopt_nested {\n  opt_string: 'é'\n}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ast.Position{
+		{Offset: 4, Line: 2, Column: 1},
+		{Offset: 16, Line: 2, Column: 13},
+		{Offset: 20, Line: 3, Column: 1},
+		{Offset: 31, Line: 3, Column: 12},
+		{Offset: 35, Line: 4, Column: 3},
+	}
+	nested := root.Fields[1].Value.(*ast.Message)
+	got := []ast.Position{
+		root.Fields[0].Pos(),
+		root.Fields[0].Value.Pos(),
+		root.Fields[1].Pos(),
+		nested.Pos(),
+		nested.Fields[0].Pos(),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("positions mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestComments(t *testing.T) {
+	in := "# file\n\n# a1\n# a2\nopt_string: 'a' # trailing\nopt_nested {\n  #nested\n  opt_string: 'b'\n}\n"
+	root, err := ast.Parse([]byte(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := root.Fields[0]
+	if got, want := f.LeadingComments(), []string{"a1", "a2"}; !cmp.Equal(got, want) {
+		t.Errorf("LeadingComments() = %q, want %q", got, want)
+	}
+	if got, want := f.TrailingComment(), "trailing"; got != want {
+		t.Errorf("TrailingComment() = %q, want %q", got, want)
+	}
+	nested := root.Fields[1].Value.(*ast.Message).Fields[0]
+	if got, want := nested.LeadingComments(), []string{"nested"}; !cmp.Equal(got, want) {
+		t.Errorf("LeadingComments() = %q, want %q", got, want)
+	}
+	if got := root.Fields[1].LeadingComments(); got != nil {
+		t.Errorf("LeadingComments() = %q, want none", got)
+	}
+
+	f.SetLeadingComments([]string{"replaced"})
+	nested.SetLeadingComments(nil)
+	root.Fields[1].SetLeadingComments([]string{"new", ""})
+	want := "# file\n\n# replaced\nopt_string: 'a' # trailing\n# new\n#\nopt_nested {\n  opt_string: 'b'\n}\n"
+	if got := string(root.Format()); got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
+
+func TestScalarValue(t *testing.T) {
+	fds := (&pb2.Scalars{}).ProtoReflect().Descriptor().Fields()
+	enumFd := (&pb2.Enums{}).ProtoReflect().Descriptor().Fields().ByName("opt_enum")
+	tests := []struct {
+		fd   protoreflect.FieldDescriptor
+		in   string
+		want protoreflect.Value
+	}{
+		{fds.ByName("opt_bool"), "t", protoreflect.ValueOfBool(true)},
+		{fds.ByName("opt_int32"), "-0x10", protoreflect.ValueOfInt32(-16)},
+		{fds.ByName("opt_uint64"), "18446744073709551615", protoreflect.ValueOfUint64(18446744073709551615)},
+		{fds.ByName("opt_float"), "1.5f", protoreflect.ValueOfFloat32(1.5)},
+		{fds.ByName("opt_double"), "-inf", protoreflect.ValueOfFloat64(protoreflect.ValueOfFloat64(-1 / zero()).Float())},
+		{fds.ByName("opt_string"), `'a' "b"`, protoreflect.ValueOfString("ab")},
+		{fds.ByName("opt_bytes"), `"\x01"`, protoreflect.ValueOfBytes([]byte{1})},
+		{enumFd, "TEN", protoreflect.ValueOfEnum(10)},
+		{enumFd, "42", protoreflect.ValueOfEnum(42)},
+	}
+	for _, tt := range tests {
+		root, err := ast.Parse([]byte("x: " + tt.in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := root.Fields[0].Value.(*ast.Scalar).Value(tt.fd)
+		if err != nil {
+			t.Errorf("Value(%v) of %q error: %v", tt.fd.Kind(), tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("Value(%v) of %q = %v, want %v", tt.fd.Kind(), tt.in, got, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		fd protoreflect.FieldDescriptor
+		in string
+	}{
+		{fds.ByName("opt_bool"), "1.5"},
+		{fds.ByName("opt_int32"), "4294967296"},
+		{fds.ByName("opt_string"), "foo"},
+		{enumFd, "ELEVEN"},
+	} {
+		root, err := ast.Parse([]byte("x: " + tt.in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := root.Fields[0].Value.(*ast.Scalar).Value(tt.fd); err == nil {
+			t.Errorf("Value(%v) of %q = %v, want error", tt.fd.Kind(), tt.in, got)
+		}
+	}
+}
+
+func zero() float64 { return 0 }
+
+func TestSet(t *testing.T) {
+	scalars := (&pb2.Scalars{}).ProtoReflect().Descriptor().Fields()
+	nests := (&pb2.Nests{}).ProtoReflect().Descriptor().Fields()
+	repeats := (&pb2.Repeats{}).ProtoReflect().Descriptor().Fields()
+	maps := (&pb2.Maps{}).ProtoReflect().Descriptor().Fields()
+
+	list := func(m proto.Message, name protoreflect.Name) protoreflect.Value {
+		return m.ProtoReflect().Get(m.ProtoReflect().Descriptor().Fields().ByName(name))
+	}
+
+	tests := []struct {
+		desc string
+		in   string
+		edit func(*ast.Message)
+		want string
+		msg  proto.Message // if set, the output must unmarshal to this message
+	}{{
+		desc: "replace scalar keeps comments",
+		in:   "# leading\nopt_int32: 1 # trailing\nopt_string: 'a'\n",
+		edit: func(m *ast.Message) {
+			m.Set(scalars.ByName("opt_int32"), protoreflect.ValueOfInt32(30))
+		},
+		want: "# leading\nopt_int32: 30 # trailing\nopt_string: 'a'\n",
+	}, {
+		desc: "replace by field number",
+		in:   "2: 1\n",
+		edit: func(m *ast.Message) {
+			m.Set(scalars.ByName("opt_int32"), protoreflect.ValueOfInt32(2))
+		},
+		want: "2: 2\n",
+	}, {
+		desc: "replace duplicate fields",
+		in:   "opt_string: 'a'\nopt_bool: true\nopt_string: 'b'\n",
+		edit: func(m *ast.Message) {
+			m.Set(scalars.ByName("opt_string"), protoreflect.ValueOfString("c\n"))
+		},
+		want: "opt_string: \"c\\n\"\nopt_bool: true\n",
+	}, {
+		desc: "append scalars",
+		in:   "# header\nopt_bool: true\n",
+		edit: func(m *ast.Message) {
+			m.Set(scalars.ByName("opt_float"), protoreflect.ValueOfFloat32(1.5))
+			m.Set(scalars.ByName("opt_bytes"), protoreflect.ValueOfBytes([]byte{0xff}))
+		},
+		want: "# header\nopt_bool: true\nopt_float: 1.5\nopt_bytes: \"\\xff\"\n",
+		msg:  &pb2.Scalars{OptBool: proto.Bool(true), OptFloat: proto.Float32(1.5), OptBytes: []byte{0xff}},
+	}, {
+		desc: "append to single line",
+		in:   "opt_bool: true opt_int32: 1",
+		edit: func(m *ast.Message) {
+			m.Set(scalars.ByName("opt_string"), protoreflect.ValueOfString("a"))
+		},
+		want: "opt_bool: true opt_int32: 1 opt_string: \"a\"",
+	}, {
+		desc: "append after trailing comment",
+		in:   "opt_bool: true # comment",
+		edit: func(m *ast.Message) {
+			m.Set(scalars.ByName("opt_string"), protoreflect.ValueOfString("a"))
+		},
+		want: "opt_bool: true # comment\nopt_string: \"a\"",
+	}, {
+		desc: "set in empty input",
+		in:   "",
+		edit: func(m *ast.Message) {
+			m.Set(scalars.ByName("opt_int64"), protoreflect.ValueOfInt64(-1))
+		},
+		want: "opt_int64: -1\n",
+	}, {
+		desc: "set in comment-only input",
+		in:   "# comment\n",
+		edit: func(m *ast.Message) {
+			m.Set(scalars.ByName("opt_int64"), protoreflect.ValueOfInt64(-1))
+		},
+		want: "# comment\nopt_int64: -1\n",
+	}, {
+		desc: "set in nested message",
+		in:   "opt_nested {\n  # keep\n  opt_string: 'a'\n}\nOptGroup {}\n",
+		edit: func(m *ast.Message) {
+			nested := m.Lookup(nests.ByName("opt_nested"))[0].Value.(*ast.Message)
+			nested.Set(nests.ByName("opt_nested").Message().Fields().ByName("opt_string"), protoreflect.ValueOfString("b"))
+			group := m.Lookup(nests.ByName("optgroup"))[0].Value.(*ast.Message)
+			group.Set(nests.ByName("optgroup").Message().Fields().ByName("opt_string"), protoreflect.ValueOfString("c"))
+		},
+		want: "opt_nested {\n  # keep\n  opt_string: \"b\"\n}\nOptGroup {\n  opt_string: \"c\"\n}\n",
+	}, {
+		desc: "replace message",
+		in:   "opt_nested { opt_string: 'a' } # old\n",
+		edit: func(m *ast.Message) {
+			m.Set(nests.ByName("opt_nested"), protoreflect.ValueOfMessage((&pb2.Nested{
+				OptString: proto.String("x"),
+				OptNested: &pb2.Nested{},
+			}).ProtoReflect()))
+		},
+		want: "opt_nested {\n  opt_string: \"x\"\n  opt_nested {}\n} # old\n",
+	}, {
+		desc: "replace list elements",
+		in:   "rpt_int32: [1, 2, # two\n  3]\nrpt_int32: 4\n",
+		edit: func(m *ast.Message) {
+			m.Set(repeats.ByName("rpt_int32"), list(&pb2.Repeats{RptInt32: []int32{5, 6}}, "rpt_int32"))
+		},
+		want: "rpt_int32: [5, 6]\n",
+	}, {
+		desc: "append list elements",
+		in:   "rpt_int32: [1] # c\nrpt_string: 'a'\n",
+		edit: func(m *ast.Message) {
+			m.Set(repeats.ByName("rpt_int32"), list(&pb2.Repeats{RptInt32: []int32{1, 2, 3}}, "rpt_int32"))
+			m.Set(repeats.ByName("rpt_string"), list(&pb2.Repeats{RptString: []string{"a", "b"}}, "rpt_string"))
+			m.Set(repeats.ByName("rpt_bool"), list(&pb2.Repeats{RptBool: []bool{true}}, "rpt_bool"))
+		},
+		want: "rpt_int32: [1, 2, 3] # c\nrpt_string: \"a\"\nrpt_string: \"b\"\nrpt_bool: true\n",
+		msg: &pb2.Repeats{
+			RptInt32:  []int32{1, 2, 3},
+			RptString: []string{"a", "b"},
+			RptBool:   []bool{true},
+		},
+	}, {
+		desc: "clear list",
+		in:   "rpt_int32: [1]\nrpt_bool: true\nrpt_int32: 2\n",
+		edit: func(m *ast.Message) {
+			m.Set(repeats.ByName("rpt_int32"), list(&pb2.Repeats{}, "rpt_int32"))
+		},
+		want: "rpt_bool: true\n",
+	}, {
+		desc: "set map",
+		in:   "int32_to_str { key: 1 value: 'a' } # one\nint32_to_str { key: 2 value: 'b' }\n",
+		edit: func(m *ast.Message) {
+			m.Set(maps.ByName("int32_to_str"), list(&pb2.Maps{
+				Int32ToStr: map[int32]string{1: "x", 3: "z", 4: "w"},
+			}, "int32_to_str"))
+		},
+		want: "int32_to_str { key: 1 value: \"x\" } # one\nint32_to_str {\n  key: 3\n  value: \"z\"\n}\nint32_to_str {\n  key: 4\n  value: \"w\"\n}\n",
+		msg: &pb2.Maps{
+			Int32ToStr: map[int32]string{1: "x", 3: "z", 4: "w"},
+		},
+	}, {
+		desc: "set map of messages",
+		in:   "str_to_nested: [{key: 'a'}, {key: 'b' value {}}]\n",
+		edit: func(m *ast.Message) {
+			m.Set(maps.ByName("str_to_nested"), list(&pb2.Maps{
+				StrToNested: map[string]*pb2.Nested{"a": {OptString: proto.String("x")}},
+			}, "str_to_nested"))
+		},
+		want: "str_to_nested: [{key: 'a' value {opt_string: \"x\"}}]\n",
+		msg: &pb2.Maps{
+			StrToNested: map[string]*pb2.Nested{"a": {OptString: proto.String("x")}},
+		},
+	}, {
+		desc: "set extension",
+		in:   "[pb2.opt_ext_bool]: false\n",
+		edit: func(m *ast.Message) {
+			m.Set(pb2.E_OptExtBool.TypeDescriptor(), protoreflect.ValueOfBool(true))
+			m.Set(pb2.E_OptExtString.TypeDescriptor(), protoreflect.ValueOfString("s"))
+		},
+		want: "[pb2.opt_ext_bool]: true\n[pb2.opt_ext_string]: \"s\"\n",
+	}, {
+		desc: "set enum",
+		in:   "",
+		edit: func(m *ast.Message) {
+			fds := (&pb2.Enums{}).ProtoReflect().Descriptor().Fields()
+			m.Set(fds.ByName("opt_enum"), protoreflect.ValueOfEnum(pb2.Enum_TEN.Number()))
+			m.Set(fds.ByName("rpt_enum"), list(&pb2.Enums{RptEnum: []pb2.Enum{pb2.Enum_ONE, 42}}, "rpt_enum"))
+		},
+		want: "opt_enum: TEN\nrpt_enum: ONE\nrpt_enum: 42\n",
+	}, {
+		desc: "clear",
+		in:   "opt_bool: true\n# about int32\nopt_int32: 1 # trailing\nopt_string: 'a'\n2: 3\n",
+		edit: func(m *ast.Message) {
+			m.Clear(scalars.ByName("opt_int32"))
+		},
+		want: "opt_bool: true\nopt_string: 'a'\n",
+	}, {
+		desc: "clear first field",
+		in:   "# file\n\n# about bool\nopt_bool: true\nopt_int32: 1\n",
+		edit: func(m *ast.Message) {
+			m.Clear(scalars.ByName("opt_bool"))
+		},
+		want: "# file\n\nopt_int32: 1\n",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			root, err := ast.Parse([]byte(tt.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.edit(root)
+			got := string(root.Format())
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Format() mismatch (-want +got):\n%s", diff)
+			}
+			if _, err := ast.Parse([]byte(got)); err != nil {
+				t.Errorf("Parse(Format()) error: %v", err)
+			}
+			if tt.msg != nil {
+				m := tt.msg.ProtoReflect().New().Interface()
+				if err := prototext.Unmarshal([]byte(got), m); err != nil {
+					t.Fatalf("Unmarshal error: %v", err)
+				}
+				if diff := cmp.Diff(tt.msg, m, protocmp.Transform()); diff != "" {
+					t.Errorf("Unmarshal mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	root, err := ast.Parse([]byte("a: 1\nb: 2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := root.Fields[0]
+	if !root.Remove(f) {
+		t.Errorf("Remove() = false, want true")
+	}
+	if root.Remove(f) {
+		t.Errorf("Remove() of removed field = true, want false")
+	}
+	if got, want := string(root.Format()), "b: 2\n"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,475 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ast
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/internal/encoding/text"
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/order"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Value returns the value of the scalar as a value of the field fd,
+// which must not be a message field. For list and map fields,
+// fd.Kind determines the kind of the value.
+func (s *Scalar) Value(fd protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+	d := text.NewDecoder([]byte("v: " + s.raw))
+	d.Read()
+	tok, err := d.Read()
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+	if tok.Kind() == text.Scalar {
+		switch kind := fd.Kind(); kind {
+		case protoreflect.BoolKind:
+			if b, ok := tok.Bool(); ok {
+				return protoreflect.ValueOfBool(b), nil
+			}
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+			if n, ok := tok.Int32(); ok {
+				return protoreflect.ValueOfInt32(n), nil
+			}
+		case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			if n, ok := tok.Int64(); ok {
+				return protoreflect.ValueOfInt64(n), nil
+			}
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+			if n, ok := tok.Uint32(); ok {
+				return protoreflect.ValueOfUint32(n), nil
+			}
+		case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			if n, ok := tok.Uint64(); ok {
+				return protoreflect.ValueOfUint64(n), nil
+			}
+		case protoreflect.FloatKind:
+			if n, ok := tok.Float32(); ok {
+				return protoreflect.ValueOfFloat32(n), nil
+			}
+		case protoreflect.DoubleKind:
+			if n, ok := tok.Float64(); ok {
+				return protoreflect.ValueOfFloat64(n), nil
+			}
+		case protoreflect.StringKind:
+			if s, ok := tok.String(); ok {
+				return protoreflect.ValueOfString(s), nil
+			}
+		case protoreflect.BytesKind:
+			if b, ok := tok.String(); ok {
+				return protoreflect.ValueOfBytes([]byte(b)), nil
+			}
+		case protoreflect.EnumKind:
+			if lit, ok := tok.Enum(); ok {
+				if ev := fd.Enum().Values().ByName(protoreflect.Name(lit)); ev != nil {
+					return protoreflect.ValueOfEnum(ev.Number()), nil
+				}
+			}
+			if n, ok := tok.Int32(); ok {
+				return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+			}
+		}
+	}
+	return protoreflect.Value{}, errors.New("invalid value for %v type: %v", fd.Kind(), s.raw)
+}
+
+// Lookup returns the fields of m that populate the field fd.
+// A field matches fd if its name is the text name of fd, the field number
+// of fd, or the full name of fd in brackets if fd is an extension.
+func (m *Message) Lookup(fd protoreflect.FieldDescriptor) []*Field {
+	var out []*Field
+	for _, f := range m.Fields {
+		if matches(f, fd) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func matches(f *Field, fd protoreflect.FieldDescriptor) bool {
+	switch {
+	case fd.IsExtension():
+		return f.Name == "["+string(fd.FullName())+"]"
+	case f.Name == fd.TextName():
+		return true
+	default:
+		return f.Name == strconv.Itoa(int(fd.Number()))
+	}
+}
+
+// Remove removes the field f from m. It reports whether f was a field of m.
+// The comments that precede or follow the field on the same line
+// are removed with it.
+func (m *Message) Remove(f *Field) bool {
+	for i, x := range m.Fields {
+		if x == f {
+			if i == 0 && m.open == 0 && len(m.Fields) > 1 {
+				// The first field of the root message has no preceding line,
+				// so the next field takes over any text before the comment
+				// block of the removed field, such as a file header.
+				lines := strings.Split(f.leading, "\n")
+				keep := strings.Join(lines[:commentBlockStart(lines)], "\n")
+				if keep != "" {
+					keep += "\n"
+				}
+				next := m.Fields[1]
+				next.leading = keep + strings.TrimPrefix(next.leading, "\n")
+			}
+			m.Fields = append(m.Fields[:i:i], m.Fields[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Clear removes all fields of m that populate the field fd.
+func (m *Message) Clear(fd protoreflect.FieldDescriptor) {
+	for _, f := range m.Lookup(fd) {
+		m.Remove(f)
+	}
+}
+
+// Set sets the field fd of m to the value v, which must be valid for fd
+// as for [protoreflect.Message.Set].
+//
+// Existing values of the field are replaced in place, keeping their comments.
+// For a list field, the elements of v replace the existing elements in order,
+// surplus existing elements are removed and additional elements are appended
+// after the last existing element. For a map field, the values of existing
+// entries are replaced, entries with keys that are not in v are removed and
+// entries for new keys are appended in key order. New fields are appended to
+// the end of the message.
+// A message value replaces the entire existing message, including
+// any comments within it.
+func (m *Message) Set(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	switch {
+	case fd.IsList():
+		m.setList(fd, v.List())
+	case fd.IsMap():
+		m.setMap(fd, v.Map())
+	default:
+		fields := m.Lookup(fd)
+		if len(fields) == 0 {
+			m.appendField(fd, v)
+			return
+		}
+		// Only the last occurrence of a singular field takes effect
+		// when the textproto is unmarshaled, but keep the first one
+		// since it likely carries the relevant comments.
+		f := fields[0]
+		f.Value = m.newValue(fd, v)
+		f.setSep(f.Value)
+		for _, f := range fields[1:] {
+			m.Remove(f)
+		}
+	}
+}
+
+// slot is an element of a list field, which is either the value of a field
+// or an element within the list value of a field.
+type slot struct {
+	field *Field
+	list  *List
+	index int
+}
+
+func (s slot) get() Value {
+	if s.list != nil {
+		return s.list.Elems[s.index]
+	}
+	return s.field.Value
+}
+
+func (s slot) set(v Value) {
+	if s.list != nil {
+		v.setBefore(s.list.Elems[s.index].beforeText())
+		s.list.Elems[s.index] = v
+		return
+	}
+	s.field.setSep(v)
+	s.field.Value = v
+}
+
+func (m *Message) slots(fd protoreflect.FieldDescriptor) []slot {
+	var out []slot
+	for _, f := range m.Lookup(fd) {
+		if l, ok := f.Value.(*List); ok {
+			for i := range l.Elems {
+				out = append(out, slot{field: f, list: l, index: i})
+			}
+			continue
+		}
+		out = append(out, slot{field: f})
+	}
+	return out
+}
+
+// removeSlots removes the elements in slots from m.
+func (m *Message) removeSlots(slots []slot) {
+	for i := len(slots) - 1; i >= 0; i-- {
+		s := slots[i]
+		switch {
+		case s.list == nil:
+			m.Remove(s.field)
+		default:
+			s.list.Elems = append(s.list.Elems[:s.index:s.index], s.list.Elems[s.index+1:]...)
+			if len(s.list.Elems) == 0 {
+				m.Remove(s.field)
+			}
+		}
+	}
+}
+
+// appendSlot appends the value v of fd after the slot last,
+// or to the end of the message if last is the zero slot.
+func (m *Message) appendSlot(fd protoreflect.FieldDescriptor, last slot, v protoreflect.Value) slot {
+	if last.list != nil {
+		elem := m.newValue(fd, v)
+		last.list.Elems = append(last.list.Elems, elem)
+		return slot{field: last.field, list: last.list, index: len(last.list.Elems) - 1}
+	}
+	f := m.newField(fd, v)
+	m.insertAfter(last.field, f)
+	return slot{field: f}
+}
+
+func (m *Message) setList(fd protoreflect.FieldDescriptor, l protoreflect.List) {
+	slots := m.slots(fd)
+	var last slot
+	for i := 0; i < l.Len(); i++ {
+		if i < len(slots) {
+			slots[i].set(m.newValue(fd, l.Get(i)))
+			last = slots[i]
+			continue
+		}
+		last = m.appendSlot(fd, last, l.Get(i))
+	}
+	if l.Len() < len(slots) {
+		m.removeSlots(slots[l.Len():])
+	}
+}
+
+func (m *Message) setMap(fd protoreflect.FieldDescriptor, mm protoreflect.Map) {
+	keyFd, valFd := fd.MapKey(), fd.MapValue()
+	seen := make(map[any]bool)
+	var last slot
+	var removed []slot
+	for _, s := range m.slots(fd) {
+		last = s
+		entry, ok := s.get().(*Message)
+		if !ok {
+			removed = append(removed, s)
+			continue
+		}
+		k := keyFd.Default()
+		if keys := entry.Lookup(keyFd); len(keys) > 0 {
+			sc, ok := keys[len(keys)-1].Value.(*Scalar)
+			if !ok {
+				removed = append(removed, s)
+				continue
+			}
+			var err error
+			if k, err = sc.Value(keyFd); err != nil {
+				removed = append(removed, s)
+				continue
+			}
+		}
+		mk := k.MapKey()
+		if !mm.Has(mk) || seen[mk.Interface()] {
+			removed = append(removed, s)
+			continue
+		}
+		seen[mk.Interface()] = true
+		entry.Set(valFd, mm.Get(mk))
+	}
+	order.RangeEntries(mm, order.GenericKeyOrder, func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		if !seen[k.Interface()] {
+			last = m.appendSlot(fd, last, mapEntryValue(fd, k, v))
+		}
+		return true
+	})
+	m.removeSlots(removed)
+}
+
+// mapEntryValue returns the map entry message for the key k and value v
+// of the map field fd.
+func mapEntryValue(fd protoreflect.FieldDescriptor, k protoreflect.MapKey, v protoreflect.Value) protoreflect.Value {
+	entry := dynamicpb.NewMessage(fd.Message())
+	entry.Set(fd.MapKey(), k.Value())
+	entry.Set(fd.MapValue(), v)
+	return protoreflect.ValueOfMessage(entry)
+}
+
+// fieldIndent returns the indentation of the fields of m.
+func (m *Message) fieldIndent() string {
+	for i := len(m.Fields) - 1; i >= 0; i-- {
+		if strings.Contains(m.Fields[i].leading, "\n") {
+			return lastLine(m.Fields[i].leading)
+		}
+	}
+	return m.indent
+}
+
+// appendField appends a new field for fd with the value v.
+func (m *Message) appendField(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	var last *Field
+	if len(m.Fields) > 0 {
+		last = m.Fields[len(m.Fields)-1]
+	}
+	m.insertAfter(last, m.newField(fd, v))
+}
+
+// insertAfter inserts the new field f after the field prev,
+// or at the end of the message if prev is nil.
+func (m *Message) insertAfter(prev *Field, f *Field) {
+	i := len(m.Fields)
+	if prev != nil {
+		for j, x := range m.Fields {
+			if x == prev {
+				i = j + 1
+			}
+		}
+	} else if len(m.Fields) > 0 {
+		prev = m.Fields[len(m.Fields)-1]
+	}
+
+	switch {
+	case prev != nil && !strings.Contains(prev.trailing, "#") && !m.multiline() && (m.open != 0 || len(m.Fields) > 1):
+		// Fields on a single line.
+		f.leading = " "
+		if strings.HasSuffix(prev.trailing, " ") {
+			f.leading = ""
+		}
+	case prev != nil:
+		f.leading = "\n" + m.fieldIndent()
+	case m.open == 0:
+		// The first field of the root message takes over
+		// any comments in an otherwise empty input.
+		f.leading, m.closeLeading = m.closeLeading, "\n"
+		if f.leading != "" && !strings.HasSuffix(f.leading, "\n") {
+			f.leading += "\n"
+		}
+	default:
+		f.leading = "\n" + m.indent
+		if !strings.Contains(m.closeLeading, "\n") {
+			m.closeLeading = "\n" + strings.TrimSuffix(m.indent, "  ")
+		}
+	}
+	m.Fields = append(m.Fields[:i:i], append([]*Field{f}, m.Fields[i:]...)...)
+}
+
+// newField returns a new field for fd with the value v.
+// The leading text of the field is set when it is inserted.
+func (m *Message) newField(fd protoreflect.FieldDescriptor, v protoreflect.Value) *Field {
+	name := fd.TextName()
+	if fd.IsExtension() {
+		name = "[" + string(fd.FullName()) + "]"
+	}
+	f := &Field{Name: name, rawName: name}
+	f.Value = m.newValue(fd, v)
+	f.setSep(f.Value)
+	return f
+}
+
+// setSep ensures that the separator of the field is valid for the value v.
+func (f *Field) setSep(v Value) {
+	_, isMessage := v.(*Message)
+	switch {
+	case f.sep == "" && isMessage:
+		f.sep = " "
+	case f.sep == "" || (!isMessage && !strings.Contains(stripComments(f.sep), ":")):
+		f.sep = ": "
+	}
+}
+
+// newValue returns the syntax tree of a singular value v of the field fd
+// for a field of m. Messages are formatted on a single line if the fields
+// of m are on a single line.
+func (m *Message) newValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) Value {
+	if fd.Message() == nil {
+		return &Scalar{raw: formatScalar(fd, v)}
+	}
+
+	indent := m.fieldIndent()
+	compact := m.open != 0 && len(m.Fields) > 0 && !m.multiline()
+	nm := &Message{open: '{', indent: indent + "  "}
+	add := func(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+		f := nm.newField(fd, v)
+		switch {
+		case !compact:
+			f.leading = "\n" + nm.indent
+		case len(nm.Fields) > 0:
+			f.leading = " "
+		}
+		nm.Fields = append(nm.Fields, f)
+	}
+	order.RangeFields(v.Message(), order.IndexNameFieldOrder, func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			l := v.List()
+			for i := 0; i < l.Len(); i++ {
+				add(fd, l.Get(i))
+			}
+		case fd.IsMap():
+			order.RangeEntries(v.Map(), order.GenericKeyOrder, func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				add(fd, mapEntryValue(fd, k, v))
+				return true
+			})
+		default:
+			add(fd, v)
+		}
+		return true
+	})
+	if len(nm.Fields) > 0 && !compact {
+		nm.closeLeading = "\n" + indent
+	}
+	return nm
+}
+
+// multiline reports whether the fields of m are on separate lines.
+func (m *Message) multiline() bool {
+	for _, f := range m.Fields {
+		if strings.Contains(f.leading, "\n") {
+			return true
+		}
+	}
+	return strings.Contains(m.closeLeading, "\n")
+}
+
+func formatScalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch kind := fd.Kind(); kind {
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(v.Bool())
+	case protoreflect.StringKind:
+		return string(text.AppendString(nil, v.String()))
+	case protoreflect.BytesKind:
+		return string(text.AppendString(nil, string(v.Bytes())))
+	case protoreflect.Int32Kind, protoreflect.Int64Kind,
+		protoreflect.Sint32Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(v.Int(), 10)
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind,
+		protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return strconv.FormatUint(v.Uint(), 10)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		bitSize := 64
+		if kind == protoreflect.FloatKind {
+			bitSize = 32
+		}
+		e, _ := text.NewEncoder(nil, "", [2]byte{'{', '}'}, false)
+		e.WriteFloat(v.Float(), bitSize)
+		return string(e.Bytes())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return strconv.FormatInt(int64(v.Enum()), 10)
+	default:
+		panic(fmt.Sprintf("invalid scalar kind %v", kind))
+	}
+}
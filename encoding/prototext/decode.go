@@ -13,6 +13,7 @@ import (
 	"google.golang.org/protobuf/internal/errors"
 	"google.golang.org/protobuf/internal/flags"
 	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/internal/pathfmt"
 	"google.golang.org/protobuf/internal/pragma"
 	"google.golang.org/protobuf/internal/set"
 	"google.golang.org/protobuf/internal/strs"
@@ -54,6 +55,7 @@ type UnmarshalOptions struct {
 // Unmarshal reads the given []byte and populates the given [proto.Message]
 // using options in the UnmarshalOptions object.
 // The provided message must be mutable (e.g., a non-nil pointer to a message).
+// Errors in the input are reported as a [*SyntaxError].
 func (o UnmarshalOptions) Unmarshal(b []byte, m proto.Message) error {
 	return o.unmarshal(b, m)
 }
@@ -68,9 +70,10 @@ func (o UnmarshalOptions) unmarshal(b []byte, m proto.Message) error {
 		o.Resolver = protoregistry.GlobalTypes
 	}
 
-	dec := decoder{text.NewDecoder(b), o}
+	dec := decoder{text.NewDecoder(b), o, new(pathfmt.Path)}
+	dec.path.Root(m.ProtoReflect().Descriptor())
 	if err := dec.unmarshalMessage(m.ProtoReflect(), false); err != nil {
-		return err
+		return dec.wrapError(err)
 	}
 	if o.AllowPartial {
 		return nil
@@ -78,16 +81,87 @@ func (o UnmarshalOptions) unmarshal(b []byte, m proto.Message) error {
 	return proto.CheckInitialized(m)
 }
 
+// SyntaxError is the error returned by Unmarshal for input that is malformed
+// or does not match the message being unmarshaled.
+// It reports the position of the error in the input and the path to the
+// value that was being decoded.
+type SyntaxError struct {
+	// Offset is the byte offset of the error in the input, starting at 0.
+	Offset int
+	// Line and Column are the line and column of the error in the input,
+	// starting at 1. The column is counted in characters.
+	Line, Column int
+
+	// Path is the path to the value being decoded in the syntax of
+	// protopath.Path, such as "(pkg.Message).field[2].nested".
+	// It can be converted to a protopath.Path with protopath.Parse.
+	// For an unknown field, it is the path to the message containing it.
+	Path string
+
+	// Category is the category of the error.
+	Category ErrorCategory
+
+	err error
+}
+
+func (e *SyntaxError) Error() string { return e.err.Error() }
+
+// Unwrap returns the underlying error, which matches [proto.Error].
+func (e *SyntaxError) Unwrap() error { return e.err }
+
+// ErrorCategory is the category of a [SyntaxError].
+type ErrorCategory int
+
+const (
+	_ ErrorCategory = iota
+	// CategorySyntax is an error in the textproto syntax,
+	// including input that ends unexpectedly.
+	CategorySyntax
+	// CategoryUnknownField is a field name or number that does not
+	// identify a field of the message.
+	CategoryUnknownField
+	// CategoryDuplicateField is a non-repeated field, oneof or map entry
+	// field that is set more than once.
+	CategoryDuplicateField
+	// CategoryInvalidValue is a value that is not valid for the type
+	// of the field.
+	CategoryInvalidValue
+	// CategoryUnresolvable is an extension or google.protobuf.Any type
+	// that cannot be resolved.
+	CategoryUnresolvable
+)
+
+func (c ErrorCategory) String() string {
+	switch c {
+	case CategorySyntax:
+		return "syntax"
+	case CategoryUnknownField:
+		return "unknown field"
+	case CategoryDuplicateField:
+		return "duplicate field"
+	case CategoryInvalidValue:
+		return "invalid value"
+	case CategoryUnresolvable:
+		return "unresolvable"
+	default:
+		return fmt.Sprintf("<unknown:%d>", int(c))
+	}
+}
+
 type decoder struct {
 	*text.Decoder
 	opts UnmarshalOptions
+	// path is the path to the value being decoded. It is only truncated
+	// after a value is successfully decoded, such that it points to the
+	// value that failed to decode when an error is returned.
+	path *pathfmt.Path
 }
 
 // newError returns an error object with position info.
-func (d decoder) newError(pos int, f string, x ...any) error {
+func (d decoder) newError(pos int, c ErrorCategory, f string, x ...any) error {
 	line, column := d.Position(pos)
 	head := fmt.Sprintf("(line %d:%d): ", line, column)
-	return errors.New(head+f, x...)
+	return d.newSyntaxError(pos, c, errors.New(head+f, x...))
 }
 
 // unexpectedTokenError returns a syntax error for the given unexpected token.
@@ -99,7 +173,32 @@ func (d decoder) unexpectedTokenError(tok text.Token) error {
 func (d decoder) syntaxError(pos int, f string, x ...any) error {
 	line, column := d.Position(pos)
 	head := fmt.Sprintf("syntax error (line %d:%d): ", line, column)
-	return errors.New(head+f, x...)
+	return d.newSyntaxError(pos, CategorySyntax, errors.New(head+f, x...))
+}
+
+func (d decoder) newSyntaxError(pos int, c ErrorCategory, err error) *SyntaxError {
+	line, column := d.Position(pos)
+	return &SyntaxError{
+		Offset:   pos,
+		Line:     line,
+		Column:   column,
+		Path:     d.path.String(),
+		Category: c,
+		err:      err,
+	}
+}
+
+// wrapError converts errors from the underlying text decoder
+// into a *SyntaxError.
+func (d decoder) wrapError(err error) error {
+	switch e := err.(type) {
+	case *text.SyntaxError:
+		return d.newSyntaxError(e.Pos, CategorySyntax, e.Unwrap())
+	}
+	if err == text.ErrUnexpectedEOF {
+		return d.newSyntaxError(d.Offset(), CategorySyntax, err)
+	}
+	return err
 }
 
 // unmarshalMessage unmarshals into the given protoreflect.Message.
@@ -169,7 +268,7 @@ func (d decoder) unmarshalMessage(m protoreflect.Message, checkDelims bool) erro
 			isFieldNumberName = true
 			num := protoreflect.FieldNumber(tok.FieldNumber())
 			if !num.IsValid() {
-				return d.newError(tok.Pos(), "invalid field number: %d", num)
+				return d.newError(tok.Pos(), CategoryUnknownField, "invalid field number: %d", num)
 			}
 			fd = fieldDescs.ByNumber(num)
 			if fd == nil {
@@ -180,10 +279,10 @@ func (d decoder) unmarshalMessage(m protoreflect.Message, checkDelims bool) erro
 		if xt != nil {
 			fd = xt.TypeDescriptor()
 			if !messageDesc.ExtensionRanges().Has(fd.Number()) || fd.ContainingMessage().FullName() != messageDesc.FullName() {
-				return d.newError(tok.Pos(), "message %v cannot be extended by %v", messageDesc.FullName(), fd.FullName())
+				return d.newError(tok.Pos(), CategoryUnknownField, "message %v cannot be extended by %v", messageDesc.FullName(), fd.FullName())
 			}
 		} else if xtErr != nil && xtErr != protoregistry.NotFound {
-			return d.newError(tok.Pos(), "unable to resolve [%s]: %v", tok.RawString(), xtErr)
+			return d.newError(tok.Pos(), CategoryUnresolvable, "unable to resolve [%s]: %v", tok.RawString(), xtErr)
 		}
 		if flags.ProtoLegacy {
 			if fd != nil && fd.IsWeak() && fd.Message().IsPlaceholder() {
@@ -197,7 +296,7 @@ func (d decoder) unmarshalMessage(m protoreflect.Message, checkDelims bool) erro
 				d.skipValue()
 				continue
 			}
-			return d.newError(tok.Pos(), "unknown field: %v", tok.RawString())
+			return d.newError(tok.Pos(), CategoryUnknownField, "unknown field: %v", tok.RawString())
 		}
 
 		// Handle fields identified by field number.
@@ -209,9 +308,11 @@ func (d decoder) unmarshalMessage(m protoreflect.Message, checkDelims bool) erro
 			// best-effort textual representation of the field value.  In that case,
 			// it may not be possible to unmarshal the value from a parser that does
 			// have information about the unknown field.
-			return d.newError(tok.Pos(), "cannot specify field by number: %v", tok.RawString())
+			return d.newError(tok.Pos(), CategoryUnknownField, "cannot specify field by number: %v", tok.RawString())
 		}
 
+		n := d.path.Len()
+		d.path.Field(fd)
 		switch {
 		case fd.IsList():
 			kind := fd.Kind()
@@ -240,14 +341,14 @@ func (d decoder) unmarshalMessage(m protoreflect.Message, checkDelims bool) erro
 			if od := fd.ContainingOneof(); od != nil {
 				idx := uint64(od.Index())
 				if seenOneofs.Has(idx) {
-					return d.newError(tok.Pos(), "error parsing %q, oneof %v is already set", tok.RawString(), od.FullName())
+					return d.newError(tok.Pos(), CategoryDuplicateField, "error parsing %q, oneof %v is already set", tok.RawString(), od.FullName())
 				}
 				seenOneofs.Set(idx)
 			}
 
 			num := uint64(fd.Number())
 			if seenNums.Has(num) {
-				return d.newError(tok.Pos(), "non-repeated field %q is repeated", tok.RawString())
+				return d.newError(tok.Pos(), CategoryDuplicateField, "non-repeated field %q is repeated", tok.RawString())
 			}
 
 			if err := d.unmarshalSingular(fd, m); err != nil {
@@ -255,6 +356,7 @@ func (d decoder) unmarshalMessage(m protoreflect.Message, checkDelims bool) erro
 			}
 			seenNums.Set(num)
 		}
+		d.path.Truncate(n)
 	}
 
 	return nil
@@ -330,7 +432,7 @@ func (d decoder) unmarshalScalar(fd protoreflect.FieldDescriptor) (protoreflect.
 	case protoreflect.StringKind:
 		if s, ok := tok.String(); ok {
 			if strs.EnforceUTF8(fd) && !utf8.ValidString(s) {
-				return protoreflect.Value{}, d.newError(tok.Pos(), "contains invalid UTF-8")
+				return protoreflect.Value{}, d.newError(tok.Pos(), CategoryInvalidValue, "contains invalid UTF-8")
 			}
 			return protoreflect.ValueOfString(s), nil
 		}
@@ -355,7 +457,7 @@ func (d decoder) unmarshalScalar(fd protoreflect.FieldDescriptor) (protoreflect.
 		panic(fmt.Sprintf("invalid scalar kind %v", kind))
 	}
 
-	return protoreflect.Value{}, d.newError(tok.Pos(), "invalid value for %v type: %v", kind, tok.RawString())
+	return protoreflect.Value{}, d.newError(tok.Pos(), CategoryInvalidValue, "invalid value for %v type: %v", kind, tok.RawString())
 }
 
 // unmarshalList unmarshals into given protoreflect.List. A list value can
@@ -382,23 +484,16 @@ func (d decoder) unmarshalList(fd protoreflect.FieldDescriptor, list protoreflec
 					d.Read()
 					return nil
 				case text.MessageOpen:
-					pval := list.NewElement()
-					if err := d.unmarshalMessage(pval.Message(), true); err != nil {
+					if err := d.unmarshalListElement(fd, list); err != nil {
 						return err
 					}
-					list.Append(pval)
 				default:
 					return d.unexpectedTokenError(tok)
 				}
 			}
 
 		case text.MessageOpen:
-			pval := list.NewElement()
-			if err := d.unmarshalMessage(pval.Message(), true); err != nil {
-				return err
-			}
-			list.Append(pval)
-			return nil
+			return d.unmarshalListElement(fd, list)
 		}
 
 	default:
@@ -416,29 +511,44 @@ func (d decoder) unmarshalList(fd protoreflect.FieldDescriptor, list protoreflec
 					d.Read()
 					return nil
 				case text.Scalar:
-					pval, err := d.unmarshalScalar(fd)
-					if err != nil {
+					if err := d.unmarshalListElement(fd, list); err != nil {
 						return err
 					}
-					list.Append(pval)
 				default:
 					return d.unexpectedTokenError(tok)
 				}
 			}
 
 		case text.Scalar:
-			pval, err := d.unmarshalScalar(fd)
-			if err != nil {
-				return err
-			}
-			list.Append(pval)
-			return nil
+			return d.unmarshalListElement(fd, list)
 		}
 	}
 
 	return d.unexpectedTokenError(tok)
 }
 
+// unmarshalListElement unmarshals a single list element and appends it to
+// the given protoreflect.List.
+func (d decoder) unmarshalListElement(fd protoreflect.FieldDescriptor, list protoreflect.List) error {
+	n := d.path.Len()
+	d.path.ListIndex(list.Len())
+	var val protoreflect.Value
+	var err error
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		val = list.NewElement()
+		err = d.unmarshalMessage(val.Message(), true)
+	default:
+		val, err = d.unmarshalScalar(fd)
+	}
+	if err != nil {
+		return err
+	}
+	list.Append(val)
+	d.path.Truncate(n)
+	return nil
+}
+
 // unmarshalMap unmarshals into given protoreflect.Map. A map value is a
 // textproto message containing {key: <kvalue>, value: <mvalue>}.
 func (d decoder) unmarshalMap(fd protoreflect.FieldDescriptor, mmap protoreflect.Map) error {
@@ -508,7 +618,7 @@ Loop:
 		case text.Name:
 			if tok.NameKind() != text.IdentName {
 				if !d.opts.DiscardUnknown {
-					return d.newError(tok.Pos(), "unknown map entry field %q", tok.RawString())
+					return d.newError(tok.Pos(), CategoryUnknownField, "unknown map entry field %q", tok.RawString())
 				}
 				d.skipValue()
 				continue Loop
@@ -526,7 +636,7 @@ Loop:
 				return d.syntaxError(tok.Pos(), "missing field separator :")
 			}
 			if key.IsValid() {
-				return d.newError(tok.Pos(), "map entry %q cannot be repeated", name)
+				return d.newError(tok.Pos(), CategoryDuplicateField, "map entry %q cannot be repeated", name)
 			}
 			val, err := d.unmarshalScalar(fd.MapKey())
 			if err != nil {
@@ -541,16 +651,21 @@ Loop:
 				}
 			}
 			if pval.IsValid() {
-				return d.newError(tok.Pos(), "map entry %q cannot be repeated", name)
+				return d.newError(tok.Pos(), CategoryDuplicateField, "map entry %q cannot be repeated", name)
+			}
+			n := d.path.Len()
+			if key.IsValid() {
+				d.path.MapIndex(key)
 			}
 			pval, err = unmarshalMapValue()
 			if err != nil {
 				return err
 			}
+			d.path.Truncate(n)
 
 		default:
 			if !d.opts.DiscardUnknown {
-				return d.newError(tok.Pos(), "unknown map entry field %q", name)
+				return d.newError(tok.Pos(), CategoryUnknownField, "unknown map entry field %q", name)
 			}
 			d.skipValue()
 		}
@@ -622,10 +737,10 @@ Loop:
 			switch name := protoreflect.Name(tok.IdentName()); name {
 			case genid.Any_TypeUrl_field_name:
 				if seenTypeUrl {
-					return d.newError(tok.Pos(), "duplicate %v field", genid.Any_TypeUrl_field_fullname)
+					return d.newError(tok.Pos(), CategoryDuplicateField, "duplicate %v field", genid.Any_TypeUrl_field_fullname)
 				}
 				if isExpanded {
-					return d.newError(tok.Pos(), "conflict with [%s] field", typeURL)
+					return d.newError(tok.Pos(), CategoryDuplicateField, "conflict with [%s] field", typeURL)
 				}
 				tok, err := d.Read()
 				if err != nil {
@@ -634,16 +749,16 @@ Loop:
 				var ok bool
 				typeURL, ok = tok.String()
 				if !ok {
-					return d.newError(tok.Pos(), "invalid %v field value: %v", genid.Any_TypeUrl_field_fullname, tok.RawString())
+					return d.newError(tok.Pos(), CategoryInvalidValue, "invalid %v field value: %v", genid.Any_TypeUrl_field_fullname, tok.RawString())
 				}
 				seenTypeUrl = true
 
 			case genid.Any_Value_field_name:
 				if seenValue {
-					return d.newError(tok.Pos(), "duplicate %v field", genid.Any_Value_field_fullname)
+					return d.newError(tok.Pos(), CategoryDuplicateField, "duplicate %v field", genid.Any_Value_field_fullname)
 				}
 				if isExpanded {
-					return d.newError(tok.Pos(), "conflict with [%s] field", typeURL)
+					return d.newError(tok.Pos(), CategoryDuplicateField, "conflict with [%s] field", typeURL)
 				}
 				tok, err := d.Read()
 				if err != nil {
@@ -651,23 +766,23 @@ Loop:
 				}
 				s, ok := tok.String()
 				if !ok {
-					return d.newError(tok.Pos(), "invalid %v field value: %v", genid.Any_Value_field_fullname, tok.RawString())
+					return d.newError(tok.Pos(), CategoryInvalidValue, "invalid %v field value: %v", genid.Any_Value_field_fullname, tok.RawString())
 				}
 				bValue = []byte(s)
 				seenValue = true
 
 			default:
 				if !d.opts.DiscardUnknown {
-					return d.newError(tok.Pos(), "invalid field name %q in %v message", tok.RawString(), genid.Any_message_fullname)
+					return d.newError(tok.Pos(), CategoryUnknownField, "invalid field name %q in %v message", tok.RawString(), genid.Any_message_fullname)
 				}
 			}
 
 		case text.TypeName:
 			if isExpanded {
-				return d.newError(tok.Pos(), "cannot have more than one type")
+				return d.newError(tok.Pos(), CategoryDuplicateField, "cannot have more than one type")
 			}
 			if seenTypeUrl {
-				return d.newError(tok.Pos(), "conflict with type_url field")
+				return d.newError(tok.Pos(), CategoryDuplicateField, "conflict with type_url field")
 			}
 			typeURL = tok.TypeName()
 			var err error
@@ -679,7 +794,7 @@ Loop:
 
 		default:
 			if !d.opts.DiscardUnknown {
-				return d.newError(tok.Pos(), "invalid field name %q in %v message", tok.RawString(), genid.Any_message_fullname)
+				return d.newError(tok.Pos(), CategoryUnknownField, "invalid field name %q in %v message", tok.RawString(), genid.Any_message_fullname)
 			}
 		}
 	}
@@ -697,21 +812,24 @@ Loop:
 func (d decoder) unmarshalExpandedAny(typeURL string, pos int) ([]byte, error) {
 	mt, err := d.opts.Resolver.FindMessageByURL(typeURL)
 	if err != nil {
-		return nil, d.newError(pos, "unable to resolve message [%v]: %v", typeURL, err)
+		return nil, d.newError(pos, CategoryUnresolvable, "unable to resolve message [%v]: %v", typeURL, err)
 	}
 	// Create new message for the embedded message type and unmarshal the value
 	// field into it.
 	m := mt.New()
+	n := d.path.Len()
+	d.path.AnyExpand(m.Descriptor())
 	if err := d.unmarshalMessage(m, true); err != nil {
 		return nil, err
 	}
+	d.path.Truncate(n)
 	// Serialize the embedded message and return the resulting bytes.
 	b, err := proto.MarshalOptions{
 		AllowPartial:  true, // Never check required fields inside an Any.
 		Deterministic: true,
 	}.Marshal(m.Interface())
 	if err != nil {
-		return nil, d.newError(pos, "error in marshaling message into Any.value: %v", err)
+		return nil, d.newError(pos, CategoryInvalidValue, "error in marshaling message into Any.value: %v", err)
 	}
 	return b, nil
 }
@@ -5,6 +5,7 @@
 package prototext_test
 
 import (
+	"errors"
 	"math"
 	"strings"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/internal/flags"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
 	"google.golang.org/protobuf/reflect/protoregistry"
 
 	testpb "google.golang.org/protobuf/internal/testprotos/test"
@@ -1920,3 +1922,136 @@ type_url: "pb2.Nested"
 		})
 	}
 }
+
+func TestSyntaxError(t *testing.T) {
+	tests := []struct {
+		desc      string
+		m         proto.Message
+		inputText string
+		want      prototext.SyntaxError // Error is not compared
+	}{{
+		desc:      "invalid value",
+		m:         &pb2.Nests{},
+		inputText: "opt_nested: {\n  opt_string: 1\n}",
+		want: prototext.SyntaxError{
+			Offset: 28, Line: 2, Column: 15,
+			Path:     "(pb2.Nests).opt_nested.opt_string",
+			Category: prototext.CategoryInvalidValue,
+		},
+	}, {
+		desc:      "unknown field in list element",
+		m:         &pb2.Nests{},
+		inputText: "rpt_nested {} rpt_nested { unknown: 1 }",
+		want: prototext.SyntaxError{
+			Offset: 27, Line: 1, Column: 28,
+			Path:     "(pb2.Nests).rpt_nested[1]",
+			Category: prototext.CategoryUnknownField,
+		},
+	}, {
+		desc:      "invalid list element",
+		m:         &pb3.Repeats{},
+		inputText: "rpt_int32: [1, 2, true]",
+		want: prototext.SyntaxError{
+			Offset: 18, Line: 1, Column: 19,
+			Path:     "(pb3.Repeats).rpt_int32[2]",
+			Category: prototext.CategoryInvalidValue,
+		},
+	}, {
+		desc:      "invalid map value",
+		m:         &pb3.Maps{},
+		inputText: `str_to_nested { key: "é" value { s_string: 1 } }`,
+		want: prototext.SyntaxError{
+			Offset: 44, Line: 1, Column: 44,
+			Path:     `(pb3.Maps).str_to_nested["é"].s_string`,
+			Category: prototext.CategoryInvalidValue,
+		},
+	}, {
+		desc:      "duplicate field",
+		m:         &pb2.Nests{},
+		inputText: "opt_nested {}\nopt_nested {}",
+		want: prototext.SyntaxError{
+			Offset: 14, Line: 2, Column: 1,
+			Path:     "(pb2.Nests).opt_nested",
+			Category: prototext.CategoryDuplicateField,
+		},
+	}, {
+		desc:      "unresolvable extension",
+		m:         &pb2.Extensions{},
+		inputText: "[pb2.unknown]: 1",
+		want: prototext.SyntaxError{
+			Offset: 0, Line: 1, Column: 1,
+			Path:     "(pb2.Extensions)",
+			Category: prototext.CategoryUnknownField,
+		},
+	}, {
+		desc:      "expanded Any",
+		m:         &pb2.KnownTypes{},
+		inputText: "opt_any: {\n  [type.googleapis.com/pb2.Nested]: {opt_string: 1}\n}",
+		want: prototext.SyntaxError{
+			Offset: 60, Line: 2, Column: 50,
+			Path:     "(pb2.KnownTypes).opt_any.(pb2.Nested).opt_string",
+			Category: prototext.CategoryInvalidValue,
+		},
+	}, {
+		desc:      "unresolvable Any",
+		m:         &pb2.KnownTypes{},
+		inputText: "opt_any: {[type.googleapis.com/pb2.Unknown]: {}}",
+		want: prototext.SyntaxError{
+			Offset: 10, Line: 1, Column: 11,
+			Path:     "(pb2.KnownTypes).opt_any",
+			Category: prototext.CategoryUnresolvable,
+		},
+	}, {
+		desc:      "lexical error",
+		m:         &pb2.Nests{},
+		inputText: "opt_nested: {\n  opt_string: 'a'\n  @\n}",
+		want: prototext.SyntaxError{
+			Offset: 34, Line: 3, Column: 3,
+			Path:     "(pb2.Nests).opt_nested",
+			Category: prototext.CategorySyntax,
+		},
+	}, {
+		desc:      "missing field separator",
+		m:         &pb2.Scalars{},
+		inputText: "opt_string 'a'",
+		want: prototext.SyntaxError{
+			Offset: 0, Line: 1, Column: 1,
+			Path:     "(pb2.Scalars).opt_string",
+			Category: prototext.CategorySyntax,
+		},
+	}, {
+		desc:      "unexpected EOF",
+		m:         &pb2.Nests{},
+		inputText: "opt_nested {",
+		want: prototext.SyntaxError{
+			Offset: 12, Line: 1, Column: 13,
+			Path:     "(pb2.Nests).opt_nested",
+			Category: prototext.CategorySyntax,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := prototext.Unmarshal([]byte(tt.inputText), tt.m)
+			var got *prototext.SyntaxError
+			if !errors.As(err, &got) {
+				t.Fatalf("Unmarshal() error = %v, want *SyntaxError", err)
+			}
+			if !errors.Is(err, proto.Error) {
+				t.Errorf("Unmarshal() error = %v, want proto.Error", err)
+			}
+			if got.Offset != tt.want.Offset || got.Line != tt.want.Line || got.Column != tt.want.Column {
+				t.Errorf("position = %d (%d:%d), want %d (%d:%d)", got.Offset, got.Line, got.Column, tt.want.Offset, tt.want.Line, tt.want.Column)
+			}
+			if got.Path != tt.want.Path {
+				t.Errorf("Path = %q, want %q", got.Path, tt.want.Path)
+			}
+			if got.Category != tt.want.Category {
+				t.Errorf("Category = %v, want %v", got.Category, tt.want.Category)
+			}
+			if _, err := protopath.Parse(tt.m.ProtoReflect().Descriptor(), got.Path); err != nil {
+				t.Errorf("protopath.Parse(%q) error: %v", got.Path, err)
+			}
+		})
+	}
+}
@@ -13,10 +13,12 @@ import (
 	"google.golang.org/protobuf/internal/encoding/messageset"
 	"google.golang.org/protobuf/internal/encoding/text"
 	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/fieldmask"
 	"google.golang.org/protobuf/internal/flags"
 	"google.golang.org/protobuf/internal/genid"
 	"google.golang.org/protobuf/internal/order"
 	"google.golang.org/protobuf/internal/pragma"
+	"google.golang.org/protobuf/internal/redact"
 	"google.golang.org/protobuf/internal/strs"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -82,9 +84,29 @@ type MarshalOptions struct {
 		protoregistry.ExtensionTypeResolver
 		protoregistry.MessageTypeResolver
 	}
+
+	// FieldMask restricts the output to the fields selected by its paths,
+	// which is typically a *fieldmaskpb.FieldMask. Each path is a sequence of
+	// field names separated by dots, where a path through a repeated message
+	// field applies to every element, and the name of a map field may be
+	// followed by a map key to select map entries, where a key containing
+	// a dot is quoted in backticks. The wildcard "*" selects every field or
+	// map entry. Extension fields are named by their full name in parentheses.
+	// A google.protobuf.Any message is only expanded if selected in its
+	// entirety, and unknown fields of a masked message are never emitted.
+	// If FieldMask is nil or has no paths, all fields are marshaled.
+	FieldMask interface{ GetPaths() []string }
+
+	// Redact replaces the values of fields marked with the debug_redact option,
+	// including fields whose options are set to enum values marked with the
+	// debug_redact option, with a placeholder. The unmarshaler is unable to
+	// parse the output of redacted fields.
+	// Format always redacts fields.
+	Redact bool
 }
 
 // Format formats the message as a string.
+// The values of fields marked with the debug_redact option are redacted.
 // This method is only intended for human consumption and ignores errors.
 // Do not depend on the output being stable. Its output will change across
 // different builds of your program, even when using the same version of the
@@ -96,6 +118,7 @@ func (o MarshalOptions) Format(m proto.Message) string {
 	o.allowInvalidUTF8 = true
 	o.AllowPartial = true
 	o.EmitUnknown = true
+	o.Redact = true
 	b, _ := o.Marshal(m)
 	return string(b)
 }
@@ -138,7 +161,11 @@ func (o MarshalOptions) marshal(b []byte, m proto.Message) ([]byte, error) {
 		return b, nil
 	}
 
-	enc := encoder{internalEnc, o}
+	var mask *fieldmask.Mask
+	if o.FieldMask != nil {
+		mask = fieldmask.New(o.FieldMask.GetPaths())
+	}
+	enc := encoder{internalEnc, o, mask}
 	err = enc.marshalMessage(m.ProtoReflect(), false)
 	if err != nil {
 		return nil, err
@@ -156,6 +183,9 @@ func (o MarshalOptions) marshal(b []byte, m proto.Message) ([]byte, error) {
 type encoder struct {
 	*text.Encoder
 	opts MarshalOptions
+
+	// mask selects the fields of the message currently being marshaled.
+	mask *fieldmask.Mask
 }
 
 // marshalMessage marshals the given protoreflect.Message.
@@ -171,16 +201,29 @@ func (e encoder) marshalMessage(m protoreflect.Message, inclDelims bool) error {
 	}
 
 	// Handle Any expansion.
-	if messageDesc.FullName() == genid.Any_message_fullname {
-		if e.marshalAny(m) {
+	if messageDesc.FullName() == genid.Any_message_fullname && selectsAny(e.mask, messageDesc) {
+		anyEnc := e
+		anyEnc.mask = nil
+		if anyEnc.marshalAny(m) {
 			return nil
 		}
 		// If unable to expand, continue on to marshal Any as a regular message.
 	}
 
 	// Marshal fields.
+	var fields order.FieldRanger = m
+	mask := e.mask
+	if mask != nil {
+		fields = fieldmask.FieldRanger{FieldRanger: fields, Mask: mask}
+	}
 	var err error
-	order.RangeFields(m, order.IndexNameFieldOrder, func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+	order.RangeFields(fields, order.IndexNameFieldOrder, func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if e.opts.Redact && redact.IsRedacted(fd) {
+			e.WriteName(fd.TextName())
+			e.WriteLiteral(redact.Placeholder)
+			return true
+		}
+		e.mask, _ = mask.Field(fd)
 		if err = e.marshalField(fd.TextName(), v, fd); err != nil {
 			return false
 		}
@@ -191,13 +234,25 @@ func (e encoder) marshalMessage(m protoreflect.Message, inclDelims bool) error {
 	}
 
 	// Marshal unknown fields.
-	if e.opts.EmitUnknown {
+	if e.opts.EmitUnknown && mask == nil {
 		e.marshalUnknown(m.GetUnknown())
 	}
 
 	return nil
 }
 
+// selectsAny reports whether the mask selects the entirety of a
+// google.protobuf.Any message, which is required to expand it.
+func selectsAny(mask *fieldmask.Mask, md protoreflect.MessageDescriptor) bool {
+	fds := md.Fields()
+	for _, num := range []protoreflect.FieldNumber{genid.Any_TypeUrl_field_number, genid.Any_Value_field_number} {
+		if sub, ok := mask.Field(fds.ByNumber(num)); !ok || sub != nil {
+			return false
+		}
+	}
+	return true
+}
+
 // marshalField marshals the given field with protoreflect.Value.
 func (e encoder) marshalField(name string, val protoreflect.Value, fd protoreflect.FieldDescriptor) error {
 	switch {
@@ -278,8 +333,14 @@ func (e encoder) marshalList(name string, list protoreflect.List, fd protoreflec
 
 // marshalMap marshals the given protoreflect.Map as multiple name-value fields.
 func (e encoder) marshalMap(name string, mmap protoreflect.Map, fd protoreflect.FieldDescriptor) error {
+	mask := e.mask
 	var err error
 	order.RangeEntries(mmap, order.GenericKeyOrder, func(key protoreflect.MapKey, val protoreflect.Value) bool {
+		sub, ok := mask.MapKey(key)
+		if !ok {
+			return true
+		}
+		e.mask = sub
 		e.WriteName(name)
 		e.StartMessage()
 		defer e.EndMessage()
@@ -8,20 +8,24 @@ import (
 	"bytes"
 	"math"
 	"testing"
+	"testing/fstest"
 
 	"github.com/google/go-cmp/cmp"
 
+	"google.golang.org/protobuf/compiler/protoparse"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/internal/detrand"
 	"google.golang.org/protobuf/internal/flags"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/testing/protopack"
+	"google.golang.org/protobuf/types/dynamicpb"
 
 	pb2 "google.golang.org/protobuf/internal/testprotos/textpb2"
 	pb3 "google.golang.org/protobuf/internal/testprotos/textpb3"
 	pbeditions "google.golang.org/protobuf/internal/testprotos/textpbeditions"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func init() {
@@ -1716,3 +1720,159 @@ func TestMarshalAppendAllocations(t *testing.T) {
 		t.Errorf("expect amortized allocs/op to be identical")
 	}
 }
+
+func TestMarshalFieldMask(t *testing.T) {
+	maps := &pb2.Maps{
+		Int32ToStr: map[int32]string{1: "one", 2: "two"},
+		StrToNested: map[string]*pb2.Nested{
+			"a": {OptString: proto.String("a"), OptNested: &pb2.Nested{}},
+			"b": {OptString: proto.String("b")},
+		},
+	}
+	anyMsg := &pb2.Nested{OptString: proto.String("embedded")}
+	anyValue, err := proto.Marshal(anyMsg)
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %v", err)
+	}
+	anyInput := &anypb.Any{TypeUrl: "pb2.Nested", Value: anyValue}
+
+	tests := []struct {
+		desc  string
+		paths []string
+		input proto.Message
+		want  proto.Message
+		// wantText is the expected output if want is nil.
+		wantText string
+	}{{
+		desc:  "nested fields",
+		paths: []string{"opt_nested.opt_string", "OptGroup.opt_string", "rpt_nested.opt_nested"},
+		input: &pb2.Nests{
+			OptNested: &pb2.Nested{OptString: proto.String("nested"), OptNested: &pb2.Nested{}},
+			Optgroup: &pb2.Nests_OptGroup{
+				OptString: proto.String("group"),
+				OptNested: &pb2.Nested{},
+			},
+			RptNested: []*pb2.Nested{
+				{OptString: proto.String("one"), OptNested: &pb2.Nested{OptString: proto.String("inner")}},
+				{OptString: proto.String("two")},
+			},
+		},
+		want: &pb2.Nests{
+			OptNested: &pb2.Nested{OptString: proto.String("nested")},
+			Optgroup:  &pb2.Nests_OptGroup{OptString: proto.String("group")},
+			RptNested: []*pb2.Nested{
+				{OptNested: &pb2.Nested{OptString: proto.String("inner")}},
+				{},
+			},
+		},
+	}, {
+		desc:  "map entries",
+		paths: []string{"int32_to_str.1", "str_to_nested.*.opt_string"},
+		input: maps,
+		want: &pb2.Maps{
+			Int32ToStr: map[int32]string{1: "one"},
+			StrToNested: map[string]*pb2.Nested{
+				"a": {OptString: proto.String("a")},
+				"b": {OptString: proto.String("b")},
+			},
+		},
+	}, {
+		desc:  "entire any",
+		paths: []string{"type_url", "value"},
+		input: anyInput,
+		want:  anyInput,
+	}, {
+		desc:     "partial any",
+		paths:    []string{"type_url"},
+		input:    anyInput,
+		wantText: `type_url:"pb2.Nested"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := prototext.MarshalOptions{
+				FieldMask: &fieldmaskpb.FieldMask{Paths: tt.paths},
+			}.Marshal(tt.input)
+			if err != nil {
+				t.Fatalf("Marshal() returned error: %v", err)
+			}
+			want := []byte(tt.wantText)
+			if tt.want != nil {
+				want, err = prototext.Marshal(tt.want)
+				if err != nil {
+					t.Fatalf("Marshal() returned error: %v", err)
+				}
+			}
+			if string(got) != string(want) {
+				t.Errorf("Marshal() with mask %v:\ngot:  %s\nwant: %s", tt.paths, got, want)
+			}
+		})
+	}
+}
+
+const redactSource = `syntax = "proto2";
+package redact.test;
+import "google/protobuf/any.proto";
+
+message Message {
+	optional string name = 1;
+	optional string password = 2 [debug_redact = true];
+	repeated string tokens = 3 [debug_redact = true];
+	map<string, Message> children = 4;
+	map<string, string> secrets = 5 [debug_redact = true];
+	optional google.protobuf.Any any = 6;
+	extensions 100 to max;
+}
+extend Message {
+	optional string api_key = 100 [debug_redact = true];
+}
+`
+
+func TestMarshalRedact(t *testing.T) {
+	files, err := protoparse.Parser{
+		FS: fstest.MapFS{"redact.proto": {Data: []byte(redactSource)}},
+	}.ParseFiles("redact.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := dynamicpb.NewTypes(files)
+	mt, err := types.FindMessageByName("redact.test.Message")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := mt.New().Interface()
+	if err := (prototext.UnmarshalOptions{Resolver: types}).Unmarshal([]byte(`
+		name: "name"
+		password: "hunter2"
+		tokens: ["hunter2", "hunter2"]
+		children: {key: "child" value: {name: "child" password: "hunter2"}}
+		secrets: {key: "key" value: "hunter2"}
+		any: {[type.googleapis.com/redact.test.Message]: {name: "any" password: "hunter2"}}
+		[redact.test.api_key]: "hunter2"
+	`), m); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `name:"name" password:[REDACTED] tokens:[REDACTED] ` +
+		`children:{key:"child" value:{name:"child" password:[REDACTED]}} secrets:[REDACTED] ` +
+		`any:{[type.googleapis.com/redact.test.Message]:{name:"any" password:[REDACTED]}} ` +
+		`[redact.test.api_key]:[REDACTED]`
+	got, err := prototext.MarshalOptions{Redact: true, Resolver: types}.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("Marshal() with Redact:\ngot:  %s\nwant: %s", got, want)
+	}
+	if got := (prototext.MarshalOptions{Resolver: types}).Format(m); got != want {
+		t.Errorf("Format():\ngot:  %s\nwant: %s", got, want)
+	}
+
+	got, err = prototext.MarshalOptions{Resolver: types}.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %v", err)
+	}
+	if bytes.Contains(got, []byte("REDACTED")) || bytes.Count(got, []byte("hunter2")) != 7 {
+		t.Errorf("Marshal() without Redact:\ngot:  %s\nwant every field", got)
+	}
+}
@@ -89,6 +89,6 @@ func roundTripAndCompareProto(t *testing.T, wireBytes []byte, messages ...proto.
 func FuzzEncodeDecodeRoundTrip(f *testing.F) {
 	f.Add([]byte("Hello World!"))
 	f.Fuzz(func(t *testing.T, in []byte) {
-		roundTripAndCompareProto(t, in, (*testfuzzpb.TestAllTypesProto2)(nil), (*testfuzzpb.TestAllTypesProto2Editions)(nil), (*testfuzzpb.TestAllTypesProto3)(nil), (*testfuzzpb.TestAllTypesProto3Editions)(nil))
+		roundTripAndCompareProto(t, in, (*testfuzzpb.TestAllTypesProto2)(nil), (*testfuzzpb.TestAllTypesProto2Editions)(nil), (*testfuzzpb.TestAllTypesProto2Editions2024)(nil), (*testfuzzpb.TestAllTypesProto3)(nil), (*testfuzzpb.TestAllTypesProto3Editions)(nil), (*testfuzzpb.TestAllTypesProto3Editions2024)(nil))
 	})
 }
@@ -144,6 +144,10 @@ func generateLocalProtos() {
 	}, {
 		path:    "internal/testprotos",
 		exclude: map[string]bool{"internal/testprotos/irregular/irregular.proto": true},
+	}, {
+		path: "internal/gonamespb",
+	}, {
+		path: "internal/validatepb",
 	}, {
 		path: "src/",
 	}}
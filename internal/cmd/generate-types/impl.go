@@ -68,6 +68,10 @@ b = protowire.Append{{.WireType}}(b, {{.FromValue}})
 {{- end -}}
 {{- end -}}
 
+{{- define "ToValue" -}}
+{{if .ToValueAlloc}}{{.ToValueAlloc}}{{else}}{{.ToValue}}{{end}}
+{{- end -}}
+
 {{- define "Consume" -}}
 {{- if eq .WireType "Varint" -}}
 var v uint64
@@ -282,7 +286,11 @@ func consume{{.Name}}Ptr(b []byte, p pointer, wtyp protowire.Type, f *coderField
 	}
 	vp := p.{{.GoType.PointerMethod}}Ptr()
 	if *vp == nil {
-		*vp = new({{.GoType}})
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*{{.GoType}})
+		} else {
+			*vp = new({{.GoType}})
+		}
 	}
 	**vp = {{.ToGoType}}
 	out.n = n
@@ -324,7 +332,11 @@ func consume{{.Name}}PtrValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f
 	}
 	vp := p.{{.GoType.PointerMethod}}Ptr()
 	if *vp == nil {
-		*vp = new({{.GoType}})
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*{{.GoType}})
+		} else {
+			*vp = new({{.GoType}})
+		}
 	}
 	**vp = {{.ToGoType}}
 	out.n = n
@@ -382,7 +394,11 @@ func consume{{.Name}}Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFie
 		}
 		{{- end}}
 		if count > 0 {
-			p.grow{{.GoType.PointerMethod}}Slice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.grow{{.GoType.PointerMethod}}Slice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -405,6 +421,9 @@ func consume{{.Name}}Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFie
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, {{.ToGoType}})
 	out.n = n
 	return out, nil
@@ -444,6 +463,9 @@ func consume{{.Name}}SliceValidateUTF8(b []byte, p pointer, wtyp protowire.Type,
 		return out, errInvalidUTF8{}
 	}
 	sp := p.{{.GoType.PointerMethod}}Slice()
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, {{.ToGoType}})
 	out.n = n
 	return out, nil
@@ -530,7 +552,7 @@ func consume{{.Name}}Value(b []byte, _ protoreflect.Value, _ protowire.Number, w
 		return protoreflect.Value{}, out, errDecode
 	}
 	out.n = n
-	return {{.ToValue}}, out, nil
+	return {{template "ToValue" .}}, out, nil
 }
 
 var coder{{.Name}}Value = valueCoderFuncs{
@@ -568,7 +590,7 @@ func consume{{.Name}}ValueValidateUTF8(b []byte, _ protoreflect.Value, _ protowi
 		return protoreflect.Value{}, out, errInvalidUTF8{}
 	}
 	out.n = n
-	return {{.ToValue}}, out, nil
+	return {{template "ToValue" .}}, out, nil
 }
 
 var coder{{.Name}}ValueValidateUTF8 = valueCoderFuncs{
@@ -618,7 +640,7 @@ func consume{{.Name}}SliceValue(b []byte, listv protoreflect.Value, _ protowire.
 			if n < 0 {
 				return protoreflect.Value{}, out, errDecode
 			}
-			list.Append({{.ToValue}})
+			list.Append({{template "ToValue" .}})
 			b = b[n:]
 		}
 		out.n = n
@@ -632,7 +654,7 @@ func consume{{.Name}}SliceValue(b []byte, listv protoreflect.Value, _ protowire.
 	if n < 0 {
 		return protoreflect.Value{}, out, errDecode
 	}
-	list.Append({{.ToValue}})
+	list.Append({{template "ToValue" .}})
 	out.n = n
 	return listv, out, nil
 }
@@ -96,6 +96,10 @@ type ProtoKind struct {
 	ToValue   Expr
 	FromValue Expr
 
+	// ToValueAlloc is ToValue in internal/impl, where the storage of values
+	// may be allocated by the unmarshal options. It defaults to ToValue.
+	ToValueAlloc Expr
+
 	// Conversions to/from generated structures.
 	GoType         GoType
 	ToGoType       Expr
@@ -234,22 +238,24 @@ var ProtoKinds = []ProtoKind{
 		FromGoType: "math.Float64bits(v)",
 	},
 	{
-		Name:       "String",
-		WireType:   WireBytes,
-		ToValue:    "protoreflect.ValueOfString(string(v))",
-		FromValue:  "v.String()",
-		GoType:     GoString,
-		ToGoType:   "string(v)",
-		FromGoType: "v",
+		Name:         "String",
+		WireType:     WireBytes,
+		ToValue:      "protoreflect.ValueOfString(string(v))",
+		ToValueAlloc: "protoreflect.ValueOfString(opts.string(v))",
+		FromValue:    "v.String()",
+		GoType:       GoString,
+		ToGoType:     "opts.string(v)",
+		FromGoType:   "v",
 	},
 	{
 		Name:           "Bytes",
 		WireType:       WireBytes,
 		ToValue:        "protoreflect.ValueOfBytes(append(emptyBuf[:], v...))",
+		ToValueAlloc:   "protoreflect.ValueOfBytes(opts.appendBytes(emptyBuf[:], v))",
 		FromValue:      "v.Bytes()",
 		GoType:         GoBytes,
-		ToGoType:       "append(emptyBuf[:], v...)",
-		ToGoTypeNoZero: "append(([]byte)(nil), v...)",
+		ToGoType:       "opts.appendBytes(emptyBuf[:], v)",
+		ToGoTypeNoZero: "opts.appendBytes(nil, v)",
 		FromGoType:     "v",
 		NoPointer:      true,
 	},
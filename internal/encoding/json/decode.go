@@ -48,6 +48,25 @@ type Decoder struct {
 	orig []byte
 	// in contains the unconsumed input.
 	in []byte
+
+	// base is the offset of orig within the entire input. It is only non-zero
+	// for a stream decoder that has discarded consumed input.
+	base int
+
+	// src is the shared input source of a stream decoder. It is nil for a
+	// decoder created by NewDecoder.
+	src *source
+	// clone reports whether this is a stream decoder created by Clone,
+	// which must never discard input that the original may still read.
+	clone bool
+	// line and col are the zero-based line and column of in[0].
+	// They are only tracked for a stream decoder.
+	line, col int
+	// marks records the positions of recently read tokens and of the
+	// currently open objects and arrays of a stream decoder.
+	marks     [numMarks]mark
+	nmarks    int
+	openMarks []mark
 }
 
 // NewDecoder returns a Decoder to read the given []byte.
@@ -104,6 +123,11 @@ func (d *Decoder) Read() (Token, error) {
 		if d.lastToken.kind&(ObjectOpen|comma) == 0 {
 			return Token{}, d.newSyntaxError(tok.pos, unexpectedFmt, tok.RawString())
 		}
+		if d.src != nil {
+			if err := d.fillToken(); err != nil {
+				return Token{}, err
+			}
+		}
 		if len(d.in) == 0 {
 			return Token{}, ErrUnexpectedEOF
 		}
@@ -118,6 +142,9 @@ func (d *Decoder) Read() (Token, error) {
 			return Token{}, d.newSyntaxError(tok.pos, unexpectedFmt, tok.RawString())
 		}
 		d.openStack = append(d.openStack, tok.kind)
+		if d.src != nil {
+			d.openMarks = append(d.openMarks, d.lastMark())
+		}
 
 	case ObjectClose:
 		if len(d.openStack) == 0 ||
@@ -126,6 +153,7 @@ func (d *Decoder) Read() (Token, error) {
 			return Token{}, d.newSyntaxError(tok.pos, unexpectedFmt, tok.RawString())
 		}
 		d.openStack = d.openStack[:len(d.openStack)-1]
+		d.popMark()
 
 	case ArrayClose:
 		if len(d.openStack) == 0 ||
@@ -134,6 +162,7 @@ func (d *Decoder) Read() (Token, error) {
 			return Token{}, d.newSyntaxError(tok.pos, unexpectedFmt, tok.RawString())
 		}
 		d.openStack = d.openStack[:len(d.openStack)-1]
+		d.popMark()
 
 	case comma:
 		if len(d.openStack) == 0 ||
@@ -151,6 +180,18 @@ func (d *Decoder) Read() (Token, error) {
 	return tok, nil
 }
 
+// NextValue prepares the decoder to read another top-level JSON value after
+// the previous one has been completely read, allowing a stream of
+// concatenated or newline-delimited JSON values to be decoded.
+// It reports false if the previous value has not been completely read.
+func (d *Decoder) NextValue() bool {
+	if len(d.openStack) != 0 || d.lastCall == peekCall {
+		return false
+	}
+	d.lastToken = Token{}
+	return true
+}
+
 // Any sequence that looks like a non-delimiter (for error reporting).
 var errRegexp = regexp.MustCompile(`^([-+._a-zA-Z0-9]{1,32}|.)`)
 
@@ -158,6 +199,12 @@ var errRegexp = regexp.MustCompile(`^([-+._a-zA-Z0-9]{1,32}|.)`)
 // different types, except for Name. It does not handle whether the next token
 // is in a valid sequence or not.
 func (d *Decoder) parseNext() (Token, error) {
+	if d.src != nil {
+		if err := d.fillToken(); err != nil {
+			return Token{}, err
+		}
+	}
+
 	// Trim leading spaces.
 	d.consume(0)
 
@@ -212,17 +259,36 @@ func (d *Decoder) parseNext() (Token, error) {
 	return Token{}, d.newSyntaxError(d.currPos(), "invalid value %s", errRegexp.Find(in))
 }
 
+// SyntaxError is a syntax error at a position in the input.
+type SyntaxError struct {
+	// Pos is the byte offset of the error in the input.
+	Pos int
+	err error
+}
+
+func (e *SyntaxError) Error() string { return e.err.Error() }
+func (e *SyntaxError) Unwrap() error { return e.err }
+
 // newSyntaxError returns an error with line and column information useful for
 // syntax errors.
 func (d *Decoder) newSyntaxError(pos int, f string, x ...any) error {
 	e := errors.New(f, x...)
 	line, column := d.Position(pos)
-	return errors.New("syntax error (line %d:%d): %v", line, column, e)
+	return &SyntaxError{Pos: pos, err: errors.New("syntax error (line %d:%d): %v", line, column, e)}
+}
+
+// Offset returns the byte offset of the unconsumed input, which is the end
+// of the input once Read has returned ErrUnexpectedEOF.
+func (d *Decoder) Offset() int {
+	return d.currPos()
 }
 
 // Position returns line and column number of given index of the original input.
 // It will panic if index is out of range.
 func (d *Decoder) Position(idx int) (line int, column int) {
+	if d.src != nil {
+		return d.streamPosition(idx)
+	}
 	b := d.orig[:idx]
 	line = bytes.Count(b, []byte("\n")) + 1
 	if i := bytes.LastIndexByte(b, '\n'); i >= 0 {
@@ -234,7 +300,7 @@ func (d *Decoder) Position(idx int) (line int, column int) {
 
 // currPos returns the current index position of d.in from d.orig.
 func (d *Decoder) currPos() int {
-	return len(d.orig) - len(d.in)
+	return d.base + len(d.orig) - len(d.in)
 }
 
 // matchWithDelim matches s with the input b and verifies that the match
@@ -263,10 +329,17 @@ func isNotDelim(c byte) bool {
 
 // consume consumes n bytes of input and any subsequent whitespace.
 func (d *Decoder) consume(n int) {
+	if d.src != nil {
+		// Tokens never contain newlines.
+		d.col += utf8.RuneCount(d.in[:n])
+	}
 	d.in = d.in[n:]
 	for len(d.in) > 0 {
 		switch d.in[0] {
 		case ' ', '\n', '\r', '\t':
+			if d.src != nil {
+				d.advanceSpace(d.in[0])
+			}
 			d.in = d.in[1:]
 		default:
 			return
@@ -299,8 +372,9 @@ func (d *Decoder) consumeToken(kind Kind, size int) Token {
 	tok := Token{
 		kind: kind,
 		raw:  d.in[:size],
-		pos:  len(d.orig) - len(d.in),
+		pos:  d.currPos(),
 	}
+	d.addMark(tok.pos)
 	d.consume(size)
 	return tok
 }
@@ -311,9 +385,10 @@ func (d *Decoder) consumeBoolToken(b bool, size int) Token {
 	tok := Token{
 		kind: Bool,
 		raw:  d.in[:size],
-		pos:  len(d.orig) - len(d.in),
+		pos:  d.currPos(),
 		boo:  b,
 	}
+	d.addMark(tok.pos)
 	d.consume(size)
 	return tok
 }
@@ -324,9 +399,10 @@ func (d *Decoder) consumeStringToken(s string, size int) Token {
 	tok := Token{
 		kind: String,
 		raw:  d.in[:size],
-		pos:  len(d.orig) - len(d.in),
+		pos:  d.currPos(),
 		str:  s,
 	}
+	d.addMark(tok.pos)
 	d.consume(size)
 	return tok
 }
@@ -336,5 +412,9 @@ func (d *Decoder) consumeStringToken(s string, size int) Token {
 func (d *Decoder) Clone() *Decoder {
 	ret := *d
 	ret.openStack = append([]Kind(nil), ret.openStack...)
+	if d.src != nil {
+		ret.clone = true
+		ret.openMarks = append([]mark(nil), ret.openMarks...)
+	}
 	return &ret
 }
@@ -0,0 +1,242 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// minRead is the minimum number of bytes requested from the underlying reader
+// of a stream decoder.
+const minRead = 4096
+
+// numMarks is the number of recently read token positions that a stream
+// decoder remembers for reporting line and column information.
+const numMarks = 16
+
+// source is the buffered input of a stream decoder. It is shared between a
+// decoder and its clones so that input read ahead by a clone is not lost.
+type source struct {
+	r io.Reader
+	// err is the sticky error returned from r, which is io.EOF once the
+	// input has been fully read.
+	err error
+
+	// buf contains the buffered input starting at offset off of the entire
+	// input. Input before off has been discarded.
+	buf []byte
+	off int
+	// line and col are the zero-based line and column of buf[0].
+	line, col int
+}
+
+// mark records the line and column of a position in the input.
+type mark struct {
+	pos, line, col int
+}
+
+// NewStreamDecoder returns a Decoder that reads from r. Unlike NewDecoder,
+// the input is read incrementally as tokens are requested and consumed input
+// is discarded, such that the entire input is never held in memory at once.
+func NewStreamDecoder(r io.Reader) *Decoder {
+	return &Decoder{src: &source{r: r}}
+}
+
+// Buffered returns the bytes that have been read from the underlying reader
+// of a stream decoder but not yet consumed as tokens.
+func (d *Decoder) Buffered() []byte {
+	if d.src == nil {
+		return d.in
+	}
+	pos := d.currPos()
+	return d.src.buf[pos-d.src.off:]
+}
+
+// fillToken reads from the source until the next token is entirely contained
+// in d.in or until the end of the input.
+func (d *Decoder) fillToken() error {
+	var from int
+	for {
+		d.consume(0)
+		var done bool
+		if done, from = tokenComplete(d.in, from); done {
+			return nil
+		}
+		more, err := d.fill()
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+}
+
+// tokenComplete reports whether in starts with a complete token, resuming the
+// scan at the given offset. If the token is incomplete, it returns the offset
+// at which to resume once more input is available.
+func tokenComplete(in []byte, from int) (bool, int) {
+	if len(in) == 0 {
+		return false, 0
+	}
+	switch c := in[0]; {
+	case c == '"':
+		i := from
+		if i == 0 {
+			i = 1
+		}
+		for i < len(in) {
+			switch in[i] {
+			case '\\':
+				if i+1 >= len(in) {
+					return false, i
+				}
+				i += 2
+				continue
+			case '"':
+				return true, i
+			}
+			i++
+		}
+		return false, i
+	case isNotDelim(c):
+		// Literals and numbers are terminated by the first delimiter,
+		// which must be read in order to know the token is complete.
+		i := from
+		for i < len(in) && isNotDelim(in[i]) {
+			i++
+		}
+		return i < len(in), i
+	}
+	return true, 0
+}
+
+// fill reads more input from the source into d.in. It reports whether any
+// progress was made, which is false only at the end of the input.
+func (d *Decoder) fill() (bool, error) {
+	s := d.src
+	pos := d.currPos()
+	for {
+		// A clone may have already read ahead of this decoder.
+		if s.off+len(s.buf) > d.base+len(d.orig) {
+			d.view(pos)
+			return true, nil
+		}
+		switch s.err {
+		case nil:
+		case io.EOF:
+			return false, nil
+		default:
+			return false, s.err
+		}
+
+		if !d.clone && pos > s.off {
+			// Discard consumed input. A new array is always allocated so that
+			// previously returned tokens continue to refer to valid input.
+			n := len(s.buf) - (pos - s.off)
+			buf := make([]byte, n, 2*n+minRead)
+			copy(buf, s.buf[pos-s.off:])
+			s.buf, s.off, s.line, s.col = buf, pos, d.line, d.col
+			d.view(pos)
+		}
+		if cap(s.buf)-len(s.buf) < minRead {
+			buf := make([]byte, len(s.buf), 2*cap(s.buf)+minRead)
+			copy(buf, s.buf)
+			s.buf = buf
+			d.view(pos)
+		}
+
+		n, err := s.r.Read(s.buf[len(s.buf):cap(s.buf)])
+		s.buf = s.buf[:len(s.buf)+n]
+		if err != nil {
+			s.err = err
+		}
+	}
+}
+
+// view updates the decoder to read from the current source buffer, given the
+// current offset of the decoder within the entire input.
+func (d *Decoder) view(pos int) {
+	d.orig = d.src.buf
+	d.base = d.src.off
+	d.in = d.src.buf[pos-d.src.off:]
+}
+
+// advanceSpace updates the line and column tracked by a stream decoder for
+// a consumed whitespace character.
+func (d *Decoder) advanceSpace(c byte) {
+	if c == '\n' {
+		d.line++
+		d.col = 0
+		return
+	}
+	d.col++
+}
+
+// addMark records the line and column of the token at pos, which must be the
+// current position of a stream decoder.
+func (d *Decoder) addMark(pos int) {
+	if d.src == nil {
+		return
+	}
+	d.marks[d.nmarks%numMarks] = mark{pos, d.line, d.col}
+	d.nmarks++
+}
+
+// lastMark returns the most recently recorded mark.
+func (d *Decoder) lastMark() mark {
+	return d.marks[(d.nmarks+numMarks-1)%numMarks]
+}
+
+// popMark removes the mark of the object or array that was just closed,
+// keeping it as a recent mark since errors often refer to its start.
+func (d *Decoder) popMark() {
+	if d.src == nil {
+		return
+	}
+	m := d.openMarks[len(d.openMarks)-1]
+	d.openMarks = d.openMarks[:len(d.openMarks)-1]
+	d.marks[d.nmarks%numMarks] = m
+	d.nmarks++
+}
+
+// streamPosition returns the line and column number of the given offset of the
+// input of a stream decoder. If the input at idx has been discarded, it is only
+// able to report positions for the recently read tokens and for the start of
+// open objects and arrays, otherwise it reports the position of the oldest
+// input that is still buffered.
+func (d *Decoder) streamPosition(idx int) (line int, column int) {
+	s := d.src
+	if idx >= s.off && idx <= s.off+len(s.buf) {
+		b := s.buf[:idx-s.off]
+		line, column = s.line, s.col
+		for i := 0; i < len(b); {
+			j := i
+			for j < len(b) && b[j] != '\n' {
+				j++
+			}
+			column += utf8.RuneCount(b[i:j])
+			if j < len(b) {
+				line++
+				column = 0
+				j++
+			}
+			i = j
+		}
+		return line + 1, column + 1
+	}
+	for i := 0; i < numMarks && i < d.nmarks; i++ {
+		if m := d.marks[i]; m.pos == idx {
+			return m.line + 1, m.col + 1
+		}
+	}
+	for _, m := range d.openMarks {
+		if m.pos == idx {
+			return m.line + 1, m.col + 1
+		}
+	}
+	return s.line + 1, s.col + 1
+}
@@ -5,10 +5,13 @@
 package json_test
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"unicode/utf8"
 
 	"github.com/google/go-cmp/cmp"
@@ -1329,32 +1332,40 @@ func TestDecoder(t *testing.T) {
 
 	for _, tc := range tests {
 		tc := tc
-		t.Run("", func(t *testing.T) {
-			dec := json.NewDecoder([]byte(tc.in))
-			for i, want := range tc.want {
-				peekTok, peekErr := dec.Peek()
-				tok, err := dec.Read()
-				if err != nil {
-					if want.E == "" {
-						errorf(t, tc.in, "want#%d: Read() got unexpected error: %v", i, err)
-					} else if !strings.Contains(err.Error(), want.E) {
-						errorf(t, tc.in, "want#%d: Read() got %q, want %q", i, err, want.E)
-					}
-					return
-				}
-				if want.E != "" {
-					errorf(t, tc.in, "want#%d: Read() got nil error, want %q", i, want.E)
-					return
-				}
-				checkToken(t, tok, i, want, tc.in)
-				if !cmp.Equal(tok, peekTok, cmp.Comparer(json.TokenEquals)) {
-					errorf(t, tc.in, "want#%d: Peek() %+v != Read() token %+v", i, peekTok, tok)
-				}
-				if err != peekErr {
-					errorf(t, tc.in, "want#%d: Peek() error %v != Read() error %v", i, err, peekErr)
-				}
+		for _, newDecoder := range []func(string) *json.Decoder{
+			func(s string) *json.Decoder { return json.NewDecoder([]byte(s)) },
+			func(s string) *json.Decoder { return json.NewStreamDecoder(iotest.OneByteReader(strings.NewReader(s))) },
+		} {
+			t.Run("", func(t *testing.T) {
+				testDecoder(t, newDecoder(tc.in), tc.in, tc.want)
+			})
+		}
+	}
+}
+
+func testDecoder(t *testing.T, dec *json.Decoder, in string, wants []R) {
+	for i, want := range wants {
+		peekTok, peekErr := dec.Peek()
+		tok, err := dec.Read()
+		if err != nil {
+			if want.E == "" {
+				errorf(t, in, "want#%d: Read() got unexpected error: %v", i, err)
+			} else if !strings.Contains(err.Error(), want.E) {
+				errorf(t, in, "want#%d: Read() got %q, want %q", i, err, want.E)
 			}
-		})
+			return
+		}
+		if want.E != "" {
+			errorf(t, in, "want#%d: Read() got nil error, want %q", i, want.E)
+			return
+		}
+		checkToken(t, tok, i, want, in)
+		if !cmp.Equal(tok, peekTok, cmp.Comparer(json.TokenEquals)) {
+			errorf(t, in, "want#%d: Peek() %+v != Read() token %+v", i, peekTok, tok)
+		}
+		if err != peekErr {
+			errorf(t, in, "want#%d: Peek() error %v != Read() error %v", i, err, peekErr)
+		}
 	}
 }
 
@@ -1427,3 +1438,97 @@ func compareDecoders(t *testing.T, d1 *json.Decoder, d2 *json.Decoder) {
 		}
 	}
 }
+
+func TestStreamDecoder(t *testing.T) {
+	// Construct an input much larger than the internal buffer such that
+	// consumed input is discarded many times.
+	var b strings.Builder
+	b.WriteString("[\n")
+	const n = 10000
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "  {\"key\": \"value ü %d\", \"num\": %d},\n", i, i)
+	}
+	b.WriteString("  x\n]")
+	in := b.String()
+
+	for _, r := range []io.Reader{
+		strings.NewReader(in),
+		iotest.HalfReader(strings.NewReader(in)),
+		iotest.DataErrReader(strings.NewReader(in)),
+	} {
+		dec := json.NewStreamDecoder(r)
+		if tok, err := dec.Read(); err != nil || tok.Kind() != json.ArrayOpen {
+			t.Fatalf("Read() = %v, %v, want [", tok.Kind(), err)
+		}
+		for i := 0; i < n; i++ {
+			want := []R{
+				{V: ObjectOpen},
+				{V: Name{"key"}},
+				{V: Str{fmt.Sprintf("value ü %d", i)}},
+				{V: Name{"num"}},
+				{V: I64{int64(i)}},
+				{V: ObjectClose},
+			}
+			for j, w := range want {
+				tok, err := dec.Read()
+				if err != nil {
+					t.Fatalf("object %d: Read() returned error: %v", i, err)
+				}
+				checkToken(t, tok, j, w, "")
+			}
+		}
+		_, err := dec.Read()
+		if want := fmt.Sprintf("syntax error (line %d:3): invalid value x", n+2); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Read() error got %v, want %v", err, want)
+		}
+	}
+}
+
+func TestStreamDecoderReadError(t *testing.T) {
+	wantErr := errors.New("read error")
+	dec := json.NewStreamDecoder(io.MultiReader(strings.NewReader(`{"a":`), iotest.ErrReader(wantErr)))
+	for _, want := range []json.Kind{json.ObjectOpen, json.Name} {
+		if tok, err := dec.Read(); err != nil || tok.Kind() != want {
+			t.Fatalf("Read() = %v, %v, want %v", tok.Kind(), err, want)
+		}
+	}
+	if _, err := dec.Read(); err != wantErr {
+		t.Errorf("Read() error got %v, want %v", err, wantErr)
+	}
+}
+
+func TestStreamClone(t *testing.T) {
+	input := `{"outer":{"str":"hello", "number": 123}}`
+	dec := json.NewStreamDecoder(iotest.OneByteReader(strings.NewReader(input)))
+
+	// Advance to inner object, clone and read ahead to the end of the input.
+	dec.Read() // Read ObjectOpen.
+	dec.Read() // Read Name.
+	clone := dec.Clone()
+	for {
+		tok, err := clone.Read()
+		if err != nil {
+			t.Fatalf("Read() returned error: %v", err)
+		}
+		if tok.Kind() == json.EOF {
+			break
+		}
+	}
+
+	// The original decoder must still observe the input read by the clone.
+	var got []string
+	for {
+		tok, err := dec.Read()
+		if err != nil {
+			t.Fatalf("Read() returned error: %v", err)
+		}
+		if tok.Kind() == json.EOF {
+			break
+		}
+		got = append(got, tok.RawString())
+	}
+	want := []string{`{`, `"str"`, `"hello"`, `"number"`, `123`, `}`, `}`}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() mismatch (-want +got):\n%s", diff)
+	}
+}
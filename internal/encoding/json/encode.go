@@ -5,6 +5,7 @@
 package json
 
 import (
+	"io"
 	"math"
 	"math/bits"
 	"strconv"
@@ -35,8 +36,18 @@ type Encoder struct {
 	lastKind kind
 	indents  []byte
 	out      []byte
+
+	// w is the destination of a stream encoder, to which out is
+	// periodically flushed. It is nil for an encoder created by NewEncoder.
+	w io.Writer
+	// err is the first error returned from w.
+	err error
 }
 
+// flushSize is the size of buffered output at which a stream encoder writes
+// the output to its destination.
+const flushSize = 32 << 10
+
 // NewEncoder returns an Encoder.
 //
 // If indent is a non-empty string, it causes every entry for an Array or Object
@@ -54,11 +65,37 @@ func NewEncoder(buf []byte, indent string) (*Encoder, error) {
 	return e, nil
 }
 
+// NewStreamEncoder returns an Encoder that writes its output to w. Output is
+// buffered and written to w in chunks, and Flush must be called once all
+// values have been written.
+func NewStreamEncoder(w io.Writer, indent string) (*Encoder, error) {
+	e, err := NewEncoder(nil, indent)
+	if err != nil {
+		return nil, err
+	}
+	e.w = w
+	return e, nil
+}
+
 // Bytes returns the content of the written bytes.
+// For a stream encoder, it only returns the content not yet flushed.
 func (e *Encoder) Bytes() []byte {
 	return e.out
 }
 
+// Flush writes any buffered output of a stream encoder to its destination and
+// returns the first error encountered while writing.
+func (e *Encoder) Flush() error {
+	if e.w == nil {
+		return nil
+	}
+	if e.err == nil && len(e.out) > 0 {
+		_, e.err = e.w.Write(e.out)
+	}
+	e.out = e.out[:0]
+	return e.err
+}
+
 // WriteNull writes out the null value.
 func (e *Encoder) WriteNull() {
 	e.prepareNext(scalar)
@@ -226,6 +263,9 @@ func (e *Encoder) EndArray() {
 // prepareNext adds possible comma and indentation for the next value based
 // on last type and indent option. It also updates lastKind to next.
 func (e *Encoder) prepareNext(next kind) {
+	if e.w != nil && len(e.out) >= flushSize {
+		e.Flush()
+	}
 	defer func() {
 		// Set lastKind to next.
 		e.lastKind = next
@@ -397,3 +397,53 @@ func TestWriteStringError(t *testing.T) {
 		})
 	}
 }
+
+// chunkWriter records the individual writes made to it.
+type chunkWriter struct {
+	chunks []string
+}
+
+func (w *chunkWriter) Write(b []byte) (int, error) {
+	w.chunks = append(w.chunks, string(b))
+	return len(b), nil
+}
+
+func TestStreamEncoder(t *testing.T) {
+	write := func(enc *json.Encoder) {
+		enc.StartArray()
+		for i := 0; i < 10000; i++ {
+			enc.StartObject()
+			enc.WriteName("key")
+			enc.WriteString("value")
+			enc.WriteName("num")
+			enc.WriteInt(int64(i))
+			enc.EndObject()
+		}
+		enc.EndArray()
+	}
+
+	for _, indent := range []string{"", "\t"} {
+		enc, err := json.NewEncoder(nil, indent)
+		if err != nil {
+			t.Fatalf("NewEncoder() returned error: %v", err)
+		}
+		write(enc)
+		want := string(enc.Bytes())
+
+		w := new(chunkWriter)
+		enc, err = json.NewStreamEncoder(w, indent)
+		if err != nil {
+			t.Fatalf("NewStreamEncoder() returned error: %v", err)
+		}
+		write(enc)
+		if err := enc.Flush(); err != nil {
+			t.Fatalf("Flush() returned error: %v", err)
+		}
+		if len(w.chunks) < 2 {
+			t.Errorf("got %d writes, want output to be written incrementally", len(w.chunks))
+		}
+		if got := strings.Join(w.chunks, ""); got != want {
+			t.Errorf("stream output mismatch (-want +got):\n%s", cmp.Diff(want, got, splitLines))
+		}
+	}
+}
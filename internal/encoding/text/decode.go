@@ -599,12 +599,29 @@ func (d *Decoder) consumeToken(kind Kind, size int, attrs uint8) Token {
 	return tok
 }
 
+// SyntaxError is a syntax error at a position in the input.
+type SyntaxError struct {
+	// Pos is the byte offset of the error in the input.
+	Pos int
+	err error
+}
+
+func (e *SyntaxError) Error() string { return e.err.Error() }
+func (e *SyntaxError) Unwrap() error { return e.err }
+
 // newSyntaxError returns a syntax error with line and column information for
 // current position.
 func (d *Decoder) newSyntaxError(f string, x ...any) error {
 	e := errors.New(f, x...)
-	line, column := d.Position(len(d.orig) - len(d.in))
-	return errors.New("syntax error (line %d:%d): %v", line, column, e)
+	pos := d.Offset()
+	line, column := d.Position(pos)
+	return &SyntaxError{Pos: pos, err: errors.New("syntax error (line %d:%d): %v", line, column, e)}
+}
+
+// Offset returns the byte offset of the unconsumed input, which is the end
+// of the input once Read has returned ErrUnexpectedEOF.
+func (d *Decoder) Offset() int {
+	return len(d.orig) - len(d.in)
 }
 
 // Position returns line and column number of given index of the original input.
@@ -0,0 +1,248 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fieldmask provides a tree representation of field mask paths
+// that can be applied while traversing a message.
+package fieldmask
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/internal/order"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Wildcard is a path component that selects every field of a message
+// or every entry of a map field.
+const Wildcard = "*"
+
+// Name is a component of a field mask path.
+type Name struct {
+	// Text is the field name or the unquoted map key.
+	Text string
+	// IsWildcard reports whether the component is the unquoted Wildcard.
+	IsWildcard bool
+}
+
+// Mask is a set of field mask paths arranged as a tree.
+// Each path is a sequence of field names separated by dots,
+// where a group field is named by its message name,
+// an extension field is named by its full name in parentheses,
+// a map key may follow the name of a map field to select a single entry,
+// and a path through a repeated message field applies to every element.
+// A map key that contains a dot or that is the Wildcard itself is quoted
+// in backticks, where two backticks stand for one within the quotes.
+//
+// The paths through the Wildcard also apply to every field or map key
+// named by another path at the same position.
+//
+// A nil *Mask selects all fields.
+type Mask struct {
+	// children maps a path component to the mask for that component,
+	// where a nil mask selects the entire value.
+	children map[string]*Mask
+
+	// wildcard is the mask for the Wildcard component if hasWildcard is set.
+	wildcard    *Mask
+	hasWildcard bool
+}
+
+// New returns a Mask for the given paths.
+// It returns nil, which selects all fields, if there are no paths.
+func New(paths []string) *Mask {
+	if len(paths) == 0 {
+		return nil
+	}
+	return Parse(paths)
+}
+
+// Parse returns a Mask for the given paths.
+// Unlike New, it returns a Mask that selects no fields if there are no paths.
+func Parse(paths []string) *Mask {
+	m := newMask()
+	for _, p := range paths {
+		m.add(SplitPath(p))
+	}
+	m.expand()
+	return m
+}
+
+func newMask() *Mask {
+	return &Mask{children: make(map[string]*Mask)}
+}
+
+// SplitPath splits a path into its components, where the dots within the
+// parenthesized name of an extension field or within a quoted map key
+// do not separate components.
+func SplitPath(p string) []Name {
+	var names []Name
+	for {
+		var n Name
+		if len(p) > 0 && p[0] == '`' {
+			n.Text, p = unquote(p[1:])
+		} else {
+			i := 0
+			if len(p) > 0 && p[0] == '(' {
+				i = strings.IndexByte(p, ')') + 1
+			}
+			if j := strings.IndexByte(p[i:], '.'); j >= 0 {
+				i += j
+			} else {
+				i = len(p)
+			}
+			n.Text, p = p[:i], p[i:]
+			n.IsWildcard = n.Text == Wildcard
+		}
+		names = append(names, n)
+
+		i := strings.IndexByte(p, '.')
+		if i < 0 {
+			return names
+		}
+		p = p[i+1:]
+	}
+}
+
+// unquote returns the text of a map key up to the closing backtick,
+// where two backticks stand for one, and the rest of s after the quotes.
+func unquote(s string) (string, string) {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] == '`' {
+			if i+1 == len(s) || s[i+1] != '`' {
+				return string(b), s[i+1:]
+			}
+			i++
+		}
+		b = append(b, s[i])
+	}
+	return string(b), ""
+}
+
+// lookup returns the mask for exactly the component n
+// and reports whether it is present.
+func (m *Mask) lookup(n Name) (*Mask, bool) {
+	if n.IsWildcard {
+		return m.wildcard, m.hasWildcard
+	}
+	sub, ok := m.children[n.Text]
+	return sub, ok
+}
+
+func (m *Mask) set(n Name, sub *Mask) {
+	if n.IsWildcard {
+		m.wildcard, m.hasWildcard = sub, true
+	} else {
+		m.children[n.Text] = sub
+	}
+}
+
+func (m *Mask) add(names []Name) {
+	child, ok := m.lookup(names[0])
+	if ok && child == nil {
+		return // entire value is already selected
+	}
+	if len(names) == 1 {
+		m.set(names[0], nil)
+		return
+	}
+	if !ok {
+		child = newMask()
+		m.set(names[0], child)
+	}
+	child.add(names[1:])
+}
+
+// union adds the paths selected by src to m and returns the result,
+// which is nil if either selects the entire value.
+// The masks of src are copied rather than shared.
+func (m *Mask) union(src *Mask) *Mask {
+	if m == nil || src == nil {
+		return nil
+	}
+	for name, sub := range src.children {
+		cur, ok := m.children[name]
+		if !ok {
+			cur = newMask()
+		}
+		m.children[name] = cur.union(sub)
+	}
+	if src.hasWildcard {
+		if !m.hasWildcard {
+			m.wildcard, m.hasWildcard = newMask(), true
+		}
+		m.wildcard = m.wildcard.union(src.wildcard)
+	}
+	return m
+}
+
+// expand merges the mask of the Wildcard into the mask of every other
+// component at the same position, such that a lookup of a named component
+// does not need to consider the Wildcard as well.
+func (m *Mask) expand() {
+	if m == nil {
+		return
+	}
+	if m.hasWildcard {
+		m.wildcard.expand()
+		for name, sub := range m.children {
+			m.children[name] = sub.union(m.wildcard)
+		}
+	}
+	for _, sub := range m.children {
+		sub.expand()
+	}
+}
+
+// Get reports whether the path component n is selected by the mask and
+// returns the mask to apply to its value, which is nil if the entire value
+// is selected. A named component that no path refers to is selected by
+// the Wildcard, if present.
+func (m *Mask) Get(n Name) (*Mask, bool) {
+	if m == nil {
+		return nil, true
+	}
+	if sub, ok := m.lookup(n); ok {
+		return sub, true
+	}
+	return m.wildcard, m.hasWildcard
+}
+
+// FieldName returns the path component naming fd, which is the name of the
+// group message for group fields as with fieldmaskpb.
+func FieldName(fd protoreflect.FieldDescriptor) string {
+	if fd.IsExtension() {
+		return "(" + string(fd.FullName()) + ")"
+	}
+	return fd.TextName()
+}
+
+// Field reports whether fd is selected by the mask and returns the mask
+// to apply to its value, which is nil if the entire value is selected.
+func (m *Mask) Field(fd protoreflect.FieldDescriptor) (*Mask, bool) {
+	return m.Get(Name{Text: FieldName(fd)})
+}
+
+// MapKey reports whether the map entry for k is selected by the mask of a
+// map field and returns the mask to apply to its value, which is nil if the
+// entire value is selected.
+func (m *Mask) MapKey(k protoreflect.MapKey) (*Mask, bool) {
+	return m.Get(Name{Text: k.String()})
+}
+
+// FieldRanger wraps a field ranger and filters its Range method
+// to only visit fields selected by the mask.
+type FieldRanger struct {
+	order.FieldRanger
+	Mask *Mask
+}
+
+func (m FieldRanger) Range(f func(protoreflect.FieldDescriptor, protoreflect.Value) bool) {
+	m.FieldRanger.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if _, ok := m.Mask.Field(fd); !ok {
+			return true
+		}
+		return f(fd, v)
+	})
+}
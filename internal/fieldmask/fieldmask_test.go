@@ -0,0 +1,140 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fieldmask_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/protobuf/internal/fieldmask"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+)
+
+func TestMask(t *testing.T) {
+	fds := (&testpb.TestAllTypes{}).ProtoReflect().Descriptor().Fields()
+	nestedFds := (&testpb.TestAllTypes_NestedMessage{}).ProtoReflect().Descriptor().Fields()
+	xd := testpb.E_OptionalInt32.TypeDescriptor()
+
+	if m := fieldmask.New(nil); m != nil {
+		t.Fatalf("New(nil) = %v, want nil", m)
+	}
+
+	m := fieldmask.New([]string{
+		"optional_int32",
+		"optional_nested_message.a",
+		"optional_nested_message.corecursive",
+		"optional_nested_message.corecursive.optional_string",
+		"map_string_nested_message.key.a",
+		"map_string_nested_message.*.corecursive",
+		"(goproto.proto.test.optional_int32)",
+	})
+
+	for _, tt := range []struct {
+		fd     protoreflect.FieldDescriptor
+		wantOK bool
+		// wantAll reports whether the entire field value is selected.
+		wantAll bool
+	}{
+		{fd: fds.ByName("optional_int32"), wantOK: true, wantAll: true},
+		{fd: fds.ByName("optional_int64"), wantOK: false},
+		{fd: fds.ByName("optional_nested_message"), wantOK: true, wantAll: false},
+		{fd: fds.ByName("map_string_nested_message"), wantOK: true, wantAll: false},
+		{fd: xd, wantOK: true, wantAll: true},
+	} {
+		sub, ok := m.Field(tt.fd)
+		if ok != tt.wantOK || (ok && (sub == nil) != tt.wantAll) {
+			t.Errorf("Field(%v) = (%v, %v), want ok %v and entire value %v", tt.fd.FullName(), sub, ok, tt.wantOK, tt.wantAll)
+		}
+	}
+
+	nested, _ := m.Field(fds.ByName("optional_nested_message"))
+	if sub, ok := nested.Field(nestedFds.ByName("a")); !ok || sub != nil {
+		t.Errorf("Field(a) = (%v, %v), want entire value", sub, ok)
+	}
+	if sub, ok := nested.Field(nestedFds.ByName("corecursive")); !ok || sub != nil {
+		t.Errorf("Field(corecursive) = (%v, %v), want entire value since a prefix selects it", sub, ok)
+	}
+
+	mapMask, _ := m.Field(fds.ByName("map_string_nested_message"))
+	keyMask, ok := mapMask.MapKey(protoreflect.ValueOfString("key").MapKey())
+	if !ok {
+		t.Fatalf("MapKey(key) not selected")
+	}
+	if _, ok := keyMask.Field(nestedFds.ByName("a")); !ok {
+		t.Errorf("MapKey(key).Field(a) not selected")
+	}
+	if _, ok := keyMask.Field(nestedFds.ByName("corecursive")); !ok {
+		t.Errorf("MapKey(key).Field(corecursive) not selected, want wildcard path to apply to explicit key")
+	}
+	otherMask, ok := mapMask.MapKey(protoreflect.ValueOfString("other").MapKey())
+	if !ok {
+		t.Fatalf("MapKey(other) not selected by wildcard")
+	}
+	if _, ok := otherMask.Field(nestedFds.ByName("corecursive")); !ok {
+		t.Errorf("MapKey(other).Field(corecursive) not selected")
+	}
+}
+
+func TestSplitPath(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []fieldmask.Name
+	}{
+		{in: "a", want: []fieldmask.Name{{Text: "a"}}},
+		{in: "a.b.c", want: []fieldmask.Name{{Text: "a"}, {Text: "b"}, {Text: "c"}}},
+		{in: "a.*", want: []fieldmask.Name{{Text: "a"}, {Text: "*", IsWildcard: true}}},
+		{in: "(pkg.ext).a", want: []fieldmask.Name{{Text: "(pkg.ext)"}, {Text: "a"}}},
+		{in: "m.`a.b`.c", want: []fieldmask.Name{{Text: "m"}, {Text: "a.b"}, {Text: "c"}}},
+		{in: "m.`*`", want: []fieldmask.Name{{Text: "m"}, {Text: "*"}}},
+		{in: "m.`a``b`", want: []fieldmask.Name{{Text: "m"}, {Text: "a`b"}}},
+		{in: "m.`a.b", want: []fieldmask.Name{{Text: "m"}, {Text: "a.b"}}},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, fieldmask.SplitPath(tt.in)); diff != "" {
+			t.Errorf("SplitPath(%q) mismatch (-want +got):\n%s", tt.in, diff)
+		}
+	}
+}
+
+func TestMaskMapKeys(t *testing.T) {
+	m := fieldmask.New([]string{
+		"map_string_nested_message.`a.b`.a",
+		"map_string_nested_message.`*`",
+	})
+	mapMask, _ := m.Field((&testpb.TestAllTypes{}).ProtoReflect().Descriptor().Fields().ByName("map_string_nested_message"))
+	for _, tt := range []struct {
+		key     string
+		wantOK  bool
+		wantAll bool
+	}{
+		{key: "a.b", wantOK: true, wantAll: false},
+		{key: "*", wantOK: true, wantAll: true},
+		{key: "a", wantOK: false},
+	} {
+		sub, ok := mapMask.MapKey(protoreflect.ValueOfString(tt.key).MapKey())
+		if ok != tt.wantOK || (ok && (sub == nil) != tt.wantAll) {
+			t.Errorf("MapKey(%q) = (%v, %v), want ok %v and entire value %v", tt.key, sub, ok, tt.wantOK, tt.wantAll)
+		}
+	}
+}
+
+func TestMaskWildcardField(t *testing.T) {
+	fds := (&testpb.TestAllTypes{}).ProtoReflect().Descriptor().Fields()
+	nestedFds := (&testpb.TestAllTypes_NestedMessage{}).ProtoReflect().Descriptor().Fields()
+
+	m := fieldmask.New([]string{"optional_nested_message.a", "*.corecursive"})
+	if _, ok := m.Field(fds.ByName("optional_int32")); !ok {
+		t.Errorf("Field(optional_int32) not selected by wildcard")
+	}
+	nested, _ := m.Field(fds.ByName("optional_nested_message"))
+	for _, name := range []protoreflect.Name{"a", "corecursive"} {
+		if _, ok := nested.Field(nestedFds.ByName(name)); !ok {
+			t.Errorf("Field(optional_nested_message).Field(%v) not selected", name)
+		}
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"google.golang.org/protobuf/internal/encoding/messageset"
 	"google.golang.org/protobuf/internal/genid"
 	"google.golang.org/protobuf/internal/pragma"
+	"google.golang.org/protobuf/internal/redact"
 	"google.golang.org/protobuf/internal/strs"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/reflect/protoregistry"
@@ -32,6 +33,7 @@ const (
 	EditionProto2      Edition = 998
 	EditionProto3      Edition = 999
 	Edition2023        Edition = 1000
+	Edition2024        Edition = 1001
 	EditionUnsupported Edition = 100000
 )
 
@@ -248,7 +250,8 @@ type (
 
 	Field struct {
 		Base
-		L1 FieldL1
+		L1       FieldL1
+		redacted redacted
 	}
 	FieldL1 struct {
 		Options          func() protoreflect.ProtoMessage
@@ -390,6 +393,10 @@ func (fd *Field) IsMapEntry() bool {
 func (fd *Field) Format(s fmt.State, r rune)             { descfmt.FormatDesc(s, r, fd) }
 func (fd *Field) ProtoType(protoreflect.FieldDescriptor) {}
 
+// IsRedacted reports whether the values of the field are redacted.
+// It is exported for use by internal/redact.
+func (fd *Field) IsRedacted() bool { return fd.redacted.get(fd) }
+
 // EnforceUTF8 is a pseudo-internal API to determine whether to enforce UTF-8
 // validation for the string field. This exists for Google-internal use only
 // since proto3 did not enforce UTF-8 validity prior to the open-source release.
@@ -417,8 +424,9 @@ func (od *Oneof) ProtoType(protoreflect.OneofDescriptor) {}
 type (
 	Extension struct {
 		Base
-		L1 ExtensionL1
-		L2 *ExtensionL2 // protected by fileDesc.once
+		L1       ExtensionL1
+		L2       *ExtensionL2 // protected by fileDesc.once
+		redacted redacted
 	}
 	ExtensionL1 struct {
 		Number          protoreflect.FieldNumber
@@ -481,6 +489,11 @@ func (xd *Extension) Message() protoreflect.MessageDescriptor           { return
 func (xd *Extension) Format(s fmt.State, r rune)                        { descfmt.FormatDesc(s, r, xd) }
 func (xd *Extension) ProtoType(protoreflect.FieldDescriptor)            {}
 func (xd *Extension) ProtoInternal(pragma.DoNotImplement)               {}
+
+// IsRedacted reports whether the values of the extension are redacted.
+// It is exported for use by internal/redact.
+func (xd *Extension) IsRedacted() bool { return xd.redacted.get(xd) }
+
 func (xd *Extension) lazyInit() *ExtensionL2 {
 	xd.L0.ParentFile.lazyInit() // implicitly initializes L2
 	return xd.L2
@@ -574,6 +587,17 @@ func (d *Base) Syntax() protoreflect.Syntax         { return d.L0.ParentFile.Syn
 func (d *Base) IsPlaceholder() bool                 { return false }
 func (d *Base) ProtoInternal(pragma.DoNotImplement) {}
 
+// redacted lazily computes whether the values of a field are redacted.
+type redacted struct {
+	once     sync.Once
+	redacted bool
+}
+
+func (r *redacted) get(fd protoreflect.FieldDescriptor) bool {
+	r.once.Do(func() { r.redacted = redact.Compute(fd) })
+	return r.redacted
+}
+
 type stringName struct {
 	hasJSON  bool
 	once     sync.Once
@@ -0,0 +1,68 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"reflect"
+
+	"google.golang.org/protobuf/internal/strs"
+)
+
+// newValue returns a pointer to a new zero value of type t,
+// which is allocated by the allocator if there is one.
+func (o unmarshalOptions) newValue(t reflect.Type) reflect.Value {
+	if o.allocator == nil {
+		return reflect.New(t)
+	}
+	return o.allocator.New(t)
+}
+
+// newMessage returns a pointer to a new message of Go type t,
+// which is a pointer to a message struct.
+func (o unmarshalOptions) newMessage(t reflect.Type) pointer {
+	return pointerOfValue(o.newValue(t.Elem()))
+}
+
+// growSlice grows the slice of type t at p, if needed, to have room
+// for n more elements. It must only be called if there is an allocator.
+func (o unmarshalOptions) growSlice(p pointer, t reflect.Type, n int) {
+	s := p.AsValueOf(t).Elem()
+	if s.Cap()-s.Len() >= n {
+		return
+	}
+	newCap := s.Len() + n
+	if c := 2 * s.Cap(); c > newCap {
+		newCap = c
+	}
+	ns := o.allocator.MakeSlice(t, s.Len(), newCap)
+	reflect.Copy(ns, s)
+	s.Set(ns)
+}
+
+// appendPointerSlice appends v to the slice of type t at p, which must be a []*T.
+func (o unmarshalOptions) appendPointerSlice(p pointer, t reflect.Type, v pointer) {
+	if o.allocator != nil {
+		o.growSlice(p, t, 1)
+	}
+	p.AppendPointerSlice(v)
+}
+
+// appendBytes returns append(b, v...) for an empty b.
+// The bytes are allocated by the allocator if there is one.
+func (o unmarshalOptions) appendBytes(b, v []byte) []byte {
+	if o.allocator == nil || len(v) == 0 {
+		return append(b, v...)
+	}
+	return append(o.allocator.MakeSlice(bytesType, 0, len(v)).Bytes(), v...)
+}
+
+// string returns v as a string, whose bytes are allocated
+// by the allocator if there is one.
+func (o unmarshalOptions) string(v []byte) string {
+	if o.allocator == nil || len(v) == 0 {
+		return string(v)
+	}
+	return strs.UnsafeString(o.appendBytes(nil, v))
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/strs"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// ValueCoder encodes and decodes the values of a field, which are represented
+// as protoreflect.Values. It is used by message implementations that are not
+// backed by Go structs, such as dynamicpb.
+//
+// Fields of message and group kinds are not supported, since the coders
+// of their values do not track the recursion depth, and neither are maps.
+type ValueCoder struct {
+	funcs valueCoderFuncs
+}
+
+// NewValueCoder returns a coder for the values of fd,
+// which are lists if fd is repeated.
+func NewValueCoder(fd protoreflect.FieldDescriptor) ValueCoder {
+	if fd.IsList() && fd.Kind() == protoreflect.StringKind && strs.EnforceUTF8(fd) {
+		return ValueCoder{coderStringSliceValueValidateUTF8}
+	}
+	return ValueCoder{encoderFuncsForValue(fd)}
+}
+
+// Size returns the size of the encoding of v,
+// where tagsize is the size of the tag of each encoded value.
+func (c ValueCoder) Size(v protoreflect.Value, tagsize int, flags protoiface.MarshalInputFlags) int {
+	return c.funcs.size(v, tagsize, marshalOptions{flags: flags})
+}
+
+// Marshal appends the encoding of v, including tags, to b.
+func (c ValueCoder) Marshal(b []byte, v protoreflect.Value, wiretag uint64, flags protoiface.MarshalInputFlags) ([]byte, error) {
+	return c.funcs.marshal(b, v, wiretag, marshalOptions{flags: flags})
+}
+
+// Unmarshal decodes a value of the field with the given number and wire type
+// from b, which starts after the tag. It returns the decoded value, which for
+// a list is v with the decoded elements appended, and the number of bytes
+// consumed. The error satisfies IsUnknown if the wire type does not match
+// the field, in which case the field is to be treated as an unknown field.
+func (c ValueCoder) Unmarshal(b []byte, v protoreflect.Value, num protowire.Number, wtyp protowire.Type, in protoiface.UnmarshalInput) (protoreflect.Value, int, error) {
+	v, out, err := c.funcs.unmarshal(b, v, num, wtyp, unmarshalOptions{
+		flags:     in.Flags,
+		resolver:  in.Resolver,
+		depth:     in.Depth,
+		allocator: in.Allocator,
+	})
+	return v, out.n, err
+}
+
+// IsUnknown reports whether err was returned by ValueCoder.Unmarshal
+// for a value whose wire type does not match its field.
+func IsUnknown(err error) bool {
+	return err == errUnknown
+}
+
+// NeedsInitCheck reports whether messages of type md may have required
+// fields that are not set, either directly or in nested messages.
+func NeedsInitCheck(md protoreflect.MessageDescriptor) bool {
+	return needsInitCheck(md)
+}
+
+// coderStringSliceValueValidateUTF8 is coderStringSliceValue
+// with validation of the decoded strings.
+var coderStringSliceValueValidateUTF8 = valueCoderFuncs{
+	size:      sizeStringSliceValue,
+	marshal:   appendStringSliceValue,
+	unmarshal: consumeStringSliceValueValidateUTF8,
+	merge:     mergeListValue,
+}
+
+func consumeStringSliceValueValidateUTF8(b []byte, listv protoreflect.Value, num protowire.Number, wtyp protowire.Type, opts unmarshalOptions) (protoreflect.Value, unmarshalOutput, error) {
+	listv, out, err := consumeStringSliceValue(b, listv, num, wtyp, opts)
+	if err != nil {
+		return listv, out, err
+	}
+	list := listv.List()
+	if !utf8.ValidString(list.Get(list.Len() - 1).String()) {
+		return listv, out, errInvalidUTF8{}
+	}
+	return listv, out, nil
+}
@@ -59,7 +59,7 @@ func (mi *MessageInfo) initOneofFieldCoders(od protoreflect.OneofDescriptor, si
 			if !vi.IsNil() && !vi.Elem().IsNil() && vi.Elem().Elem().Type() == ot {
 				vw = vi.Elem()
 			} else {
-				vw = reflect.New(ot)
+				vw = opts.newValue(ot)
 			}
 			out, err := cf.funcs.unmarshal(b, pointerOfValue(vw).Apply(zeroOffset), wtyp, &cf, opts)
 			if err != nil {
@@ -215,7 +215,7 @@ func makeMessageFieldCoder(fd protoreflect.FieldDescriptor, ft reflect.Type) poi
 			unmarshal: func(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo, opts unmarshalOptions) (unmarshalOutput, error) {
 				mp := p.AsValueOf(ft).Elem()
 				if mp.IsNil() {
-					mp.Set(reflect.New(ft.Elem()))
+					mp.Set(newMessagePointer(ft, fd.Message()))
 				}
 				return consumeMessage(b, asMessage(mp), wtyp, opts)
 			},
@@ -253,7 +253,7 @@ func consumeMessageInfo(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 		return out, errDecode
 	}
 	if p.Elem().IsNil() {
-		p.SetPointer(pointerOfValue(reflect.New(f.mi.GoReflectType.Elem())))
+		p.SetPointer(opts.newMessage(f.mi.GoReflectType))
 	}
 	o, err := f.mi.unmarshalPointer(v, p.Elem(), 0, opts)
 	if err != nil {
@@ -384,7 +384,7 @@ func makeGroupFieldCoder(fd protoreflect.FieldDescriptor, ft reflect.Type) point
 			unmarshal: func(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo, opts unmarshalOptions) (unmarshalOutput, error) {
 				mp := p.AsValueOf(ft).Elem()
 				if mp.IsNil() {
-					mp.Set(reflect.New(ft.Elem()))
+					mp.Set(newMessagePointer(ft, fd.Message()))
 				}
 				return consumeGroup(b, asMessage(mp), num, wtyp, opts)
 			},
@@ -413,7 +413,7 @@ func consumeGroupType(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInf
 		return out, errUnknown
 	}
 	if p.Elem().IsNil() {
-		p.SetPointer(pointerOfValue(reflect.New(f.mi.GoReflectType.Elem())))
+		p.SetPointer(opts.newMessage(f.mi.GoReflectType))
 	}
 	return f.mi.unmarshalPointer(b, p.Elem(), f.num, opts)
 }
@@ -470,7 +470,7 @@ func makeMessageSliceFieldCoder(fd protoreflect.FieldDescriptor, ft reflect.Type
 			return appendMessageSlice(b, p, f.wiretag, ft, opts)
 		},
 		unmarshal: func(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo, opts unmarshalOptions) (unmarshalOutput, error) {
-			return consumeMessageSlice(b, p, ft, wtyp, opts)
+			return consumeMessageSlice(b, p, ft, fd.Message(), wtyp, opts)
 		},
 		isInit: func(p pointer, f *coderFieldInfo) error {
 			return isInitMessageSlice(p, ft)
@@ -515,13 +515,12 @@ func consumeMessageSliceInfo(b []byte, p pointer, wtyp protowire.Type, f *coderF
 	if n < 0 {
 		return out, errDecode
 	}
-	m := reflect.New(f.mi.GoReflectType.Elem()).Interface()
-	mp := pointerOfIface(m)
+	mp := opts.newMessage(f.mi.GoReflectType)
 	o, err := f.mi.unmarshalPointer(v, mp, 0, opts)
 	if err != nil {
 		return out, err
 	}
-	p.AppendPointerSlice(mp)
+	opts.appendPointerSlice(p, f.ft, mp)
 	out.n = n
 	out.initialized = o.initialized
 	return out, nil
@@ -569,7 +568,7 @@ func appendMessageSlice(b []byte, p pointer, wiretag uint64, goType reflect.Type
 	return b, nil
 }
 
-func consumeMessageSlice(b []byte, p pointer, goType reflect.Type, wtyp protowire.Type, opts unmarshalOptions) (out unmarshalOutput, err error) {
+func consumeMessageSlice(b []byte, p pointer, goType reflect.Type, md protoreflect.MessageDescriptor, wtyp protowire.Type, opts unmarshalOptions) (out unmarshalOutput, err error) {
 	if wtyp != protowire.BytesType {
 		return out, errUnknown
 	}
@@ -577,7 +576,7 @@ func consumeMessageSlice(b []byte, p pointer, goType reflect.Type, wtyp protowir
 	if n < 0 {
 		return out, errDecode
 	}
-	mp := reflect.New(goType.Elem())
+	mp := newMessagePointer(goType, md)
 	o, err := opts.Options().UnmarshalState(protoiface.UnmarshalInput{
 		Buf:     v,
 		Message: asMessage(mp).ProtoReflect(),
@@ -758,7 +757,7 @@ func makeGroupSliceFieldCoder(fd protoreflect.FieldDescriptor, ft reflect.Type)
 			return appendGroupSlice(b, p, f.wiretag, ft, opts)
 		},
 		unmarshal: func(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo, opts unmarshalOptions) (unmarshalOutput, error) {
-			return consumeGroupSlice(b, p, num, wtyp, ft, opts)
+			return consumeGroupSlice(b, p, num, wtyp, ft, fd.Message(), opts)
 		},
 		isInit: func(p pointer, f *coderFieldInfo) error {
 			return isInitMessageSlice(p, ft)
@@ -793,7 +792,7 @@ func appendGroupSlice(b []byte, p pointer, wiretag uint64, messageType reflect.T
 	return b, nil
 }
 
-func consumeGroupSlice(b []byte, p pointer, num protowire.Number, wtyp protowire.Type, goType reflect.Type, opts unmarshalOptions) (out unmarshalOutput, err error) {
+func consumeGroupSlice(b []byte, p pointer, num protowire.Number, wtyp protowire.Type, goType reflect.Type, md protoreflect.MessageDescriptor, opts unmarshalOptions) (out unmarshalOutput, err error) {
 	if wtyp != protowire.StartGroupType {
 		return out, errUnknown
 	}
@@ -801,7 +800,7 @@ func consumeGroupSlice(b []byte, p pointer, num protowire.Number, wtyp protowire
 	if n < 0 {
 		return out, errDecode
 	}
-	mp := reflect.New(goType.Elem())
+	mp := newMessagePointer(goType, md)
 	o, err := opts.Options().UnmarshalState(protoiface.UnmarshalInput{
 		Buf:     b,
 		Message: asMessage(mp).ProtoReflect(),
@@ -842,13 +841,12 @@ func consumeGroupSliceInfo(b []byte, p pointer, wtyp protowire.Type, f *coderFie
 	if wtyp != protowire.StartGroupType {
 		return unmarshalOutput{}, errUnknown
 	}
-	m := reflect.New(f.mi.GoReflectType.Elem()).Interface()
-	mp := pointerOfIface(m)
+	mp := opts.newMessage(f.mi.GoReflectType)
 	out, err := f.mi.unmarshalPointer(b, mp, f.num, opts)
 	if err != nil {
 		return out, err
 	}
-	p.AppendPointerSlice(mp)
+	opts.appendPointerSlice(p, f.ft, mp)
 	return out, nil
 }
 
@@ -8,6 +8,7 @@ package impl
 
 import (
 	"math"
+	"reflect"
 	"unicode/utf8"
 
 	"google.golang.org/protobuf/encoding/protowire"
@@ -125,7 +126,11 @@ func consumeBoolPtr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo,
 	}
 	vp := p.BoolPtr()
 	if *vp == nil {
-		*vp = new(bool)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*bool)
+		} else {
+			*vp = new(bool)
+		}
 	}
 	**vp = protowire.DecodeBool(v)
 	out.n = n
@@ -173,7 +178,11 @@ func consumeBoolSlice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInf
 			}
 		}
 		if count > 0 {
-			p.growBoolSlice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.growBoolSlice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -215,6 +224,9 @@ func consumeBoolSlice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInf
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, protowire.DecodeBool(v))
 	out.n = n
 	return out, nil
@@ -704,7 +716,11 @@ func consumeInt32Ptr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo
 	}
 	vp := p.Int32Ptr()
 	if *vp == nil {
-		*vp = new(int32)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*int32)
+		} else {
+			*vp = new(int32)
+		}
 	}
 	**vp = int32(v)
 	out.n = n
@@ -752,7 +768,11 @@ func consumeInt32Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldIn
 			}
 		}
 		if count > 0 {
-			p.growInt32Slice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.growInt32Slice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -794,6 +814,9 @@ func consumeInt32Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldIn
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, int32(v))
 	out.n = n
 	return out, nil
@@ -1119,7 +1142,11 @@ func consumeSint32Ptr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInf
 	}
 	vp := p.Int32Ptr()
 	if *vp == nil {
-		*vp = new(int32)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*int32)
+		} else {
+			*vp = new(int32)
+		}
 	}
 	**vp = int32(protowire.DecodeZigZag(v & math.MaxUint32))
 	out.n = n
@@ -1167,7 +1194,11 @@ func consumeSint32Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 			}
 		}
 		if count > 0 {
-			p.growInt32Slice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.growInt32Slice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -1209,6 +1240,9 @@ func consumeSint32Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, int32(protowire.DecodeZigZag(v&math.MaxUint32)))
 	out.n = n
 	return out, nil
@@ -1534,7 +1568,11 @@ func consumeUint32Ptr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInf
 	}
 	vp := p.Uint32Ptr()
 	if *vp == nil {
-		*vp = new(uint32)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*uint32)
+		} else {
+			*vp = new(uint32)
+		}
 	}
 	**vp = uint32(v)
 	out.n = n
@@ -1582,7 +1620,11 @@ func consumeUint32Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 			}
 		}
 		if count > 0 {
-			p.growUint32Slice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.growUint32Slice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -1624,6 +1666,9 @@ func consumeUint32Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, uint32(v))
 	out.n = n
 	return out, nil
@@ -1949,7 +1994,11 @@ func consumeInt64Ptr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo
 	}
 	vp := p.Int64Ptr()
 	if *vp == nil {
-		*vp = new(int64)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*int64)
+		} else {
+			*vp = new(int64)
+		}
 	}
 	**vp = int64(v)
 	out.n = n
@@ -1997,7 +2046,11 @@ func consumeInt64Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldIn
 			}
 		}
 		if count > 0 {
-			p.growInt64Slice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.growInt64Slice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -2039,6 +2092,9 @@ func consumeInt64Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldIn
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, int64(v))
 	out.n = n
 	return out, nil
@@ -2364,7 +2420,11 @@ func consumeSint64Ptr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInf
 	}
 	vp := p.Int64Ptr()
 	if *vp == nil {
-		*vp = new(int64)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*int64)
+		} else {
+			*vp = new(int64)
+		}
 	}
 	**vp = protowire.DecodeZigZag(v)
 	out.n = n
@@ -2412,7 +2472,11 @@ func consumeSint64Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 			}
 		}
 		if count > 0 {
-			p.growInt64Slice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.growInt64Slice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -2454,6 +2518,9 @@ func consumeSint64Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, protowire.DecodeZigZag(v))
 	out.n = n
 	return out, nil
@@ -2779,7 +2846,11 @@ func consumeUint64Ptr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInf
 	}
 	vp := p.Uint64Ptr()
 	if *vp == nil {
-		*vp = new(uint64)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*uint64)
+		} else {
+			*vp = new(uint64)
+		}
 	}
 	**vp = v
 	out.n = n
@@ -2827,7 +2898,11 @@ func consumeUint64Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 			}
 		}
 		if count > 0 {
-			p.growUint64Slice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.growUint64Slice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -2869,6 +2944,9 @@ func consumeUint64Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, v)
 	out.n = n
 	return out, nil
@@ -3173,7 +3251,11 @@ func consumeSfixed32Ptr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 	}
 	vp := p.Int32Ptr()
 	if *vp == nil {
-		*vp = new(int32)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*int32)
+		} else {
+			*vp = new(int32)
+		}
 	}
 	**vp = int32(v)
 	out.n = n
@@ -3214,7 +3296,11 @@ func consumeSfixed32Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFiel
 		}
 		count := len(b) / protowire.SizeFixed32()
 		if count > 0 {
-			p.growInt32Slice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.growInt32Slice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -3236,6 +3322,9 @@ func consumeSfixed32Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFiel
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, int32(v))
 	out.n = n
 	return out, nil
@@ -3493,7 +3582,11 @@ func consumeFixed32Ptr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldIn
 	}
 	vp := p.Uint32Ptr()
 	if *vp == nil {
-		*vp = new(uint32)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*uint32)
+		} else {
+			*vp = new(uint32)
+		}
 	}
 	**vp = v
 	out.n = n
@@ -3534,7 +3627,11 @@ func consumeFixed32Slice(b []byte, p pointer, wtyp protowire.Type, f *coderField
 		}
 		count := len(b) / protowire.SizeFixed32()
 		if count > 0 {
-			p.growUint32Slice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.growUint32Slice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -3556,6 +3653,9 @@ func consumeFixed32Slice(b []byte, p pointer, wtyp protowire.Type, f *coderField
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, v)
 	out.n = n
 	return out, nil
@@ -3813,7 +3913,11 @@ func consumeFloatPtr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo
 	}
 	vp := p.Float32Ptr()
 	if *vp == nil {
-		*vp = new(float32)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*float32)
+		} else {
+			*vp = new(float32)
+		}
 	}
 	**vp = math.Float32frombits(v)
 	out.n = n
@@ -3854,7 +3958,11 @@ func consumeFloatSlice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldIn
 		}
 		count := len(b) / protowire.SizeFixed32()
 		if count > 0 {
-			p.growFloat32Slice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.growFloat32Slice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -3876,6 +3984,9 @@ func consumeFloatSlice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldIn
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, math.Float32frombits(v))
 	out.n = n
 	return out, nil
@@ -4133,7 +4244,11 @@ func consumeSfixed64Ptr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 	}
 	vp := p.Int64Ptr()
 	if *vp == nil {
-		*vp = new(int64)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*int64)
+		} else {
+			*vp = new(int64)
+		}
 	}
 	**vp = int64(v)
 	out.n = n
@@ -4174,7 +4289,11 @@ func consumeSfixed64Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFiel
 		}
 		count := len(b) / protowire.SizeFixed64()
 		if count > 0 {
-			p.growInt64Slice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.growInt64Slice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -4196,6 +4315,9 @@ func consumeSfixed64Slice(b []byte, p pointer, wtyp protowire.Type, f *coderFiel
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, int64(v))
 	out.n = n
 	return out, nil
@@ -4453,7 +4575,11 @@ func consumeFixed64Ptr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldIn
 	}
 	vp := p.Uint64Ptr()
 	if *vp == nil {
-		*vp = new(uint64)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*uint64)
+		} else {
+			*vp = new(uint64)
+		}
 	}
 	**vp = v
 	out.n = n
@@ -4494,7 +4620,11 @@ func consumeFixed64Slice(b []byte, p pointer, wtyp protowire.Type, f *coderField
 		}
 		count := len(b) / protowire.SizeFixed64()
 		if count > 0 {
-			p.growUint64Slice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.growUint64Slice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -4516,6 +4646,9 @@ func consumeFixed64Slice(b []byte, p pointer, wtyp protowire.Type, f *coderField
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, v)
 	out.n = n
 	return out, nil
@@ -4773,7 +4906,11 @@ func consumeDoublePtr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInf
 	}
 	vp := p.Float64Ptr()
 	if *vp == nil {
-		*vp = new(float64)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*float64)
+		} else {
+			*vp = new(float64)
+		}
 	}
 	**vp = math.Float64frombits(v)
 	out.n = n
@@ -4814,7 +4951,11 @@ func consumeDoubleSlice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 		}
 		count := len(b) / protowire.SizeFixed64()
 		if count > 0 {
-			p.growFloat64Slice(count)
+			if opts.allocator != nil {
+				opts.growSlice(p, reflect.TypeOf(*sp), count)
+			} else {
+				p.growFloat64Slice(count)
+			}
 		}
 		s := *sp
 		for len(b) > 0 {
@@ -4836,6 +4977,9 @@ func consumeDoubleSlice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 	if n < 0 {
 		return out, errDecode
 	}
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
 	*sp = append(*sp, math.Float64frombits(v))
 	out.n = n
 	return out, nil
@@ -5026,7 +5170,7 @@ func consumeString(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo,
 	if n < 0 {
 		return out, errDecode
 	}
-	*p.String() = string(v)
+	*p.String() = opts.string(v)
 	out.n = n
 	return out, nil
 }
@@ -5061,7 +5205,7 @@ func consumeStringValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f *code
 	if !utf8.Valid(v) {
 		return out, errInvalidUTF8{}
 	}
-	*p.String() = string(v)
+	*p.String() = opts.string(v)
 	out.n = n
 	return out, nil
 }
@@ -5151,9 +5295,13 @@ func consumeStringPtr(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInf
 	}
 	vp := p.StringPtr()
 	if *vp == nil {
-		*vp = new(string)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*string)
+		} else {
+			*vp = new(string)
+		}
 	}
-	**vp = string(v)
+	**vp = opts.string(v)
 	out.n = n
 	return out, nil
 }
@@ -5191,9 +5339,13 @@ func consumeStringPtrValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f *c
 	}
 	vp := p.StringPtr()
 	if *vp == nil {
-		*vp = new(string)
+		if opts.allocator != nil {
+			*vp = opts.allocator.New(reflect.TypeOf(*vp).Elem()).Interface().(*string)
+		} else {
+			*vp = new(string)
+		}
 	}
-	**vp = string(v)
+	**vp = opts.string(v)
 	out.n = n
 	return out, nil
 }
@@ -5234,7 +5386,10 @@ func consumeStringSlice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 	if n < 0 {
 		return out, errDecode
 	}
-	*sp = append(*sp, string(v))
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
+	*sp = append(*sp, opts.string(v))
 	out.n = n
 	return out, nil
 }
@@ -5272,7 +5427,10 @@ func consumeStringSliceValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f
 		return out, errInvalidUTF8{}
 	}
 	sp := p.StringSlice()
-	*sp = append(*sp, string(v))
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
+	*sp = append(*sp, opts.string(v))
 	out.n = n
 	return out, nil
 }
@@ -5306,7 +5464,7 @@ func consumeStringValue(b []byte, _ protoreflect.Value, _ protowire.Number, wtyp
 		return protoreflect.Value{}, out, errDecode
 	}
 	out.n = n
-	return protoreflect.ValueOfString(string(v)), out, nil
+	return protoreflect.ValueOfString(opts.string(v)), out, nil
 }
 
 var coderStringValue = valueCoderFuncs{
@@ -5339,7 +5497,7 @@ func consumeStringValueValidateUTF8(b []byte, _ protoreflect.Value, _ protowire.
 		return protoreflect.Value{}, out, errInvalidUTF8{}
 	}
 	out.n = n
-	return protoreflect.ValueOfString(string(v)), out, nil
+	return protoreflect.ValueOfString(opts.string(v)), out, nil
 }
 
 var coderStringValueValidateUTF8 = valueCoderFuncs{
@@ -5380,7 +5538,7 @@ func consumeStringSliceValue(b []byte, listv protoreflect.Value, _ protowire.Num
 	if n < 0 {
 		return protoreflect.Value{}, out, errDecode
 	}
-	list.Append(protoreflect.ValueOfString(string(v)))
+	list.Append(protoreflect.ValueOfString(opts.string(v)))
 	out.n = n
 	return listv, out, nil
 }
@@ -5415,7 +5573,7 @@ func consumeBytes(b []byte, p pointer, wtyp protowire.Type, f *coderFieldInfo, o
 	if n < 0 {
 		return out, errDecode
 	}
-	*p.Bytes() = append(emptyBuf[:], v...)
+	*p.Bytes() = opts.appendBytes(emptyBuf[:], v)
 	out.n = n
 	return out, nil
 }
@@ -5450,7 +5608,7 @@ func consumeBytesValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f *coder
 	if !utf8.Valid(v) {
 		return out, errInvalidUTF8{}
 	}
-	*p.Bytes() = append(emptyBuf[:], v...)
+	*p.Bytes() = opts.appendBytes(emptyBuf[:], v)
 	out.n = n
 	return out, nil
 }
@@ -5494,7 +5652,7 @@ func consumeBytesNoZero(b []byte, p pointer, wtyp protowire.Type, f *coderFieldI
 	if n < 0 {
 		return out, errDecode
 	}
-	*p.Bytes() = append(([]byte)(nil), v...)
+	*p.Bytes() = opts.appendBytes(nil, v)
 	out.n = n
 	return out, nil
 }
@@ -5533,7 +5691,7 @@ func consumeBytesNoZeroValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f
 	if !utf8.Valid(v) {
 		return out, errInvalidUTF8{}
 	}
-	*p.Bytes() = append(([]byte)(nil), v...)
+	*p.Bytes() = opts.appendBytes(nil, v)
 	out.n = n
 	return out, nil
 }
@@ -5574,7 +5732,10 @@ func consumeBytesSlice(b []byte, p pointer, wtyp protowire.Type, f *coderFieldIn
 	if n < 0 {
 		return out, errDecode
 	}
-	*sp = append(*sp, append(emptyBuf[:], v...))
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
+	*sp = append(*sp, opts.appendBytes(emptyBuf[:], v))
 	out.n = n
 	return out, nil
 }
@@ -5612,7 +5773,10 @@ func consumeBytesSliceValidateUTF8(b []byte, p pointer, wtyp protowire.Type, f *
 		return out, errInvalidUTF8{}
 	}
 	sp := p.BytesSlice()
-	*sp = append(*sp, append(emptyBuf[:], v...))
+	if opts.allocator != nil && len(*sp) == cap(*sp) {
+		opts.growSlice(p, reflect.TypeOf(*sp), 1)
+	}
+	*sp = append(*sp, opts.appendBytes(emptyBuf[:], v))
 	out.n = n
 	return out, nil
 }
@@ -5646,7 +5810,7 @@ func consumeBytesValue(b []byte, _ protoreflect.Value, _ protowire.Number, wtyp
 		return protoreflect.Value{}, out, errDecode
 	}
 	out.n = n
-	return protoreflect.ValueOfBytes(append(emptyBuf[:], v...)), out, nil
+	return protoreflect.ValueOfBytes(opts.appendBytes(emptyBuf[:], v)), out, nil
 }
 
 var coderBytesValue = valueCoderFuncs{
@@ -5687,7 +5851,7 @@ func consumeBytesSliceValue(b []byte, listv protoreflect.Value, _ protowire.Numb
 	if n < 0 {
 		return protoreflect.Value{}, out, errDecode
 	}
-	list.Append(protoreflect.ValueOfBytes(append(emptyBuf[:], v...)))
+	list.Append(protoreflect.ValueOfBytes(opts.appendBytes(emptyBuf[:], v)))
 	out.n = n
 	return listv, out, nil
 }
@@ -179,7 +179,7 @@ func consumeMapOfMessage(b []byte, mapv reflect.Value, wtyp protowire.Type, mapi
 	}
 	var (
 		key = mapi.keyZero
-		val = reflect.New(f.mi.GoReflectType.Elem())
+		val = opts.newValue(f.mi.GoReflectType.Elem())
 	)
 	for len(b) > 0 {
 		num, wtyp, n := protowire.ConsumeTag(b)
@@ -388,11 +388,14 @@ func mergeMapOfMessage(dst, src pointer, f *coderFieldInfo, opts mergeOptions) {
 	}
 	iter := mapRange(srcm)
 	for iter.Next() {
-		val := reflect.New(f.ft.Elem().Elem())
+		var val reflect.Value
 		if f.mi != nil {
+			val = reflect.New(f.ft.Elem().Elem())
 			f.mi.mergePointer(pointerOfValue(val), pointerOfValue(iter.Value()), opts)
 		} else {
-			opts.Merge(asMessage(val), asMessage(iter.Value()))
+			sm := asMessage(iter.Value())
+			val = newMessagePointer(f.ft.Elem(), sm.ProtoReflect().Descriptor())
+			opts.Merge(asMessage(val), sm)
 		}
 		dstm.SetMapIndex(iter.Key(), val)
 	}
@@ -137,7 +137,7 @@ func newSingularConverter(t reflect.Type, fd protoreflect.FieldDescriptor) Conve
 			return newEnumConverter(t, fd)
 		}
 	case protoreflect.MessageKind, protoreflect.GroupKind:
-		return newMessageConverter(t)
+		return newMessageConverter(t, fd.Message())
 	}
 	panic(fmt.Sprintf("invalid Go type %v for field %v", t, fd.FullName()))
 }
@@ -412,10 +412,15 @@ func (c *enumConverter) Zero() protoreflect.Value {
 
 type messageConverter struct {
 	goType reflect.Type
+	mt     protoreflect.MessageType // creates values if goType is a message Go type of many message types
 }
 
-func newMessageConverter(goType reflect.Type) Converter {
-	return &messageConverter{goType}
+func newMessageConverter(goType reflect.Type, md protoreflect.MessageDescriptor) Converter {
+	c := &messageConverter{goType: goType}
+	if f, ok := messageTypeFuncs.Load(goType); ok {
+		c.mt = f.(func(protoreflect.MessageDescriptor) protoreflect.MessageType)(md)
+	}
+	return c
 }
 
 func (c *messageConverter) PBValueOf(v reflect.Value) protoreflect.Value {
@@ -478,6 +483,9 @@ func (c *messageConverter) IsValidGo(v reflect.Value) bool {
 }
 
 func (c *messageConverter) New() protoreflect.Value {
+	if c.mt != nil {
+		return protoreflect.ValueOfMessage(c.mt.New())
+	}
 	if c.isNonPointer() {
 		return c.PBValueOf(reflect.New(c.goType).Elem())
 	}
@@ -485,6 +493,9 @@ func (c *messageConverter) New() protoreflect.Value {
 }
 
 func (c *messageConverter) Zero() protoreflect.Value {
+	if c.mt != nil {
+		return protoreflect.ValueOfMessage(c.mt.Zero())
+	}
 	return c.PBValueOf(reflect.Zero(c.goType))
 }
 
@@ -25,7 +25,8 @@ type unmarshalOptions struct {
 		FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error)
 		FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error)
 	}
-	depth int
+	depth     int
+	allocator protoiface.Allocator
 }
 
 func (o unmarshalOptions) Options() proto.UnmarshalOptions {
@@ -34,6 +35,7 @@ func (o unmarshalOptions) Options() proto.UnmarshalOptions {
 		AllowPartial:   true,
 		DiscardUnknown: o.DiscardUnknown(),
 		Resolver:       o.resolver,
+		Allocator:      o.allocator,
 	}
 }
 
@@ -42,7 +44,7 @@ func (o unmarshalOptions) DiscardUnknown() bool {
 }
 
 func (o unmarshalOptions) IsDefault() bool {
-	return o.flags == 0 && o.resolver == protoregistry.GlobalTypes
+	return o.flags == 0 && o.resolver == protoregistry.GlobalTypes && o.allocator == nil
 }
 
 var lazyUnmarshalOptions = unmarshalOptions{
@@ -64,9 +66,10 @@ func (mi *MessageInfo) unmarshal(in protoiface.UnmarshalInput) (protoiface.Unmar
 		p = in.Message.(*messageReflectWrapper).pointer()
 	}
 	out, err := mi.unmarshalPointer(in.Buf, p, 0, unmarshalOptions{
-		flags:    in.Flags,
-		resolver: in.Resolver,
-		depth:    in.Depth,
+		flags:     in.Flags,
+		resolver:  in.Resolver,
+		depth:     in.Depth,
+		allocator: in.Allocator,
 	})
 	var flags protoiface.UnmarshalOutputFlags
 	if out.initialized {
@@ -139,7 +139,7 @@ func mergeMessage(dst, src pointer, f *coderFieldInfo, opts mergeOptions) {
 		dm := dst.AsValueOf(f.ft).Elem()
 		sm := src.AsValueOf(f.ft).Elem()
 		if dm.IsNil() {
-			dm.Set(reflect.New(f.ft.Elem()))
+			dm.Set(newMessagePointer(f.ft, asMessage(sm).ProtoReflect().Descriptor()))
 		}
 		opts.Merge(asMessage(dm), asMessage(sm))
 	}
@@ -147,11 +147,14 @@ func mergeMessage(dst, src pointer, f *coderFieldInfo, opts mergeOptions) {
 
 func mergeMessageSlice(dst, src pointer, f *coderFieldInfo, opts mergeOptions) {
 	for _, sp := range src.PointerSlice() {
-		dm := reflect.New(f.ft.Elem().Elem())
+		var dm reflect.Value
 		if f.mi != nil {
+			dm = reflect.New(f.ft.Elem().Elem())
 			f.mi.mergePointer(pointerOfValue(dm), sp, opts)
 		} else {
-			opts.Merge(asMessage(dm), asMessage(sp.AsValueOf(f.ft.Elem().Elem())))
+			sm := asMessage(sp.AsValueOf(f.ft.Elem().Elem()))
+			dm = newMessagePointer(f.ft.Elem(), sm.ProtoReflect().Descriptor())
+			opts.Merge(asMessage(dm), sm)
 		}
 		dst.AppendPointerSlice(pointerOfValue(dm))
 	}
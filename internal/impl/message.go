@@ -55,6 +55,9 @@ type exporter func(v any, i int) any
 func getMessageInfo(mt reflect.Type) *MessageInfo {
 	m, ok := reflect.Zero(mt).Interface().(protoreflect.ProtoMessage)
 	if !ok {
+		if mi, ok := runtimeMessageInfos.Load(mt); ok {
+			return mi.(*MessageInfo)
+		}
 		return nil
 	}
 	mr, ok := m.ProtoReflect().(interface{ ProtoMessageInfo() *MessageInfo })
@@ -22,6 +22,10 @@ type reflectMessageInfo struct {
 	// For maps, it contains the entry value type.
 	fieldTypes map[protoreflect.FieldNumber]any
 
+	// runtimeFieldTypes takes precedence over the Go types of the fields
+	// in fieldTypes. See NewRuntimeMessageInfo.
+	runtimeFieldTypes map[protoreflect.FieldNumber]any
+
 	// denseFields is a subset of fields where:
 	//	0 < fieldDesc.Number() < len(denseFields)
 	// It provides faster access to the fieldInfo, but may be incomplete.
@@ -226,7 +230,11 @@ func (mi *MessageInfo) makeFieldTypes(si structInfo) {
 			if mi.fieldTypes == nil {
 				mi.fieldTypes = make(map[protoreflect.FieldNumber]any)
 			}
-			mi.fieldTypes[fd.Number()] = reflect.Zero(ft).Interface()
+			if v, ok := mi.runtimeFieldTypes[fd.Number()]; ok {
+				mi.fieldTypes[fd.Number()] = v
+			} else {
+				mi.fieldTypes[fd.Number()] = reflect.Zero(ft).Interface()
+			}
 		}
 	}
 }
@@ -0,0 +1,62 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package impl
+
+import (
+	"reflect"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// messageTypeFuncs maps Go types that are shared by many message types
+// to functions that return their message types by descriptor.
+var messageTypeFuncs sync.Map // map[reflect.Type]func(protoreflect.MessageDescriptor) protoreflect.MessageType
+
+// RegisterMessageTypeFunc registers f to return the message types of t,
+// which is the Go type of messages of many message types, for fields of type t.
+// The zero values of such Go types do not identify their message types,
+// so new values of the fields are created by the message types instead.
+func RegisterMessageTypeFunc(t reflect.Type, f func(protoreflect.MessageDescriptor) protoreflect.MessageType) {
+	messageTypeFuncs.Store(t, f)
+}
+
+// newMessagePointer returns a pointer to a new message of type md,
+// where t is the Go pointer type of the message.
+func newMessagePointer(t reflect.Type, md protoreflect.MessageDescriptor) reflect.Value {
+	if f, ok := messageTypeFuncs.Load(t); ok {
+		mt := f.(func(protoreflect.MessageDescriptor) protoreflect.MessageType)(md)
+		return reflect.ValueOf(mt.New().Interface())
+	}
+	return reflect.New(t.Elem())
+}
+
+// runtimeMessageInfos is a registry of MessageInfos for message types
+// whose Go types are created at run time.
+var runtimeMessageInfos sync.Map // map[reflect.Type]*MessageInfo
+
+// NewRuntimeMessageInfo returns a MessageInfo for messages of type md,
+// whose Go type t is a pointer to a struct type created at run time with
+// reflect.StructOf and laid out like a generated message struct.
+// Such a struct type has no methods, so its messages are wrapped by the
+// MessageInfo, which is registered to be used for fields of type t.
+//
+// Since the Go types of enum fields are not named and may not identify
+// their enum types, fieldTypes provides zero values of the enum and message
+// types of fields, by field number, which take precedence over the zero
+// values of their Go types.
+func NewRuntimeMessageInfo(t reflect.Type, md protoreflect.MessageDescriptor, oneofWrappers []any, fieldTypes map[protoreflect.FieldNumber]any) *MessageInfo {
+	mi := &MessageInfo{
+		GoReflectType: t,
+		Desc:          md,
+		OneofWrappers: oneofWrappers,
+	}
+	mi.runtimeFieldTypes = fieldTypes
+	if mi, ok := runtimeMessageInfos.LoadOrStore(t, mi); ok {
+		return mi.(*MessageInfo)
+	}
+	legacyMessageTypeCache.Store(t, mi)
+	return mi
+}
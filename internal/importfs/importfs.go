@@ -0,0 +1,74 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package importfs provides the handling of protoc-style import paths
+// shared by the commands that parse .proto source files.
+package importfs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// StringList is a flag.Value that collects the values of a repeated flag.
+type StringList []string
+
+func (l *StringList) String() string { return strings.Join(*l, ",") }
+
+func (l *StringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// FS is a list of directories to search for source files,
+// in order of precedence.
+type FS []string
+
+// New returns an FS for the given import paths. As in protoc,
+// each path may list several directories separated by os.PathListSeparator.
+func New(paths []string) FS {
+	var dirs FS
+	for _, s := range paths {
+		dirs = append(dirs, filepath.SplitList(s)...)
+	}
+	if len(dirs) == 0 {
+		dirs = FS{"."}
+	}
+	return dirs
+}
+
+// Open opens the named file in the first directory that contains it.
+func (dirs FS) Open(name string) (fs.File, error) {
+	for _, dir := range dirs {
+		f, err := os.DirFS(dir).Open(name)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return f, err
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// VirtualName returns the name by which the source file at the given
+// path is known, which is its path relative to the directory that contains it.
+func (dirs FS) VirtualName(file string) (string, error) {
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(dir, file)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		name := filepath.ToSlash(rel)
+		if _, err := fs.Stat(os.DirFS(dir), name); err != nil {
+			continue
+		}
+		return name, nil
+	}
+	if _, err := os.Stat(file); err != nil {
+		return "", fmt.Errorf("%s: No such file or directory", file)
+	}
+	return "", fmt.Errorf("%s: File does not reside within any path specified using --proto_path (or -I).  You must specify a --proto_path which encompasses this file.", file)
+}
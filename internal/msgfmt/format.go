@@ -20,12 +20,14 @@ import (
 	"google.golang.org/protobuf/internal/detrand"
 	"google.golang.org/protobuf/internal/genid"
 	"google.golang.org/protobuf/internal/order"
+	"google.golang.org/protobuf/internal/redact"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
 // Format returns a formatted string for the message.
+// The values of fields marked with the debug_redact option are redacted.
 func Format(m proto.Message) string {
 	return string(appendMessage(nil, m.ProtoReflect()))
 }
@@ -76,7 +78,11 @@ func appendMessage(b []byte, m protoreflect.Message) []byte {
 	order.RangeFields(m, order.IndexNameFieldOrder, func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
 		b = append(b, fd.TextName()...)
 		b = append(b, ':')
-		b = appendValue(b, v, fd)
+		if redact.IsRedacted(fd) {
+			b = append(b, redact.Placeholder...)
+		} else {
+			b = appendValue(b, v, fd)
+		}
 		b = append(b, delim()...)
 		return true
 	})
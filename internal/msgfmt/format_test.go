@@ -8,12 +8,15 @@ import (
 	"math"
 	"sync"
 	"testing"
+	"testing/fstest"
 
 	"github.com/google/go-cmp/cmp"
 
+	"google.golang.org/protobuf/compiler/protoparse"
 	"google.golang.org/protobuf/internal/detrand"
 	"google.golang.org/protobuf/internal/msgfmt"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/testing/protopack"
 
@@ -252,3 +255,33 @@ func TestFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatRedact(t *testing.T) {
+	files, err := protoparse.Parser{FS: fstest.MapFS{"redact.proto": {Data: []byte(`
+		syntax = "proto3";
+		package redact.test;
+		message Message {
+			string name = 1;
+			string password = 2 [debug_redact = true];
+			map<string, Message> children = 3;
+		}
+	`)}}}.ParseFiles("redact.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mt, err := dynpb.NewTypes(files).FindMessageByName("redact.test.Message")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := mt.New()
+	fds := m.Descriptor().Fields()
+	child := m.Mutable(fds.ByName("children")).Map().Mutable(protoreflect.ValueOfString("child").MapKey()).Message()
+	child.Set(fds.ByName("password"), protoreflect.ValueOfString("hunter2"))
+	m.Set(fds.ByName("name"), protoreflect.ValueOfString("name"))
+	m.Set(fds.ByName("password"), protoreflect.ValueOfString("hunter2"))
+
+	want := `{name:"name", password:[REDACTED], children:{"child":{password:[REDACTED]}}}`
+	if got := msgfmt.Format(m.Interface()); got != want {
+		t.Errorf("Format() = %v, want %v", got, want)
+	}
+}
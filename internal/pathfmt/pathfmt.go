@@ -0,0 +1,148 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pathfmt formats paths to values within a message in the syntax
+// of protopath.Path for packages that cannot depend on protopath.
+package pathfmt
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/internal/encoding/text"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// AppendRoot appends the root step for a message of type md, e.g., "(pkg.Message)".
+func AppendRoot(b []byte, md protoreflect.MessageDescriptor) []byte {
+	b = append(b, '(')
+	b = append(b, md.FullName()...)
+	return append(b, ')')
+}
+
+// AppendField appends the step accessing the field fd, e.g., ".field" or
+// ".(pkg.extension)".
+func AppendField(b []byte, fd protoreflect.FieldDescriptor) []byte {
+	b = append(b, '.')
+	if fd.IsExtension() {
+		b = append(b, '(')
+		b = append(b, strings.Trim(fd.TextName(), "[]")...)
+		return append(b, ')')
+	}
+	return append(b, fd.TextName()...)
+}
+
+// AppendListIndex appends the step indexing a list element, e.g., "[5]".
+func AppendListIndex(b []byte, i int) []byte {
+	b = append(b, '[')
+	b = strconv.AppendInt(b, int64(i), 10)
+	return append(b, ']')
+}
+
+// AppendMapIndex appends the step indexing a map entry by the key k,
+// e.g., "[-32]" or `["hello, world"]`.
+func AppendMapIndex(b []byte, k protoreflect.Value) []byte {
+	b = append(b, '[')
+	switch k := k.Interface().(type) {
+	case bool:
+		b = strconv.AppendBool(b, bool(k)) // e.g., "true" or "false"
+	case int32:
+		b = strconv.AppendInt(b, int64(k), 10) // e.g., "-32"
+	case int64:
+		b = strconv.AppendInt(b, int64(k), 10) // e.g., "-64"
+	case uint32:
+		b = strconv.AppendUint(b, uint64(k), 10) // e.g., "32"
+	case uint64:
+		b = strconv.AppendUint(b, uint64(k), 10) // e.g., "64"
+	case string:
+		b = text.AppendString(b, k) // e.g., `"hello, world"`
+	}
+	return append(b, ']')
+}
+
+// AppendAnyExpand appends the step expanding a google.protobuf.Any message
+// into a message of type md, e.g., ".(pkg.Message)".
+func AppendAnyExpand(b []byte, md protoreflect.MessageDescriptor) []byte {
+	b = append(b, '.')
+	return AppendRoot(b, md)
+}
+
+type stepKind int8
+
+const (
+	rootStep stepKind = iota
+	fieldStep
+	listIndexStep
+	mapIndexStep
+	anyExpandStep
+)
+
+type step struct {
+	kind  stepKind
+	desc  protoreflect.Descriptor
+	index int
+	key   protoreflect.Value
+}
+
+// Path is a path that is built up while traversing a message.
+// Steps are only formatted when String is called, which is cheap enough
+// to track the path of every value that is decoded.
+type Path struct {
+	steps []step
+}
+
+// Root appends the root step for a message of type md.
+func (p *Path) Root(md protoreflect.MessageDescriptor) {
+	p.steps = append(p.steps, step{kind: rootStep, desc: md})
+}
+
+// Field appends the step accessing the field fd.
+func (p *Path) Field(fd protoreflect.FieldDescriptor) {
+	p.steps = append(p.steps, step{kind: fieldStep, desc: fd})
+}
+
+// ListIndex appends the step indexing the list element i.
+func (p *Path) ListIndex(i int) {
+	p.steps = append(p.steps, step{kind: listIndexStep, index: i})
+}
+
+// MapIndex appends the step indexing the map entry with the key k.
+func (p *Path) MapIndex(k protoreflect.MapKey) {
+	p.steps = append(p.steps, step{kind: mapIndexStep, key: k.Value()})
+}
+
+// AnyExpand appends the step expanding an Any message into the type md.
+func (p *Path) AnyExpand(md protoreflect.MessageDescriptor) {
+	p.steps = append(p.steps, step{kind: anyExpandStep, desc: md})
+}
+
+// Len returns the number of steps in the path.
+func (p *Path) Len() int {
+	return len(p.steps)
+}
+
+// Truncate removes all but the first n steps of the path.
+func (p *Path) Truncate(n int) {
+	p.steps = p.steps[:n]
+}
+
+// String formats the path in the syntax of protopath.Path.
+func (p *Path) String() string {
+	var b []byte
+	for _, s := range p.steps {
+		switch s.kind {
+		case rootStep:
+			b = AppendRoot(b, s.desc.(protoreflect.MessageDescriptor))
+		case fieldStep:
+			b = AppendField(b, s.desc.(protoreflect.FieldDescriptor))
+		case listIndexStep:
+			b = AppendListIndex(b, s.index)
+		case mapIndexStep:
+			b = AppendMapIndex(b, s.key)
+		case anyExpandStep:
+			b = AppendAnyExpand(b, s.desc.(protoreflect.MessageDescriptor))
+		}
+	}
+	return string(b)
+}
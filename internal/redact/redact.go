@@ -0,0 +1,107 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package redact reports which fields have their values redacted
+// from debugging output, as marked by the debug_redact option.
+package redact
+
+import (
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Placeholder is the text that replaces the values of redacted fields.
+const Placeholder = "[REDACTED]"
+
+// IsRedacted reports whether the values of fd are redacted.
+//
+// A field is redacted if its debug_redact option is set, or if any of its
+// options, or any field of a message within its options, is set to an enum
+// value whose debug_redact option is set.
+func IsRedacted(fd protoreflect.FieldDescriptor) bool {
+	// Descriptors from internal/filedesc cache the result of Compute,
+	// since walking the options of a field is expensive and this is
+	// called for every field that is printed.
+	if xd, ok := fd.(protoreflect.ExtensionTypeDescriptor); ok {
+		fd = xd.Descriptor()
+	}
+	if fd, ok := fd.(interface{ IsRedacted() bool }); ok {
+		return fd.IsRedacted()
+	}
+	return Compute(fd)
+}
+
+// Compute reports whether the values of fd are redacted, as IsRedacted does,
+// without consulting a cached result.
+// It is exported for use by other internal packages.
+func Compute(fd protoreflect.FieldDescriptor) bool {
+	opts := fd.Options()
+	if opts == nil {
+		return false
+	}
+	m := opts.ProtoReflect()
+	if !m.IsValid() {
+		return false
+	}
+	if m.Descriptor().FullName() == genid.FieldOptions_message_fullname {
+		if dfd := m.Descriptor().Fields().ByNumber(genid.FieldOptions_DebugRedact_field_number); dfd != nil && m.Get(dfd).Bool() {
+			return true
+		}
+	}
+	return hasRedactedEnumValue(m)
+}
+
+// hasRedactedEnumValue reports whether any field of m, or of the messages
+// within m, is set to an enum value whose debug_redact option is set.
+func hasRedactedEnumValue(m protoreflect.Message) (redacted bool) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil && fd.MapValue().Enum() == nil {
+				return true
+			}
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				redacted = isRedactedValue(fd.MapValue(), v)
+				return !redacted
+			})
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len() && !redacted; i++ {
+				redacted = isRedactedValue(fd, list.Get(i))
+			}
+		default:
+			redacted = isRedactedValue(fd, v)
+		}
+		return !redacted
+	})
+	return redacted
+}
+
+// isRedactedValue reports whether v, which is a single value of fd,
+// is or has an enum value whose debug_redact option is set.
+func isRedactedValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		return isRedactedEnumValue(fd.Enum().Values().ByNumber(v.Enum()))
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return hasRedactedEnumValue(v.Message())
+	}
+	return false
+}
+
+func isRedactedEnumValue(evd protoreflect.EnumValueDescriptor) bool {
+	if evd == nil {
+		return false
+	}
+	opts := evd.Options()
+	if opts == nil {
+		return false
+	}
+	m := opts.ProtoReflect()
+	if !m.IsValid() || m.Descriptor().FullName() != genid.EnumValueOptions_message_fullname {
+		return false
+	}
+	fd := m.Descriptor().Fields().ByNumber(genid.EnumValueOptions_DebugRedact_field_number)
+	return fd != nil && m.Get(fd).Bool()
+}
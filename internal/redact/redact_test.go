@@ -0,0 +1,113 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package redact_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"google.golang.org/protobuf/compiler/protoparse"
+	"google.golang.org/protobuf/internal/redact"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const source = `syntax = "proto2";
+package redact.test;
+import "google/protobuf/descriptor.proto";
+
+enum Sensitivity {
+	PUBLIC = 0;
+	SECRET = 1 [debug_redact = true];
+}
+message Policy {
+	optional Sensitivity sensitivity = 1;
+	repeated Sensitivity tags = 2;
+	map<string, Sensitivity> labels = 3;
+}
+extend google.protobuf.FieldOptions {
+	optional Sensitivity sensitivity = 50000;
+	optional Policy policy = 50001;
+}
+
+message Message {
+	optional string plain = 1;
+	optional string password = 2 [debug_redact = true];
+	optional string secret = 3 [(sensitivity) = SECRET];
+	optional string public = 4 [(sensitivity) = PUBLIC];
+	optional string nested = 5 [(policy).sensitivity = SECRET];
+	optional string tagged = 6 [(policy) = { tags: PUBLIC tags: SECRET }];
+	optional string labeled = 7 [(policy) = { labels: { key: "a" value: SECRET } }];
+	optional string untagged = 8 [(policy) = { tags: PUBLIC }];
+	repeated string passwords = 9 [debug_redact = true];
+}
+`
+
+// messageDescriptor returns the descriptor of redact.test.Message,
+// whose field options hold the custom options as extension fields.
+func messageDescriptor(t testing.TB) protoreflect.MessageDescriptor {
+	t.Helper()
+	p := protoparse.Parser{FS: fstest.MapFS{"redact.proto": {Data: []byte(source)}}}
+	files, err := p.ParseFiles("redact.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fdps, err := p.Parse("redact.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The parsed custom options are unknown fields, since their extensions
+	// are not registered globally, so decode them with their types.
+	b, err := proto.Marshal(fdps[len(fdps)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	fdp := new(descriptorpb.FileDescriptorProto)
+	if err := (proto.UnmarshalOptions{Resolver: dynamicpb.NewTypes(files)}).Unmarshal(b, fdp); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd.Messages().ByName("Message")
+}
+
+func TestIsRedacted(t *testing.T) {
+	fields := messageDescriptor(t).Fields()
+	for _, tt := range []struct {
+		field protoreflect.Name
+		want  bool
+	}{
+		{"plain", false},
+		{"password", true},
+		{"secret", true},
+		{"public", false},
+		{"nested", true},
+		{"tagged", true},
+		{"labeled", true},
+		{"untagged", false},
+		{"passwords", true},
+	} {
+		// The second call reports the result cached by the descriptor.
+		for i := 0; i < 2; i++ {
+			if got := redact.IsRedacted(fields.ByName(tt.field)); got != tt.want {
+				t.Errorf("IsRedacted(%v) = %v, want %v", tt.field, got, tt.want)
+			}
+		}
+	}
+}
+
+func BenchmarkIsRedacted(b *testing.B) {
+	fd := messageDescriptor(b).Fields().ByName("labeled")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		redact.IsRedacted(fd)
+	}
+}
@@ -0,0 +1,863 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: internal/testprotos/validate/validate.proto
+
+package validate
+
+import (
+	_ "google.golang.org/protobuf/internal/validatepb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+type Enum int32
+
+const (
+	Enum_ZERO Enum = 0
+	Enum_ONE  Enum = 1
+	Enum_TWO  Enum = 2
+)
+
+// Enum value maps for Enum.
+var (
+	Enum_name = map[int32]string{
+		0: "ZERO",
+		1: "ONE",
+		2: "TWO",
+	}
+	Enum_value = map[string]int32{
+		"ZERO": 0,
+		"ONE":  1,
+		"TWO":  2,
+	}
+)
+
+func (x Enum) Enum() *Enum {
+	p := new(Enum)
+	*p = x
+	return p
+}
+
+func (x Enum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Enum) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_testprotos_validate_validate_proto_enumTypes[0].Descriptor()
+}
+
+func (Enum) Type() protoreflect.EnumType {
+	return &file_internal_testprotos_validate_validate_proto_enumTypes[0]
+}
+
+func (x Enum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Enum.Descriptor instead.
+func (Enum) EnumDescriptor() ([]byte, []int) {
+	return file_internal_testprotos_validate_validate_proto_rawDescGZIP(), []int{0}
+}
+
+type Scalars struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Int32    int32   `protobuf:"varint,1,opt,name=int32,proto3" json:"int32,omitempty"`
+	Sint64   int64   `protobuf:"zigzag64,2,opt,name=sint64,proto3" json:"sint64,omitempty"`
+	Uint32   uint32  `protobuf:"varint,3,opt,name=uint32,proto3" json:"uint32,omitempty"`
+	Fixed64  uint64  `protobuf:"fixed64,4,opt,name=fixed64,proto3" json:"fixed64,omitempty"`
+	Double   float64 `protobuf:"fixed64,5,opt,name=double,proto3" json:"double,omitempty"`
+	Float    float32 `protobuf:"fixed32,6,opt,name=float,proto3" json:"float,omitempty"`
+	String_  string  `protobuf:"bytes,7,opt,name=string,proto3" json:"string,omitempty"`
+	Pattern  string  `protobuf:"bytes,8,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Affix    string  `protobuf:"bytes,9,opt,name=affix,proto3" json:"affix,omitempty"`
+	Bytes    []byte  `protobuf:"bytes,10,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Enum     Enum    `protobuf:"varint,11,opt,name=enum,proto3,enum=goproto.proto.validate.Enum" json:"enum,omitempty"`
+	Required bool    `protobuf:"varint,12,opt,name=required,proto3" json:"required,omitempty"`
+}
+
+func (x *Scalars) Reset() {
+	*x = Scalars{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_testprotos_validate_validate_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Scalars) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scalars) ProtoMessage() {}
+
+func (x *Scalars) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_testprotos_validate_validate_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scalars.ProtoReflect.Descriptor instead.
+func (*Scalars) Descriptor() ([]byte, []int) {
+	return file_internal_testprotos_validate_validate_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Scalars) GetInt32() int32 {
+	if x != nil {
+		return x.Int32
+	}
+	return 0
+}
+
+func (x *Scalars) GetSint64() int64 {
+	if x != nil {
+		return x.Sint64
+	}
+	return 0
+}
+
+func (x *Scalars) GetUint32() uint32 {
+	if x != nil {
+		return x.Uint32
+	}
+	return 0
+}
+
+func (x *Scalars) GetFixed64() uint64 {
+	if x != nil {
+		return x.Fixed64
+	}
+	return 0
+}
+
+func (x *Scalars) GetDouble() float64 {
+	if x != nil {
+		return x.Double
+	}
+	return 0
+}
+
+func (x *Scalars) GetFloat() float32 {
+	if x != nil {
+		return x.Float
+	}
+	return 0
+}
+
+func (x *Scalars) GetString_() string {
+	if x != nil {
+		return x.String_
+	}
+	return ""
+}
+
+func (x *Scalars) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *Scalars) GetAffix() string {
+	if x != nil {
+		return x.Affix
+	}
+	return ""
+}
+
+func (x *Scalars) GetBytes() []byte {
+	if x != nil {
+		return x.Bytes
+	}
+	return nil
+}
+
+func (x *Scalars) GetEnum() Enum {
+	if x != nil {
+		return x.Enum
+	}
+	return Enum_ZERO
+}
+
+func (x *Scalars) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+type Collections struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ints         []int32           `protobuf:"varint,1,rep,packed,name=ints,proto3" json:"ints,omitempty"`
+	Strings      []string          `protobuf:"bytes,2,rep,name=strings,proto3" json:"strings,omitempty"`
+	Nested       []*Nested         `protobuf:"bytes,3,rep,name=nested,proto3" json:"nested,omitempty"`
+	Counts       map[string]int64  `protobuf:"bytes,4,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	NestedMap    map[int32]*Nested `protobuf:"bytes,5,rep,name=nested_map,json=nestedMap,proto3" json:"nested_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	UniqueNested []*Nested         `protobuf:"bytes,6,rep,name=unique_nested,json=uniqueNested,proto3" json:"unique_nested,omitempty"`
+}
+
+func (x *Collections) Reset() {
+	*x = Collections{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_testprotos_validate_validate_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Collections) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collections) ProtoMessage() {}
+
+func (x *Collections) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_testprotos_validate_validate_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collections.ProtoReflect.Descriptor instead.
+func (*Collections) Descriptor() ([]byte, []int) {
+	return file_internal_testprotos_validate_validate_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Collections) GetInts() []int32 {
+	if x != nil {
+		return x.Ints
+	}
+	return nil
+}
+
+func (x *Collections) GetStrings() []string {
+	if x != nil {
+		return x.Strings
+	}
+	return nil
+}
+
+func (x *Collections) GetNested() []*Nested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *Collections) GetCounts() map[string]int64 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *Collections) GetNestedMap() map[int32]*Nested {
+	if x != nil {
+		return x.NestedMap
+	}
+	return nil
+}
+
+func (x *Collections) GetUniqueNested() []*Nested {
+	if x != nil {
+		return x.UniqueNested
+	}
+	return nil
+}
+
+type Nested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Child   *Nested `protobuf:"bytes,2,opt,name=child,proto3" json:"child,omitempty"`
+	Skipped *Nested `protobuf:"bytes,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+}
+
+func (x *Nested) Reset() {
+	*x = Nested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_testprotos_validate_validate_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nested) ProtoMessage() {}
+
+func (x *Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_testprotos_validate_validate_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nested.ProtoReflect.Descriptor instead.
+func (*Nested) Descriptor() ([]byte, []int) {
+	return file_internal_testprotos_validate_validate_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Nested) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Nested) GetChild() *Nested {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+func (x *Nested) GetSkipped() *Nested {
+	if x != nil {
+		return x.Skipped
+	}
+	return nil
+}
+
+type Required struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nested *Nested `protobuf:"bytes,1,opt,name=nested,proto3" json:"nested,omitempty"`
+	List   []int32 `protobuf:"varint,2,rep,packed,name=list,proto3" json:"list,omitempty"`
+	// Types that are assignable to Choice:
+	//
+	//	*Required_A
+	//	*Required_B
+	Choice isRequired_Choice `protobuf_oneof:"choice"`
+}
+
+func (x *Required) Reset() {
+	*x = Required{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_testprotos_validate_validate_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Required) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Required) ProtoMessage() {}
+
+func (x *Required) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_testprotos_validate_validate_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Required.ProtoReflect.Descriptor instead.
+func (*Required) Descriptor() ([]byte, []int) {
+	return file_internal_testprotos_validate_validate_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Required) GetNested() *Nested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *Required) GetList() []int32 {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (m *Required) GetChoice() isRequired_Choice {
+	if m != nil {
+		return m.Choice
+	}
+	return nil
+}
+
+func (x *Required) GetA() string {
+	if x, ok := x.GetChoice().(*Required_A); ok {
+		return x.A
+	}
+	return ""
+}
+
+func (x *Required) GetB() int32 {
+	if x, ok := x.GetChoice().(*Required_B); ok {
+		return x.B
+	}
+	return 0
+}
+
+type isRequired_Choice interface {
+	isRequired_Choice()
+}
+
+type Required_A struct {
+	A string `protobuf:"bytes,3,opt,name=a,proto3,oneof"`
+}
+
+type Required_B struct {
+	B int32 `protobuf:"varint,4,opt,name=b,proto3,oneof"`
+}
+
+func (*Required_A) isRequired_Choice() {}
+
+func (*Required_B) isRequired_Choice() {}
+
+type Disabled struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Disabled) Reset() {
+	*x = Disabled{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_testprotos_validate_validate_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Disabled) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Disabled) ProtoMessage() {}
+
+func (x *Disabled) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_testprotos_validate_validate_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Disabled.ProtoReflect.Descriptor instead.
+func (*Disabled) Descriptor() ([]byte, []int) {
+	return file_internal_testprotos_validate_validate_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Disabled) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type InvalidKind struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *InvalidKind) Reset() {
+	*x = InvalidKind{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_testprotos_validate_validate_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvalidKind) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidKind) ProtoMessage() {}
+
+func (x *InvalidKind) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_testprotos_validate_validate_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidKind.ProtoReflect.Descriptor instead.
+func (*InvalidKind) Descriptor() ([]byte, []int) {
+	return file_internal_testprotos_validate_validate_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InvalidKind) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type InvalidPattern struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *InvalidPattern) Reset() {
+	*x = InvalidPattern{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_testprotos_validate_validate_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvalidPattern) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidPattern) ProtoMessage() {}
+
+func (x *InvalidPattern) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_testprotos_validate_validate_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidPattern.ProtoReflect.Descriptor instead.
+func (*InvalidPattern) Descriptor() ([]byte, []int) {
+	return file_internal_testprotos_validate_validate_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *InvalidPattern) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_internal_testprotos_validate_validate_proto protoreflect.FileDescriptor
+
+var file_internal_testprotos_validate_validate_proto_rawDesc = []byte{
+	0x0a, 0x2b, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x67,
+	0x6f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x1a, 0x25, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x70, 0x62, 0x2f, 0x67, 0x6f, 0x5f, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf7, 0x03, 0x0a,
+	0x07, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x73, 0x12, 0x20, 0x0a, 0x05, 0x69, 0x6e, 0x74, 0x33,
+	0x32, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x0a, 0x8a, 0xb5, 0x18, 0x06, 0x52, 0x04, 0x08,
+	0x0a, 0x20, 0x01, 0x52, 0x05, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x69,
+	0x6e, 0x74, 0x36, 0x34, 0x18, 0x02, 0x20, 0x01, 0x28, 0x12, 0x42, 0x15, 0x8a, 0xb5, 0x18, 0x11,
+	0x52, 0x0f, 0x10, 0x05, 0x18, 0xfb, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x30,
+	0x03, 0x52, 0x06, 0x73, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x12, 0x24, 0x0a, 0x06, 0x75, 0x69, 0x6e,
+	0x74, 0x33, 0x32, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x5a,
+	0x06, 0x28, 0x00, 0x28, 0x01, 0x28, 0x02, 0x52, 0x06, 0x75, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x12,
+	0x22, 0x0a, 0x07, 0x66, 0x69, 0x78, 0x65, 0x64, 0x36, 0x34, 0x18, 0x04, 0x20, 0x01, 0x28, 0x06,
+	0x42, 0x08, 0x8a, 0xb5, 0x18, 0x04, 0x5a, 0x02, 0x10, 0x64, 0x52, 0x07, 0x66, 0x69, 0x78, 0x65,
+	0x64, 0x36, 0x34, 0x12, 0x20, 0x0a, 0x06, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x01, 0x42, 0x08, 0x8a, 0xb5, 0x18, 0x04, 0x62, 0x02, 0x28, 0x01, 0x52, 0x06, 0x64,
+	0x6f, 0x75, 0x62, 0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x05, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x02, 0x42, 0x18, 0x8a, 0xb5, 0x18, 0x14, 0x62, 0x12, 0x09, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0xf0, 0x3f, 0x21, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x52, 0x05,
+	0x66, 0x6c, 0x6f, 0x61, 0x74, 0x12, 0x22, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x8a, 0xb5, 0x18, 0x06, 0x6a, 0x04, 0x10, 0x03, 0x20,
+	0x06, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x42, 0x10, 0x8a, 0xb5, 0x18, 0x0c,
+	0x6a, 0x0a, 0x2a, 0x08, 0x5e, 0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2a, 0x24, 0x52, 0x07, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x2a, 0x0a, 0x05, 0x61, 0x66, 0x66, 0x69, 0x78, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x14, 0x8a, 0xb5, 0x18, 0x10, 0x6a, 0x0e, 0x32, 0x01, 0x61, 0x3a,
+	0x01, 0x7a, 0x42, 0x01, 0x6d, 0x52, 0x03, 0x61, 0x6d, 0x7a, 0x52, 0x05, 0x61, 0x66, 0x66, 0x69,
+	0x78, 0x12, 0x21, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c,
+	0x42, 0x0b, 0x8a, 0xb5, 0x18, 0x07, 0x72, 0x05, 0x10, 0x04, 0x22, 0x01, 0x01, 0x52, 0x05, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x04, 0x65, 0x6e, 0x75, 0x6d, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x45, 0x6e, 0x75, 0x6d,
+	0x42, 0x0a, 0x8a, 0xb5, 0x18, 0x06, 0x7a, 0x04, 0x08, 0x01, 0x18, 0x02, 0x52, 0x04, 0x65, 0x6e,
+	0x75, 0x6d, 0x12, 0x22, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x08, 0x42, 0x06, 0x8a, 0xb5, 0x18, 0x02, 0x08, 0x01, 0x52, 0x08, 0x72, 0x65,
+	0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x22, 0xc6, 0x04, 0x0a, 0x0b, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x04, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x05, 0x42, 0x11, 0x8a, 0xb5, 0x18, 0x0d, 0x82, 0x01, 0x0a, 0x10, 0x03, 0x18,
+	0x01, 0x22, 0x04, 0x52, 0x02, 0x20, 0x00, 0x52, 0x04, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a,
+	0x07, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x42, 0x0f,
+	0x8a, 0xb5, 0x18, 0x0b, 0x82, 0x01, 0x08, 0x08, 0x01, 0x22, 0x04, 0x6a, 0x02, 0x08, 0x01, 0x52,
+	0x07, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x41, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x09, 0x8a, 0xb5, 0x18, 0x05, 0x82, 0x01,
+	0x02, 0x10, 0x02, 0x52, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x66, 0x0a, 0x06, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x67, 0x6f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x1d, 0x8a, 0xb5,
+	0x18, 0x19, 0x8a, 0x01, 0x16, 0x10, 0x02, 0x1a, 0x0c, 0x6a, 0x0a, 0x2a, 0x08, 0x5e, 0x5b, 0x61,
+	0x2d, 0x7a, 0x5d, 0x2b, 0x24, 0x22, 0x04, 0x52, 0x02, 0x20, 0x00, 0x52, 0x06, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x12, 0x51, 0x0a, 0x0a, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x6d, 0x61,
+	0x70, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4e, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6e, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x4d, 0x61, 0x70, 0x12, 0x4e, 0x0a, 0x0d, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65,
+	0x5f, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x67, 0x6f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x09, 0x8a,
+	0xb5, 0x18, 0x05, 0x82, 0x01, 0x02, 0x18, 0x01, 0x52, 0x0c, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65,
+	0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x1a, 0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x1a, 0x5c, 0x0a, 0x0e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x34, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x4e, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x9e, 0x01, 0x0a, 0x06, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0x8a, 0xb5, 0x18, 0x04, 0x6a, 0x02,
+	0x08, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x05, 0x63, 0x68, 0x69, 0x6c,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x12, 0x40,
+	0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42,
+	0x06, 0x8a, 0xb5, 0x18, 0x02, 0x10, 0x01, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x22, 0x98, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x3e, 0x0a,
+	0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x67, 0x6f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x06, 0x8a,
+	0xb5, 0x18, 0x02, 0x08, 0x01, 0x52, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a,
+	0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x42, 0x06, 0x8a, 0xb5, 0x18,
+	0x02, 0x08, 0x01, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x01, 0x61, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x01, 0x61, 0x12, 0x0e, 0x0a, 0x01, 0x62, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x01, 0x62, 0x42, 0x10, 0x0a, 0x06, 0x63, 0x68, 0x6f,
+	0x69, 0x63, 0x65, 0x12, 0x06, 0x8a, 0xb5, 0x18, 0x02, 0x08, 0x01, 0x22, 0x30, 0x0a, 0x08, 0x44,
+	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0x8a, 0xb5, 0x18, 0x04, 0x6a, 0x02, 0x08, 0x01, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x3a, 0x06, 0x8a, 0xb5, 0x18, 0x02, 0x08, 0x01, 0x22, 0x2b, 0x0a,
+	0x0b, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0x8a, 0xb5, 0x18, 0x04,
+	0x52, 0x02, 0x20, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x2f, 0x0a, 0x0e, 0x49, 0x6e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x1d, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x09, 0x8a, 0xb5, 0x18, 0x05,
+	0x6a, 0x03, 0x2a, 0x01, 0x28, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x2a, 0x22, 0x0a, 0x04, 0x45,
+	0x6e, 0x75, 0x6d, 0x12, 0x08, 0x0a, 0x04, 0x5a, 0x45, 0x52, 0x4f, 0x10, 0x00, 0x12, 0x07, 0x0a,
+	0x03, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x57, 0x4f, 0x10, 0x02, 0x42,
+	0x39, 0x5a, 0x37, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67,
+	0x2e, 0x6f, 0x72, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_internal_testprotos_validate_validate_proto_rawDescOnce sync.Once
+	file_internal_testprotos_validate_validate_proto_rawDescData = file_internal_testprotos_validate_validate_proto_rawDesc
+)
+
+func file_internal_testprotos_validate_validate_proto_rawDescGZIP() []byte {
+	file_internal_testprotos_validate_validate_proto_rawDescOnce.Do(func() {
+		file_internal_testprotos_validate_validate_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_testprotos_validate_validate_proto_rawDescData)
+	})
+	return file_internal_testprotos_validate_validate_proto_rawDescData
+}
+
+var file_internal_testprotos_validate_validate_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_internal_testprotos_validate_validate_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_internal_testprotos_validate_validate_proto_goTypes = []any{
+	(Enum)(0),              // 0: goproto.proto.validate.Enum
+	(*Scalars)(nil),        // 1: goproto.proto.validate.Scalars
+	(*Collections)(nil),    // 2: goproto.proto.validate.Collections
+	(*Nested)(nil),         // 3: goproto.proto.validate.Nested
+	(*Required)(nil),       // 4: goproto.proto.validate.Required
+	(*Disabled)(nil),       // 5: goproto.proto.validate.Disabled
+	(*InvalidKind)(nil),    // 6: goproto.proto.validate.InvalidKind
+	(*InvalidPattern)(nil), // 7: goproto.proto.validate.InvalidPattern
+	nil,                    // 8: goproto.proto.validate.Collections.CountsEntry
+	nil,                    // 9: goproto.proto.validate.Collections.NestedMapEntry
+}
+var file_internal_testprotos_validate_validate_proto_depIdxs = []int32{
+	0, // 0: goproto.proto.validate.Scalars.enum:type_name -> goproto.proto.validate.Enum
+	3, // 1: goproto.proto.validate.Collections.nested:type_name -> goproto.proto.validate.Nested
+	8, // 2: goproto.proto.validate.Collections.counts:type_name -> goproto.proto.validate.Collections.CountsEntry
+	9, // 3: goproto.proto.validate.Collections.nested_map:type_name -> goproto.proto.validate.Collections.NestedMapEntry
+	3, // 4: goproto.proto.validate.Collections.unique_nested:type_name -> goproto.proto.validate.Nested
+	3, // 5: goproto.proto.validate.Nested.child:type_name -> goproto.proto.validate.Nested
+	3, // 6: goproto.proto.validate.Nested.skipped:type_name -> goproto.proto.validate.Nested
+	3, // 7: goproto.proto.validate.Required.nested:type_name -> goproto.proto.validate.Nested
+	3, // 8: goproto.proto.validate.Collections.NestedMapEntry.value:type_name -> goproto.proto.validate.Nested
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_internal_testprotos_validate_validate_proto_init() }
+func file_internal_testprotos_validate_validate_proto_init() {
+	if File_internal_testprotos_validate_validate_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_testprotos_validate_validate_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Scalars); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_testprotos_validate_validate_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Collections); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_testprotos_validate_validate_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*Nested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_testprotos_validate_validate_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*Required); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_testprotos_validate_validate_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*Disabled); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_testprotos_validate_validate_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*InvalidKind); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_testprotos_validate_validate_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*InvalidPattern); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_internal_testprotos_validate_validate_proto_msgTypes[3].OneofWrappers = []any{
+		(*Required_A)(nil),
+		(*Required_B)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_testprotos_validate_validate_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_internal_testprotos_validate_validate_proto_goTypes,
+		DependencyIndexes: file_internal_testprotos_validate_validate_proto_depIdxs,
+		EnumInfos:         file_internal_testprotos_validate_validate_proto_enumTypes,
+		MessageInfos:      file_internal_testprotos_validate_validate_proto_msgTypes,
+	}.Build()
+	File_internal_testprotos_validate_validate_proto = out.File
+	file_internal_testprotos_validate_validate_proto_rawDesc = nil
+	file_internal_testprotos_validate_validate_proto_goTypes = nil
+	file_internal_testprotos_validate_validate_proto_depIdxs = nil
+}
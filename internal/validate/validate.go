@@ -0,0 +1,566 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package validate checks messages against the constraints that are declared
+// in their descriptors.
+//
+// Constraints are declared as options defined in
+// internal/validatepb/go_validate.proto, for example:
+//
+//	import "internal/validatepb/go_validate.proto";
+//
+//	message User {
+//	  string name = 1 [(pb.field) = {string: {min_len: 1, max_len: 64}}];
+//	  uint32 age = 2 [(pb.field) = {uint: {lte: 150}}];
+//	  repeated string emails = 3 [(pb.field) = {
+//	    repeated: {unique: true, items: {string: {pattern: "^[^@]+@[^@]+$"}}}
+//	  }];
+//	}
+//
+// The constraints are read from the descriptors at runtime through
+// protobuf reflection, such that validation works for generated messages and
+// dynamic messages alike without any additional generated code.
+// See the validatepb package for the available constraints.
+//
+// The package is internal, along with the constraints, until an extension
+// number is allocated for them in the global extension registry.
+package validate
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/order"
+	"google.golang.org/protobuf/internal/validatepb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Violation describes a value that does not satisfy a constraint.
+type Violation struct {
+	// Path is the path to the invalid value, starting from the validated
+	// message. The path of a violated oneof constraint is the path of
+	// the message containing the oneof.
+	Path protopath.Path
+
+	// Constraint is the name of the violated rule relative to the
+	// constraints declared for the field, such as "required",
+	// "string.min_len" or "map.keys.string.pattern".
+	// Violated oneof constraints are named "oneof.required".
+	Constraint string
+
+	// Message is a human-readable description of the violation.
+	Message string
+}
+
+func (v *Violation) String() string {
+	return fmt.Sprintf("%v: %v", v.Path, v.Message)
+}
+
+// ValidationError is the error returned by [Validate]
+// when a message does not satisfy its constraints.
+type ValidationError struct {
+	// Violations are all violations in the message,
+	// in the order that fields are declared.
+	Violations []*Violation
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("proto: validation failed: ")
+	for i, v := range e.Violations {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(v.String())
+	}
+	return b.String()
+}
+
+// Validate reports whether the message m satisfies the constraints
+// declared for it and for all messages contained within it.
+//
+// If any value does not satisfy its constraints, Validate returns a
+// [*ValidationError] that lists all violations. If the constraints themselves
+// are invalid, such as rules that do not apply to the kind of a field or
+// a pattern that is not a valid regular expression, it returns
+// a different error.
+func Validate(m proto.Message) error {
+	if m == nil {
+		return nil
+	}
+	mr := m.ProtoReflect()
+	if !mr.IsValid() {
+		return nil
+	}
+	v := &validator{
+		path:        protopath.Path{protopath.Root(mr.Descriptor())},
+		constraints: make(map[protoreflect.Descriptor]any),
+		patterns:    make(map[string]*regexp.Regexp),
+	}
+	if err := v.validateMessage(mr); err != nil {
+		return err
+	}
+	if len(v.violations) > 0 {
+		return &ValidationError{Violations: v.violations}
+	}
+	return nil
+}
+
+type validator struct {
+	path       protopath.Path
+	violations []*Violation
+
+	// constraints and patterns memoize the constraints of each descriptor
+	// and the compiled patterns for the duration of a call to Validate.
+	constraints map[protoreflect.Descriptor]any
+	patterns    map[string]*regexp.Regexp
+}
+
+func (v *validator) push(s protopath.Step) {
+	v.path = append(v.path, s)
+}
+
+func (v *validator) pop() {
+	v.path = v.path[:len(v.path)-1]
+}
+
+func (v *validator) report(constraint, format string, args ...any) {
+	v.violations = append(v.violations, &Violation{
+		Path:       append(protopath.Path(nil), v.path...),
+		Constraint: constraint,
+		Message:    fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) validateMessage(m protoreflect.Message) error {
+	md := m.Descriptor()
+	if mc, _ := v.getExtension(md, validatepb.E_Message).(*validatepb.MessageConstraints); mc.GetDisabled() {
+		return nil
+	}
+	for i := 0; i < md.Oneofs().Len(); i++ {
+		od := md.Oneofs().Get(i)
+		oc, _ := v.getExtension(od, validatepb.E_Oneof).(*validatepb.OneofConstraints)
+		if oc.GetRequired() && m.WhichOneof(od) == nil {
+			v.report("oneof.required", "one of the fields of oneof %v is required", od.Name())
+		}
+	}
+	for i := 0; i < md.Fields().Len(); i++ {
+		if err := v.validateField(m, md.Fields().Get(i)); err != nil {
+			return err
+		}
+	}
+	var err error
+	order.RangeFields(m, order.NumberFieldOrder, func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if fd.IsExtension() {
+			err = v.validateField(m, fd)
+		}
+		return err == nil
+	})
+	return err
+}
+
+func (v *validator) validateField(m protoreflect.Message, fd protoreflect.FieldDescriptor) error {
+	fc := v.fieldConstraints(fd)
+	if fc == nil && fd.Message() == nil {
+		return nil
+	}
+	if err := checkRules(fd, fc, false); err != nil {
+		return err
+	}
+
+	v.push(protopath.FieldAccess(fd))
+	defer v.pop()
+	if !m.Has(fd) {
+		if fc.GetRequired() {
+			v.report("required", "value is required")
+		}
+		// Unpopulated fields without presence still have the zero value,
+		// which is validated as any other value.
+		if fd.HasPresence() {
+			return nil
+		}
+	}
+	switch {
+	case fd.IsList():
+		return v.validateList(fd, m.Get(fd).List(), fc.GetRepeated())
+	case fd.IsMap():
+		return v.validateMap(fd, m.Get(fd).Map(), fc.GetMap())
+	default:
+		return v.validateValue(fd, m.Get(fd), fc, "")
+	}
+}
+
+func (v *validator) validateList(fd protoreflect.FieldDescriptor, l protoreflect.List, rr *validatepb.RepeatedRules) error {
+	if uint64(l.Len()) < rr.GetMinItems() {
+		v.report("repeated.min_items", "list must have at least %d items", rr.GetMinItems())
+	}
+	if rr != nil && rr.MaxItems != nil && uint64(l.Len()) > rr.GetMaxItems() {
+		v.report("repeated.max_items", "list must have at most %d items", rr.GetMaxItems())
+	}
+	for i := 0; i < l.Len(); i++ {
+		v.push(protopath.ListIndex(i))
+		if rr.GetUnique() {
+			for j := 0; j < i; j++ {
+				if l.Get(j).Equal(l.Get(i)) {
+					v.report("repeated.unique", "item duplicates item %d", j)
+					break
+				}
+			}
+		}
+		err := v.validateValue(fd, l.Get(i), rr.GetItems(), "repeated.items.")
+		v.pop()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *validator) validateMap(fd protoreflect.FieldDescriptor, mm protoreflect.Map, mr *validatepb.MapRules) error {
+	if uint64(mm.Len()) < mr.GetMinPairs() {
+		v.report("map.min_pairs", "map must have at least %d entries", mr.GetMinPairs())
+	}
+	if mr != nil && mr.MaxPairs != nil && uint64(mm.Len()) > mr.GetMaxPairs() {
+		v.report("map.max_pairs", "map must have at most %d entries", mr.GetMaxPairs())
+	}
+	var err error
+	order.RangeEntries(mm, order.GenericKeyOrder, func(k protoreflect.MapKey, val protoreflect.Value) bool {
+		v.push(protopath.MapIndex(k))
+		defer v.pop()
+		if err = v.validateValue(fd.MapKey(), k.Value(), mr.GetKeys(), "map.keys."); err != nil {
+			return false
+		}
+		err = v.validateValue(fd.MapValue(), val, mr.GetValues(), "map.values.")
+		return err == nil
+	})
+	return err
+}
+
+// validateValue validates a singular value of fd, which is the value of
+// a singular field, a list element, or a map key or value.
+// The prefix is the name of the rules of fc relative to the field constraints.
+func (v *validator) validateValue(fd protoreflect.FieldDescriptor, val protoreflect.Value, fc *validatepb.FieldConstraints, prefix string) error {
+	switch fc.GetType().(type) {
+	case *validatepb.FieldConstraints_Int:
+		v.validateInt(val, fc.GetInt(), prefix+"int.")
+	case *validatepb.FieldConstraints_Uint:
+		v.validateUint(val, fc.GetUint(), prefix+"uint.")
+	case *validatepb.FieldConstraints_Float:
+		v.validateFloat(val, fc.GetFloat(), prefix+"float.")
+	case *validatepb.FieldConstraints_String_:
+		return v.validateString(val, fc.GetString_(), prefix+"string.")
+	case *validatepb.FieldConstraints_Bytes:
+		return v.validateBytes(val, fc.GetBytes(), prefix+"bytes.")
+	case *validatepb.FieldConstraints_Enum:
+		v.validateEnum(fd, val, fc.GetEnum(), prefix+"enum.")
+	}
+	if fd.Message() != nil && !fc.GetSkip() {
+		return v.validateMessage(val.Message())
+	}
+	return nil
+}
+
+func (v *validator) validateInt(val protoreflect.Value, r *validatepb.IntRules, prefix string) {
+	n := val.Int()
+	if r.Lt != nil && !(n < r.GetLt()) {
+		v.report(prefix+"lt", "value must be less than %d", r.GetLt())
+	}
+	if r.Lte != nil && !(n <= r.GetLte()) {
+		v.report(prefix+"lte", "value must be less than or equal to %d", r.GetLte())
+	}
+	if r.Gt != nil && !(n > r.GetGt()) {
+		v.report(prefix+"gt", "value must be greater than %d", r.GetGt())
+	}
+	if r.Gte != nil && !(n >= r.GetGte()) {
+		v.report(prefix+"gte", "value must be greater than or equal to %d", r.GetGte())
+	}
+	if len(r.GetIn()) > 0 && !containsInt(r.GetIn(), n) {
+		v.report(prefix+"in", "value must be one of %v", r.GetIn())
+	}
+	if containsInt(r.GetNotIn(), n) {
+		v.report(prefix+"not_in", "value must not be one of %v", r.GetNotIn())
+	}
+}
+
+func (v *validator) validateUint(val protoreflect.Value, r *validatepb.UintRules, prefix string) {
+	n := val.Uint()
+	if r.Lt != nil && !(n < r.GetLt()) {
+		v.report(prefix+"lt", "value must be less than %d", r.GetLt())
+	}
+	if r.Lte != nil && !(n <= r.GetLte()) {
+		v.report(prefix+"lte", "value must be less than or equal to %d", r.GetLte())
+	}
+	if r.Gt != nil && !(n > r.GetGt()) {
+		v.report(prefix+"gt", "value must be greater than %d", r.GetGt())
+	}
+	if r.Gte != nil && !(n >= r.GetGte()) {
+		v.report(prefix+"gte", "value must be greater than or equal to %d", r.GetGte())
+	}
+	if len(r.GetIn()) > 0 && !containsUint(r.GetIn(), n) {
+		v.report(prefix+"in", "value must be one of %v", r.GetIn())
+	}
+	if containsUint(r.GetNotIn(), n) {
+		v.report(prefix+"not_in", "value must not be one of %v", r.GetNotIn())
+	}
+}
+
+func (v *validator) validateFloat(val protoreflect.Value, r *validatepb.FloatRules, prefix string) {
+	// Comparisons with NaN are always false, such that NaN violates
+	// all range rules.
+	f := val.Float()
+	if r.Lt != nil && !(f < r.GetLt()) {
+		v.report(prefix+"lt", "value must be less than %v", r.GetLt())
+	}
+	if r.Lte != nil && !(f <= r.GetLte()) {
+		v.report(prefix+"lte", "value must be less than or equal to %v", r.GetLte())
+	}
+	if r.Gt != nil && !(f > r.GetGt()) {
+		v.report(prefix+"gt", "value must be greater than %v", r.GetGt())
+	}
+	if r.Gte != nil && !(f >= r.GetGte()) {
+		v.report(prefix+"gte", "value must be greater than or equal to %v", r.GetGte())
+	}
+	if r.GetFinite() && (math.IsInf(f, 0) || math.IsNaN(f)) {
+		v.report(prefix+"finite", "value must be finite")
+	}
+}
+
+func (v *validator) validateString(val protoreflect.Value, r *validatepb.StringRules, prefix string) error {
+	s := val.String()
+	if n := uint64(utf8.RuneCountInString(s)); r.MinLen != nil && n < r.GetMinLen() {
+		v.report(prefix+"min_len", "value must be at least %d characters long", r.GetMinLen())
+	} else if r.MaxLen != nil && n > r.GetMaxLen() {
+		v.report(prefix+"max_len", "value must be at most %d characters long", r.GetMaxLen())
+	}
+	if n := uint64(len(s)); r.MinBytes != nil && n < r.GetMinBytes() {
+		v.report(prefix+"min_bytes", "value must be at least %d bytes long", r.GetMinBytes())
+	} else if r.MaxBytes != nil && n > r.GetMaxBytes() {
+		v.report(prefix+"max_bytes", "value must be at most %d bytes long", r.GetMaxBytes())
+	}
+	if r.Pattern != nil {
+		re, err := v.compilePattern(r.GetPattern())
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(s) {
+			v.report(prefix+"pattern", "value must match pattern %q", r.GetPattern())
+		}
+	}
+	if r.Prefix != nil && !strings.HasPrefix(s, r.GetPrefix()) {
+		v.report(prefix+"prefix", "value must start with %q", r.GetPrefix())
+	}
+	if r.Suffix != nil && !strings.HasSuffix(s, r.GetSuffix()) {
+		v.report(prefix+"suffix", "value must end with %q", r.GetSuffix())
+	}
+	if r.Contains != nil && !strings.Contains(s, r.GetContains()) {
+		v.report(prefix+"contains", "value must contain %q", r.GetContains())
+	}
+	if len(r.GetIn()) > 0 && !containsString(r.GetIn(), s) {
+		v.report(prefix+"in", "value must be one of %q", r.GetIn())
+	}
+	if containsString(r.GetNotIn(), s) {
+		v.report(prefix+"not_in", "value must not be one of %q", r.GetNotIn())
+	}
+	return nil
+}
+
+func (v *validator) validateBytes(val protoreflect.Value, r *validatepb.BytesRules, prefix string) error {
+	b := val.Bytes()
+	if n := uint64(len(b)); r.MinLen != nil && n < r.GetMinLen() {
+		v.report(prefix+"min_len", "value must be at least %d bytes long", r.GetMinLen())
+	} else if r.MaxLen != nil && n > r.GetMaxLen() {
+		v.report(prefix+"max_len", "value must be at most %d bytes long", r.GetMaxLen())
+	}
+	if r.Pattern != nil {
+		re, err := v.compilePattern(r.GetPattern())
+		if err != nil {
+			return err
+		}
+		if !re.Match(b) {
+			v.report(prefix+"pattern", "value must match pattern %q", r.GetPattern())
+		}
+	}
+	if r.Prefix != nil && !bytes.HasPrefix(b, r.GetPrefix()) {
+		v.report(prefix+"prefix", "value must start with %q", r.GetPrefix())
+	}
+	if r.Suffix != nil && !bytes.HasSuffix(b, r.GetSuffix()) {
+		v.report(prefix+"suffix", "value must end with %q", r.GetSuffix())
+	}
+	return nil
+}
+
+func (v *validator) validateEnum(fd protoreflect.FieldDescriptor, val protoreflect.Value, r *validatepb.EnumRules, prefix string) {
+	n := val.Enum()
+	if r.GetDefinedOnly() && fd.Enum().Values().ByNumber(n) == nil {
+		v.report(prefix+"defined_only", "value %d is not defined in enum %v", n, fd.Enum().FullName())
+	}
+	if len(r.GetIn()) > 0 && !containsInt32(r.GetIn(), int32(n)) {
+		v.report(prefix+"in", "value must be one of %v", r.GetIn())
+	}
+	if containsInt32(r.GetNotIn(), int32(n)) {
+		v.report(prefix+"not_in", "value must not be one of %v", r.GetNotIn())
+	}
+}
+
+// checkRules reports an error if the type-specific rules of fc do not apply
+// to fd. If elem is set, the rules apply to the elements of a list field.
+func checkRules(fd protoreflect.FieldDescriptor, fc *validatepb.FieldConstraints, elem bool) error {
+	if fc == nil {
+		return nil
+	}
+	var want protoreflect.Name
+	switch {
+	case fd.IsList() && !elem:
+		want = "repeated"
+	case fd.IsMap():
+		want = "map"
+	default:
+		switch fd.Kind() {
+		case protoreflect.Int32Kind, protoreflect.Int64Kind,
+			protoreflect.Sint32Kind, protoreflect.Sint64Kind,
+			protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+			want = "int"
+		case protoreflect.Uint32Kind, protoreflect.Uint64Kind,
+			protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+			want = "uint"
+		case protoreflect.FloatKind, protoreflect.DoubleKind:
+			want = "float"
+		case protoreflect.StringKind:
+			want = "string"
+		case protoreflect.BytesKind:
+			want = "bytes"
+		case protoreflect.EnumKind:
+			want = "enum"
+		}
+	}
+	m := fc.ProtoReflect()
+	rules := m.WhichOneof(m.Descriptor().Oneofs().ByName("type"))
+	if rules != nil && rules.Name() != want {
+		return errors.New("invalid constraints for field %v: %v rules do not apply to a %v field", fd.FullName(), rules.Name(), fieldKind(fd, elem))
+	}
+	switch {
+	case fd.IsList() && !elem:
+		return checkRules(fd, fc.GetRepeated().GetItems(), true)
+	case fd.IsMap():
+		if err := checkRules(fd.MapKey(), fc.GetMap().GetKeys(), false); err != nil {
+			return err
+		}
+		return checkRules(fd.MapValue(), fc.GetMap().GetValues(), false)
+	}
+	return nil
+}
+
+func fieldKind(fd protoreflect.FieldDescriptor, elem bool) string {
+	switch {
+	case fd.IsList() && !elem:
+		return "repeated"
+	case fd.IsMap():
+		return "map"
+	default:
+		return fd.Kind().String()
+	}
+}
+
+func (v *validator) fieldConstraints(fd protoreflect.FieldDescriptor) *validatepb.FieldConstraints {
+	fc, _ := v.getExtension(fd, validatepb.E_Field).(*validatepb.FieldConstraints)
+	return fc
+}
+
+// getExtension returns the value of the extension xt in the options of d,
+// or nil if it is not populated. Every kind of descriptor has a single
+// constraints extension, so the descriptor alone is the key in v.constraints.
+func (v *validator) getExtension(d protoreflect.Descriptor, xt protoreflect.ExtensionType) any {
+	if x, ok := v.constraints[d]; ok {
+		return x
+	}
+	x := resolveExtension(d.Options(), xt)
+	v.constraints[d] = x
+	return x
+}
+
+// resolveExtension returns the value of the extension xt in the options
+// message, or nil if it is not populated.
+//
+// Options of descriptors that were constructed while the extension type was
+// not registered hold the extension in their unknown fields,
+// in which case they are unmarshaled again with the extension type registered.
+func resolveExtension(opts proto.Message, xt protoreflect.ExtensionType) any {
+	if opts == nil || !opts.ProtoReflect().IsValid() {
+		return nil
+	}
+	if proto.HasExtension(opts, xt) {
+		return proto.GetExtension(opts, xt)
+	}
+	if len(opts.ProtoReflect().GetUnknown()) == 0 {
+		return nil
+	}
+	b, err := proto.MarshalOptions{AllowPartial: true}.Marshal(opts)
+	if err != nil {
+		return nil
+	}
+	resolved := opts.ProtoReflect().New().Interface()
+	if err := (proto.UnmarshalOptions{
+		AllowPartial: true,
+		Resolver:     protoregistry.GlobalTypes,
+	}).Unmarshal(b, resolved); err != nil || !proto.HasExtension(resolved, xt) {
+		return nil
+	}
+	return proto.GetExtension(resolved, xt)
+}
+
+func (v *validator) compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := v.patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.New("invalid constraints: invalid pattern %q: %v", pattern, err)
+	}
+	v.patterns[pattern] = re
+	return re, nil
+}
+
+func containsInt(s []int64, v int64) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt32(s []int32, v int32) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsUint(s []uint64, v uint64) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(s []string, v string) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
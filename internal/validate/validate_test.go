@@ -0,0 +1,257 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package validate_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/internal/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	validatepb "google.golang.org/protobuf/internal/testprotos/validate"
+)
+
+func validScalars() *validatepb.Scalars {
+	return &validatepb.Scalars{
+		Int32:    1,
+		Affix:    "abmz",
+		Bytes:    []byte{1},
+		Required: true,
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc string
+		m    proto.Message
+		want []string // violations as "path: constraint"
+	}{{
+		desc: "valid scalars",
+		m:    validScalars(),
+	}, {
+		desc: "zero scalars",
+		m:    &validatepb.Scalars{},
+		want: []string{
+			"(goproto.proto.validate.Scalars).int32: int.gte",
+			"(goproto.proto.validate.Scalars).affix: string.prefix",
+			"(goproto.proto.validate.Scalars).affix: string.suffix",
+			"(goproto.proto.validate.Scalars).affix: string.contains",
+			"(goproto.proto.validate.Scalars).bytes: bytes.prefix",
+			"(goproto.proto.validate.Scalars).required: required",
+		},
+	}, {
+		desc: "invalid numbers",
+		m: func() proto.Message {
+			m := validScalars()
+			m.Int32 = 10
+			m.Sint64 = 3
+			m.Uint32 = 3
+			m.Fixed64 = 101
+			m.Double = math.Inf(-1)
+			m.Float = float32(math.NaN())
+			return m
+		}(),
+		want: []string{
+			"(goproto.proto.validate.Scalars).int32: int.lt",
+			"(goproto.proto.validate.Scalars).sint64: int.not_in",
+			"(goproto.proto.validate.Scalars).uint32: uint.in",
+			"(goproto.proto.validate.Scalars).fixed64: uint.lte",
+			"(goproto.proto.validate.Scalars).double: float.finite",
+			"(goproto.proto.validate.Scalars).float: float.lt",
+			"(goproto.proto.validate.Scalars).float: float.gte",
+		},
+	}, {
+		desc: "invalid strings and bytes",
+		m: func() proto.Message {
+			m := validScalars()
+			m.String_ = "ééé"
+			m.Pattern = "A"
+			m.Affix = "amz"
+			m.Bytes = []byte{1, 2, 3, 4, 5}
+			return m
+		}(),
+		want: []string{
+			"(goproto.proto.validate.Scalars).pattern: string.pattern",
+			"(goproto.proto.validate.Scalars).affix: string.not_in",
+			"(goproto.proto.validate.Scalars).bytes: bytes.max_len",
+		},
+	}, {
+		desc: "string length",
+		m: func() proto.Message {
+			m := validScalars()
+			m.String_ = "éééé"
+			return m
+		}(),
+		want: []string{
+			"(goproto.proto.validate.Scalars).string: string.max_len",
+			"(goproto.proto.validate.Scalars).string: string.max_bytes",
+		},
+	}, {
+		desc: "invalid enums",
+		m: func() proto.Message {
+			m := validScalars()
+			m.Enum = 5
+			return m
+		}(),
+		want: []string{
+			"(goproto.proto.validate.Scalars).enum: enum.defined_only",
+		},
+	}, {
+		desc: "excluded enum",
+		m: func() proto.Message {
+			m := validScalars()
+			m.Enum = validatepb.Enum_TWO
+			return m
+		}(),
+		want: []string{
+			"(goproto.proto.validate.Scalars).enum: enum.not_in",
+		},
+	}, {
+		desc: "valid collections",
+		m: &validatepb.Collections{
+			Ints:    []int32{1, 2, 3},
+			Strings: []string{"a"},
+			Counts:  map[string]int64{"a": 1, "b": 2},
+		},
+	}, {
+		desc: "invalid collections",
+		m: &validatepb.Collections{
+			Ints:   []int32{1, -1, 1, 2},
+			Nested: []*validatepb.Nested{{Name: "a"}, {Name: "b"}, {}},
+			Counts: map[string]int64{"a": 1, "B": -1, "c": 0},
+			NestedMap: map[int32]*validatepb.Nested{
+				1: {Name: "a"},
+				2: {Child: &validatepb.Nested{}},
+			},
+			UniqueNested: []*validatepb.Nested{{Name: "a"}, {Name: "b"}, {Name: "a"}},
+		},
+		want: []string{
+			"(goproto.proto.validate.Collections).ints: repeated.max_items",
+			"(goproto.proto.validate.Collections).ints[1]: repeated.items.int.gte",
+			"(goproto.proto.validate.Collections).ints[2]: repeated.unique",
+			"(goproto.proto.validate.Collections).strings: repeated.min_items",
+			"(goproto.proto.validate.Collections).nested: repeated.max_items",
+			"(goproto.proto.validate.Collections).nested[2].name: string.min_len",
+			"(goproto.proto.validate.Collections).counts: map.max_pairs",
+			`(goproto.proto.validate.Collections).counts["B"]: map.keys.string.pattern`,
+			`(goproto.proto.validate.Collections).counts["B"]: map.values.int.gte`,
+			"(goproto.proto.validate.Collections).nested_map[2].name: string.min_len",
+			"(goproto.proto.validate.Collections).nested_map[2].child.name: string.min_len",
+			"(goproto.proto.validate.Collections).unique_nested[2]: repeated.unique",
+		},
+	}, {
+		desc: "skipped message",
+		m: &validatepb.Nested{
+			Name:    "a",
+			Skipped: &validatepb.Nested{},
+		},
+	}, {
+		desc: "missing required fields",
+		m:    &validatepb.Required{},
+		want: []string{
+			"(goproto.proto.validate.Required): oneof.required",
+			"(goproto.proto.validate.Required).nested: required",
+			"(goproto.proto.validate.Required).list: required",
+		},
+	}, {
+		desc: "populated required fields",
+		m: &validatepb.Required{
+			Nested: &validatepb.Nested{Name: "a"},
+			List:   []int32{0},
+			Choice: &validatepb.Required_B{},
+		},
+	}, {
+		desc: "disabled message",
+		m:    &validatepb.Disabled{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			for _, dynamic := range []bool{false, true} {
+				m := tt.m
+				if dynamic {
+					dm := dynamicpb.NewMessage(m.ProtoReflect().Descriptor())
+					proto.Merge(dm, m)
+					m = dm
+				}
+				got := violations(t, validate.Validate(m))
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("Validate(dynamic=%v) mismatch (-want +got):\n%s", dynamic, diff)
+				}
+			}
+		})
+	}
+}
+
+// violations returns the violations in err formatted as "path: constraint".
+func violations(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var verr *validate.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Validate() error = %v, want *ValidationError", err)
+	}
+	var out []string
+	for _, v := range verr.Violations {
+		out = append(out, v.Path.String()+": "+v.Constraint)
+	}
+	return out
+}
+
+func TestValidateInvalidConstraints(t *testing.T) {
+	for _, m := range []proto.Message{
+		&validatepb.InvalidKind{},
+		&validatepb.InvalidPattern{},
+	} {
+		err := validate.Validate(m)
+		var verr *validate.ValidationError
+		if err == nil || errors.As(err, &verr) {
+			t.Errorf("Validate(%T) = %v, want invalid constraints error", m, err)
+		}
+	}
+}
+
+func TestValidateUnresolvedOptions(t *testing.T) {
+	// Descriptors that were constructed from descriptor protos which were
+	// unmarshaled without the constraint extensions being resolvable
+	// hold the constraints in the unknown fields of their options.
+	fdp := protodesc.ToFileDescriptorProto(validatepb.File_internal_testprotos_validate_validate_proto)
+	b, err := proto.Marshal(fdp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fdp = new(descriptorpb.FileDescriptorProto)
+	if err := (proto.UnmarshalOptions{Resolver: new(protoregistry.Types)}).Unmarshal(b, fdp); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := dynamicpb.NewMessage(fd.Messages().ByName("Nested"))
+	got := violations(t, validate.Validate(m))
+	want := []string{"(goproto.proto.validate.Nested).name: string.min_len"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Validate() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	err := validate.Validate(&validatepb.Nested{Child: &validatepb.Nested{Name: "a"}})
+	want := "proto: validation failed: (goproto.proto.validate.Nested).name: value must be at least 1 characters long"
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %v", err, want)
+	}
+}
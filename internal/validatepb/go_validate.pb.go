@@ -0,0 +1,1418 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Constraint annotations for fields, oneofs and messages,
+// which are checked by the internal/validate package.
+//
+// The constraints are internal to this module until an extension number is
+// allocated for them in the global extension registry. Until then, they use
+// a number from the range for in-house use.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: internal/validatepb/go_validate.proto
+
+package validatepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+// MessageConstraints are the constraints for a message.
+type MessageConstraints struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether to skip validation of all fields of the message.
+	Disabled *bool `protobuf:"varint,1,opt,name=disabled" json:"disabled,omitempty"`
+}
+
+func (x *MessageConstraints) Reset() {
+	*x = MessageConstraints{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_validatepb_go_validate_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageConstraints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageConstraints) ProtoMessage() {}
+
+func (x *MessageConstraints) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_validatepb_go_validate_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageConstraints.ProtoReflect.Descriptor instead.
+func (*MessageConstraints) Descriptor() ([]byte, []int) {
+	return file_internal_validatepb_go_validate_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MessageConstraints) GetDisabled() bool {
+	if x != nil && x.Disabled != nil {
+		return *x.Disabled
+	}
+	return false
+}
+
+// OneofConstraints are the constraints for a oneof.
+type OneofConstraints struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether exactly one field of the oneof must be populated.
+	Required *bool `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+}
+
+func (x *OneofConstraints) Reset() {
+	*x = OneofConstraints{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_validatepb_go_validate_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OneofConstraints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OneofConstraints) ProtoMessage() {}
+
+func (x *OneofConstraints) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_validatepb_go_validate_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OneofConstraints.ProtoReflect.Descriptor instead.
+func (*OneofConstraints) Descriptor() ([]byte, []int) {
+	return file_internal_validatepb_go_validate_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OneofConstraints) GetRequired() bool {
+	if x != nil && x.Required != nil {
+		return *x.Required
+	}
+	return false
+}
+
+// FieldConstraints are the constraints for a field.
+//
+// The type-specific rules must match the kind of the field.
+// The rules for the elements of a repeated field or the entries of a
+// map field are specified within the repeated or map rules.
+type FieldConstraints struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether the field must be populated. A repeated or map field is populated
+	// if it is not empty. A field without presence is populated if it is not
+	// the zero value.
+	Required *bool `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	// Whether to skip validation of the fields of a message value.
+	Skip *bool `protobuf:"varint,2,opt,name=skip" json:"skip,omitempty"`
+	// Types that are assignable to Type:
+	//
+	//	*FieldConstraints_Int
+	//	*FieldConstraints_Uint
+	//	*FieldConstraints_Float
+	//	*FieldConstraints_String_
+	//	*FieldConstraints_Bytes
+	//	*FieldConstraints_Enum
+	//	*FieldConstraints_Repeated
+	//	*FieldConstraints_Map
+	Type isFieldConstraints_Type `protobuf_oneof:"type"`
+}
+
+func (x *FieldConstraints) Reset() {
+	*x = FieldConstraints{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_validatepb_go_validate_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldConstraints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldConstraints) ProtoMessage() {}
+
+func (x *FieldConstraints) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_validatepb_go_validate_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldConstraints.ProtoReflect.Descriptor instead.
+func (*FieldConstraints) Descriptor() ([]byte, []int) {
+	return file_internal_validatepb_go_validate_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FieldConstraints) GetRequired() bool {
+	if x != nil && x.Required != nil {
+		return *x.Required
+	}
+	return false
+}
+
+func (x *FieldConstraints) GetSkip() bool {
+	if x != nil && x.Skip != nil {
+		return *x.Skip
+	}
+	return false
+}
+
+func (m *FieldConstraints) GetType() isFieldConstraints_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (x *FieldConstraints) GetInt() *IntRules {
+	if x, ok := x.GetType().(*FieldConstraints_Int); ok {
+		return x.Int
+	}
+	return nil
+}
+
+func (x *FieldConstraints) GetUint() *UintRules {
+	if x, ok := x.GetType().(*FieldConstraints_Uint); ok {
+		return x.Uint
+	}
+	return nil
+}
+
+func (x *FieldConstraints) GetFloat() *FloatRules {
+	if x, ok := x.GetType().(*FieldConstraints_Float); ok {
+		return x.Float
+	}
+	return nil
+}
+
+func (x *FieldConstraints) GetString_() *StringRules {
+	if x, ok := x.GetType().(*FieldConstraints_String_); ok {
+		return x.String_
+	}
+	return nil
+}
+
+func (x *FieldConstraints) GetBytes() *BytesRules {
+	if x, ok := x.GetType().(*FieldConstraints_Bytes); ok {
+		return x.Bytes
+	}
+	return nil
+}
+
+func (x *FieldConstraints) GetEnum() *EnumRules {
+	if x, ok := x.GetType().(*FieldConstraints_Enum); ok {
+		return x.Enum
+	}
+	return nil
+}
+
+func (x *FieldConstraints) GetRepeated() *RepeatedRules {
+	if x, ok := x.GetType().(*FieldConstraints_Repeated); ok {
+		return x.Repeated
+	}
+	return nil
+}
+
+func (x *FieldConstraints) GetMap() *MapRules {
+	if x, ok := x.GetType().(*FieldConstraints_Map); ok {
+		return x.Map
+	}
+	return nil
+}
+
+type isFieldConstraints_Type interface {
+	isFieldConstraints_Type()
+}
+
+type FieldConstraints_Int struct {
+	Int *IntRules `protobuf:"bytes,10,opt,name=int,oneof"`
+}
+
+type FieldConstraints_Uint struct {
+	Uint *UintRules `protobuf:"bytes,11,opt,name=uint,oneof"`
+}
+
+type FieldConstraints_Float struct {
+	Float *FloatRules `protobuf:"bytes,12,opt,name=float,oneof"`
+}
+
+type FieldConstraints_String_ struct {
+	String_ *StringRules `protobuf:"bytes,13,opt,name=string,oneof"`
+}
+
+type FieldConstraints_Bytes struct {
+	Bytes *BytesRules `protobuf:"bytes,14,opt,name=bytes,oneof"`
+}
+
+type FieldConstraints_Enum struct {
+	Enum *EnumRules `protobuf:"bytes,15,opt,name=enum,oneof"`
+}
+
+type FieldConstraints_Repeated struct {
+	Repeated *RepeatedRules `protobuf:"bytes,16,opt,name=repeated,oneof"`
+}
+
+type FieldConstraints_Map struct {
+	Map *MapRules `protobuf:"bytes,17,opt,name=map,oneof"`
+}
+
+func (*FieldConstraints_Int) isFieldConstraints_Type() {}
+
+func (*FieldConstraints_Uint) isFieldConstraints_Type() {}
+
+func (*FieldConstraints_Float) isFieldConstraints_Type() {}
+
+func (*FieldConstraints_String_) isFieldConstraints_Type() {}
+
+func (*FieldConstraints_Bytes) isFieldConstraints_Type() {}
+
+func (*FieldConstraints_Enum) isFieldConstraints_Type() {}
+
+func (*FieldConstraints_Repeated) isFieldConstraints_Type() {}
+
+func (*FieldConstraints_Map) isFieldConstraints_Type() {}
+
+// IntRules are the rules for int32, int64, sint32, sint64, sfixed32 and
+// sfixed64 values.
+type IntRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The value must be less than lt.
+	Lt *int64 `protobuf:"varint,1,opt,name=lt" json:"lt,omitempty"`
+	// The value must be less than or equal to lte.
+	Lte *int64 `protobuf:"varint,2,opt,name=lte" json:"lte,omitempty"`
+	// The value must be greater than gt.
+	Gt *int64 `protobuf:"varint,3,opt,name=gt" json:"gt,omitempty"`
+	// The value must be greater than or equal to gte.
+	Gte *int64 `protobuf:"varint,4,opt,name=gte" json:"gte,omitempty"`
+	// The value must be one of the listed values.
+	In []int64 `protobuf:"varint,5,rep,name=in" json:"in,omitempty"`
+	// The value must not be one of the listed values.
+	NotIn []int64 `protobuf:"varint,6,rep,name=not_in,json=notIn" json:"not_in,omitempty"`
+}
+
+func (x *IntRules) Reset() {
+	*x = IntRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_validatepb_go_validate_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntRules) ProtoMessage() {}
+
+func (x *IntRules) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_validatepb_go_validate_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntRules.ProtoReflect.Descriptor instead.
+func (*IntRules) Descriptor() ([]byte, []int) {
+	return file_internal_validatepb_go_validate_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *IntRules) GetLt() int64 {
+	if x != nil && x.Lt != nil {
+		return *x.Lt
+	}
+	return 0
+}
+
+func (x *IntRules) GetLte() int64 {
+	if x != nil && x.Lte != nil {
+		return *x.Lte
+	}
+	return 0
+}
+
+func (x *IntRules) GetGt() int64 {
+	if x != nil && x.Gt != nil {
+		return *x.Gt
+	}
+	return 0
+}
+
+func (x *IntRules) GetGte() int64 {
+	if x != nil && x.Gte != nil {
+		return *x.Gte
+	}
+	return 0
+}
+
+func (x *IntRules) GetIn() []int64 {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *IntRules) GetNotIn() []int64 {
+	if x != nil {
+		return x.NotIn
+	}
+	return nil
+}
+
+// UintRules are the rules for uint32, uint64, fixed32 and fixed64 values.
+type UintRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The value must be less than lt.
+	Lt *uint64 `protobuf:"varint,1,opt,name=lt" json:"lt,omitempty"`
+	// The value must be less than or equal to lte.
+	Lte *uint64 `protobuf:"varint,2,opt,name=lte" json:"lte,omitempty"`
+	// The value must be greater than gt.
+	Gt *uint64 `protobuf:"varint,3,opt,name=gt" json:"gt,omitempty"`
+	// The value must be greater than or equal to gte.
+	Gte *uint64 `protobuf:"varint,4,opt,name=gte" json:"gte,omitempty"`
+	// The value must be one of the listed values.
+	In []uint64 `protobuf:"varint,5,rep,name=in" json:"in,omitempty"`
+	// The value must not be one of the listed values.
+	NotIn []uint64 `protobuf:"varint,6,rep,name=not_in,json=notIn" json:"not_in,omitempty"`
+}
+
+func (x *UintRules) Reset() {
+	*x = UintRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_validatepb_go_validate_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UintRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UintRules) ProtoMessage() {}
+
+func (x *UintRules) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_validatepb_go_validate_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UintRules.ProtoReflect.Descriptor instead.
+func (*UintRules) Descriptor() ([]byte, []int) {
+	return file_internal_validatepb_go_validate_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UintRules) GetLt() uint64 {
+	if x != nil && x.Lt != nil {
+		return *x.Lt
+	}
+	return 0
+}
+
+func (x *UintRules) GetLte() uint64 {
+	if x != nil && x.Lte != nil {
+		return *x.Lte
+	}
+	return 0
+}
+
+func (x *UintRules) GetGt() uint64 {
+	if x != nil && x.Gt != nil {
+		return *x.Gt
+	}
+	return 0
+}
+
+func (x *UintRules) GetGte() uint64 {
+	if x != nil && x.Gte != nil {
+		return *x.Gte
+	}
+	return 0
+}
+
+func (x *UintRules) GetIn() []uint64 {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *UintRules) GetNotIn() []uint64 {
+	if x != nil {
+		return x.NotIn
+	}
+	return nil
+}
+
+// FloatRules are the rules for float and double values.
+type FloatRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The value must be less than lt.
+	Lt *float64 `protobuf:"fixed64,1,opt,name=lt" json:"lt,omitempty"`
+	// The value must be less than or equal to lte.
+	Lte *float64 `protobuf:"fixed64,2,opt,name=lte" json:"lte,omitempty"`
+	// The value must be greater than gt.
+	Gt *float64 `protobuf:"fixed64,3,opt,name=gt" json:"gt,omitempty"`
+	// The value must be greater than or equal to gte.
+	Gte *float64 `protobuf:"fixed64,4,opt,name=gte" json:"gte,omitempty"`
+	// The value must not be infinite or NaN.
+	Finite *bool `protobuf:"varint,5,opt,name=finite" json:"finite,omitempty"`
+}
+
+func (x *FloatRules) Reset() {
+	*x = FloatRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_validatepb_go_validate_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FloatRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FloatRules) ProtoMessage() {}
+
+func (x *FloatRules) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_validatepb_go_validate_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FloatRules.ProtoReflect.Descriptor instead.
+func (*FloatRules) Descriptor() ([]byte, []int) {
+	return file_internal_validatepb_go_validate_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FloatRules) GetLt() float64 {
+	if x != nil && x.Lt != nil {
+		return *x.Lt
+	}
+	return 0
+}
+
+func (x *FloatRules) GetLte() float64 {
+	if x != nil && x.Lte != nil {
+		return *x.Lte
+	}
+	return 0
+}
+
+func (x *FloatRules) GetGt() float64 {
+	if x != nil && x.Gt != nil {
+		return *x.Gt
+	}
+	return 0
+}
+
+func (x *FloatRules) GetGte() float64 {
+	if x != nil && x.Gte != nil {
+		return *x.Gte
+	}
+	return 0
+}
+
+func (x *FloatRules) GetFinite() bool {
+	if x != nil && x.Finite != nil {
+		return *x.Finite
+	}
+	return false
+}
+
+// StringRules are the rules for string values.
+type StringRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The minimum length of the value in Unicode code points.
+	MinLen *uint64 `protobuf:"varint,1,opt,name=min_len,json=minLen" json:"min_len,omitempty"`
+	// The maximum length of the value in Unicode code points.
+	MaxLen *uint64 `protobuf:"varint,2,opt,name=max_len,json=maxLen" json:"max_len,omitempty"`
+	// The minimum length of the value in bytes.
+	MinBytes *uint64 `protobuf:"varint,3,opt,name=min_bytes,json=minBytes" json:"min_bytes,omitempty"`
+	// The maximum length of the value in bytes.
+	MaxBytes *uint64 `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes" json:"max_bytes,omitempty"`
+	// A regular expression in RE2 syntax that the value must match.
+	Pattern *string `protobuf:"bytes,5,opt,name=pattern" json:"pattern,omitempty"`
+	// The value must start with the prefix.
+	Prefix *string `protobuf:"bytes,6,opt,name=prefix" json:"prefix,omitempty"`
+	// The value must end with the suffix.
+	Suffix *string `protobuf:"bytes,7,opt,name=suffix" json:"suffix,omitempty"`
+	// The value must contain the substring.
+	Contains *string `protobuf:"bytes,8,opt,name=contains" json:"contains,omitempty"`
+	// The value must be one of the listed values.
+	In []string `protobuf:"bytes,9,rep,name=in" json:"in,omitempty"`
+	// The value must not be one of the listed values.
+	NotIn []string `protobuf:"bytes,10,rep,name=not_in,json=notIn" json:"not_in,omitempty"`
+}
+
+func (x *StringRules) Reset() {
+	*x = StringRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_validatepb_go_validate_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StringRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringRules) ProtoMessage() {}
+
+func (x *StringRules) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_validatepb_go_validate_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringRules.ProtoReflect.Descriptor instead.
+func (*StringRules) Descriptor() ([]byte, []int) {
+	return file_internal_validatepb_go_validate_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StringRules) GetMinLen() uint64 {
+	if x != nil && x.MinLen != nil {
+		return *x.MinLen
+	}
+	return 0
+}
+
+func (x *StringRules) GetMaxLen() uint64 {
+	if x != nil && x.MaxLen != nil {
+		return *x.MaxLen
+	}
+	return 0
+}
+
+func (x *StringRules) GetMinBytes() uint64 {
+	if x != nil && x.MinBytes != nil {
+		return *x.MinBytes
+	}
+	return 0
+}
+
+func (x *StringRules) GetMaxBytes() uint64 {
+	if x != nil && x.MaxBytes != nil {
+		return *x.MaxBytes
+	}
+	return 0
+}
+
+func (x *StringRules) GetPattern() string {
+	if x != nil && x.Pattern != nil {
+		return *x.Pattern
+	}
+	return ""
+}
+
+func (x *StringRules) GetPrefix() string {
+	if x != nil && x.Prefix != nil {
+		return *x.Prefix
+	}
+	return ""
+}
+
+func (x *StringRules) GetSuffix() string {
+	if x != nil && x.Suffix != nil {
+		return *x.Suffix
+	}
+	return ""
+}
+
+func (x *StringRules) GetContains() string {
+	if x != nil && x.Contains != nil {
+		return *x.Contains
+	}
+	return ""
+}
+
+func (x *StringRules) GetIn() []string {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *StringRules) GetNotIn() []string {
+	if x != nil {
+		return x.NotIn
+	}
+	return nil
+}
+
+// BytesRules are the rules for bytes values.
+type BytesRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The minimum length of the value in bytes.
+	MinLen *uint64 `protobuf:"varint,1,opt,name=min_len,json=minLen" json:"min_len,omitempty"`
+	// The maximum length of the value in bytes.
+	MaxLen *uint64 `protobuf:"varint,2,opt,name=max_len,json=maxLen" json:"max_len,omitempty"`
+	// A regular expression in RE2 syntax that the value must match.
+	Pattern *string `protobuf:"bytes,3,opt,name=pattern" json:"pattern,omitempty"`
+	// The value must start with the prefix.
+	Prefix []byte `protobuf:"bytes,4,opt,name=prefix" json:"prefix,omitempty"`
+	// The value must end with the suffix.
+	Suffix []byte `protobuf:"bytes,5,opt,name=suffix" json:"suffix,omitempty"`
+}
+
+func (x *BytesRules) Reset() {
+	*x = BytesRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_validatepb_go_validate_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BytesRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BytesRules) ProtoMessage() {}
+
+func (x *BytesRules) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_validatepb_go_validate_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BytesRules.ProtoReflect.Descriptor instead.
+func (*BytesRules) Descriptor() ([]byte, []int) {
+	return file_internal_validatepb_go_validate_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BytesRules) GetMinLen() uint64 {
+	if x != nil && x.MinLen != nil {
+		return *x.MinLen
+	}
+	return 0
+}
+
+func (x *BytesRules) GetMaxLen() uint64 {
+	if x != nil && x.MaxLen != nil {
+		return *x.MaxLen
+	}
+	return 0
+}
+
+func (x *BytesRules) GetPattern() string {
+	if x != nil && x.Pattern != nil {
+		return *x.Pattern
+	}
+	return ""
+}
+
+func (x *BytesRules) GetPrefix() []byte {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+func (x *BytesRules) GetSuffix() []byte {
+	if x != nil {
+		return x.Suffix
+	}
+	return nil
+}
+
+// EnumRules are the rules for enum values.
+type EnumRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The value must be one of the values declared in the enum.
+	DefinedOnly *bool `protobuf:"varint,1,opt,name=defined_only,json=definedOnly" json:"defined_only,omitempty"`
+	// The value must be one of the listed numbers.
+	In []int32 `protobuf:"varint,2,rep,name=in" json:"in,omitempty"`
+	// The value must not be one of the listed numbers.
+	NotIn []int32 `protobuf:"varint,3,rep,name=not_in,json=notIn" json:"not_in,omitempty"`
+}
+
+func (x *EnumRules) Reset() {
+	*x = EnumRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_validatepb_go_validate_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnumRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnumRules) ProtoMessage() {}
+
+func (x *EnumRules) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_validatepb_go_validate_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnumRules.ProtoReflect.Descriptor instead.
+func (*EnumRules) Descriptor() ([]byte, []int) {
+	return file_internal_validatepb_go_validate_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EnumRules) GetDefinedOnly() bool {
+	if x != nil && x.DefinedOnly != nil {
+		return *x.DefinedOnly
+	}
+	return false
+}
+
+func (x *EnumRules) GetIn() []int32 {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *EnumRules) GetNotIn() []int32 {
+	if x != nil {
+		return x.NotIn
+	}
+	return nil
+}
+
+// RepeatedRules are the rules for repeated fields.
+type RepeatedRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The minimum number of elements.
+	MinItems *uint64 `protobuf:"varint,1,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
+	// The maximum number of elements.
+	MaxItems *uint64 `protobuf:"varint,2,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
+	// Whether all elements must be distinct.
+	Unique *bool `protobuf:"varint,3,opt,name=unique" json:"unique,omitempty"`
+	// The constraints for each element.
+	Items *FieldConstraints `protobuf:"bytes,4,opt,name=items" json:"items,omitempty"`
+}
+
+func (x *RepeatedRules) Reset() {
+	*x = RepeatedRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_validatepb_go_validate_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepeatedRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepeatedRules) ProtoMessage() {}
+
+func (x *RepeatedRules) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_validatepb_go_validate_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepeatedRules.ProtoReflect.Descriptor instead.
+func (*RepeatedRules) Descriptor() ([]byte, []int) {
+	return file_internal_validatepb_go_validate_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RepeatedRules) GetMinItems() uint64 {
+	if x != nil && x.MinItems != nil {
+		return *x.MinItems
+	}
+	return 0
+}
+
+func (x *RepeatedRules) GetMaxItems() uint64 {
+	if x != nil && x.MaxItems != nil {
+		return *x.MaxItems
+	}
+	return 0
+}
+
+func (x *RepeatedRules) GetUnique() bool {
+	if x != nil && x.Unique != nil {
+		return *x.Unique
+	}
+	return false
+}
+
+func (x *RepeatedRules) GetItems() *FieldConstraints {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// MapRules are the rules for map fields.
+type MapRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The minimum number of entries.
+	MinPairs *uint64 `protobuf:"varint,1,opt,name=min_pairs,json=minPairs" json:"min_pairs,omitempty"`
+	// The maximum number of entries.
+	MaxPairs *uint64 `protobuf:"varint,2,opt,name=max_pairs,json=maxPairs" json:"max_pairs,omitempty"`
+	// The constraints for each key.
+	Keys *FieldConstraints `protobuf:"bytes,3,opt,name=keys" json:"keys,omitempty"`
+	// The constraints for each value.
+	Values *FieldConstraints `protobuf:"bytes,4,opt,name=values" json:"values,omitempty"`
+}
+
+func (x *MapRules) Reset() {
+	*x = MapRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_validatepb_go_validate_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MapRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapRules) ProtoMessage() {}
+
+func (x *MapRules) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_validatepb_go_validate_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapRules.ProtoReflect.Descriptor instead.
+func (*MapRules) Descriptor() ([]byte, []int) {
+	return file_internal_validatepb_go_validate_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MapRules) GetMinPairs() uint64 {
+	if x != nil && x.MinPairs != nil {
+		return *x.MinPairs
+	}
+	return 0
+}
+
+func (x *MapRules) GetMaxPairs() uint64 {
+	if x != nil && x.MaxPairs != nil {
+		return *x.MaxPairs
+	}
+	return 0
+}
+
+func (x *MapRules) GetKeys() *FieldConstraints {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *MapRules) GetValues() *FieldConstraints {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var file_internal_validatepb_go_validate_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*MessageConstraints)(nil),
+		Field:         50001,
+		Name:          "pb.message",
+		Tag:           "bytes,50001,opt,name=message",
+		Filename:      "internal/validatepb/go_validate.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.OneofOptions)(nil),
+		ExtensionType: (*OneofConstraints)(nil),
+		Field:         50001,
+		Name:          "pb.oneof",
+		Tag:           "bytes,50001,opt,name=oneof",
+		Filename:      "internal/validatepb/go_validate.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldConstraints)(nil),
+		Field:         50001,
+		Name:          "pb.field",
+		Tag:           "bytes,50001,opt,name=field",
+		Filename:      "internal/validatepb/go_validate.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// optional pb.MessageConstraints message = 50001;
+	E_Message = &file_internal_validatepb_go_validate_proto_extTypes[0]
+)
+
+// Extension fields to descriptorpb.OneofOptions.
+var (
+	// optional pb.OneofConstraints oneof = 50001;
+	E_Oneof = &file_internal_validatepb_go_validate_proto_extTypes[1]
+)
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional pb.FieldConstraints field = 50001;
+	E_Field = &file_internal_validatepb_go_validate_proto_extTypes[2]
+)
+
+var File_internal_validatepb_go_validate_proto protoreflect.FileDescriptor
+
+var file_internal_validatepb_go_validate_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x70, 0x62, 0x2f, 0x67, 0x6f, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x20, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x30, 0x0a,
+	0x12, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22,
+	0x2e, 0x0a, 0x10, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x22,
+	0x84, 0x03, 0x0a, 0x10, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04,
+	0x73, 0x6b, 0x69, 0x70, 0x12, 0x20, 0x0a, 0x03, 0x69, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x49, 0x6e, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x48,
+	0x00, 0x52, 0x03, 0x69, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x04, 0x75, 0x69, 0x6e, 0x74, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x62, 0x2e, 0x55, 0x69, 0x6e, 0x74, 0x52, 0x75,
+	0x6c, 0x65, 0x73, 0x48, 0x00, 0x52, 0x04, 0x75, 0x69, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x66,
+	0x6c, 0x6f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e,
+	0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x48, 0x00, 0x52, 0x05, 0x66, 0x6c,
+	0x6f, 0x61, 0x74, 0x12, 0x29, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52,
+	0x75, 0x6c, 0x65, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x26,
+	0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x70, 0x62, 0x2e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x48, 0x00, 0x52,
+	0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x04, 0x65, 0x6e, 0x75, 0x6d, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x75,
+	0x6c, 0x65, 0x73, 0x48, 0x00, 0x52, 0x04, 0x65, 0x6e, 0x75, 0x6d, 0x12, 0x2f, 0x0a, 0x08, 0x72,
+	0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x70, 0x62, 0x2e, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x73,
+	0x48, 0x00, 0x52, 0x08, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x03,
+	0x6d, 0x61, 0x70, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x4d,
+	0x61, 0x70, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x48, 0x00, 0x52, 0x03, 0x6d, 0x61, 0x70, 0x42, 0x06,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x75, 0x0a, 0x08, 0x49, 0x6e, 0x74, 0x52, 0x75, 0x6c,
+	0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x6c, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x6c, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x67, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x67, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x67, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x6e, 0x12, 0x15, 0x0a, 0x06, 0x6e, 0x6f, 0x74, 0x5f, 0x69, 0x6e,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x03, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x49, 0x6e, 0x22, 0x76, 0x0a,
+	0x09, 0x55, 0x69, 0x6e, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x6c, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x6c, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x74,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6c, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x67, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x67, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x67, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x67, 0x74, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x03, 0x28, 0x04, 0x52, 0x02, 0x69, 0x6e, 0x12, 0x15,
+	0x0a, 0x06, 0x6e, 0x6f, 0x74, 0x5f, 0x69, 0x6e, 0x18, 0x06, 0x20, 0x03, 0x28, 0x04, 0x52, 0x05,
+	0x6e, 0x6f, 0x74, 0x49, 0x6e, 0x22, 0x68, 0x0a, 0x0a, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x75,
+	0x6c, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x02, 0x6c, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x03, 0x6c, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x67, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x02, 0x67, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x03, 0x67, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6e, 0x69, 0x74,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x65, 0x22,
+	0x86, 0x02, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12,
+	0x17, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f,
+	0x6c, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x4c, 0x65,
+	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x75, 0x66, 0x66, 0x69, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x6e, 0x12, 0x15, 0x0a, 0x06, 0x6e, 0x6f, 0x74, 0x5f, 0x69, 0x6e, 0x18, 0x0a, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x49, 0x6e, 0x22, 0x88, 0x01, 0x0a, 0x0a, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x6c,
+	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x6e,
+	0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x75, 0x66, 0x66, 0x69, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x75, 0x66,
+	0x66, 0x69, 0x78, 0x22, 0x55, 0x0a, 0x09, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x75, 0x6c, 0x65, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x6c, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x64, 0x4f,
+	0x6e, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52,
+	0x02, 0x69, 0x6e, 0x12, 0x15, 0x0a, 0x06, 0x6e, 0x6f, 0x74, 0x5f, 0x69, 0x6e, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x49, 0x6e, 0x22, 0x8d, 0x01, 0x0a, 0x0d, 0x52,
+	0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09,
+	0x6d, 0x69, 0x6e, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x6d, 0x69, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78,
+	0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x61,
+	0x78, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x12, 0x2a,
+	0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x70, 0x62, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x74, 0x73, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x08, 0x4d,
+	0x61, 0x70, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x70,
+	0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x50,
+	0x61, 0x69, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x61, 0x69, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x61, 0x69, 0x72,
+	0x73, 0x12, 0x28, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72,
+	0x61, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62,
+	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74,
+	0x73, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x3a, 0x53, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd1, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x70, 0x62, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72,
+	0x61, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x3a, 0x4b,
+	0x0a, 0x05, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd1, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x70, 0x62, 0x2e, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x6e, 0x74, 0x73, 0x52, 0x05, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x3a, 0x4b, 0x0a, 0x05, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0xd1, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62,
+	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74,
+	0x73, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2e, 0x6f, 0x72, 0x67, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x70, 0x62,
+}
+
+var (
+	file_internal_validatepb_go_validate_proto_rawDescOnce sync.Once
+	file_internal_validatepb_go_validate_proto_rawDescData = file_internal_validatepb_go_validate_proto_rawDesc
+)
+
+func file_internal_validatepb_go_validate_proto_rawDescGZIP() []byte {
+	file_internal_validatepb_go_validate_proto_rawDescOnce.Do(func() {
+		file_internal_validatepb_go_validate_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_validatepb_go_validate_proto_rawDescData)
+	})
+	return file_internal_validatepb_go_validate_proto_rawDescData
+}
+
+var file_internal_validatepb_go_validate_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_internal_validatepb_go_validate_proto_goTypes = []any{
+	(*MessageConstraints)(nil),          // 0: pb.MessageConstraints
+	(*OneofConstraints)(nil),            // 1: pb.OneofConstraints
+	(*FieldConstraints)(nil),            // 2: pb.FieldConstraints
+	(*IntRules)(nil),                    // 3: pb.IntRules
+	(*UintRules)(nil),                   // 4: pb.UintRules
+	(*FloatRules)(nil),                  // 5: pb.FloatRules
+	(*StringRules)(nil),                 // 6: pb.StringRules
+	(*BytesRules)(nil),                  // 7: pb.BytesRules
+	(*EnumRules)(nil),                   // 8: pb.EnumRules
+	(*RepeatedRules)(nil),               // 9: pb.RepeatedRules
+	(*MapRules)(nil),                    // 10: pb.MapRules
+	(*descriptorpb.MessageOptions)(nil), // 11: google.protobuf.MessageOptions
+	(*descriptorpb.OneofOptions)(nil),   // 12: google.protobuf.OneofOptions
+	(*descriptorpb.FieldOptions)(nil),   // 13: google.protobuf.FieldOptions
+}
+var file_internal_validatepb_go_validate_proto_depIdxs = []int32{
+	3,  // 0: pb.FieldConstraints.int:type_name -> pb.IntRules
+	4,  // 1: pb.FieldConstraints.uint:type_name -> pb.UintRules
+	5,  // 2: pb.FieldConstraints.float:type_name -> pb.FloatRules
+	6,  // 3: pb.FieldConstraints.string:type_name -> pb.StringRules
+	7,  // 4: pb.FieldConstraints.bytes:type_name -> pb.BytesRules
+	8,  // 5: pb.FieldConstraints.enum:type_name -> pb.EnumRules
+	9,  // 6: pb.FieldConstraints.repeated:type_name -> pb.RepeatedRules
+	10, // 7: pb.FieldConstraints.map:type_name -> pb.MapRules
+	2,  // 8: pb.RepeatedRules.items:type_name -> pb.FieldConstraints
+	2,  // 9: pb.MapRules.keys:type_name -> pb.FieldConstraints
+	2,  // 10: pb.MapRules.values:type_name -> pb.FieldConstraints
+	11, // 11: pb.message:extendee -> google.protobuf.MessageOptions
+	12, // 12: pb.oneof:extendee -> google.protobuf.OneofOptions
+	13, // 13: pb.field:extendee -> google.protobuf.FieldOptions
+	0,  // 14: pb.message:type_name -> pb.MessageConstraints
+	1,  // 15: pb.oneof:type_name -> pb.OneofConstraints
+	2,  // 16: pb.field:type_name -> pb.FieldConstraints
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	14, // [14:17] is the sub-list for extension type_name
+	11, // [11:14] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_internal_validatepb_go_validate_proto_init() }
+func file_internal_validatepb_go_validate_proto_init() {
+	if File_internal_validatepb_go_validate_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_validatepb_go_validate_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*MessageConstraints); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_validatepb_go_validate_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*OneofConstraints); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_validatepb_go_validate_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*FieldConstraints); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_validatepb_go_validate_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*IntRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_validatepb_go_validate_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*UintRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_validatepb_go_validate_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*FloatRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_validatepb_go_validate_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*StringRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_validatepb_go_validate_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*BytesRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_validatepb_go_validate_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*EnumRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_validatepb_go_validate_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*RepeatedRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_validatepb_go_validate_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*MapRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_internal_validatepb_go_validate_proto_msgTypes[2].OneofWrappers = []any{
+		(*FieldConstraints_Int)(nil),
+		(*FieldConstraints_Uint)(nil),
+		(*FieldConstraints_Float)(nil),
+		(*FieldConstraints_String_)(nil),
+		(*FieldConstraints_Bytes)(nil),
+		(*FieldConstraints_Enum)(nil),
+		(*FieldConstraints_Repeated)(nil),
+		(*FieldConstraints_Map)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_validatepb_go_validate_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 3,
+			NumServices:   0,
+		},
+		GoTypes:           file_internal_validatepb_go_validate_proto_goTypes,
+		DependencyIndexes: file_internal_validatepb_go_validate_proto_depIdxs,
+		MessageInfos:      file_internal_validatepb_go_validate_proto_msgTypes,
+		ExtensionInfos:    file_internal_validatepb_go_validate_proto_extTypes,
+	}.Build()
+	File_internal_validatepb_go_validate_proto = out.File
+	file_internal_validatepb_go_validate_proto_rawDesc = nil
+	file_internal_validatepb_go_validate_proto_goTypes = nil
+	file_internal_validatepb_go_validate_proto_depIdxs = nil
+}
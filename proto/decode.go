@@ -47,6 +47,14 @@ type UnmarshalOptions struct {
 	// RecursionLimit limits how deeply messages may be nested.
 	// If zero, a default limit is applied.
 	RecursionLimit int
+
+	// Allocator, if non-nil, is used to allocate the storage of unmarshaled
+	// messages, repeated fields, and strings and bytes, so that it may be
+	// released in bulk once the messages are no longer in use.
+	// It is only used by message implementations which support it,
+	// such as generated and dynamic messages. Maps are always allocated
+	// on the heap.
+	Allocator protoiface.Allocator
 }
 
 // Unmarshal parses the wire-format message in b and places the result in m.
@@ -96,10 +104,11 @@ func (o UnmarshalOptions) unmarshal(b []byte, m protoreflect.Message) (out proto
 	if methods != nil && methods.Unmarshal != nil &&
 		!(o.DiscardUnknown && methods.Flags&protoiface.SupportUnmarshalDiscardUnknown == 0) {
 		in := protoiface.UnmarshalInput{
-			Message:  m,
-			Buf:      b,
-			Resolver: o.Resolver,
-			Depth:    o.RecursionLimit,
+			Message:   m,
+			Buf:       b,
+			Resolver:  o.Resolver,
+			Depth:     o.RecursionLimit,
+			Allocator: o.Allocator,
 		}
 		if o.DiscardUnknown {
 			in.Flags |= protoiface.UnmarshalDiscardUnknown
@@ -10,6 +10,7 @@ import (
 
 	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/internal/encoding/messageset"
+	"google.golang.org/protobuf/internal/fieldmask"
 	"google.golang.org/protobuf/internal/order"
 	"google.golang.org/protobuf/internal/pragma"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -73,6 +74,24 @@ type MarshalOptions struct {
 	// There is absolutely no guarantee that Size followed by Marshal with
 	// UseCachedSize set will perform equivalently to Marshal alone.
 	UseCachedSize bool
+
+	// FieldMask restricts the output to the fields selected by its paths,
+	// which is typically a *fieldmaskpb.FieldMask. Each path is a sequence of
+	// field names separated by dots, where a path through a repeated message
+	// field applies to every element, and the name of a map field may be
+	// followed by a map key to select map entries, where a key containing
+	// a dot is quoted in backticks. The wildcard "*" selects every field or
+	// map entry. Extension fields are named by their full name in parentheses.
+	// Unknown fields are never included in the output of a masked message.
+	// If FieldMask is nil or has no paths, all fields are marshaled.
+	//
+	// Required fields are checked on the entire message regardless of the
+	// mask, unless AllowPartial is set.
+	FieldMask interface{ GetPaths() []string }
+
+	// mask is the parsed FieldMask that applies to the message currently
+	// being marshaled.
+	mask *fieldmask.Mask
 }
 
 // flags turns the specified MarshalOptions (user-facing) into
@@ -173,7 +192,11 @@ func (o MarshalOptions) MarshalState(in protoiface.MarshalInput) (protoiface.Mar
 func (o MarshalOptions) marshal(b []byte, m protoreflect.Message) (out protoiface.MarshalOutput, err error) {
 	allowPartial := o.AllowPartial
 	o.AllowPartial = true
-	if methods := protoMethods(m); methods != nil && methods.Marshal != nil &&
+	if o.FieldMask != nil {
+		o.mask = fieldmask.New(o.FieldMask.GetPaths())
+		o.FieldMask = nil
+	}
+	if methods := protoMethods(m); o.mask == nil && methods != nil && methods.Marshal != nil &&
 		!(o.Deterministic && methods.Flags&protoiface.SupportMarshalDeterministic == 0) {
 		in := protoiface.MarshalInput{
 			Message: m,
@@ -250,6 +273,9 @@ func (o MarshalOptions) marshalMessageSlow(b []byte, m protoreflect.Message) ([]
 		// output stability of this implementation.
 		fieldOrder = order.LegacyFieldOrder
 	}
+	if o.mask != nil {
+		return o.marshalMessageMasked(b, m, fieldOrder)
+	}
 	var err error
 	order.RangeFields(m, fieldOrder, func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
 		b, err = o.marshalField(b, fd, v)
@@ -262,6 +288,22 @@ func (o MarshalOptions) marshalMessageSlow(b []byte, m protoreflect.Message) ([]
 	return b, nil
 }
 
+// marshalMessageMasked marshals the fields of m that are selected by o.mask.
+func (o MarshalOptions) marshalMessageMasked(b []byte, m protoreflect.Message, fieldOrder order.FieldOrder) ([]byte, error) {
+	mask := o.mask
+	var err error
+	order.RangeFields(m, fieldOrder, func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		sub, ok := mask.Field(fd)
+		if !ok {
+			return true
+		}
+		o.mask = sub
+		b, err = o.marshalField(b, fd, v)
+		return err == nil
+	})
+	return b, err
+}
+
 func (o MarshalOptions) marshalField(b []byte, fd protoreflect.FieldDescriptor, value protoreflect.Value) ([]byte, error) {
 	switch {
 	case fd.IsList():
@@ -308,8 +350,13 @@ func (o MarshalOptions) marshalMap(b []byte, fd protoreflect.FieldDescriptor, ma
 	if o.Deterministic {
 		keyOrder = order.GenericKeyOrder
 	}
+	mask := o.mask
 	var err error
 	order.RangeEntries(mapv, keyOrder, func(key protoreflect.MapKey, value protoreflect.Value) bool {
+		sub, ok := mask.MapKey(key)
+		if !ok {
+			return true
+		}
 		b = protowire.AppendTag(b, fd.Number(), protowire.BytesType)
 		var pos int
 		b, pos = appendSpeculativeLength(b)
@@ -318,6 +365,7 @@ func (o MarshalOptions) marshalMap(b []byte, fd protoreflect.FieldDescriptor, ma
 		if err != nil {
 			return false
 		}
+		o.mask = sub
 		b, err = o.marshalField(b, valf, value)
 		if err != nil {
 			return false
@@ -17,7 +17,9 @@ import (
 	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"google.golang.org/protobuf/internal/errors"
 	orderpb "google.golang.org/protobuf/internal/testprotos/order"
@@ -338,3 +340,142 @@ func ExampleMarshalOptions_MarshalAppend_sameBuffer() {
 		// write buf to disk, network, etc.
 	}
 }
+
+func TestMarshalFieldMask(t *testing.T) {
+	input := func() *testpb.TestAllTypes {
+		return &testpb.TestAllTypes{
+			OptionalInt32:  proto.Int32(1),
+			OptionalString: proto.String("string"),
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A: proto.Int32(2),
+				Corecursive: &testpb.TestAllTypes{
+					OptionalInt32:  proto.Int32(3),
+					OptionalString: proto.String("nested"),
+				},
+			},
+			RepeatedInt32: []int32{4, 5},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(6), Corecursive: &testpb.TestAllTypes{OptionalInt32: proto.Int32(7)}},
+				{A: proto.Int32(8)},
+			},
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"a": {A: proto.Int32(9), Corecursive: &testpb.TestAllTypes{OptionalInt32: proto.Int32(10)}},
+				"b": {A: proto.Int32(11)},
+			},
+		}
+	}
+
+	tests := []struct {
+		desc  string
+		paths []string
+		want  proto.Message
+	}{{
+		desc: "no paths",
+		want: input(),
+	}, {
+		desc:  "scalar fields",
+		paths: []string{"optional_int32", "repeated_int32"},
+		want: &testpb.TestAllTypes{
+			OptionalInt32: proto.Int32(1),
+			RepeatedInt32: []int32{4, 5},
+		},
+	}, {
+		desc:  "entire message field",
+		paths: []string{"optional_nested_message", "optional_nested_message.a"},
+		want: &testpb.TestAllTypes{
+			OptionalNestedMessage: input().OptionalNestedMessage,
+		},
+	}, {
+		desc:  "nested fields",
+		paths: []string{"optional_nested_message.a", "optional_nested_message.corecursive.optional_string"},
+		want: &testpb.TestAllTypes{
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A:           proto.Int32(2),
+				Corecursive: &testpb.TestAllTypes{OptionalString: proto.String("nested")},
+			},
+		},
+	}, {
+		desc:  "repeated message elements",
+		paths: []string{"repeated_nested_message.a"},
+		want: &testpb.TestAllTypes{
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(6)},
+				{A: proto.Int32(8)},
+			},
+		},
+	}, {
+		desc:  "map entry",
+		paths: []string{"map_string_nested_message.a"},
+		want: &testpb.TestAllTypes{
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"a": input().MapStringNestedMessage["a"],
+			},
+		},
+	}, {
+		desc:  "map entry values",
+		paths: []string{"map_string_nested_message.*.a"},
+		want: &testpb.TestAllTypes{
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"a": {A: proto.Int32(9)},
+				"b": {A: proto.Int32(11)},
+			},
+		},
+	}, {
+		desc:  "unset and unknown paths",
+		paths: []string{"optional_bytes", "no_such_field", "optional_foreign_message.c"},
+		want:  &testpb.TestAllTypes{},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			m := input()
+			m.ProtoReflect().SetUnknown(protowire.AppendVarint(protowire.AppendTag(nil, 10000, protowire.VarintType), 1))
+			for _, deterministic := range []bool{false, true} {
+				opts := proto.MarshalOptions{
+					Deterministic: deterministic,
+					FieldMask:     &fieldmaskpb.FieldMask{Paths: test.paths},
+				}
+				b, err := opts.Marshal(m)
+				if err != nil {
+					t.Fatalf("Marshal() returned error: %v", err)
+				}
+				if got, want := opts.Size(m), len(b); got != want {
+					t.Errorf("Size() = %d, want %d", got, want)
+				}
+				got := &testpb.TestAllTypes{}
+				if err := proto.Unmarshal(b, got); err != nil {
+					t.Fatalf("Unmarshal() returned error: %v", err)
+				}
+				want := test.want
+				if len(test.paths) == 0 {
+					want = m
+				}
+				if !proto.Equal(got, want) {
+					t.Errorf("Marshal() with mask %v:\ngot:  %v\nwant: %v", test.paths, prototext.Format(got), prototext.Format(want))
+				}
+			}
+		})
+	}
+}
+
+func TestMarshalFieldMaskExtension(t *testing.T) {
+	m := &testpb.TestAllExtensions{}
+	proto.SetExtension(m, testpb.E_OptionalInt32, int32(1))
+	proto.SetExtension(m, testpb.E_OptionalNestedMessage, &testpb.TestAllExtensions_NestedMessage{A: proto.Int32(2)})
+
+	b, err := proto.MarshalOptions{
+		FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"(goproto.proto.test.optional_int32)"}},
+	}.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %v", err)
+	}
+	want := &testpb.TestAllExtensions{}
+	proto.SetExtension(want, testpb.E_OptionalInt32, int32(1))
+	got := &testpb.TestAllExtensions{}
+	if err := (proto.UnmarshalOptions{Resolver: protoregistry.GlobalTypes}).Unmarshal(b, got); err != nil {
+		t.Fatalf("Unmarshal() returned error: %v", err)
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("Marshal() with extension mask:\ngot:  %v\nwant: %v", prototext.Format(got), prototext.Format(want))
+	}
+}
@@ -70,6 +70,7 @@ func FuzzProto2EditionConversion(f *testing.F) {
 	f.Add([]byte("\x82\x01\x010"))
 	f.Fuzz(func(t *testing.T, in []byte) {
 		compareEquivalentProtos(t, in, (*testfuzzpb.TestAllTypesProto2)(nil), (*testfuzzpb.TestAllTypesProto2Editions)(nil))
+		compareEquivalentProtos(t, in, (*testfuzzpb.TestAllTypesProto2)(nil), (*testfuzzpb.TestAllTypesProto2Editions2024)(nil))
 	})
 }
 
@@ -78,5 +79,6 @@ func FuzzProto3EditionConversion(f *testing.F) {
 	f.Add([]byte("\x82\x01\x010"))
 	f.Fuzz(func(t *testing.T, in []byte) {
 		compareEquivalentProtos(t, in, (*testfuzzpb.TestAllTypesProto3)(nil), (*testfuzzpb.TestAllTypesProto3Editions)(nil))
+		compareEquivalentProtos(t, in, (*testfuzzpb.TestAllTypesProto3)(nil), (*testfuzzpb.TestAllTypesProto3Editions2024)(nil))
 	})
 }
@@ -30,6 +30,13 @@ func (o MarshalOptions) Size(m Message) int {
 // For profiling purposes, avoid changing the name of this function or
 // introducing other code paths for size that do not go through this.
 func (o MarshalOptions) size(m protoreflect.Message) (size int) {
+	if o.FieldMask != nil && len(o.FieldMask.GetPaths()) > 0 {
+		// Only the selected fields are output, which the size methods
+		// know nothing about, so measure the masked output instead.
+		o.AllowPartial = true
+		out, _ := o.marshal(nil, m)
+		return len(out.Buf)
+	}
 	methods := protoMethods(m)
 	if methods != nil && methods.Size != nil {
 		out := methods.Size(protoiface.SizeInput{
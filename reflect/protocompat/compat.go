@@ -0,0 +1,593 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protocompat detects incompatible changes between two versions
+// of a set of protobuf schemas.
+//
+// [Check] compares every element of the old files with the element of the
+// same name in the new files, and reports each change that breaks
+// compatibility with data or code that was produced with the old schemas:
+//
+//	for _, f := range protocompat.Check(oldFiles, newFiles) {
+//		if f.Severity >= protocompat.JSON {
+//			log.Print(f)
+//		}
+//	}
+//
+// Elements that only exist in the new files are additions, which are
+// compatible with the exception of required fields.
+//
+// To compare sets of files in the form of a [descriptorpb.FileDescriptorSet],
+// first convert them with [protodesc.NewFiles].
+//
+// [descriptorpb.FileDescriptorSet]: https://pkg.go.dev/google.golang.org/protobuf/types/descriptorpb#FileDescriptorSet
+// [protodesc.NewFiles]: https://pkg.go.dev/google.golang.org/protobuf/reflect/protodesc#NewFiles
+package protocompat
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+
+	"google.golang.org/protobuf/internal/strs"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Severity is the kind of compatibility that a change breaks.
+// Severities are ordered, such that a more severe change usually breaks the
+// kinds of compatibility of all lesser severities too.
+type Severity int
+
+const (
+	// Source reports a change that breaks code generated from the schema,
+	// but not data serialized with it, such as the removal of a message.
+	Source Severity = iota + 1
+	// JSON reports a change that breaks data serialized in the JSON or text
+	// format, but not in the wire format, such as the renaming of a field.
+	JSON
+	// Wire reports a change that breaks data serialized in the wire format,
+	// such as a change to the number of a field.
+	Wire
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case Source:
+		return "source"
+	case JSON:
+		return "JSON"
+	case Wire:
+		return "wire"
+	default:
+		return fmt.Sprintf("<unknown:%d>", int(s))
+	}
+}
+
+// Finding is an incompatible change between two versions of a schema.
+type Finding struct {
+	// File is the path of the old file that declares the changed element.
+	File string
+	// Name is the full name of the changed element.
+	// It is empty for a change to the file itself.
+	Name     protoreflect.FullName
+	Severity Severity
+	// Message describes the change.
+	Message string
+}
+
+// String returns a humanly readable representation of the finding.
+// Do not depend on the output being stable.
+//
+// For example:
+//
+//	wire: foo.proto: foo.Bar.baz: type changed from int32 to string
+func (f Finding) String() string {
+	if f.Name == "" {
+		return fmt.Sprintf("%v: %s: %s", f.Severity, f.File, f.Message)
+	}
+	return fmt.Sprintf("%v: %s: %s: %s", f.Severity, f.File, f.Name, f.Message)
+}
+
+// Check reports the incompatible changes from the old files to the new files.
+// The findings are ordered by the path of the old file and then by the order
+// of declaration of the changed elements in that file.
+func Check(old, new *protoregistry.Files) []Finding {
+	c := &checker{new: new, extensions: make(map[extensionKey]protoreflect.ExtensionDescriptor)}
+	new.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		rangeExtensions(fd, func(xd protoreflect.ExtensionDescriptor) {
+			c.extensions[extensionKey{xd.ContainingMessage().FullName(), xd.Number()}] = xd
+		})
+		return true
+	})
+
+	var files []protoreflect.FileDescriptor
+	old.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		files = append(files, fd)
+		return true
+	})
+	sort.Slice(files, func(i, j int) bool { return files[i].Path() < files[j].Path() })
+	for _, fd := range files {
+		c.checkFile(fd)
+	}
+	return c.findings
+}
+
+type extensionKey struct {
+	extendee protoreflect.FullName
+	number   protoreflect.FieldNumber
+}
+
+type checker struct {
+	new        *protoregistry.Files
+	extensions map[extensionKey]protoreflect.ExtensionDescriptor
+	findings   []Finding
+
+	// file is the path of the old file being checked.
+	file string
+}
+
+func (c *checker) report(d protoreflect.Descriptor, s Severity, format string, args ...any) {
+	f := Finding{File: c.file, Severity: s, Message: fmt.Sprintf(format, args...)}
+	if _, ok := d.(protoreflect.FileDescriptor); !ok {
+		f.Name = d.FullName()
+	}
+	c.findings = append(c.findings, f)
+}
+
+// find returns the descriptor in the new files with the same name and kind
+// as d, or nil if there is none.
+func find[D protoreflect.Descriptor](c *checker, d D) D {
+	var zero D
+	nd, err := c.new.FindDescriptorByName(d.FullName())
+	if err != nil {
+		return zero
+	}
+	if nd, ok := nd.(D); ok {
+		return nd
+	}
+	return zero
+}
+
+func (c *checker) checkFile(fd protoreflect.FileDescriptor) {
+	c.file = fd.Path()
+	nfd, err := c.new.FindFileByPath(fd.Path())
+	switch {
+	case err != nil:
+		c.report(fd, Source, "file was removed")
+	case fileOptions(fd).GetGoPackage() != fileOptions(nfd).GetGoPackage():
+		c.report(fd, Source, "go_package changed from %q to %q", fileOptions(fd).GetGoPackage(), fileOptions(nfd).GetGoPackage())
+	}
+	c.checkDecls(fd)
+}
+
+// checkDecls checks the declarations of a file or message.
+func (c *checker) checkDecls(d interface {
+	protoreflect.Descriptor
+	Messages() protoreflect.MessageDescriptors
+	Enums() protoreflect.EnumDescriptors
+	Extensions() protoreflect.ExtensionDescriptors
+}) {
+	for i := 0; i < d.Messages().Len(); i++ {
+		c.checkMessage(d.Messages().Get(i))
+	}
+	for i := 0; i < d.Enums().Len(); i++ {
+		c.checkEnum(d.Enums().Get(i))
+	}
+	for i := 0; i < d.Extensions().Len(); i++ {
+		c.checkExtension(d.Extensions().Get(i))
+	}
+	if fd, ok := d.(protoreflect.FileDescriptor); ok {
+		for i := 0; i < fd.Services().Len(); i++ {
+			c.checkService(fd.Services().Get(i))
+		}
+	}
+}
+
+// checkMoved reports a top-level declaration that moved to another file.
+func (c *checker) checkMoved(d, nd protoreflect.Descriptor) {
+	if _, ok := d.Parent().(protoreflect.FileDescriptor); !ok {
+		return
+	}
+	if p := nd.ParentFile().Path(); p != d.ParentFile().Path() {
+		c.report(d, Source, "moved from file %q to %q", d.ParentFile().Path(), p)
+	}
+}
+
+func (c *checker) checkMessage(md protoreflect.MessageDescriptor) {
+	if md.IsMapEntry() {
+		return
+	}
+	nmd := find(c, md)
+	if nmd == nil {
+		c.report(md, Source, "message was removed")
+		return
+	}
+	c.checkMoved(md, nmd)
+
+	if messageOptions(md).GetMessageSetWireFormat() != messageOptions(nmd).GetMessageSetWireFormat() {
+		c.report(md, Wire, "message_set_wire_format changed from %v to %v", messageOptions(md).GetMessageSetWireFormat(), messageOptions(nmd).GetMessageSetWireFormat())
+	}
+
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		nfd := nmd.Fields().ByNumber(fd.Number())
+		switch {
+		case nfd != nil:
+			c.checkField(fd, nfd)
+		case nmd.Fields().ByName(fd.Name()) != nil:
+			c.report(fd, Wire, "field number changed from %d to %d", fd.Number(), nmd.Fields().ByName(fd.Name()).Number())
+		default:
+			c.checkRemoved(fd, "field", fd.Number(), nmd.ReservedRanges().Has(fd.Number()), nmd.ReservedNames().Has(fd.Name()))
+		}
+	}
+	for i := 0; i < nmd.Fields().Len(); i++ {
+		nfd := nmd.Fields().Get(i)
+		if md.Fields().ByNumber(nfd.Number()) == nil && nfd.Cardinality() == protoreflect.Required {
+			c.report(md, Wire, "required field %q was added", nfd.Name())
+		}
+	}
+
+	for i := 0; i < md.ExtensionRanges().Len(); i++ {
+		r := md.ExtensionRanges().Get(i)
+		if !covers(fieldRanges(nmd.ExtensionRanges()), [2]int64{int64(r[0]), int64(r[1])}) {
+			c.report(md, Wire, "extension range %s was removed", formatRange(r))
+		}
+	}
+	for i := 0; i < md.ReservedRanges().Len(); i++ {
+		r := md.ReservedRanges().Get(i)
+		if !covers(fieldRanges(nmd.ReservedRanges()), [2]int64{int64(r[0]), int64(r[1])}) {
+			c.report(md, Wire, "reserved range %s was removed", formatRange(r))
+		}
+	}
+	for i := 0; i < md.ReservedNames().Len(); i++ {
+		if s := md.ReservedNames().Get(i); !nmd.ReservedNames().Has(s) {
+			c.report(md, JSON, "reserved name %q was removed", s)
+		}
+	}
+
+	c.checkDecls(md)
+}
+
+// checkRemoved reports a field or enum value that was removed.
+// Reserving its number keeps wire compatibility,
+// and reserving its name as well keeps JSON compatibility.
+func (c *checker) checkRemoved(d protoreflect.Descriptor, kind string, n any, numberReserved, nameReserved bool) {
+	switch {
+	case !numberReserved:
+		c.report(d, Wire, "%s was removed without reserving its number %d", kind, n)
+	case !nameReserved:
+		c.report(d, JSON, "%s was removed without reserving its name %q", kind, d.Name())
+	default:
+		c.report(d, Source, "%s was removed", kind)
+	}
+}
+
+func (c *checker) checkExtension(xd protoreflect.ExtensionDescriptor) {
+	nxd := c.extensions[extensionKey{xd.ContainingMessage().FullName(), xd.Number()}]
+	if nxd == nil {
+		c.report(xd, Wire, "extension %d of %s was removed", xd.Number(), xd.ContainingMessage().FullName())
+		return
+	}
+	if nxd.FullName() != xd.FullName() {
+		c.report(xd, JSON, "extension renamed to %s", nxd.FullName())
+	} else {
+		c.checkMoved(xd, nxd)
+	}
+	c.checkField(xd, nxd)
+}
+
+func (c *checker) checkField(fd, nfd protoreflect.FieldDescriptor) {
+	if fd.Name() != nfd.Name() && !fd.IsExtension() {
+		c.report(fd, JSON, "field renamed to %q", nfd.Name())
+	} else if fd.JSONName() != nfd.JSONName() && !fd.IsExtension() {
+		c.report(fd, JSON, "JSON name changed from %q to %q", fd.JSONName(), nfd.JSONName())
+	}
+
+	switch k, nk := fd.Kind(), nfd.Kind(); {
+	case k == nk:
+		if name, nname := typeName(fd), typeName(nfd); name != nname {
+			c.report(fd, Wire, "type changed from %s to %s", name, nname)
+		}
+	case isMessage(k) && isMessage(nk):
+		c.report(fd, Wire, "encoding changed from %s to %s", messageEncoding(k), messageEncoding(nk))
+	case wireGroup(k) != 0 && wireGroup(k) == wireGroup(nk):
+		c.report(fd, JSON, "type changed from %s to %s", typeName(fd), typeName(nfd))
+	default:
+		c.report(fd, Wire, "type changed from %s to %s", typeName(fd), typeName(nfd))
+	}
+
+	if card, ncard := fd.Cardinality(), nfd.Cardinality(); card != ncard {
+		c.report(fd, Wire, "cardinality changed from %v to %v", card, ncard)
+	} else if card == protoreflect.Optional && fd.HasPresence() != nfd.HasPresence() && !fd.IsMap() {
+		c.report(fd, JSON, "field presence changed from %s to %s", presence(fd), presence(nfd))
+	}
+	// Parsers accept both the packed and expanded encoding of a repeated
+	// scalar field, so a change between them only affects the bytes that
+	// are produced, which matters to code that compares serialized data.
+	if fd.IsList() && nfd.IsList() && fd.IsPacked() != nfd.IsPacked() {
+		c.report(fd, Source, "encoding changed from %s to %s", listEncoding(fd), listEncoding(nfd))
+	}
+	if fd.Kind() == protoreflect.StringKind && nfd.Kind() == protoreflect.StringKind && strs.EnforceUTF8(fd) != strs.EnforceUTF8(nfd) {
+		c.report(fd, Wire, "UTF-8 validation changed from %v to %v", strs.EnforceUTF8(fd), strs.EnforceUTF8(nfd))
+	}
+
+	if od, nod := realOneof(fd), realOneof(nfd); od != nod {
+		switch {
+		case od == "":
+			c.report(fd, Wire, "moved into oneof %q", nod)
+		case nod == "":
+			c.report(fd, Wire, "moved out of oneof %q", od)
+		default:
+			c.report(fd, Wire, "moved from oneof %q to %q", od, nod)
+		}
+	}
+
+	if fd.Kind() == nfd.Kind() && !fd.IsList() && !nfd.IsList() && fd.Message() == nil && !equalDefault(fd, nfd) {
+		c.report(fd, Wire, "default value changed from %s to %s", formatDefault(fd), formatDefault(nfd))
+	}
+}
+
+func (c *checker) checkEnum(ed protoreflect.EnumDescriptor) {
+	ned := find(c, ed)
+	if ned == nil {
+		c.report(ed, Source, "enum was removed")
+		return
+	}
+	c.checkMoved(ed, ned)
+	if ed.IsClosed() != ned.IsClosed() {
+		c.report(ed, Wire, "enum changed from %s to %s", enumType(ed), enumType(ned))
+	}
+	for i := 0; i < ed.Values().Len(); i++ {
+		vd := ed.Values().Get(i)
+		nvd := ned.Values().ByNumber(vd.Number())
+		switch {
+		case nvd == nil && ned.Values().ByName(vd.Name()) != nil:
+			c.report(vd, Wire, "enum value number changed from %d to %d", vd.Number(), ned.Values().ByName(vd.Name()).Number())
+		case nvd == nil:
+			c.checkRemoved(vd, "enum value", vd.Number(), ned.ReservedRanges().Has(vd.Number()), ned.ReservedNames().Has(vd.Name()))
+		case ned.Values().ByName(vd.Name()) == nil:
+			// The value was renamed, rather than given another alias.
+			c.report(vd, JSON, "enum value renamed to %q", nvd.Name())
+		}
+	}
+	for i := 0; i < ed.ReservedRanges().Len(); i++ {
+		r := ed.ReservedRanges().Get(i)
+		if !covers(enumRanges(ned.ReservedRanges()), [2]int64{int64(r[0]), int64(r[1]) + 1}) {
+			c.report(ed, Wire, "reserved range %s was removed", formatEnumRange(r))
+		}
+	}
+	for i := 0; i < ed.ReservedNames().Len(); i++ {
+		if s := ed.ReservedNames().Get(i); !ned.ReservedNames().Has(s) {
+			c.report(ed, JSON, "reserved name %q was removed", s)
+		}
+	}
+}
+
+func (c *checker) checkService(sd protoreflect.ServiceDescriptor) {
+	nsd := find(c, sd)
+	if nsd == nil {
+		c.report(sd, Source, "service was removed")
+		return
+	}
+	c.checkMoved(sd, nsd)
+	for i := 0; i < sd.Methods().Len(); i++ {
+		md := sd.Methods().Get(i)
+		nmd := nsd.Methods().ByName(md.Name())
+		if nmd == nil {
+			c.report(md, Source, "method was removed")
+			continue
+		}
+		if md.Input().FullName() != nmd.Input().FullName() {
+			c.report(md, Wire, "input type changed from %s to %s", md.Input().FullName(), nmd.Input().FullName())
+		}
+		if md.Output().FullName() != nmd.Output().FullName() {
+			c.report(md, Wire, "output type changed from %s to %s", md.Output().FullName(), nmd.Output().FullName())
+		}
+		if md.IsStreamingClient() != nmd.IsStreamingClient() {
+			c.report(md, Wire, "client streaming changed from %v to %v", md.IsStreamingClient(), nmd.IsStreamingClient())
+		}
+		if md.IsStreamingServer() != nmd.IsStreamingServer() {
+			c.report(md, Wire, "server streaming changed from %v to %v", md.IsStreamingServer(), nmd.IsStreamingServer())
+		}
+	}
+}
+
+// rangeExtensions calls f for every extension declared in the file.
+func rangeExtensions(fd protoreflect.FileDescriptor, f func(protoreflect.ExtensionDescriptor)) {
+	var walk func(interface {
+		Messages() protoreflect.MessageDescriptors
+		Extensions() protoreflect.ExtensionDescriptors
+	})
+	walk = func(d interface {
+		Messages() protoreflect.MessageDescriptors
+		Extensions() protoreflect.ExtensionDescriptors
+	}) {
+		for i := 0; i < d.Extensions().Len(); i++ {
+			f(d.Extensions().Get(i))
+		}
+		for i := 0; i < d.Messages().Len(); i++ {
+			walk(d.Messages().Get(i))
+		}
+	}
+	walk(fd)
+}
+
+func fileOptions(fd protoreflect.FileDescriptor) *descriptorpb.FileOptions {
+	opts, _ := fd.Options().(*descriptorpb.FileOptions)
+	return opts
+}
+
+func messageOptions(md protoreflect.MessageDescriptor) *descriptorpb.MessageOptions {
+	opts, _ := md.Options().(*descriptorpb.MessageOptions)
+	return opts
+}
+
+// covers reports whether the half-open range r is included in the union
+// of the half-open ranges rs, which may be split differently than r.
+func covers(rs [][2]int64, r [2]int64) bool {
+	for n := r[0]; n < r[1]; {
+		next := n
+		for _, nr := range rs {
+			if nr[0] <= n && n < nr[1] && nr[1] > next {
+				next = nr[1]
+			}
+		}
+		if next == n {
+			return false
+		}
+		n = next
+	}
+	return true
+}
+
+func fieldRanges(rs protoreflect.FieldRanges) [][2]int64 {
+	var out [][2]int64
+	for i := 0; i < rs.Len(); i++ {
+		r := rs.Get(i)
+		out = append(out, [2]int64{int64(r[0]), int64(r[1])})
+	}
+	return out
+}
+
+// enumRanges returns enum ranges, whose end is inclusive, as half-open ranges.
+func enumRanges(rs protoreflect.EnumRanges) [][2]int64 {
+	var out [][2]int64
+	for i := 0; i < rs.Len(); i++ {
+		r := rs.Get(i)
+		out = append(out, [2]int64{int64(r[0]), int64(r[1]) + 1})
+	}
+	return out
+}
+
+// formatRange formats a field range, whose end is exclusive.
+func formatRange(r [2]protoreflect.FieldNumber) string {
+	if r[1]-r[0] == 1 {
+		return fmt.Sprint(r[0])
+	}
+	return fmt.Sprintf("%d to %d", r[0], r[1]-1)
+}
+
+// formatEnumRange formats an enum range, whose end is inclusive.
+func formatEnumRange(r [2]protoreflect.EnumNumber) string {
+	if r[0] == r[1] {
+		return fmt.Sprint(r[0])
+	}
+	return fmt.Sprintf("%d to %d", r[0], r[1])
+}
+
+func typeName(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.IsMap():
+		return fmt.Sprintf("map<%s, %s>", typeName(fd.MapKey()), typeName(fd.MapValue()))
+	case fd.Message() != nil:
+		return string(fd.Message().FullName())
+	case fd.Enum() != nil:
+		return string(fd.Enum().FullName())
+	default:
+		return fd.Kind().String()
+	}
+}
+
+func isMessage(k protoreflect.Kind) bool {
+	return k == protoreflect.MessageKind || k == protoreflect.GroupKind
+}
+
+func messageEncoding(k protoreflect.Kind) string {
+	if k == protoreflect.GroupKind {
+		return "delimited"
+	}
+	return "length-prefixed"
+}
+
+// wireGroup returns a number that identifies the set of kinds that a kind
+// can be changed to while keeping wire compatibility, or zero if there is none.
+func wireGroup(k protoreflect.Kind) int {
+	switch k {
+	case protoreflect.Int32Kind, protoreflect.Uint32Kind, protoreflect.Int64Kind, protoreflect.Uint64Kind, protoreflect.BoolKind, protoreflect.EnumKind:
+		return 1
+	case protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+		return 2
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind:
+		return 3
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind:
+		return 4
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return 5
+	default:
+		return 0
+	}
+}
+
+func presence(fd protoreflect.FieldDescriptor) string {
+	if fd.HasPresence() {
+		return "explicit"
+	}
+	return "implicit"
+}
+
+func listEncoding(fd protoreflect.FieldDescriptor) string {
+	if fd.IsPacked() {
+		return "packed"
+	}
+	return "expanded"
+}
+
+func enumType(ed protoreflect.EnumDescriptor) string {
+	if ed.IsClosed() {
+		return "closed"
+	}
+	return "open"
+}
+
+// realOneof returns the name of the oneof that contains fd,
+// or the empty string if there is none or it is synthetic.
+func realOneof(fd protoreflect.FieldDescriptor) protoreflect.Name {
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		return od.Name()
+	}
+	return ""
+}
+
+func equalDefault(fd, nfd protoreflect.FieldDescriptor) bool {
+	if fd.Kind() == protoreflect.EnumKind {
+		// Enum values are compared by name, since the default of a field
+		// is declared as the name of a value.
+		return defaultEnumName(fd) == defaultEnumName(nfd)
+	}
+	x, y := fd.Default().Interface(), nfd.Default().Interface()
+	switch x := x.(type) {
+	case []byte:
+		return bytes.Equal(x, y.([]byte))
+	case float32:
+		return x == y.(float32) || (math.IsNaN(float64(x)) && math.IsNaN(float64(y.(float32))))
+	case float64:
+		return x == y.(float64) || (math.IsNaN(x) && math.IsNaN(y.(float64)))
+	default:
+		return x == y
+	}
+}
+
+func defaultEnumName(fd protoreflect.FieldDescriptor) protoreflect.Name {
+	if ev := fd.DefaultEnumValue(); ev != nil {
+		return ev.Name()
+	}
+	return ""
+}
+
+func formatDefault(fd protoreflect.FieldDescriptor) string {
+	switch v := fd.Default().Interface().(type) {
+	case protoreflect.EnumNumber:
+		return string(defaultEnumName(fd))
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []byte:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
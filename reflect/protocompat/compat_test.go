@@ -0,0 +1,230 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocompat_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/compiler/protoparse"
+	"google.golang.org/protobuf/reflect/protocompat"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func parse(t *testing.T, files map[string]string) *protoregistry.Files {
+	t.Helper()
+	fsys := fstest.MapFS{}
+	var names []string
+	for name, src := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(src)}
+		names = append(names, name)
+	}
+	r, err := protoparse.Parser{FS: fsys}.ParseFiles(names...)
+	if err != nil {
+		t.Fatalf("ParseFiles() error: %v", err)
+	}
+	return r
+}
+
+func TestCheck(t *testing.T) {
+	const (
+		source = protocompat.Source
+		json   = protocompat.JSON
+		wire   = protocompat.Wire
+	)
+	type finding struct {
+		Name     string
+		Severity protocompat.Severity
+		Message  string
+	}
+	tests := []struct {
+		desc     string
+		old, new string
+		want     []finding
+	}{{
+		desc: "identical",
+		old:  `syntax = "proto3"; package p; message M { int32 a = 1; } enum E { A = 0; }`,
+		new:  `syntax = "proto3"; package p; message M { int32 a = 1; } enum E { A = 0; }`,
+	}, {
+		desc: "compatible additions",
+		old:  `syntax = "proto2"; package p; message M { optional int32 a = 1; }`,
+		new:  `syntax = "proto2"; package p; message M { optional int32 a = 1; optional int32 b = 2; message N {} } enum E { A = 0; }`,
+	}, {
+		desc: "removed declarations",
+		old:  `syntax = "proto3"; package p; message M { message N {} } enum E { A = 0; } service S { rpc R(M) returns (M); }`,
+		new:  `syntax = "proto3"; package p; message M {} service S {}`,
+		want: []finding{
+			{"p.M.N", source, "message was removed"},
+			{"p.E", source, "enum was removed"},
+			{"p.S.R", source, "method was removed"},
+		},
+	}, {
+		desc: "removed fields",
+		old:  `syntax = "proto3"; package p; message M { int32 a = 1; int32 b = 2; int32 c = 3; }`,
+		new:  `syntax = "proto3"; package p; message M { reserved 2, 3; reserved "c"; }`,
+		want: []finding{
+			{"p.M.a", wire, "field was removed without reserving its number 1"},
+			{"p.M.b", json, `field was removed without reserving its name "b"`},
+			{"p.M.c", source, "field was removed"},
+		},
+	}, {
+		desc: "renamed and renumbered fields",
+		old:  `syntax = "proto3"; package p; message M { int32 a = 1; int32 b = 2; string c = 3; }`,
+		new:  `syntax = "proto3"; package p; message M { int32 x = 1; int32 b = 5; string c = 3 [json_name = "C"]; }`,
+		want: []finding{
+			{"p.M.a", json, `field renamed to "x"`},
+			{"p.M.b", wire, "field number changed from 2 to 5"},
+			{"p.M.c", json, `JSON name changed from "c" to "C"`},
+		},
+	}, {
+		desc: "field types",
+		old: `syntax = "proto3"; package p; message M {
+			int32 a = 1; string b = 2; fixed32 c = 3; M d = 4; E e = 5; uint64 f = 6;
+		} message N {} enum E { A = 0; }`,
+		new: `syntax = "proto3"; package p; message M {
+			int64 a = 1; bytes b = 2; fixed64 c = 3; N d = 4; int32 e = 5; sint64 f = 6;
+		} message N {} enum E { A = 0; }`,
+		want: []finding{
+			{"p.M.a", json, "type changed from int32 to int64"},
+			{"p.M.b", json, "type changed from string to bytes"},
+			{"p.M.c", wire, "type changed from fixed32 to fixed64"},
+			{"p.M.d", wire, "type changed from p.M to p.N"},
+			{"p.M.e", json, "type changed from p.E to int32"},
+			{"p.M.f", wire, "type changed from uint64 to sint64"},
+		},
+	}, {
+		desc: "cardinality and presence",
+		old:  `syntax = "proto2"; package p; message M { optional int32 a = 1; required int32 b = 2; repeated int32 c = 3; repeated int32 d = 4 [packed = true]; }`,
+		new:  `syntax = "proto2"; package p; message M { repeated int32 a = 1; optional int32 b = 2; repeated int32 c = 3 [packed = true]; repeated int32 d = 4; required int32 e = 5; }`,
+		want: []finding{
+			{"p.M.a", wire, "cardinality changed from optional to repeated"},
+			{"p.M.b", wire, "cardinality changed from required to optional"},
+			{"p.M.c", source, "encoding changed from expanded to packed"},
+			{"p.M.d", source, "encoding changed from packed to expanded"},
+			{"p.M", wire, `required field "e" was added`},
+		},
+	}, {
+		desc: "proto3 optional",
+		old:  `syntax = "proto3"; package p; message M { int32 a = 1; optional int32 b = 2; }`,
+		new:  `syntax = "proto3"; package p; message M { optional int32 a = 1; int32 b = 2; }`,
+		want: []finding{
+			{"p.M.a", json, "field presence changed from implicit to explicit"},
+			{"p.M.b", json, "field presence changed from explicit to implicit"},
+		},
+	}, {
+		desc: "edition features",
+		old: `edition = "2023"; package p;
+			message M { int32 a = 1; M b = 2; string c = 3; repeated int32 d = 4; E e = 5; }
+			enum E { A = 0; }`,
+		new: `edition = "2023"; package p;
+			message M {
+				int32 a = 1 [features.field_presence = IMPLICIT];
+				M b = 2 [features.message_encoding = DELIMITED];
+				string c = 3 [features.utf8_validation = NONE];
+				repeated int32 d = 4 [features.repeated_field_encoding = EXPANDED];
+				E e = 5;
+			}
+			enum E { option features.enum_type = CLOSED; A = 0; }`,
+		want: []finding{
+			{"p.M.a", json, "field presence changed from explicit to implicit"},
+			{"p.M.b", wire, "encoding changed from length-prefixed to delimited"},
+			{"p.M.c", wire, "UTF-8 validation changed from true to false"},
+			{"p.M.d", source, "encoding changed from packed to expanded"},
+			{"p.E", wire, "enum changed from open to closed"},
+		},
+	}, {
+		desc: "oneofs and defaults",
+		old:  `syntax = "proto2"; package p; message M { optional int32 a = 1 [default = 5]; oneof o { int32 b = 2; } optional int32 c = 3; }`,
+		new:  `syntax = "proto2"; package p; message M { optional int32 a = 1 [default = 6]; optional int32 b = 2; oneof o { int32 c = 3; } }`,
+		want: []finding{
+			{"p.M.a", wire, "default value changed from 5 to 6"},
+			{"p.M.b", wire, `moved out of oneof "o"`},
+			{"p.M.c", wire, `moved into oneof "o"`},
+		},
+	}, {
+		desc: "ranges",
+		old:  `syntax = "proto2"; package p; message M { extensions 100 to 199; reserved 5 to 10; reserved "x"; } enum E { A = 0; reserved 3; }`,
+		new:  `syntax = "proto2"; package p; message M { extensions 100 to 149; extensions 150 to 198; reserved 5 to 7, 8 to 10; } enum E { A = 0; }`,
+		want: []finding{
+			{"p.M", wire, "extension range 100 to 199 was removed"},
+			{"p.M", json, `reserved name "x" was removed`},
+			{"p.E", wire, "reserved range 3 was removed"},
+		},
+	}, {
+		desc: "enum values",
+		old:  `syntax = "proto3"; package p; enum E { A = 0; B = 1; C = 2; D = 3; F = 4; }`,
+		new:  `syntax = "proto3"; package p; enum E { option allow_alias = true; A = 0; BB = 1; C = 5; F = 4; G = 4; reserved 3; }`,
+		want: []finding{
+			{"p.B", json, `enum value renamed to "BB"`},
+			{"p.C", wire, "enum value number changed from 2 to 5"},
+			{"p.D", json, `enum value was removed without reserving its name "D"`},
+		},
+	}, {
+		desc: "extensions",
+		old:  `syntax = "proto2"; package p; message M { extensions 1 to 10; } extend M { optional int32 a = 1; optional int32 b = 2; }`,
+		new:  `syntax = "proto2"; package p; message M { extensions 1 to 10; } extend M { optional int32 x = 1; }`,
+		want: []finding{
+			{"p.a", json, "extension renamed to p.x"},
+			{"p.b", wire, "extension 2 of p.M was removed"},
+		},
+	}, {
+		desc: "methods",
+		old:  `syntax = "proto3"; package p; message M {} message N {} service S { rpc R(M) returns (M); rpc T(M) returns (stream M); }`,
+		new:  `syntax = "proto3"; package p; message M {} message N {} service S { rpc R(N) returns (N); rpc T(stream M) returns (M); }`,
+		want: []finding{
+			{"p.S.R", wire, "input type changed from p.M to p.N"},
+			{"p.S.R", wire, "output type changed from p.M to p.N"},
+			{"p.S.T", wire, "client streaming changed from false to true"},
+			{"p.S.T", wire, "server streaming changed from true to false"},
+		},
+	}, {
+		desc: "go_package",
+		old:  `syntax = "proto3"; package p; option go_package = "example.com/a";`,
+		new:  `syntax = "proto3"; package p; option go_package = "example.com/b";`,
+		want: []finding{
+			{"", source, `go_package changed from "example.com/a" to "example.com/b"`},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			old := parse(t, map[string]string{"test.proto": tt.old})
+			new := parse(t, map[string]string{"test.proto": tt.new})
+			var got []finding
+			for _, f := range protocompat.Check(old, new) {
+				if f.File != "test.proto" {
+					t.Errorf("finding %v: File = %q, want %q", f, f.File, "test.proto")
+				}
+				got = append(got, finding{string(f.Name), f.Severity, f.Message})
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Check() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckFiles(t *testing.T) {
+	old := parse(t, map[string]string{
+		"a.proto": `syntax = "proto3"; package p; import "b.proto"; message A { B b = 1; }`,
+		"b.proto": `syntax = "proto3"; package p; message B {}`,
+		"c.proto": `syntax = "proto3"; package q;`,
+	})
+	new := parse(t, map[string]string{
+		"a.proto": `syntax = "proto3"; package p; message A { B b = 1; } message B {}`,
+		"b.proto": `syntax = "proto3"; package p;`,
+	})
+	got := protocompat.Check(old, new)
+	want := []protocompat.Finding{
+		{File: "b.proto", Name: "p.B", Severity: protocompat.Source, Message: `moved from file "b.proto" to "a.proto"`},
+		{File: "c.proto", Severity: protocompat.Source, Message: "file was removed"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Check() mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := got[0].String(), `source: b.proto: p.B: moved from file "b.proto" to "a.proto"`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protodesc
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/encoding/defval"
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ResolveFeatures returns the resolved value of the language-specific feature
+// set xt, which must be a message extension of google.protobuf.FeatureSet
+// (such as pb.go in go_features.proto), for the descriptor d.
+//
+// The value starts from the defaults of the edition of the file of d, which
+// are given by the edition_defaults option of each field of the feature set.
+// As with protoc, the fields must be singular scalars, such as enums or bools,
+// whose defaults are written in the form of the default_value of a field.
+// The features set in the options of each enclosing descriptor, from the file
+// down to d itself, are then merged into the value in turn, so that features
+// set on inner descriptors override those set on outer ones. Fields inherit
+// the features of their containing oneof, if any.
+//
+// The features may be set in the options as either a known extension field
+// or as unknown fields, so xt need not be registered when the options of d
+// are unmarshaled.
+func ResolveFeatures(d protoreflect.Descriptor, xt protoreflect.ExtensionType) (proto.Message, error) {
+	xd := xt.TypeDescriptor()
+	if xd.ContainingMessage().FullName() != genid.FeatureSet_message_fullname || xd.Message() == nil || xd.IsList() {
+		return nil, errors.New("extension %v is not a message extension of %v", xd.FullName(), genid.FeatureSet_message_fullname)
+	}
+	edition, err := editionOf(d.ParentFile())
+	if err != nil {
+		return nil, err
+	}
+	m := xt.New().Message()
+	if err := setFeatureDefaults(m, edition); err != nil {
+		return nil, err
+	}
+
+	var path []protoreflect.Descriptor
+	for ; d != nil; d = featureParent(d) {
+		path = append(path, d)
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		if err := mergeFeatures(m, path[i], xd.Number()); err != nil {
+			return nil, errors.Wrap(err, "%v", path[i].FullName())
+		}
+	}
+	return m.Interface(), nil
+}
+
+// editionOf returns the edition of fd.
+func editionOf(fd protoreflect.FileDescriptor) (descriptorpb.Edition, error) {
+	if fd == nil {
+		return 0, errors.New("descriptor has no parent file")
+	}
+	if imp, ok := fd.(protoreflect.FileImport); ok {
+		fd = imp.FileDescriptor
+	}
+	switch fd.Syntax() {
+	case protoreflect.Proto2:
+		return descriptorpb.Edition_EDITION_PROTO2, nil
+	case protoreflect.Proto3:
+		return descriptorpb.Edition_EDITION_PROTO3, nil
+	case protoreflect.Editions:
+		if ed, ok := fd.(interface{ Edition() int32 }); ok {
+			return descriptorpb.Edition(ed.Edition()), nil
+		}
+	}
+	return 0, errors.New("unable to determine the edition of %q", fd.Path())
+}
+
+// setFeatureDefaults sets each field of the feature set m to its default for
+// the given edition, which is the value of the edition_defaults option entry
+// with the latest edition that is not later than the given edition.
+func setFeatureDefaults(m protoreflect.Message, edition descriptorpb.Edition) error {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Cardinality() == protoreflect.Repeated || fd.Message() != nil {
+			// As with protoc, features are limited to singular scalars,
+			// whose defaults are written as for default_value.
+			return errors.New("feature %v is not a singular scalar", fd.FullName())
+		}
+		opts, err := fieldOptions(fd)
+		if err != nil {
+			return err
+		}
+		var def *descriptorpb.FieldOptions_EditionDefault
+		for _, d := range opts.GetEditionDefaults() {
+			if d.GetEdition() <= edition && (def == nil || d.GetEdition() > def.GetEdition()) {
+				def = d
+			}
+		}
+		if def == nil {
+			return errors.New("feature %v has no default for edition %v", fd.FullName(), edition)
+		}
+		var evs protoreflect.EnumValueDescriptors
+		if ed := fd.Enum(); ed != nil {
+			evs = ed.Values()
+		}
+		v, _, err := defval.Unmarshal(def.GetValue(), fd.Kind(), evs, defval.Descriptor)
+		if err != nil {
+			return errors.Wrap(err, "invalid default for feature %v", fd.FullName())
+		}
+		m.Set(fd, v)
+	}
+	return nil
+}
+
+// fieldOptions returns the options of fd as a FieldOptions message.
+func fieldOptions(fd protoreflect.FieldDescriptor) (*descriptorpb.FieldOptions, error) {
+	switch opts := fd.Options().(type) {
+	case nil:
+		return nil, nil
+	case *descriptorpb.FieldOptions:
+		return opts, nil
+	default:
+		b, err := proto.Marshal(opts)
+		if err != nil {
+			return nil, err
+		}
+		fo := new(descriptorpb.FieldOptions)
+		if err := proto.Unmarshal(b, fo); err != nil {
+			return nil, err
+		}
+		return fo, nil
+	}
+}
+
+// featureParent returns the descriptor whose features d inherits,
+// or nil if d is a file.
+func featureParent(d protoreflect.Descriptor) protoreflect.Descriptor {
+	if fd, ok := d.(protoreflect.FieldDescriptor); ok && !fd.IsExtension() {
+		if od := fd.ContainingOneof(); od != nil {
+			return od
+		}
+	}
+	return d.Parent()
+}
+
+// mergeFeatures merges into m the value of the feature set extension with
+// field number num that is set in the features of the options of d.
+func mergeFeatures(m protoreflect.Message, d protoreflect.Descriptor, num protoreflect.FieldNumber) error {
+	opts := d.Options()
+	if opts == nil {
+		return nil
+	}
+	om := opts.ProtoReflect()
+	fd := om.Descriptor().Fields().ByName("features")
+	if fd == nil || fd.Message() == nil || !om.Has(fd) {
+		return nil
+	}
+	// Marshal the feature set, rather than getting the extension field,
+	// since the extension may be unknown or of a different Go type than m.
+	b, err := proto.MarshalOptions{AllowPartial: true}.Marshal(om.Get(fd).Message().Interface())
+	if err != nil {
+		return err
+	}
+	for len(b) > 0 {
+		n, typ, tagLen := protowire.ConsumeTag(b)
+		if tagLen < 0 {
+			return protowire.ParseError(tagLen)
+		}
+		b = b[tagLen:]
+		valLen := protowire.ConsumeFieldValue(n, typ, b)
+		if valLen < 0 {
+			return protowire.ParseError(valLen)
+		}
+		if n == num && typ == protowire.BytesType {
+			v, _ := protowire.ConsumeBytes(b[:valLen])
+			if err := (proto.UnmarshalOptions{Merge: true, AllowPartial: true}).Unmarshal(v, m.Interface()); err != nil {
+				return err
+			}
+		}
+		b = b[valLen:]
+	}
+	return nil
+}
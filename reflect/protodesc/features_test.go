@@ -0,0 +1,259 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protodesc_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"google.golang.org/protobuf/compiler/protoparse"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/gofeaturespb"
+
+	testfuzzpb "google.golang.org/protobuf/internal/testprotos/editionsfuzztest"
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+)
+
+const featuresProto = `syntax = "proto2";
+package features.test;
+import "google/protobuf/descriptor.proto";
+
+extend google.protobuf.FeatureSet {
+	optional LangFeatures lang = 9995;
+}
+
+message LangFeatures {
+	enum Style {
+		STYLE_UNKNOWN = 0;
+		OLD = 1;
+		NEW = 2;
+	}
+	optional Style style = 1 [
+		retention = RETENTION_RUNTIME,
+		targets = TARGET_TYPE_FILE,
+		targets = TARGET_TYPE_FIELD,
+		edition_defaults = { edition: EDITION_LEGACY, value: "OLD" },
+		edition_defaults = { edition: EDITION_2023, value: "NEW" }
+	];
+	optional bool fast = 2 [
+		retention = RETENTION_RUNTIME,
+		targets = TARGET_TYPE_FILE,
+		targets = TARGET_TYPE_MESSAGE,
+		targets = TARGET_TYPE_ONEOF,
+		targets = TARGET_TYPE_FIELD,
+		edition_defaults = { edition: EDITION_LEGACY, value: "false" },
+		edition_defaults = { edition: EDITION_2024, value: "true" }
+	];
+	optional bool upper = 3 [
+		retention = RETENTION_RUNTIME,
+		targets = TARGET_TYPE_ENUM,
+		targets = TARGET_TYPE_ENUM_ENTRY,
+		edition_defaults = { edition: EDITION_LEGACY, value: "false" }
+	];
+}
+`
+
+const featuresUserProto = `edition = "2023";
+package features.test;
+import "features.proto";
+
+option features.(lang).style = OLD;
+
+message M {
+	option features.(lang).fast = true;
+
+	int32 a = 1;
+	int32 b = 2 [features.(lang).style = NEW, features.(lang).fast = false];
+	oneof o {
+		option features.(lang).fast = false;
+		int32 c = 3;
+		int32 d = 4 [features.(lang).fast = true];
+	}
+	extensions 100 to 200;
+	extend M {
+		int32 e = 100;
+	}
+}
+
+extend M {
+	int32 f = 101;
+}
+
+enum E {
+	option features.(lang).upper = true;
+	E_ZERO = 0;
+	E_ONE = 1 [features.(lang).upper = false];
+}
+`
+
+func TestResolveFeatures(t *testing.T) {
+	p := protoparse.Parser{FS: fstest.MapFS{
+		"features.proto":      {Data: []byte(featuresProto)},
+		"features_user.proto": {Data: []byte(featuresUserProto)},
+	}}
+	files, err := p.ParseFiles("features_user.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd, err := files.FindFileByPath("features_user.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The features are unknown fields of the parsed options,
+	// since the extension is not known when they are unmarshaled.
+	xt := dynamicpb.NewExtensionType(fd.Imports().Get(0).Extensions().ByName("lang"))
+	md := fd.Messages().ByName("M")
+	ed := fd.Enums().ByName("E")
+
+	tests := []struct {
+		d    protoreflect.Descriptor
+		want string
+	}{
+		{fd, `style:OLD fast:false upper:false`},
+		{md, `style:OLD fast:true upper:false`},
+		{md.Fields().ByName("a"), `style:OLD fast:true upper:false`},
+		{md.Fields().ByName("b"), `style:NEW fast:false upper:false`},
+		{md.Oneofs().ByName("o"), `style:OLD fast:false upper:false`},
+		{md.Fields().ByName("c"), `style:OLD fast:false upper:false`},
+		{md.Fields().ByName("d"), `style:OLD fast:true upper:false`},
+		{md.Extensions().ByName("e"), `style:OLD fast:true upper:false`},
+		{fd.Extensions().ByName("f"), `style:OLD fast:false upper:false`},
+		{ed, `style:OLD fast:false upper:true`},
+		{ed.Values().ByName("E_ZERO"), `style:OLD fast:false upper:true`},
+		{ed.Values().ByName("E_ONE"), `style:OLD fast:false upper:false`},
+	}
+	for _, tt := range tests {
+		got, err := protodesc.ResolveFeatures(tt.d, xt)
+		if err != nil {
+			t.Errorf("ResolveFeatures(%v) error: %v", tt.d.FullName(), err)
+			continue
+		}
+		want := xt.New().Message().Interface()
+		if err := prototext.Unmarshal([]byte(tt.want), want); err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(got, want) {
+			t.Errorf("ResolveFeatures(%v) = {%v}, want {%v}", tt.d.FullName(), got, want)
+		}
+	}
+}
+
+func TestResolveFeaturesEditionDefaults(t *testing.T) {
+	p := protoparse.Parser{FS: fstest.MapFS{"features.proto": {Data: []byte(featuresProto)}}}
+	files, err := p.ParseFiles("features.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	xd, err := files.FindDescriptorByName("features.test.lang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	xt := dynamicpb.NewExtensionType(xd.(protoreflect.ExtensionDescriptor))
+
+	for _, tt := range []struct {
+		edition descriptorpb.Edition
+		want    string
+	}{
+		{descriptorpb.Edition_EDITION_PROTO2, `style:OLD fast:false upper:false`},
+		{descriptorpb.Edition_EDITION_PROTO3, `style:OLD fast:false upper:false`},
+		{descriptorpb.Edition_EDITION_2023, `style:NEW fast:false upper:false`},
+		{descriptorpb.Edition_EDITION_2024, `style:NEW fast:true upper:false`},
+	} {
+		fdp := &descriptorpb.FileDescriptorProto{
+			Name:        proto.String("test.proto"),
+			MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("M")}},
+		}
+		switch tt.edition {
+		case descriptorpb.Edition_EDITION_PROTO2:
+		case descriptorpb.Edition_EDITION_PROTO3:
+			fdp.Syntax = proto.String("proto3")
+		default:
+			fdp.Syntax = proto.String("editions")
+			fdp.Edition = tt.edition.Enum()
+		}
+		fd, err := protodesc.NewFile(fdp, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := protodesc.ResolveFeatures(fd.Messages().ByName("M"), xt)
+		if err != nil {
+			t.Errorf("%v: ResolveFeatures() error: %v", tt.edition, err)
+			continue
+		}
+		want := xt.New().Message().Interface()
+		if err := prototext.Unmarshal([]byte(tt.want), want); err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(got, want) {
+			t.Errorf("%v: ResolveFeatures() = {%v}, want {%v}", tt.edition, got, want)
+		}
+	}
+}
+
+func TestResolveFeaturesGo(t *testing.T) {
+	tests := []struct {
+		d    protoreflect.Descriptor
+		want bool
+	}{
+		{(*testfuzzpb.TestAllTypesProto2)(nil).ProtoReflect().Descriptor(), true},
+		{(*testfuzzpb.TestAllTypesProto3)(nil).ProtoReflect().Descriptor(), false},
+		{(*testfuzzpb.TestAllTypesProto2Editions)(nil).ProtoReflect().Descriptor(), false},
+		{testfuzzpb.TestAllTypesProto2Editions_FOO.Descriptor(), true},
+		{testfuzzpb.TestAllTypesProto2Editions2024_FOO.Descriptor(), true},
+	}
+	for _, tt := range tests {
+		got, err := protodesc.ResolveFeatures(tt.d, gofeaturespb.E_Go)
+		if err != nil {
+			t.Errorf("ResolveFeatures(%v) error: %v", tt.d.FullName(), err)
+			continue
+		}
+		if got := got.(*gofeaturespb.GoFeatures).GetLegacyUnmarshalJsonEnum(); got != tt.want {
+			t.Errorf("ResolveFeatures(%v).LegacyUnmarshalJsonEnum = %v, want %v", tt.d.FullName(), got, tt.want)
+		}
+	}
+}
+
+func TestResolveFeaturesError(t *testing.T) {
+	_, err := protodesc.ResolveFeatures((*testfuzzpb.TestAllTypesProto3)(nil).ProtoReflect().Descriptor(), testpb.E_OptionalInt32)
+	if err == nil {
+		t.Errorf("ResolveFeatures() with a non-FeatureSet extension succeeded, want error")
+	}
+
+	p := protoparse.Parser{FS: fstest.MapFS{"features.proto": {Data: []byte(`syntax = "proto2";
+		package features.test;
+		import "google/protobuf/descriptor.proto";
+		extend google.protobuf.FeatureSet {
+			optional MessageFeatures lang = 9995;
+		}
+		message MessageFeatures {
+			message Naming {
+				optional string prefix = 1;
+			}
+			optional Naming naming = 1 [
+				retention = RETENTION_RUNTIME,
+				targets = TARGET_TYPE_FILE,
+				edition_defaults = { edition: EDITION_LEGACY, value: "prefix: \"x_\"" }
+			];
+		}
+	`)}}}
+	files, err := p.ParseFiles("features.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	xd, err := files.FindDescriptorByName("features.test.lang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	xt := dynamicpb.NewExtensionType(xd.(protoreflect.ExtensionDescriptor))
+	if _, err := protodesc.ResolveFeatures(xd.ParentFile(), xt); err == nil || !strings.Contains(err.Error(), "not a singular scalar") {
+		t.Errorf("ResolveFeatures() with a message feature error = %v, want not a singular scalar", err)
+	}
+}
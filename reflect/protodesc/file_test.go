@@ -434,6 +434,23 @@ func TestNewFile(t *testing.T) {
 			name: "test.proto"
 			package: "fizz"
 		`),
+	}, {
+		label: "basic edition 2024 tests",
+		inDesc: mustParseFile(`
+			syntax: "editions"
+			edition: EDITION_2024
+			name: "test.proto"
+			package: "fizz"
+		`),
+	}, {
+		label: "unsupported edition",
+		inDesc: mustParseFile(`
+			syntax: "editions"
+			edition: EDITION_99997_TEST_ONLY
+			name: "test.proto"
+			package: "fizz"
+		`),
+		wantErr: `use of edition EDITION_99997_TEST_ONLY not yet supported`,
 	}, {
 		label: "proto3 message fields conflict",
 		inDesc: mustParseFile(`
@@ -976,6 +993,76 @@ func TestNewFile(t *testing.T) {
 	}
 }
 
+func TestNewFileEditionFeatures(t *testing.T) {
+	tests := []struct {
+		inDesc       *descriptorpb.FileDescriptorProto
+		wantPresence map[protoreflect.Name]bool
+		wantUTF8     map[protoreflect.Name]bool
+	}{{
+		inDesc:       protoEdition2023Message,
+		wantPresence: map[protoreflect.Name]bool{"foo": false, "bar": false},
+		wantUTF8:     map[protoreflect.Name]bool{"foo": true, "bar": true},
+	}, {
+		inDesc:       protoEdition2024Message,
+		wantPresence: map[protoreflect.Name]bool{"foo": true, "bar": false},
+		wantUTF8:     map[protoreflect.Name]bool{"foo": true, "bar": false},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.inDesc.GetName(), func(t *testing.T) {
+			fd, err := NewFile(tt.inDesc, nil)
+			if err != nil {
+				t.Fatalf("NewFile() error: %v", err)
+			}
+			if got, want := fd.(*filedesc.File).L1.Edition, fromEditionProto(tt.inDesc.GetEdition()); got != want {
+				t.Errorf("NewFile() edition = %v, want %v", got, want)
+			}
+			fields := fd.Messages().ByName("Message").Fields()
+			for name, want := range tt.wantPresence {
+				if got := fields.ByName(name).HasPresence(); got != want {
+					t.Errorf("field %v: HasPresence() = %v, want %v", name, got, want)
+				}
+			}
+			for name, want := range tt.wantUTF8 {
+				if got := fields.ByName(name).(*filedesc.Field).L1.EditionFeatures.IsUTF8Validated; got != want {
+					t.Errorf("field %v: IsUTF8Validated = %v, want %v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewFileDelimitedExtension(t *testing.T) {
+	fd, err := NewFile(mustParseFile(`
+		syntax:    "editions"
+		edition:   EDITION_2023
+		name:      "delimited_extension.proto"
+		package:   "test.editions2023"
+		message_type: [
+			{name:"Message" extension_range:[{start:1 end:1000}]},
+			{name:"Payload"}
+		]
+		extension: [{
+			name:"delimited" number:1 extendee:".test.editions2023.Message"
+			type:TYPE_MESSAGE type_name:".test.editions2023.Payload"
+			options: {features: {message_encoding: DELIMITED}}
+		}, {
+			name:"prefixed" number:2 extendee:".test.editions2023.Message"
+			type:TYPE_MESSAGE type_name:".test.editions2023.Payload"
+		}]
+	`), nil)
+	if err != nil {
+		t.Fatalf("NewFile() error: %v", err)
+	}
+	for name, want := range map[protoreflect.Name]protoreflect.Kind{
+		"delimited": protoreflect.GroupKind,
+		"prefixed":  protoreflect.MessageKind,
+	} {
+		if got := fd.Extensions().ByName(name).Kind(); got != want {
+			t.Errorf("extension %v: Kind() = %v, want %v", name, got, want)
+		}
+	}
+}
+
 func TestNewFiles(t *testing.T) {
 	fdset := &descriptorpb.FileDescriptorSet{
 		File: []*descriptorpb.FileDescriptorProto{
@@ -0,0 +1,266 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protodiff
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ConflictError is the error returned by [Apply] when the value at the path of
+// a change in the message does not match the old value of the change.
+type ConflictError struct {
+	// Change is the change that could not be applied.
+	Change Change
+	// Current is the value at the path of the change in the message.
+	// It is invalid if there is no value at the path.
+	Current protoreflect.Value
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("proto: conflicting %v change at %v", e.Change.Kind, e.Change.Path)
+}
+
+// Apply applies changes computed by [Diff] to the message m.
+//
+// Changes are applied with three-way merge semantics, where the changes were
+// computed between a base message and a modified version of it, and m is
+// another version of the base message that may have been modified concurrently:
+//
+//	err := protodiff.Apply(theirs, protodiff.Diff(base, ours))
+//
+// Before a change is applied, the value at its path in m must match the old
+// value of the change, which is the value in the base message:
+//
+//   - An added field or map entry must not be populated in m.
+//
+//   - A removed or modified field, list element or map entry must be
+//     populated in m and be equal to the old value according to [proto.Equal].
+//
+//   - An added list element is inserted at its index, which must not be
+//     larger than the length of the list in m. A removed list element is
+//     deleted from the list, shifting any subsequent elements.
+//
+//   - Unknown fields must be identical to the old unknown fields.
+//
+// A change that has already been applied to m, such that the value at its path
+// already matches the new value of the change, is skipped, with the exception
+// of added or removed list elements. Any other mismatch is a conflict, for which Apply
+// returns a [*ConflictError] describing the first conflicting change.
+// If any change cannot be applied, m is left unmodified.
+//
+// The new values of the changes are copied into m,
+// such that m does not alias the message the changes were computed from.
+func Apply(m proto.Message, changes []Change) error {
+	dst := proto.Clone(m).ProtoReflect()
+	for _, c := range orderChanges(changes) {
+		if err := applyChange(dst, c); err != nil {
+			return err
+		}
+	}
+	proto.Reset(m)
+	proto.Merge(m, dst.Interface())
+	return nil
+}
+
+// orderChanges returns the changes in the order that they must be applied.
+// Each run of removed elements of the same list is reversed,
+// such that the indexes of elements that are yet to be removed remain valid.
+func orderChanges(changes []Change) []Change {
+	isListRemoval := func(c Change) bool {
+		return c.Kind == Removed && c.Path.Index(-1).Kind() == protopath.ListIndexStep
+	}
+	out := append([]Change(nil), changes...)
+	for i := 0; i < len(out); {
+		j := i + 1
+		if isListRemoval(out[i]) {
+			parent := out[i].Path[:len(out[i].Path)-1].String()
+			for j < len(out) && isListRemoval(out[j]) && out[j].Path[:len(out[j].Path)-1].String() == parent {
+				j++
+			}
+			for l, r := i, j-1; l < r; l, r = l+1, r-1 {
+				out[l], out[r] = out[r], out[l]
+			}
+		}
+		i = j
+	}
+	return out
+}
+
+func applyChange(root protoreflect.Message, c Change) error {
+	p := c.Path
+	if len(p) < 2 || p[0].Kind() != protopath.RootStep || p[0].MessageDescriptor().FullName() != root.Descriptor().FullName() {
+		return errors.New("invalid path %v for message %v", p, root.Descriptor().FullName())
+	}
+
+	// Walk to the message, list or map that contains the changed value,
+	// where fd is the field of a list or map.
+	m := root
+	var fd protoreflect.FieldDescriptor
+	var v protoreflect.Value
+	for _, s := range p[1 : len(p)-1] {
+		switch {
+		case s.Kind() == protopath.FieldAccessStep && fd == nil:
+			f := s.FieldDescriptor()
+			if !f.IsList() && !f.IsMap() && f.Message() == nil {
+				return errors.New("invalid path %v: field %v is not composite", p, f.FullName())
+			}
+			if !m.Has(f) && c.Kind == Removed {
+				return nil // already removed
+			}
+			v = m.Mutable(f)
+			if f.IsList() || f.IsMap() {
+				fd = f
+			} else {
+				m = v.Message()
+			}
+		case s.Kind() == protopath.ListIndexStep && fd != nil && fd.IsList() && fd.Message() != nil:
+			l := v.List()
+			if s.ListIndex() >= l.Len() {
+				return &ConflictError{Change: c}
+			}
+			m, fd = l.Get(s.ListIndex()).Message(), nil
+		case s.Kind() == protopath.MapIndexStep && fd != nil && fd.IsMap() && fd.MapValue().Message() != nil:
+			mm := v.Map()
+			if !mm.Has(s.MapIndex()) && c.Kind == Removed {
+				return nil // already removed
+			}
+			m, fd = mm.Mutable(s.MapIndex()).Message(), nil
+		default:
+			return errors.New("invalid path %v: unexpected step %v", p, s)
+		}
+	}
+
+	switch s := p[len(p)-1]; {
+	case s.Kind() == protopath.FieldAccessStep && fd == nil:
+		f := s.FieldDescriptor()
+		return applyValue(c, m.Has(f), m.Get(f), func() {
+			m.Clear(f)
+		}, func() {
+			setField(m, f, c.New)
+		})
+	case s.Kind() == protopath.UnknownAccessStep && fd == nil:
+		return applyUnknown(m, c)
+	case s.Kind() == protopath.ListIndexStep && fd != nil && fd.IsList():
+		return applyListElement(v.List(), fd, s.ListIndex(), c)
+	case s.Kind() == protopath.MapIndexStep && fd != nil && fd.IsMap():
+		mm, k := v.Map(), s.MapIndex()
+		return applyValue(c, mm.Has(k), mm.Get(k), func() {
+			mm.Clear(k)
+		}, func() {
+			mm.Set(k, cloneValue(mm.NewValue, fd.MapValue(), c.New))
+		})
+	default:
+		return errors.New("invalid path %v: unexpected step %v", p, s)
+	}
+}
+
+// applyValue applies the change c to a field or map entry with the current
+// value v, where has reports whether it is populated.
+func applyValue(c Change, has bool, v protoreflect.Value, clear, set func()) error {
+	if !has {
+		v = protoreflect.Value{}
+	}
+	switch {
+	case c.Kind == Added && !has, c.Kind != Added && has && v.Equal(c.Old):
+		if c.Kind == Removed {
+			clear()
+		} else {
+			set()
+		}
+		return nil
+	case c.Kind == Removed && !has, c.Kind != Removed && has && v.Equal(c.New):
+		return nil // already applied
+	default:
+		return &ConflictError{Change: c, Current: v}
+	}
+}
+
+func applyListElement(l protoreflect.List, fd protoreflect.FieldDescriptor, i int, c Change) error {
+	var v protoreflect.Value
+	if i < l.Len() {
+		v = l.Get(i)
+	}
+	switch {
+	case c.Kind == Added && i <= l.Len():
+		nv := cloneValue(l.NewElement, fd, c.New)
+		l.Append(nv)
+		for j := l.Len() - 1; j > i; j-- {
+			l.Set(j, l.Get(j-1))
+		}
+		l.Set(i, nv)
+	case c.Kind == Removed && v.IsValid() && v.Equal(c.Old):
+		for j := i; j < l.Len()-1; j++ {
+			l.Set(j, l.Get(j+1))
+		}
+		l.Truncate(l.Len() - 1)
+	case c.Kind == Modified && v.IsValid() && v.Equal(c.Old):
+		l.Set(i, cloneValue(l.NewElement, fd, c.New))
+	case c.Kind == Modified && v.IsValid() && v.Equal(c.New):
+		// already applied
+	default:
+		return &ConflictError{Change: c, Current: v}
+	}
+	return nil
+}
+
+func applyUnknown(m protoreflect.Message, c Change) error {
+	var rawOld, rawNew []byte
+	if c.Old.IsValid() {
+		rawOld = c.Old.Bytes()
+	}
+	if c.New.IsValid() {
+		rawNew = c.New.Bytes()
+	}
+	switch cur := m.GetUnknown(); {
+	case equalUnknown(cur, rawOld):
+		m.SetUnknown(append(protoreflect.RawFields(nil), rawNew...))
+	case equalUnknown(cur, rawNew):
+		// already applied
+	default:
+		return &ConflictError{Change: c, Current: protoreflect.ValueOfBytes(cur)}
+	}
+	return nil
+}
+
+// setField sets the field fd of m to a copy of v.
+func setField(m protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	switch {
+	case fd.IsList():
+		m.Clear(fd)
+		dl, sl := m.Mutable(fd).List(), v.List()
+		for i := 0; i < sl.Len(); i++ {
+			dl.Append(cloneValue(dl.NewElement, fd, sl.Get(i)))
+		}
+	case fd.IsMap():
+		m.Clear(fd)
+		dm := m.Mutable(fd).Map()
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			dm.Set(k, cloneValue(dm.NewValue, fd.MapValue(), v))
+			return true
+		})
+	default:
+		m.Set(fd, cloneValue(func() protoreflect.Value { return m.NewField(fd) }, fd, v))
+	}
+}
+
+// cloneValue returns a copy of the singular value v of fd, where newValue
+// returns an empty value to copy a message into.
+func cloneValue(newValue func() protoreflect.Value, fd protoreflect.FieldDescriptor, v protoreflect.Value) protoreflect.Value {
+	switch {
+	case fd.Message() != nil:
+		nv := newValue()
+		proto.Merge(nv.Message().Interface(), v.Message().Interface())
+		return nv
+	case fd.Kind() == protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(append([]byte(nil), v.Bytes()...))
+	default:
+		return v
+	}
+}
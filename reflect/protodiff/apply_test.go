@@ -0,0 +1,302 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protodiff_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodiff"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/testing/protopack"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+)
+
+func TestApplyRoundTrip(t *testing.T) {
+	unknown := protopack.Message{
+		protopack.Tag{Number: 50000, Type: protopack.VarintType}, protopack.Uvarint(1),
+	}.Marshal()
+
+	messages := []*testpb.TestAllTypes{
+		{},
+		{
+			OptionalInt32:  proto.Int32(1),
+			OptionalString: proto.String("a"),
+			OptionalBytes:  []byte("b"),
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A: proto.Int32(1),
+				Corecursive: &testpb.TestAllTypes{
+					RepeatedInt32: []int32{1, 2},
+				},
+			},
+			RepeatedInt32: []int32{1, 2, 3, 4},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(1)},
+				{A: proto.Int32(2)},
+				{A: proto.Int32(3)},
+			},
+			MapStringString: map[string]string{"a": "1", "b": "2"},
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"x": {A: proto.Int32(1)},
+			},
+			OneofField: &testpb.TestAllTypes_OneofUint32{OneofUint32: 1},
+		},
+		{
+			OptionalInt32: proto.Int32(2),
+			OptionalBool:  proto.Bool(true),
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				Corecursive: &testpb.TestAllTypes{
+					RepeatedInt32: []int32{2},
+				},
+			},
+			RepeatedInt32: []int32{4, 3},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(3)},
+			},
+			MapStringString: map[string]string{"b": "3", "c": "4"},
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"x": {A: proto.Int32(2)},
+				"y": {},
+			},
+			OneofField: &testpb.TestAllTypes_OneofString{OneofString: "a"},
+		},
+		func() *testpb.TestAllTypes {
+			m := &testpb.TestAllTypes{
+				RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+					{A: proto.Int32(2)},
+					{A: proto.Int32(2)},
+					{A: proto.Int32(1)},
+					{A: proto.Int32(4)},
+				},
+			}
+			m.ProtoReflect().SetUnknown(unknown)
+			return m
+		}(),
+	}
+
+	for _, opts := range []protodiff.Options{
+		{},
+		{EquateEmpty: true},
+		{IgnoreListOrder: true},
+	} {
+		for i, x := range messages {
+			for j, y := range messages {
+				changes := opts.Diff(x, y)
+
+				got := proto.Clone(x)
+				if err := protodiff.Apply(got, changes); err != nil {
+					t.Errorf("%+v: Apply(messages[%d], Diff(messages[%d], messages[%d])) returned error: %v", opts, i, i, j, err)
+					continue
+				}
+				if diff := opts.Diff(y, got); len(diff) > 0 {
+					t.Errorf("%+v: Apply(messages[%d], Diff(messages[%d], messages[%d])) mismatch: %v", opts, i, i, j, diff)
+				}
+
+				// Applying the same changes again has no effect,
+				// unless they add or remove list elements.
+				if !changesListLength(changes) {
+					if err := protodiff.Apply(got, changes); err != nil {
+						t.Errorf("%+v: repeated Apply(messages[%d], Diff(messages[%d], messages[%d])) returned error: %v", opts, i, i, j, err)
+					}
+					if diff := opts.Diff(y, got); len(diff) > 0 {
+						t.Errorf("%+v: repeated Apply(messages[%d], Diff(messages[%d], messages[%d])) mismatch: %v", opts, i, i, j, diff)
+					}
+				}
+
+				// Changes apply to dynamic messages as well.
+				dyn := dynamicpb.NewMessage(x.ProtoReflect().Descriptor())
+				proto.Merge(dyn, x)
+				if err := protodiff.Apply(dyn, changes); err != nil {
+					t.Errorf("%+v: Apply(dynamic messages[%d], Diff(messages[%d], messages[%d])) returned error: %v", opts, i, i, j, err)
+				} else if diff := opts.Diff(y, dyn); len(diff) > 0 {
+					t.Errorf("%+v: Apply(dynamic messages[%d], Diff(messages[%d], messages[%d])) mismatch: %v", opts, i, i, j, diff)
+				}
+			}
+		}
+	}
+}
+
+// changesListLength reports whether any of the changes
+// adds or removes a list element.
+func changesListLength(changes []protodiff.Change) bool {
+	for _, c := range changes {
+		if c.Kind != protodiff.Modified && c.Path.Index(-1).Kind() == protopath.ListIndexStep {
+			return true
+		}
+	}
+	return false
+}
+
+func TestApplyThreeWay(t *testing.T) {
+	base := &testpb.TestAllTypes{
+		OptionalInt32:   proto.Int32(1),
+		OptionalString:  proto.String("a"),
+		RepeatedInt32:   []int32{1, 2},
+		MapStringString: map[string]string{"a": "1"},
+		OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+			A: proto.Int32(1),
+		},
+	}
+
+	tests := []struct {
+		desc         string
+		ours, theirs *testpb.TestAllTypes
+		want         *testpb.TestAllTypes
+		wantConflict string
+	}{{
+		desc: "disjoint changes",
+		ours: &testpb.TestAllTypes{
+			OptionalInt32:   proto.Int32(2),
+			OptionalString:  proto.String("a"),
+			RepeatedInt32:   []int32{1, 2, 3},
+			MapStringString: map[string]string{"a": "1", "b": "2"},
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A: proto.Int32(1),
+			},
+		},
+		theirs: &testpb.TestAllTypes{
+			OptionalInt32:   proto.Int32(1),
+			RepeatedInt32:   []int32{1, 2},
+			MapStringString: map[string]string{"a": "1", "c": "3"},
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A: proto.Int32(2),
+			},
+		},
+		want: &testpb.TestAllTypes{
+			OptionalInt32:   proto.Int32(2),
+			RepeatedInt32:   []int32{1, 2, 3},
+			MapStringString: map[string]string{"a": "1", "b": "2", "c": "3"},
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A: proto.Int32(2),
+			},
+		},
+	}, {
+		desc: "identical changes",
+		ours: &testpb.TestAllTypes{
+			OptionalInt32:   proto.Int32(2),
+			RepeatedInt32:   []int32{1, 2},
+			MapStringString: map[string]string{"a": "1"},
+		},
+		theirs: &testpb.TestAllTypes{
+			OptionalInt32:   proto.Int32(2),
+			OptionalString:  proto.String("a"),
+			RepeatedInt32:   []int32{1, 2},
+			MapStringString: map[string]string{"a": "1"},
+		},
+		want: &testpb.TestAllTypes{
+			OptionalInt32:   proto.Int32(2),
+			RepeatedInt32:   []int32{1, 2},
+			MapStringString: map[string]string{"a": "1"},
+		},
+	}, {
+		desc: "conflicting field",
+		ours: &testpb.TestAllTypes{
+			OptionalInt32:   proto.Int32(2),
+			OptionalString:  proto.String("a"),
+			RepeatedInt32:   []int32{1, 2},
+			MapStringString: map[string]string{"a": "1"},
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A: proto.Int32(1),
+			},
+		},
+		theirs: &testpb.TestAllTypes{
+			OptionalInt32: proto.Int32(3),
+		},
+		wantConflict: "(goproto.proto.test.TestAllTypes).optional_int32",
+	}, {
+		desc: "conflicting list element",
+		ours: &testpb.TestAllTypes{
+			OptionalInt32:   proto.Int32(1),
+			OptionalString:  proto.String("a"),
+			RepeatedInt32:   []int32{1, 5},
+			MapStringString: map[string]string{"a": "1"},
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A: proto.Int32(1),
+			},
+		},
+		theirs: &testpb.TestAllTypes{
+			RepeatedInt32: []int32{1},
+		},
+		wantConflict: "(goproto.proto.test.TestAllTypes).repeated_int32[1]",
+	}, {
+		desc: "conflicting map entry",
+		ours: &testpb.TestAllTypes{
+			OptionalInt32:   proto.Int32(1),
+			OptionalString:  proto.String("a"),
+			RepeatedInt32:   []int32{1, 2},
+			MapStringString: map[string]string{"a": "1", "b": "2"},
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A: proto.Int32(1),
+			},
+		},
+		theirs: &testpb.TestAllTypes{
+			MapStringString: map[string]string{"b": "3"},
+		},
+		wantConflict: `(goproto.proto.test.TestAllTypes).map_string_string["b"]`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := proto.Clone(tt.theirs)
+			err := protodiff.Apply(got, protodiff.Diff(base, tt.ours))
+			if tt.wantConflict != "" {
+				var cerr *protodiff.ConflictError
+				if !errors.As(err, &cerr) {
+					t.Fatalf("Apply() error = %v, want *ConflictError", err)
+				}
+				if got := cerr.Change.Path.String(); got != tt.wantConflict {
+					t.Errorf("ConflictError.Change.Path = %v, want %v", got, tt.wantConflict)
+				}
+				if diff := cmp.Diff(tt.theirs, got, protocmp.Transform()); diff != "" {
+					t.Errorf("Apply() modified message on conflict (-want +got):\n%s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply() returned error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Apply() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyCopiesValues(t *testing.T) {
+	x := &testpb.TestAllTypes{}
+	y := &testpb.TestAllTypes{
+		OptionalBytes:         []byte("a"),
+		OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(1)},
+		RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{{A: proto.Int32(1)}},
+	}
+	got := &testpb.TestAllTypes{}
+	if err := protodiff.Apply(got, protodiff.Diff(x, y)); err != nil {
+		t.Fatalf("Apply() returned error: %v", err)
+	}
+	want := proto.Clone(y)
+	y.OptionalBytes[0] = 'b'
+	y.OptionalNestedMessage.A = proto.Int32(2)
+	y.RepeatedNestedMessage[0].A = proto.Int32(2)
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Apply() result aliases the changes (-want +got):\n%s", diff)
+	}
+}
+
+func TestApplyInvalidPath(t *testing.T) {
+	changes := protodiff.Diff(&testpb.TestAllTypes{}, &testpb.TestAllTypes{OptionalInt32: proto.Int32(1)})
+	err := protodiff.Apply(&testpb.TestAllExtensions{}, changes)
+	if err == nil {
+		t.Fatal("Apply() returned no error for mismatching message")
+	}
+	var cerr *protodiff.ConflictError
+	if errors.As(err, &cerr) {
+		t.Errorf("Apply() error = %v, want non-conflict error", err)
+	}
+}
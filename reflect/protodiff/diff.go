@@ -0,0 +1,394 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protodiff computes the structured differences between two messages.
+//
+// Unlike [proto.Equal], which only reports whether two messages are equal,
+// [Diff] reports every individual change needed to turn one message into
+// another, where each change is addressed by a [protopath.Path]:
+//
+//	for _, c := range protodiff.Diff(before, after) {
+//		log.Printf("%v %v", c.Kind, c.Path)
+//	}
+//
+// The changes can be applied to another message with [Apply], which detects
+// conflicting concurrent modifications of the same values.
+package protodiff
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/msgfmt"
+	"google.golang.org/protobuf/internal/order"
+	"google.golang.org/protobuf/internal/pragma"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Kind is the kind of a change.
+type Kind int
+
+const (
+	// Added reports a value that is only present in the new message.
+	Added Kind = iota + 1
+	// Removed reports a value that is only present in the old message.
+	Removed
+	// Modified reports a scalar value or unknown fields that are present in
+	// both messages with different contents.
+	Modified
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Modified:
+		return "modified"
+	default:
+		return fmt.Sprintf("<unknown:%d>", int(k))
+	}
+}
+
+// Change is a single difference between an old and a new message.
+type Change struct {
+	Kind Kind
+
+	// Path is the path from the root of the messages to the changed value.
+	// The last step is a [protopath.FieldAccess] for a populated field,
+	// a [protopath.ListIndex] for a list element,
+	// a [protopath.MapIndex] for a map entry, or
+	// a [protopath.UnknownAccess] for the unknown fields of a message.
+	//
+	// For changes to a list where the order of elements is ignored,
+	// the index of a removed element is its index in the old list and
+	// the index of an added element is its index in the new list.
+	Path protopath.Path
+
+	// Old is the value in the old message.
+	// It is invalid if the kind is Added.
+	Old protoreflect.Value
+	// New is the value in the new message.
+	// It is invalid if the kind is Removed.
+	New protoreflect.Value
+}
+
+// String returns a humanly readable representation of the change.
+// Do not depend on the output being stable.
+//
+// For example:
+//
+//	modified (path.to.MyMessage).list_field[5].map_field["hello"]: 1 -> 2
+func (c Change) String() string {
+	// Determine the field descriptor associated with the last step.
+	var fd protoreflect.FieldDescriptor
+	switch last := c.Path.Index(-1); last.Kind() {
+	case protopath.FieldAccessStep:
+		fd = last.FieldDescriptor()
+	case protopath.ListIndexStep:
+		fd = c.Path.Index(-2).FieldDescriptor()
+	case protopath.MapIndexStep:
+		fd = c.Path.Index(-2).FieldDescriptor().MapValue()
+	}
+
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("%v %v: %v", c.Kind, c.Path, msgfmt.FormatValue(c.New, fd))
+	case Removed:
+		return fmt.Sprintf("%v %v: %v", c.Kind, c.Path, msgfmt.FormatValue(c.Old, fd))
+	default:
+		return fmt.Sprintf("%v %v: %v -> %v", c.Kind, c.Path, msgfmt.FormatValue(c.Old, fd), msgfmt.FormatValue(c.New, fd))
+	}
+}
+
+// Diff returns the changes between the old message x and the new message y
+// using the default options.
+//
+// See [Options.Diff] for details.
+func Diff(x, y proto.Message) []Change {
+	return Options{}.Diff(x, y)
+}
+
+// Options configures the comparison of messages.
+type Options struct {
+	pragma.NoUnkeyedLiterals
+
+	// EquateEmpty specifies whether an unpopulated field is considered equal
+	// to a populated field with the default value of the field, or with an
+	// empty list, map, or message. By default, a field that is only populated
+	// in one of the messages is always reported as added or removed.
+	EquateEmpty bool
+
+	// IgnoreListOrder specifies whether repeated fields are compared as
+	// multisets, where the order of elements is ignored. Elements of the
+	// old list that do not have an equal counterpart in the new list are
+	// reported as removed and vice-versa. By default, lists are compared
+	// element-wise.
+	IgnoreListOrder bool
+
+	// UnequalNaNs specifies whether floating-point NaN values are compared
+	// using IEEE 754 semantics, such that a NaN is not equal to any value.
+	// By default, a NaN is equal to another NaN, as it is for proto.Equal.
+	UnequalNaNs bool
+}
+
+// Diff returns the changes between the old message x and the new message y,
+// ordered by the paths of the changed values.
+// With the default options, it returns no changes if and only if the messages
+// are equal according to [proto.Equal].
+//
+// The messages are compared recursively:
+//
+//   - A field that is only populated in one of the messages is reported as
+//     added or removed as a whole.
+//
+//   - Message fields that are populated in both messages are compared
+//     field by field. Populated fields are visited in ascending order
+//     by field number.
+//
+//   - Lists are compared element-wise, where elements at the same index are
+//     compared recursively and trailing elements are reported as added or
+//     removed.
+//
+//   - Maps are compared entry-wise, where entries with the same key are
+//     compared recursively and other entries are reported as added or
+//     removed. Entries are visited in ascending order by key.
+//
+//   - Scalar values that are not equal are reported as modified.
+//
+//   - Unknown fields are compared as in proto.Equal, where the raw bytes of
+//     the fields with the same number must be identical, but fields with
+//     different numbers may be in any order. They are reported as modified
+//     as a whole if they are not equal.
+//
+// Unlike proto.Equal, an invalid message, such as a nil pointer of
+// the concrete message type, is treated as an empty message.
+// It panics if the messages do not have the same message descriptor.
+func (o Options) Diff(x, y proto.Message) []Change {
+	mx, my := x.ProtoReflect(), y.ProtoReflect()
+	if mx.Descriptor().FullName() != my.Descriptor().FullName() {
+		panic(fmt.Sprintf("descriptor mismatch: %v != %v", mx.Descriptor().FullName(), my.Descriptor().FullName()))
+	}
+	d := &differ{opts: o}
+	d.diffMessage(protopath.Path{protopath.Root(mx.Descriptor())}, mx, my)
+	return d.changes
+}
+
+type differ struct {
+	opts    Options
+	changes []Change
+}
+
+// push returns a copy of p with the step s appended, such that the paths
+// of separate changes never share the same underlying array.
+func push(p protopath.Path, s protopath.Step) protopath.Path {
+	return append(p[:len(p):len(p)], s)
+}
+
+func (d *differ) add(kind Kind, p protopath.Path, vx, vy protoreflect.Value) {
+	d.changes = append(d.changes, Change{Kind: kind, Path: p, Old: vx, New: vy})
+}
+
+func (d *differ) diffMessage(p protopath.Path, mx, my protoreflect.Message) {
+	var fds []protoreflect.FieldDescriptor
+	seen := make(map[protoreflect.FieldNumber]bool)
+	collect := func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if !seen[fd.Number()] {
+			seen[fd.Number()] = true
+			fds = append(fds, fd)
+		}
+		return true
+	}
+	mx.Range(collect)
+	my.Range(collect)
+	sort.Slice(fds, func(i, j int) bool {
+		return order.NumberFieldOrder(fds[i], fds[j])
+	})
+	for _, fd := range fds {
+		d.diffField(push(p, protopath.FieldAccess(fd)), fd, mx, my)
+	}
+
+	rx, ry := mx.GetUnknown(), my.GetUnknown()
+	switch p := push(p, protopath.UnknownAccess()); {
+	case equalUnknown(rx, ry):
+	case len(ry) == 0:
+		d.add(Removed, p, protoreflect.ValueOfBytes(rx), protoreflect.Value{})
+	case len(rx) == 0:
+		d.add(Added, p, protoreflect.Value{}, protoreflect.ValueOfBytes(ry))
+	default:
+		d.add(Modified, p, protoreflect.ValueOfBytes(rx), protoreflect.ValueOfBytes(ry))
+	}
+}
+
+func (d *differ) diffField(p protopath.Path, fd protoreflect.FieldDescriptor, mx, my protoreflect.Message) {
+	hx, hy := mx.Has(fd), my.Has(fd)
+	vx, vy := mx.Get(fd), my.Get(fd)
+	switch {
+	case hx && hy:
+	case !d.opts.EquateEmpty:
+		if hx {
+			d.add(Removed, p, vx, protoreflect.Value{})
+		} else {
+			d.add(Added, p, protoreflect.Value{}, vy)
+		}
+		return
+	case fd.IsList() || fd.IsMap() || fd.Message() != nil:
+		// Compare the populated value with the empty value of the other.
+	case d.equalScalar(fd, vx, vy):
+		return
+	case hx:
+		d.add(Removed, p, vx, protoreflect.Value{})
+		return
+	default:
+		d.add(Added, p, protoreflect.Value{}, vy)
+		return
+	}
+
+	switch {
+	case fd.IsList():
+		d.diffList(p, fd, vx.List(), vy.List())
+	case fd.IsMap():
+		d.diffMap(p, fd, vx.Map(), vy.Map())
+	default:
+		d.diffSingular(p, fd, vx, vy)
+	}
+}
+
+// diffSingular compares a singular value of fd,
+// which may be the element of a list or the value of a map entry.
+func (d *differ) diffSingular(p protopath.Path, fd protoreflect.FieldDescriptor, vx, vy protoreflect.Value) {
+	if fd.Message() != nil {
+		d.diffMessage(p, vx.Message(), vy.Message())
+		return
+	}
+	if !d.equalScalar(fd, vx, vy) {
+		d.add(Modified, p, vx, vy)
+	}
+}
+
+func (d *differ) diffList(p protopath.Path, fd protoreflect.FieldDescriptor, lx, ly protoreflect.List) {
+	if d.opts.IgnoreListOrder {
+		d.diffUnorderedList(p, fd, lx, ly)
+		return
+	}
+	n := lx.Len()
+	if n > ly.Len() {
+		n = ly.Len()
+	}
+	for i := 0; i < n; i++ {
+		d.diffSingular(push(p, protopath.ListIndex(i)), fd, lx.Get(i), ly.Get(i))
+	}
+	for i := n; i < lx.Len(); i++ {
+		d.add(Removed, push(p, protopath.ListIndex(i)), lx.Get(i), protoreflect.Value{})
+	}
+	for i := n; i < ly.Len(); i++ {
+		d.add(Added, push(p, protopath.ListIndex(i)), protoreflect.Value{}, ly.Get(i))
+	}
+}
+
+func (d *differ) diffUnorderedList(p protopath.Path, fd protoreflect.FieldDescriptor, lx, ly protoreflect.List) {
+	matched := make([]bool, ly.Len())
+	for i := 0; i < lx.Len(); i++ {
+		found := false
+		for j := 0; j < ly.Len() && !found; j++ {
+			if !matched[j] && d.equalSingular(fd, lx.Get(i), ly.Get(j)) {
+				matched[j], found = true, true
+			}
+		}
+		if !found {
+			d.add(Removed, push(p, protopath.ListIndex(i)), lx.Get(i), protoreflect.Value{})
+		}
+	}
+	for j := range matched {
+		if !matched[j] {
+			d.add(Added, push(p, protopath.ListIndex(j)), protoreflect.Value{}, ly.Get(j))
+		}
+	}
+}
+
+func (d *differ) diffMap(p protopath.Path, fd protoreflect.FieldDescriptor, mx, my protoreflect.Map) {
+	var keys []protoreflect.MapKey
+	mx.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	my.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		if !mx.Has(k) {
+			keys = append(keys, k)
+		}
+		return true
+	})
+	sort.Slice(keys, func(i, j int) bool {
+		return order.GenericKeyOrder(keys[i], keys[j])
+	})
+	for _, k := range keys {
+		switch p := push(p, protopath.MapIndex(k)); {
+		case !my.Has(k):
+			d.add(Removed, p, mx.Get(k), protoreflect.Value{})
+		case !mx.Has(k):
+			d.add(Added, p, protoreflect.Value{}, my.Get(k))
+		default:
+			d.diffSingular(p, fd.MapValue(), mx.Get(k), my.Get(k))
+		}
+	}
+}
+
+// equalSingular reports whether two singular values of fd are equal
+// according to the options.
+func (d *differ) equalSingular(fd protoreflect.FieldDescriptor, vx, vy protoreflect.Value) bool {
+	if fd.Message() == nil {
+		return d.equalScalar(fd, vx, vy)
+	}
+	sub := &differ{opts: d.opts}
+	sub.diffMessage(nil, vx.Message(), vy.Message())
+	return len(sub.changes) == 0
+}
+
+func (d *differ) equalScalar(fd protoreflect.FieldDescriptor, vx, vy protoreflect.Value) bool {
+	switch fd.Kind() {
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		if d.opts.UnequalNaNs {
+			return vx.Float() == vy.Float()
+		}
+	}
+	return vx.Equal(vy)
+}
+
+// equalUnknown reports whether the unknown fields x and y are equal
+// according to proto.Equal, which compares the raw bytes of the fields
+// with each field number.
+func equalUnknown(x, y protoreflect.RawFields) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	if bytes.Equal(x, y) {
+		return true
+	}
+	mx := make(map[protoreflect.FieldNumber]protoreflect.RawFields)
+	my := make(map[protoreflect.FieldNumber]protoreflect.RawFields)
+	for len(x) > 0 {
+		num, _, n := protowire.ConsumeField(x)
+		if n < 0 {
+			return false // malformed, and the raw bytes differ
+		}
+		mx[num] = append(mx[num], x[:n]...)
+		x = x[n:]
+	}
+	for len(y) > 0 {
+		num, _, n := protowire.ConsumeField(y)
+		if n < 0 {
+			return false // malformed, and the raw bytes differ
+		}
+		my[num] = append(my[num], y[:n]...)
+		y = y[n:]
+	}
+	return reflect.DeepEqual(mx, my)
+}
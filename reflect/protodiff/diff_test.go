@@ -0,0 +1,355 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protodiff_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodiff"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/testing/protopack"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+	test3pb "google.golang.org/protobuf/internal/testprotos/test3"
+)
+
+// change is a comparable representation of a protodiff.Change.
+type change struct {
+	Kind     protodiff.Kind
+	Path     string
+	Old, New any
+}
+
+func toChanges(cs []protodiff.Change) []change {
+	var out []change
+	for _, c := range cs {
+		out = append(out, change{
+			Kind: c.Kind,
+			Path: c.Path.String(),
+			Old:  toInterface(c.Old),
+			New:  toInterface(c.New),
+		})
+	}
+	return out
+}
+
+func toInterface(v protoreflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	switch v := v.Interface().(type) {
+	case protoreflect.Message:
+		return v.Interface()
+	case protoreflect.List:
+		var out []any
+		for i := 0; i < v.Len(); i++ {
+			out = append(out, toInterface(v.Get(i)))
+		}
+		return out
+	case protoreflect.Map:
+		out := map[any]any{}
+		v.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			out[k.Interface()] = toInterface(v)
+			return true
+		})
+		return out
+	default:
+		return v
+	}
+}
+
+func TestDiff(t *testing.T) {
+	unknown := protopack.Message{
+		protopack.Tag{Number: 50000, Type: protopack.VarintType}, protopack.Uvarint(1),
+	}.Marshal()
+	unknownAB := protopack.Message{
+		protopack.Tag{Number: 50000, Type: protopack.VarintType}, protopack.Uvarint(1),
+		protopack.Tag{Number: 50001, Type: protopack.VarintType}, protopack.Uvarint(2),
+	}.Marshal()
+	unknownBA := protopack.Message{
+		protopack.Tag{Number: 50001, Type: protopack.VarintType}, protopack.Uvarint(2),
+		protopack.Tag{Number: 50000, Type: protopack.VarintType}, protopack.Uvarint(1),
+	}.Marshal()
+
+	tests := []struct {
+		desc string
+		opts protodiff.Options
+		x, y proto.Message
+		want []change
+	}{{
+		desc: "equal messages",
+		x: &testpb.TestAllTypes{
+			OptionalInt32:  proto.Int32(1),
+			RepeatedString: []string{"a", "b"},
+			MapInt32Int32:  map[int32]int32{1: 2},
+		},
+		y: &testpb.TestAllTypes{
+			OptionalInt32:  proto.Int32(1),
+			RepeatedString: []string{"a", "b"},
+			MapInt32Int32:  map[int32]int32{1: 2},
+		},
+	}, {
+		desc: "invalid message is empty",
+		x:    (*testpb.TestAllTypes)(nil),
+		y:    &testpb.TestAllTypes{},
+	}, {
+		desc: "scalar fields",
+		x: &testpb.TestAllTypes{
+			OptionalInt32:  proto.Int32(1),
+			OptionalString: proto.String("old"),
+			OptionalBytes:  []byte("same"),
+		},
+		y: &testpb.TestAllTypes{
+			OptionalInt32: proto.Int32(2),
+			OptionalBytes: []byte("same"),
+			OptionalBool:  proto.Bool(true),
+		},
+		want: []change{
+			{protodiff.Modified, "(goproto.proto.test.TestAllTypes).optional_int32", int32(1), int32(2)},
+			{protodiff.Added, "(goproto.proto.test.TestAllTypes).optional_bool", nil, true},
+			{protodiff.Removed, "(goproto.proto.test.TestAllTypes).optional_string", "old", nil},
+		},
+	}, {
+		desc: "nested messages",
+		x: &testpb.TestAllTypes{
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A: proto.Int32(1),
+				Corecursive: &testpb.TestAllTypes{
+					OptionalInt64: proto.Int64(2),
+				},
+			},
+			Optionalgroup: &testpb.TestAllTypes_OptionalGroup{A: proto.Int32(3)},
+		},
+		y: &testpb.TestAllTypes{
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A:           proto.Int32(1),
+				Corecursive: &testpb.TestAllTypes{},
+			},
+		},
+		want: []change{
+			{protodiff.Removed, "(goproto.proto.test.TestAllTypes).OptionalGroup", &testpb.TestAllTypes_OptionalGroup{A: proto.Int32(3)}, nil},
+			{protodiff.Removed, "(goproto.proto.test.TestAllTypes).optional_nested_message.corecursive.optional_int64", int64(2), nil},
+		},
+	}, {
+		desc: "lists",
+		x: &testpb.TestAllTypes{
+			RepeatedInt32: []int32{1, 2, 3},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(1)},
+			},
+		},
+		y: &testpb.TestAllTypes{
+			RepeatedInt32: []int32{1, 5},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(2)},
+				{},
+			},
+		},
+		want: []change{
+			{protodiff.Modified, "(goproto.proto.test.TestAllTypes).repeated_int32[1]", int32(2), int32(5)},
+			{protodiff.Removed, "(goproto.proto.test.TestAllTypes).repeated_int32[2]", int32(3), nil},
+			{protodiff.Modified, "(goproto.proto.test.TestAllTypes).repeated_nested_message[0].a", int32(1), int32(2)},
+			{protodiff.Added, "(goproto.proto.test.TestAllTypes).repeated_nested_message[1]", nil, &testpb.TestAllTypes_NestedMessage{}},
+		},
+	}, {
+		desc: "lists ignoring order",
+		opts: protodiff.Options{IgnoreListOrder: true},
+		x: &testpb.TestAllTypes{
+			RepeatedInt32: []int32{1, 2, 3, 3},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(1)},
+				{A: proto.Int32(2)},
+			},
+		},
+		y: &testpb.TestAllTypes{
+			RepeatedInt32: []int32{3, 4, 1, 2},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(2)},
+				{A: proto.Int32(1)},
+			},
+		},
+		want: []change{
+			{protodiff.Removed, "(goproto.proto.test.TestAllTypes).repeated_int32[3]", int32(3), nil},
+			{protodiff.Added, "(goproto.proto.test.TestAllTypes).repeated_int32[1]", nil, int32(4)},
+		},
+	}, {
+		desc: "maps",
+		x: &testpb.TestAllTypes{
+			MapStringString: map[string]string{"a": "1", "b": "2", "c": "3"},
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"x": {A: proto.Int32(1)},
+			},
+		},
+		y: &testpb.TestAllTypes{
+			MapStringString: map[string]string{"b": "2", "c": "4", "d": "5"},
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"x": {A: proto.Int32(2)},
+			},
+		},
+		want: []change{
+			{protodiff.Removed, `(goproto.proto.test.TestAllTypes).map_string_string["a"]`, "1", nil},
+			{protodiff.Modified, `(goproto.proto.test.TestAllTypes).map_string_string["c"]`, "3", "4"},
+			{protodiff.Added, `(goproto.proto.test.TestAllTypes).map_string_string["d"]`, nil, "5"},
+			{protodiff.Modified, `(goproto.proto.test.TestAllTypes).map_string_nested_message["x"].a`, int32(1), int32(2)},
+		},
+	}, {
+		desc: "oneof",
+		x:    &testpb.TestAllTypes{OneofField: &testpb.TestAllTypes_OneofUint32{OneofUint32: 1}},
+		y:    &testpb.TestAllTypes{OneofField: &testpb.TestAllTypes_OneofString{OneofString: "a"}},
+		want: []change{
+			{protodiff.Removed, "(goproto.proto.test.TestAllTypes).oneof_uint32", uint32(1), nil},
+			{protodiff.Added, "(goproto.proto.test.TestAllTypes).oneof_string", nil, "a"},
+		},
+	}, {
+		desc: "extensions",
+		x: func() proto.Message {
+			m := &testpb.TestAllExtensions{}
+			proto.SetExtension(m, testpb.E_OptionalInt32, int32(1))
+			proto.SetExtension(m, testpb.E_OptionalString, "same")
+			return m
+		}(),
+		y: func() proto.Message {
+			m := &testpb.TestAllExtensions{}
+			proto.SetExtension(m, testpb.E_OptionalInt32, int32(2))
+			proto.SetExtension(m, testpb.E_OptionalString, "same")
+			return m
+		}(),
+		want: []change{
+			{protodiff.Modified, "(goproto.proto.test.TestAllExtensions).(goproto.proto.test.optional_int32)", int32(1), int32(2)},
+		},
+	}, {
+		desc: "unknown fields",
+		x: func() proto.Message {
+			m := &testpb.TestAllTypes{}
+			m.ProtoReflect().SetUnknown(unknown)
+			return m
+		}(),
+		y: &testpb.TestAllTypes{},
+		want: []change{
+			{protodiff.Removed, "(goproto.proto.test.TestAllTypes).?", []byte(unknown), nil},
+		},
+	}, {
+		desc: "reordered unknown fields",
+		x: func() proto.Message {
+			m := &testpb.TestAllTypes{}
+			m.ProtoReflect().SetUnknown(unknownAB)
+			return m
+		}(),
+		y: func() proto.Message {
+			m := &testpb.TestAllTypes{}
+			m.ProtoReflect().SetUnknown(unknownBA)
+			return m
+		}(),
+	}, {
+		desc: "NaN",
+		x:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(math.NaN())},
+		y:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(math.NaN())},
+	}, {
+		desc: "unequal NaNs",
+		opts: protodiff.Options{UnequalNaNs: true},
+		x:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(math.NaN())},
+		y:    &testpb.TestAllTypes{OptionalDouble: proto.Float64(math.NaN())},
+		want: []change{
+			{protodiff.Modified, "(goproto.proto.test.TestAllTypes).optional_double", math.NaN(), math.NaN()},
+		},
+	}, {
+		desc: "empty versus unset",
+		x: &testpb.TestAllTypes{
+			OptionalInt32:         proto.Int32(0),
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{},
+		},
+		y: &testpb.TestAllTypes{},
+		want: []change{
+			{protodiff.Removed, "(goproto.proto.test.TestAllTypes).optional_int32", int32(0), nil},
+			{protodiff.Removed, "(goproto.proto.test.TestAllTypes).optional_nested_message", &testpb.TestAllTypes_NestedMessage{}, nil},
+		},
+	}, {
+		desc: "equate empty",
+		opts: protodiff.Options{EquateEmpty: true},
+		x: &testpb.TestAllTypes{
+			OptionalInt32:         proto.Int32(0),
+			DefaultInt32:          proto.Int32(81),
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{},
+			OptionalForeignMessage: &testpb.ForeignMessage{
+				C: proto.Int32(1),
+			},
+		},
+		y: &testpb.TestAllTypes{
+			OptionalInt64: proto.Int64(1),
+			DefaultInt64:  proto.Int64(0),
+		},
+		want: []change{
+			{protodiff.Added, "(goproto.proto.test.TestAllTypes).optional_int64", nil, int64(1)},
+			{protodiff.Removed, "(goproto.proto.test.TestAllTypes).optional_foreign_message.c", int32(1), nil},
+			{protodiff.Added, "(goproto.proto.test.TestAllTypes).default_int64", nil, int64(0)},
+		},
+	}, {
+		desc: "proto3 implicit presence",
+		x:    &test3pb.TestAllTypes{SingularInt32: 1},
+		y:    &test3pb.TestAllTypes{},
+		want: []change{
+			{protodiff.Removed, "(goproto.proto.test3.TestAllTypes).singular_int32", int32(1), nil},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := toChanges(tt.opts.Diff(tt.x, tt.y))
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform(), cmp.Comparer(func(x, y float64) bool {
+				return x == y || math.IsNaN(x) && math.IsNaN(y)
+			})); diff != "" {
+				t.Errorf("Diff() mismatch (-want +got):\n%s", diff)
+			}
+
+			// Dynamic messages must produce the same changes.
+			dx := dynamicpb.NewMessage(tt.x.ProtoReflect().Descriptor())
+			dy := dynamicpb.NewMessage(tt.y.ProtoReflect().Descriptor())
+			proto.Merge(dx, tt.x)
+			proto.Merge(dy, tt.y)
+			gotDynamic := toChanges(tt.opts.Diff(dx, dy))
+			if diff := cmp.Diff(got, gotDynamic, protocmp.Transform(), cmp.Comparer(func(x, y float64) bool {
+				return x == y || math.IsNaN(x) && math.IsNaN(y)
+			})); diff != "" {
+				t.Errorf("Diff() of dynamic messages mismatch (-generated +dynamic):\n%s", diff)
+			}
+
+			if equal := proto.Equal(tt.x, tt.y); tt.opts == (protodiff.Options{}) && equal != (len(got) == 0) && tt.x.ProtoReflect().IsValid() {
+				t.Errorf("Diff() returned %d changes, but proto.Equal() = %v", len(got), equal)
+			}
+		})
+	}
+}
+
+func TestChangeString(t *testing.T) {
+	changes := protodiff.Diff(
+		&testpb.TestAllTypes{
+			OptionalInt32:   proto.Int32(1),
+			MapStringString: map[string]string{"a": "x"},
+		},
+		&testpb.TestAllTypes{
+			OptionalInt32:  proto.Int32(2),
+			RepeatedString: []string{"s"},
+		},
+	)
+	var got []string
+	for _, c := range changes {
+		// Remove any spaces that the output may randomly contain.
+		got = append(got, strings.Join(strings.Fields(c.String()), " "))
+	}
+	want := []string{
+		"modified (goproto.proto.test.TestAllTypes).optional_int32: 1 -> 2",
+		`added (goproto.proto.test.TestAllTypes).repeated_string: ["s"]`,
+		`removed (goproto.proto.test.TestAllTypes).map_string_string: {"a":"x"}`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("String() mismatch (-want +got):\n%s", diff)
+	}
+}
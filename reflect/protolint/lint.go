@@ -0,0 +1,186 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protolint checks protobuf descriptors for style issues.
+//
+// Unlike the validation performed by [protodesc.NewFile], which rejects
+// descriptors that are invalid, the linter reports descriptors that are valid
+// but do not follow conventions, such as the naming of declarations.
+// Each check is a [Rule]; the package provides a set of [DefaultRules],
+// and custom rules can be run along with them:
+//
+//	rules := append(protolint.DefaultRules(), myRule)
+//	for _, f := range (protolint.Options{Rules: rules}).Lint(fd) {
+//		log.Print(f)
+//	}
+//
+// [protodesc.NewFile]: https://pkg.go.dev/google.golang.org/protobuf/reflect/protodesc#NewFile
+package protolint
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Rule is a check that is run on every declaration of a file.
+type Rule struct {
+	// Name is the name of the rule, which identifies it in findings
+	// and in configurations.
+	Name string
+	// Doc describes what the rule checks.
+	Doc string
+	// Check checks a descriptor and reports its issues to r.
+	// It is called for the file and for every declaration in the file,
+	// which is a message, field, oneof, extension, enum, enum value,
+	// service or method. Map entry messages and their fields are not checked.
+	Check func(r *Reporter, d protoreflect.Descriptor)
+}
+
+// Finding is an issue reported by a rule.
+type Finding struct {
+	// Rule is the name of the rule that reported the finding.
+	Rule string
+	// File is the path of the file.
+	File string
+	// Name is the full name of the declaration that has the issue.
+	// It is empty for an issue of the file itself.
+	Name protoreflect.FullName
+	// Location is the span of the declaration in the source file.
+	// It is the zero value if the file does not have source code info.
+	Location protoreflect.SourceLocation
+	// Message describes the issue.
+	Message string
+}
+
+// String returns a humanly readable representation of the finding,
+// with the 1-based line and column of the issue, if known.
+//
+// For example:
+//
+//	foo.proto:3:1: message name "foo" should be UpperCamelCase (message_names)
+func (f Finding) String() string {
+	pos := f.File
+	if f.Location.Path != nil {
+		pos = fmt.Sprintf("%s:%d:%d", f.File, f.Location.StartLine+1, f.Location.StartColumn+1)
+	}
+	return fmt.Sprintf("%s: %s (%s)", pos, f.Message, f.Rule)
+}
+
+// Reporter collects the findings of a rule.
+type Reporter struct {
+	rule     *Rule
+	file     protoreflect.FileDescriptor
+	findings []Finding
+}
+
+// Report reports an issue of the declaration d.
+func (r *Reporter) Report(d protoreflect.Descriptor, format string, args ...any) {
+	f := Finding{
+		Rule:     r.rule.Name,
+		File:     r.file.Path(),
+		Location: r.file.SourceLocations().ByDescriptor(d),
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if _, ok := d.(protoreflect.FileDescriptor); !ok {
+		f.Name = d.FullName()
+	}
+	r.findings = append(r.findings, f)
+}
+
+// ReportPath reports an issue of the file at the element with the given
+// source path, such as the path of an option.
+// If the element has no location, the location of the file is used.
+func (r *Reporter) ReportPath(path protoreflect.SourcePath, format string, args ...any) {
+	loc := r.file.SourceLocations().ByPath(path)
+	if loc.Path == nil {
+		loc = r.file.SourceLocations().ByDescriptor(r.file)
+	}
+	r.findings = append(r.findings, Finding{
+		Rule:     r.rule.Name,
+		File:     r.file.Path(),
+		Location: loc,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// File returns the file that is being checked.
+func (r *Reporter) File() protoreflect.FileDescriptor {
+	return r.file
+}
+
+// Lint checks the file with the default rules.
+func Lint(fd protoreflect.FileDescriptor) []Finding {
+	return Options{}.Lint(fd)
+}
+
+// Options configures the linter.
+type Options struct {
+	// Rules are the rules to run. If nil, the rules returned by
+	// DefaultRules are run.
+	Rules []*Rule
+}
+
+// Lint checks the file with the configured rules.
+// The findings are ordered by declaration, and then by the order of the rules.
+func (o Options) Lint(fd protoreflect.FileDescriptor) []Finding {
+	rules := o.Rules
+	if rules == nil {
+		rules = DefaultRules()
+	}
+	r := &Reporter{file: fd}
+	walk(fd, func(d protoreflect.Descriptor) {
+		for _, rule := range rules {
+			r.rule = rule
+			rule.Check(r, d)
+		}
+	})
+	return r.findings
+}
+
+// walk calls f for the file and each of its declarations, in order.
+func walk(fd protoreflect.FileDescriptor, f func(protoreflect.Descriptor)) {
+	f(fd)
+	walkDecls(fd, f)
+	for i := 0; i < fd.Services().Len(); i++ {
+		sd := fd.Services().Get(i)
+		f(sd)
+		for j := 0; j < sd.Methods().Len(); j++ {
+			f(sd.Methods().Get(j))
+		}
+	}
+}
+
+func walkDecls(d interface {
+	Messages() protoreflect.MessageDescriptors
+	Enums() protoreflect.EnumDescriptors
+	Extensions() protoreflect.ExtensionDescriptors
+}, f func(protoreflect.Descriptor)) {
+	for i := 0; i < d.Messages().Len(); i++ {
+		md := d.Messages().Get(i)
+		if md.IsMapEntry() {
+			continue
+		}
+		f(md)
+		for j := 0; j < md.Fields().Len(); j++ {
+			f(md.Fields().Get(j))
+		}
+		for j := 0; j < md.Oneofs().Len(); j++ {
+			if od := md.Oneofs().Get(j); !od.IsSynthetic() {
+				f(od)
+			}
+		}
+		walkDecls(md, f)
+	}
+	for i := 0; i < d.Enums().Len(); i++ {
+		ed := d.Enums().Get(i)
+		f(ed)
+		for j := 0; j < ed.Values().Len(); j++ {
+			f(ed.Values().Get(j))
+		}
+	}
+	for i := 0; i < d.Extensions().Len(); i++ {
+		f(d.Extensions().Get(i))
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protolint_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/compiler/protoparse"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protolint"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func parse(t *testing.T, name, src string) protoreflect.FileDescriptor {
+	t.Helper()
+	fsys := fstest.MapFS{name: &fstest.MapFile{Data: []byte(src)}}
+	r, err := protoparse.Parser{FS: fsys}.ParseFiles(name)
+	if err != nil {
+		t.Fatalf("ParseFiles() error: %v", err)
+	}
+	fd, err := r.FindFileByPath(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd
+}
+
+func TestRules(t *testing.T) {
+	type finding struct {
+		Rule    string
+		Name    protoreflect.FullName
+		Message string
+	}
+	tests := []struct {
+		desc  string
+		rule  *protolint.Rule
+		input string
+		want  []finding
+	}{{
+		desc: "message names",
+		rule: protolint.MessageNames,
+		input: `syntax = "proto3";
+			message FooBar { message inner {} }
+			message foo_bar {}
+			message HTTPRequest2 {}`,
+		want: []finding{
+			{"message_names", "FooBar.inner", `message name "inner" should be UpperCamelCase`},
+			{"message_names", "foo_bar", `message name "foo_bar" should be UpperCamelCase`},
+		},
+	}, {
+		desc: "field names",
+		rule: protolint.FieldNames,
+		input: `syntax = "proto2";
+			message M {
+				optional int32 foo_bar2 = 1;
+				optional int32 fooBar = 2;
+				optional int32 foo__bar = 3;
+				optional group Result = 4 {}
+				oneof Choice { int32 c = 5; }
+				map<string, int32> counts = 6;
+				extensions 100 to 199;
+			}
+			extend M { optional int32 _ext = 100; }`,
+		want: []finding{
+			{"field_names", "M.fooBar", `field name "fooBar" should be lower_snake_case`},
+			{"field_names", "M.foo__bar", `field name "foo__bar" should be lower_snake_case`},
+			{"field_names", "M.Choice", `oneof name "Choice" should be lower_snake_case`},
+			{"field_names", "_ext", `field name "_ext" should be lower_snake_case`},
+		},
+	}, {
+		desc: "enum names",
+		rule: protolint.EnumNames,
+		input: `syntax = "proto3";
+			enum Color { COLOR_UNSPECIFIED = 0; }
+			enum color_mode { COLOR_MODE_UNSPECIFIED = 0; }`,
+		want: []finding{
+			{"enum_names", "color_mode", `enum name "color_mode" should be UpperCamelCase`},
+		},
+	}, {
+		desc: "enum value names",
+		rule: protolint.EnumValueNames,
+		input: `syntax = "proto3";
+			enum Color { COLOR_UNSPECIFIED = 0; Red = 1; COLOR_GREEN2 = 2; }`,
+		want: []finding{
+			{"enum_value_names", "Red", `enum value name "Red" should be UPPER_SNAKE_CASE`},
+		},
+	}, {
+		desc: "enum zero value",
+		rule: protolint.EnumZeroValue,
+		input: `syntax = "proto2";
+			enum HTTPStatusCode { HTTP_STATUS_CODE_UNSPECIFIED = 0; }
+			enum Color { RED = 0; }
+			enum Size { SMALL = 1; }`,
+		want: []finding{
+			{"enum_zero_value", "RED", `zero value of enum "Color" should be named "COLOR_UNSPECIFIED"`},
+			{"enum_zero_value", "Size", `enum "Size" should have a zero value named "SIZE_UNSPECIFIED"`},
+		},
+	}, {
+		desc: "service names",
+		rule: protolint.ServiceNames,
+		input: `syntax = "proto3";
+			message M {}
+			service Greeter { rpc SayHello(M) returns (M); rpc say_bye(M) returns (M); }
+			service greeter2 {}`,
+		want: []finding{
+			{"service_names", "Greeter.say_bye", `method name "say_bye" should be UpperCamelCase`},
+			{"service_names", "greeter2", `service name "greeter2" should be UpperCamelCase`},
+		},
+	}, {
+		desc: "comments",
+		rule: protolint.Comments,
+		input: `syntax = "proto2";
+			// M is documented.
+			message M {
+				optional group G = 1 {}
+				message N {}
+			}
+			enum E { E_UNSPECIFIED = 0; }
+			// S is documented.
+			service S {
+				// R is documented.
+				rpc R(M) returns (M);
+				rpc T(M) returns (M); // Trailing comments do not count.
+			}`,
+		want: []finding{
+			{"comments", "M.N", `message "N" should have a comment`},
+			{"comments", "E", `enum "E" should have a comment`},
+			{"comments", "S.T", `method "T" should have a comment`},
+		},
+	}, {
+		desc:  "package",
+		rule:  protolint.PackageName,
+		input: `syntax = "proto3";`,
+		want: []finding{
+			{"package", "", "file should declare a package"},
+		},
+	}, {
+		desc:  "go_package",
+		rule:  protolint.GoPackage,
+		input: `syntax = "proto3"; package p;`,
+		want: []finding{
+			{"go_package", "", "file should have a go_package option"},
+		},
+	}, {
+		desc:  "go_package set",
+		rule:  protolint.GoPackage,
+		input: `syntax = "proto3"; package p; option go_package = "example.com/p";`,
+	}, {
+		desc: "field number gaps",
+		rule: protolint.FieldNumberGaps,
+		input: `syntax = "proto2";
+			message A { optional int32 a = 1; optional int32 b = 2; optional int32 c = 3; }
+			message B { optional int32 a = 1; optional int32 b = 5; optional int32 c = 7; }
+			message C { optional int32 a = 1; optional int32 b = 10; reserved 2 to 4; extensions 6 to 8; }
+			message D {}
+			message E { optional int32 a = 1; optional int32 b = 536870911; }
+			message F { optional int32 a = 2; optional int32 b = 536870911; reserved 1, 3, 5 to 100; extensions 101 to 536870910; }`,
+		want: []finding{
+			{"field_number_gaps", "B", `message "B" has unused field numbers that are not reserved: 2 to 4, 6`},
+			{"field_number_gaps", "C", `message "C" has unused field numbers that are not reserved: 5, 9`},
+			{"field_number_gaps", "E", `message "E" has unused field numbers that are not reserved: 2 to 536870910`},
+			{"field_number_gaps", "F", `message "F" has unused field numbers that are not reserved: 4`},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			fd := parse(t, "test.proto", tt.input)
+			var got []finding
+			for _, f := range (protolint.Options{Rules: []*protolint.Rule{tt.rule}}).Lint(fd) {
+				got = append(got, finding{f.Rule, f.Name, f.Message})
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Lint() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := []struct {
+		path, pkg string
+		want      string
+	}{
+		{"foo.proto", "foo", ""},
+		{"foo/bar/baz.proto", "foo.bar", ""},
+		{"foo/bar/baz.proto", "foo.baz", `package "foo.baz" does not match the directory "foo/bar" of the file`},
+		{"foo/baz.proto", "Foo", `package "Foo" should be lower case`},
+	}
+	for _, tt := range tests {
+		fd := parse(t, tt.path, `syntax = "proto3"; package `+tt.pkg+`;`)
+		var got string
+		for _, f := range (protolint.Options{Rules: []*protolint.Rule{protolint.PackageName}}).Lint(fd) {
+			got = f.Message
+			if f.Location.StartLine != 0 || f.Location.StartColumn != 19 {
+				t.Errorf("%s: location = %d:%d, want 0:19", tt.path, f.Location.StartLine, f.Location.StartColumn)
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%s: package %s: got %q, want %q", tt.path, tt.pkg, got, tt.want)
+		}
+	}
+}
+
+func TestLint(t *testing.T) {
+	fd := parse(t, "example/foo.proto", `syntax = "proto3";
+
+package example;
+
+option go_package = "example.com/example";
+
+// Request is a request.
+message Request {
+  string name = 1;
+  int32 PageSize = 3;
+}
+
+// Status is a status.
+enum Status {
+  UNKNOWN = 0;
+}
+`)
+	var got []string
+	for _, f := range protolint.Lint(fd) {
+		got = append(got, f.String())
+	}
+	want := []string{
+		`example/foo.proto:8:1: message "Request" has unused field numbers that are not reserved: 2 (field_number_gaps)`,
+		`example/foo.proto:10:3: field name "PageSize" should be lower_snake_case (field_names)`,
+		`example/foo.proto:15:3: zero value of enum "Status" should be named "STATUS_UNSPECIFIED" (enum_zero_value)`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Lint() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLintWithoutSourceInfo(t *testing.T) {
+	fd := parse(t, "foo.proto", `syntax = "proto3"; message foo {}`)
+	fdp := protodesc.ToFileDescriptorProto(fd)
+	fdp.SourceCodeInfo = nil
+	fd, err := protodesc.NewFile(fdp, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range protolint.Lint(fd) {
+		got = append(got, f.String())
+	}
+	want := []string{
+		"foo.proto: file should declare a package (package)",
+		"foo.proto: file should have a go_package option (go_package)",
+		`foo.proto: message name "foo" should be UpperCamelCase (message_names)`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Lint() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCustomRule(t *testing.T) {
+	noRequired := &protolint.Rule{
+		Name: "no_required",
+		Doc:  "fields should not be required",
+		Check: func(r *protolint.Reporter, d protoreflect.Descriptor) {
+			if fd, ok := d.(protoreflect.FieldDescriptor); ok && fd.Cardinality() == protoreflect.Required {
+				r.Report(fd, "field %q should not be required", fd.Name())
+			}
+		},
+	}
+	fd := parse(t, "foo.proto", `syntax = "proto2"; message M { required int32 a = 1; optional int32 b = 2; }`)
+	got := (protolint.Options{Rules: []*protolint.Rule{noRequired}}).Lint(fd)
+	if len(got) != 1 || got[0].Rule != "no_required" || got[0].Name != "M.a" {
+		t.Errorf("Lint() = %v, want one no_required finding for M.a", got)
+	}
+	if got, want := protolint.LookupRule("field_names"), protolint.FieldNames; got != want {
+		t.Errorf("LookupRule(%q) = %v, want %v", "field_names", got, want)
+	}
+	if got := protolint.LookupRule("no_required"); got != nil {
+		t.Errorf("LookupRule(%q) = %v, want nil", "no_required", got)
+	}
+}
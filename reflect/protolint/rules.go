@@ -0,0 +1,345 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protolint
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// DefaultRules returns the rules that are run by default.
+func DefaultRules() []*Rule {
+	return []*Rule{
+		MessageNames,
+		FieldNames,
+		EnumNames,
+		EnumValueNames,
+		EnumZeroValue,
+		ServiceNames,
+		Comments,
+		PackageName,
+		GoPackage,
+		FieldNumberGaps,
+	}
+}
+
+// LookupRule returns the default rule with the given name, or nil if none.
+func LookupRule(name string) *Rule {
+	for _, rule := range DefaultRules() {
+		if rule.Name == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+// MessageNames checks that message names are UpperCamelCase.
+var MessageNames = &Rule{
+	Name: "message_names",
+	Doc:  "message names should be UpperCamelCase",
+	Check: func(r *Reporter, d protoreflect.Descriptor) {
+		if md, ok := d.(protoreflect.MessageDescriptor); ok && !isUpperCamelCase(string(md.Name())) {
+			r.Report(md, "message name %q should be UpperCamelCase", md.Name())
+		}
+	},
+}
+
+// FieldNames checks that field, extension and oneof names are lower_snake_case.
+var FieldNames = &Rule{
+	Name: "field_names",
+	Doc:  "field, extension and oneof names should be lower_snake_case",
+	Check: func(r *Reporter, d protoreflect.Descriptor) {
+		switch d := d.(type) {
+		case protoreflect.FieldDescriptor:
+			if d.Kind() == protoreflect.GroupKind && d.Syntax() == protoreflect.Proto2 {
+				// The name of a group field is derived from its message.
+				return
+			}
+			if !isLowerSnakeCase(string(d.Name())) {
+				r.Report(d, "field name %q should be lower_snake_case", d.Name())
+			}
+		case protoreflect.OneofDescriptor:
+			if !isLowerSnakeCase(string(d.Name())) {
+				r.Report(d, "oneof name %q should be lower_snake_case", d.Name())
+			}
+		}
+	},
+}
+
+// EnumNames checks that enum names are UpperCamelCase.
+var EnumNames = &Rule{
+	Name: "enum_names",
+	Doc:  "enum names should be UpperCamelCase",
+	Check: func(r *Reporter, d protoreflect.Descriptor) {
+		if ed, ok := d.(protoreflect.EnumDescriptor); ok && !isUpperCamelCase(string(ed.Name())) {
+			r.Report(ed, "enum name %q should be UpperCamelCase", ed.Name())
+		}
+	},
+}
+
+// EnumValueNames checks that enum value names are UPPER_SNAKE_CASE.
+var EnumValueNames = &Rule{
+	Name: "enum_value_names",
+	Doc:  "enum value names should be UPPER_SNAKE_CASE",
+	Check: func(r *Reporter, d protoreflect.Descriptor) {
+		if vd, ok := d.(protoreflect.EnumValueDescriptor); ok && !isUpperSnakeCase(string(vd.Name())) {
+			r.Report(vd, "enum value name %q should be UPPER_SNAKE_CASE", vd.Name())
+		}
+	},
+}
+
+// EnumZeroValue checks that the zero value of an enum is named after the enum
+// with an _UNSPECIFIED suffix, such as FOO_BAR_UNSPECIFIED for enum FooBar.
+var EnumZeroValue = &Rule{
+	Name: "enum_zero_value",
+	Doc:  "the zero value of an enum should be named ENUM_NAME_UNSPECIFIED",
+	Check: func(r *Reporter, d protoreflect.Descriptor) {
+		ed, ok := d.(protoreflect.EnumDescriptor)
+		if !ok {
+			return
+		}
+		want := protoreflect.Name(toUpperSnakeCase(string(ed.Name())) + "_UNSPECIFIED")
+		switch vd := ed.Values().ByNumber(0); {
+		case vd == nil:
+			r.Report(ed, "enum %q should have a zero value named %q", ed.Name(), want)
+		case ed.Values().ByName(want) == nil || ed.Values().ByName(want).Number() != 0:
+			r.Report(vd, "zero value of enum %q should be named %q", ed.Name(), want)
+		}
+	},
+}
+
+// ServiceNames checks that service and method names are UpperCamelCase.
+var ServiceNames = &Rule{
+	Name: "service_names",
+	Doc:  "service and method names should be UpperCamelCase",
+	Check: func(r *Reporter, d protoreflect.Descriptor) {
+		switch d := d.(type) {
+		case protoreflect.ServiceDescriptor:
+			if !isUpperCamelCase(string(d.Name())) {
+				r.Report(d, "service name %q should be UpperCamelCase", d.Name())
+			}
+		case protoreflect.MethodDescriptor:
+			if !isUpperCamelCase(string(d.Name())) {
+				r.Report(d, "method name %q should be UpperCamelCase", d.Name())
+			}
+		}
+	},
+}
+
+// Comments checks that messages, enums, services and methods
+// have leading comments. It only reports issues for files that have
+// source code info.
+var Comments = &Rule{
+	Name: "comments",
+	Doc:  "messages, enums, services and methods should be documented",
+	Check: func(r *Reporter, d protoreflect.Descriptor) {
+		var kind string
+		switch d := d.(type) {
+		case protoreflect.MessageDescriptor:
+			kind = "message"
+			if isGroup(d) {
+				return
+			}
+		case protoreflect.EnumDescriptor:
+			kind = "enum"
+		case protoreflect.ServiceDescriptor:
+			kind = "service"
+		case protoreflect.MethodDescriptor:
+			kind = "method"
+		default:
+			return
+		}
+		if r.File().SourceLocations().Len() == 0 {
+			return
+		}
+		if loc := r.File().SourceLocations().ByDescriptor(d); strings.TrimSpace(loc.LeadingComments) == "" {
+			r.Report(d, "%s %q should have a comment", kind, d.Name())
+		}
+	},
+}
+
+// PackageName checks that the file declares a lower case package that
+// matches the directory of the file, such as package foo.bar for foo/bar/baz.proto.
+var PackageName = &Rule{
+	Name: "package",
+	Doc:  "files should declare a lower case package that matches their directory",
+	Check: func(r *Reporter, d protoreflect.Descriptor) {
+		fd, ok := d.(protoreflect.FileDescriptor)
+		if !ok {
+			return
+		}
+		pkgPath := protoreflect.SourcePath{int32(genid.FileDescriptorProto_Package_field_number)}
+		pkg := string(fd.Package())
+		switch dir := path.Dir(fd.Path()); {
+		case pkg == "":
+			r.ReportPath(nil, "file should declare a package")
+		case strings.ToLower(pkg) != pkg:
+			r.ReportPath(pkgPath, "package %q should be lower case", pkg)
+		case dir != "." && strings.ReplaceAll(pkg, ".", "/") != dir:
+			r.ReportPath(pkgPath, "package %q does not match the directory %q of the file", pkg, dir)
+		}
+	},
+}
+
+// GoPackage checks that the file has a go_package option.
+var GoPackage = &Rule{
+	Name: "go_package",
+	Doc:  "files should have a go_package option",
+	Check: func(r *Reporter, d protoreflect.Descriptor) {
+		fd, ok := d.(protoreflect.FileDescriptor)
+		if !ok {
+			return
+		}
+		if opts, _ := fd.Options().(*descriptorpb.FileOptions); opts.GetGoPackage() == "" {
+			r.ReportPath(nil, "file should have a go_package option")
+		}
+	},
+}
+
+// FieldNumberGaps checks that the numbers between the lowest and highest
+// field numbers of a message are either used or reserved.
+var FieldNumberGaps = &Rule{
+	Name: "field_number_gaps",
+	Doc:  "unused field numbers between used ones should be reserved",
+	Check: func(r *Reporter, d protoreflect.Descriptor) {
+		md, ok := d.(protoreflect.MessageDescriptor)
+		if !ok || md.Fields().Len() == 0 {
+			return
+		}
+		// Walk the used, reserved and extension numbers as half-open ranges
+		// in order, reporting the numbers below the highest used number
+		// that none of the ranges covers.
+		var ranges [][2]protoreflect.FieldNumber
+		lo, hi := md.Fields().Get(0).Number(), md.Fields().Get(0).Number()
+		for i := 0; i < md.Fields().Len(); i++ {
+			n := md.Fields().Get(i).Number()
+			ranges = append(ranges, [2]protoreflect.FieldNumber{n, n + 1})
+			if n < lo {
+				lo = n
+			}
+			if n > hi {
+				hi = n
+			}
+		}
+		for i := 0; i < md.ReservedRanges().Len(); i++ {
+			ranges = append(ranges, md.ReservedRanges().Get(i))
+		}
+		for i := 0; i < md.ExtensionRanges().Len(); i++ {
+			ranges = append(ranges, md.ExtensionRanges().Get(i))
+		}
+		sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+		var gaps []string
+		next := lo // lowest number that is not yet known to be covered
+		for _, rr := range ranges {
+			if rr[0] > hi {
+				break
+			}
+			if rr[0] > next {
+				gaps = append(gaps, formatRange(next, rr[0]-1))
+			}
+			if rr[1] > next {
+				next = rr[1]
+			}
+		}
+		if len(gaps) > 0 {
+			r.Report(md, "message %q has unused field numbers that are not reserved: %s", md.Name(), strings.Join(gaps, ", "))
+		}
+	},
+}
+
+func formatRange(start, end protoreflect.FieldNumber) string {
+	if start == end {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d to %d", start, end)
+}
+
+// isGroup reports whether md is declared by a proto2 group field.
+func isGroup(md protoreflect.MessageDescriptor) bool {
+	if md.Syntax() != protoreflect.Proto2 {
+		return false
+	}
+	var fields protoreflect.ExtensionDescriptors
+	switch parent := md.Parent().(type) {
+	case protoreflect.MessageDescriptor:
+		if fd := parent.Fields().ByName(protoreflect.Name(strings.ToLower(string(md.Name())))); fd != nil && fd.Message() == md {
+			return fd.Kind() == protoreflect.GroupKind
+		}
+		fields = parent.Extensions()
+	case protoreflect.FileDescriptor:
+		fields = parent.Extensions()
+	}
+	if fields == nil {
+		return false
+	}
+	xd := fields.ByName(protoreflect.Name(strings.ToLower(string(md.Name()))))
+	return xd != nil && xd.Kind() == protoreflect.GroupKind && xd.Message() == md
+}
+
+func isUpperCamelCase(s string) bool {
+	if s == "" || !isUpper(s[0]) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isUpper(s[i]) && !isLower(s[i]) && !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isLowerSnakeCase(s string) bool {
+	return isSnakeCase(s, isLower)
+}
+
+func isUpperSnakeCase(s string) bool {
+	return isSnakeCase(s, isUpper)
+}
+
+// isSnakeCase reports whether s consists of words of letters of the given
+// case and digits, separated by single underscores, and starts with a letter.
+func isSnakeCase(s string, isLetter func(byte) bool) bool {
+	if s == "" || !isLetter(s[0]) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '_':
+			if i == len(s)-1 || s[i+1] == '_' {
+				return false
+			}
+		case !isLetter(c) && !isDigit(c):
+			return false
+		}
+	}
+	return true
+}
+
+// toUpperSnakeCase converts an UpperCamelCase name to UPPER_SNAKE_CASE,
+// such as "HTTPRequest" to "HTTP_REQUEST".
+func toUpperSnakeCase(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if i > 0 && isUpper(c) && (!isUpper(s[i-1]) || (i+1 < len(s) && isLower(s[i+1]))) && s[i-1] != '_' {
+			b.WriteByte('_')
+		}
+		if isLower(c) {
+			c -= 'a' - 'A'
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func isUpper(c byte) bool { return 'A' <= c && c <= 'Z' }
+func isLower(c byte) bool { return 'a' <= c && c <= 'z' }
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
@@ -0,0 +1,321 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protopath
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// AccessOptions configures the access of a value through a path.
+type AccessOptions struct {
+	// Resolver is used for looking up the message types of expanded
+	// google.protobuf.Any messages and the extension fields within them.
+	// If nil, this defaults to using protoregistry.GlobalTypes.
+	Resolver interface {
+		protoregistry.ExtensionTypeResolver
+		protoregistry.MessageTypeResolver
+	}
+}
+
+// Get returns the value at the end of the path, starting from the message m.
+// See [AccessOptions.Get] for details.
+func (p Path) Get(m protoreflect.Message) (protoreflect.Value, error) {
+	return AccessOptions{}.Get(p, m)
+}
+
+// Set sets the value at the end of the path, starting from the message m.
+// See [AccessOptions.Set] for details.
+func (p Path) Set(m protoreflect.Message, v protoreflect.Value) error {
+	return AccessOptions{}.Set(p, m, v)
+}
+
+// Clear clears the value at the end of the path, starting from the message m.
+// See [AccessOptions.Clear] for details.
+func (p Path) Clear(m protoreflect.Message) error {
+	return AccessOptions{}.Clear(p, m)
+}
+
+// Get returns the value at the end of the path p, starting from the message m,
+// which must be of the message type of the [Root] step.
+//
+// Unpopulated fields evaluate to their default value as for
+// [protoreflect.Message.Get], such that the result may be a read-only value.
+// It reports an error if the path is malformed, if a list index is out of range,
+// if a map entry does not exist, or if an expanded google.protobuf.Any message
+// does not contain a message of the expanded type, or if the expanded type
+// cannot be resolved.
+func (o AccessOptions) Get(p Path, m protoreflect.Message) (protoreflect.Value, error) {
+	o.init()
+	if err := p.validate(m); err != nil {
+		return protoreflect.Value{}, err
+	}
+	v := protoreflect.ValueOfMessage(m)
+	for _, s := range p[1:] {
+		switch s.kind {
+		case FieldAccessStep:
+			v = v.Message().Get(s.FieldDescriptor())
+		case UnknownAccessStep:
+			v = protoreflect.ValueOfBytes(v.Message().GetUnknown())
+		case ListIndexStep:
+			l := v.List()
+			if s.ListIndex() >= l.Len() {
+				return protoreflect.Value{}, errors.New("list index %v out of range in path %v", s.ListIndex(), p)
+			}
+			v = l.Get(s.ListIndex())
+		case MapIndexStep:
+			v = v.Map().Get(s.MapIndex())
+			if !v.IsValid() {
+				return protoreflect.Value{}, errors.New("map key %v not found in path %v", s.MapIndex(), p)
+			}
+		case AnyExpandStep:
+			x, err := o.unpackAny(v.Message(), s.MessageDescriptor(), false)
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+			v = protoreflect.ValueOfMessage(x)
+		}
+	}
+	return v, nil
+}
+
+// Set sets the value at the end of the path p, starting from the message m,
+// which must be of the message type of the [Root] step.
+// The value must be valid for the last step as for [protoreflect.Message.Set].
+//
+// Unpopulated messages, lists, and maps along the path are populated.
+// A list index equal to the length of the list appends the value.
+// Setting an expanded google.protobuf.Any message marshals the value into
+// the Any message. Any other step into an expanded google.protobuf.Any
+// message unmarshals the underlying message, which must be of the expanded
+// type, and marshals it again after it has been modified.
+// It reports an error if the path is malformed, if a list index is
+// out of range, or if the type of an expanded google.protobuf.Any message
+// cannot be resolved.
+func (o AccessOptions) Set(p Path, m protoreflect.Message, v protoreflect.Value) error {
+	o.init()
+	if err := p.validate(m); err != nil {
+		return err
+	}
+	if len(p) < 2 {
+		return errors.New("cannot set the root of path %v", p)
+	}
+	return o.mutate(p, m, p[1:], v)
+}
+
+// Clear clears the value at the end of the path p, starting from the message m,
+// which must be of the message type of the [Root] step.
+// Clearing a list element removes it from the list, shifting any subsequent
+// elements. Clearing a value that is not populated has no effect.
+// It reports an error if the path is malformed or if the type of an expanded
+// google.protobuf.Any message cannot be resolved.
+func (o AccessOptions) Clear(p Path, m protoreflect.Message) error {
+	o.init()
+	if err := p.validate(m); err != nil {
+		return err
+	}
+	if len(p) < 2 {
+		return errors.New("cannot clear the root of path %v", p)
+	}
+	return o.mutate(p, m, p[1:], protoreflect.Value{})
+}
+
+func (o *AccessOptions) init() {
+	if o.Resolver == nil {
+		o.Resolver = protoregistry.GlobalTypes
+	}
+}
+
+// mutate sets the value at the end of the steps starting from the message m
+// to v, or clears it if v is invalid.
+func (o AccessOptions) mutate(p Path, m protoreflect.Message, steps []Step, v protoreflect.Value) error {
+	s, last := steps[0], len(steps) == 1
+	switch s.kind {
+	case UnknownAccessStep:
+		if v.IsValid() {
+			m.SetUnknown(protoreflect.RawFields(v.Bytes()))
+		} else {
+			m.SetUnknown(nil)
+		}
+	case AnyExpandStep:
+		if last {
+			if !v.IsValid() {
+				m.Clear(m.Descriptor().Fields().ByNumber(genid.Any_TypeUrl_field_number))
+				m.Clear(m.Descriptor().Fields().ByNumber(genid.Any_Value_field_number))
+				return nil
+			}
+			return packAny(m, v.Message())
+		}
+		if !v.IsValid() && !m.Has(m.Descriptor().Fields().ByNumber(genid.Any_Value_field_number)) {
+			return nil
+		}
+		x, err := o.unpackAny(m, s.MessageDescriptor(), true)
+		if err != nil {
+			return err
+		}
+		if err := o.mutate(p, x, steps[1:], v); err != nil {
+			return err
+		}
+		return packAny(m, x)
+	case FieldAccessStep:
+		fd := s.FieldDescriptor()
+		switch {
+		case last && v.IsValid():
+			m.Set(fd, v)
+		case last:
+			m.Clear(fd)
+		case !v.IsValid() && !m.Has(fd):
+		case fd.IsList():
+			return o.mutateList(p, m.Mutable(fd).List(), steps[1:], v)
+		case fd.IsMap():
+			return o.mutateMap(p, m.Mutable(fd).Map(), steps[1:], v)
+		default:
+			return o.mutate(p, m.Mutable(fd).Message(), steps[1:], v)
+		}
+	}
+	return nil
+}
+
+func (o AccessOptions) mutateList(p Path, l protoreflect.List, steps []Step, v protoreflect.Value) error {
+	i, last := steps[0].ListIndex(), len(steps) == 1
+	switch {
+	case i > l.Len() || (i == l.Len() && !last):
+		if !v.IsValid() {
+			return nil
+		}
+		return errors.New("list index %v out of range in path %v", i, p)
+	case last && v.IsValid() && i == l.Len():
+		l.Append(v)
+	case last && v.IsValid():
+		l.Set(i, v)
+	case last && i < l.Len():
+		for j := i; j < l.Len()-1; j++ {
+			l.Set(j, l.Get(j+1))
+		}
+		l.Truncate(l.Len() - 1)
+	case !last:
+		return o.mutate(p, l.Get(i).Message(), steps[1:], v)
+	}
+	return nil
+}
+
+func (o AccessOptions) mutateMap(p Path, mm protoreflect.Map, steps []Step, v protoreflect.Value) error {
+	k, last := steps[0].MapIndex(), len(steps) == 1
+	switch {
+	case last && v.IsValid():
+		mm.Set(k, v)
+	case last:
+		mm.Clear(k)
+	case !v.IsValid() && !mm.Has(k):
+	default:
+		return o.mutate(p, mm.Mutable(k).Message(), steps[1:], v)
+	}
+	return nil
+}
+
+// unpackAny unmarshals the message of type md within the google.protobuf.Any
+// message m. If allowEmpty is set, an empty Any message results in a new
+// empty message of type md.
+func (o AccessOptions) unpackAny(m protoreflect.Message, md protoreflect.MessageDescriptor, allowEmpty bool) (protoreflect.Message, error) {
+	fds := m.Descriptor().Fields()
+	url := m.Get(fds.ByNumber(genid.Any_TypeUrl_field_number)).String()
+	value := m.Get(fds.ByNumber(genid.Any_Value_field_number)).Bytes()
+
+	mt, err := o.Resolver.FindMessageByName(md.FullName())
+	if err != nil {
+		return nil, errors.New("unable to resolve message %v: %v", md.FullName(), err)
+	}
+	x := mt.New()
+	switch {
+	case url == "" && len(value) == 0 && allowEmpty:
+		return x, nil
+	case protoreflect.FullName(url[strings.LastIndexByte(url, '/')+1:]) != md.FullName():
+		return nil, errors.New("google.protobuf.Any contains %q, not %v", url, md.FullName())
+	}
+	if err := (proto.UnmarshalOptions{Resolver: o.Resolver}).Unmarshal(value, x.Interface()); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// packAny marshals the message x into the google.protobuf.Any message m.
+func packAny(m protoreflect.Message, x protoreflect.Message) error {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(x.Interface())
+	if err != nil {
+		return err
+	}
+	fds := m.Descriptor().Fields()
+	fdURL := fds.ByNumber(genid.Any_TypeUrl_field_number)
+	url := m.Get(fdURL).String()
+	if protoreflect.FullName(url[strings.LastIndexByte(url, '/')+1:]) != x.Descriptor().FullName() {
+		url = "type.googleapis.com/" + string(x.Descriptor().FullName())
+	}
+	m.Set(fdURL, protoreflect.ValueOfString(url))
+	m.Set(fds.ByNumber(genid.Any_Value_field_number), protoreflect.ValueOfBytes(b))
+	return nil
+}
+
+// validate reports whether p is a well-formed path for the message m.
+func (p Path) validate(m protoreflect.Message) error {
+	if len(p) == 0 || p[0].kind != RootStep {
+		return errors.New("path %v does not start with a root step", p)
+	}
+	if p[0].MessageDescriptor().FullName() != m.Descriptor().FullName() {
+		return errors.New("path %v does not apply to message %v", p, m.Descriptor().FullName())
+	}
+
+	// The current value is a message of type md or a list or map of the
+	// field fd, or neither if the current value is a scalar.
+	md := p[0].MessageDescriptor()
+	var fd protoreflect.FieldDescriptor
+	for i, s := range p[1:] {
+		switch {
+		case s.kind == FieldAccessStep && md != nil && s.FieldDescriptor().ContainingMessage().FullName() == md.FullName():
+			f := s.FieldDescriptor()
+			md, fd = nil, nil
+			if f.IsList() || f.IsMap() {
+				fd = f
+			} else {
+				md = f.Message()
+			}
+		case s.kind == UnknownAccessStep && md != nil && i == len(p)-2:
+			md = nil
+		case s.kind == ListIndexStep && fd != nil && fd.IsList():
+			md, fd = fd.Message(), nil
+		case s.kind == MapIndexStep && fd != nil && fd.IsMap() && isMapKeyKind(s.key, fd.MapKey().Kind()):
+			md, fd = fd.MapValue().Message(), nil
+		case s.kind == AnyExpandStep && md != nil && md.FullName() == genid.Any_message_fullname:
+			md = s.MessageDescriptor()
+		default:
+			return errors.New("invalid step %v in path %v", s, p)
+		}
+	}
+	return nil
+}
+
+// isMapKeyKind reports whether the map key k is valid for keys of kind.
+func isMapKeyKind(k protoreflect.Value, kind protoreflect.Kind) bool {
+	switch k.Interface().(type) {
+	case bool:
+		return kind == protoreflect.BoolKind
+	case int32:
+		return kind == protoreflect.Int32Kind || kind == protoreflect.Sint32Kind || kind == protoreflect.Sfixed32Kind
+	case int64:
+		return kind == protoreflect.Int64Kind || kind == protoreflect.Sint64Kind || kind == protoreflect.Sfixed64Kind
+	case uint32:
+		return kind == protoreflect.Uint32Kind || kind == protoreflect.Fixed32Kind
+	case uint64:
+		return kind == protoreflect.Uint64Kind || kind == protoreflect.Fixed64Kind
+	case string:
+		return kind == protoreflect.StringKind
+	default:
+		return false
+	}
+}
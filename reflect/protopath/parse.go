@@ -0,0 +1,235 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protopath
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/internal/encoding/text"
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Parse parses a path relative to messages of type md using the default options.
+//
+// See [ParseOptions.Parse] for details.
+func Parse(md protoreflect.MessageDescriptor, s string) (Path, error) {
+	return ParseOptions{}.Parse(md, s)
+}
+
+// ParseOptions configures the parsing of a path.
+type ParseOptions struct {
+	// Resolver is used for looking up extension fields and the message types
+	// of expanded google.protobuf.Any messages.
+	// If nil, this defaults to using protoregistry.GlobalTypes.
+	Resolver interface {
+		protoregistry.ExtensionTypeResolver
+		protoregistry.MessageTypeResolver
+	}
+}
+
+// Parse parses a path relative to messages of type md in the syntax
+// produced by [Path.String]. For example:
+//
+//	(path.to.MyMessage).list_field[5].map_field["hello"].(path.to.extension).any_field.(path.to.AnyMessage).field
+//
+// The leading [Root] step is optional, but must name md if present.
+// A parenthesized name is the full name of an extension field, or the full
+// name of the underlying message type if the current message is a
+// google.protobuf.Any message, in which case it is parsed as an [AnyExpand]
+// step. The name of a group field is the name of its message type.
+// Map keys are formatted as in the text format, where string keys are quoted.
+func (o ParseOptions) Parse(md protoreflect.MessageDescriptor, s string) (Path, error) {
+	if o.Resolver == nil {
+		o.Resolver = protoregistry.GlobalTypes
+	}
+	p := Path{Root(md)}
+	in := s
+	if strings.HasPrefix(in, "(") {
+		name, rest, err := parseParens(in)
+		if err != nil {
+			return nil, errors.New("invalid path %q: %v", s, err)
+		}
+		if name != md.FullName() {
+			return nil, errors.New("invalid path %q: root message %v does not match %v", s, name, md.FullName())
+		}
+		in = rest
+	}
+
+	// The current value is a message of type msg or a list or map of the
+	// field fd, or neither if the current value is a scalar.
+	msg := md
+	var fd protoreflect.FieldDescriptor
+	access := func(f protoreflect.FieldDescriptor) {
+		p = append(p, FieldAccess(f))
+		msg, fd = nil, nil
+		if f.IsList() || f.IsMap() {
+			fd = f
+		} else {
+			msg = f.Message()
+		}
+	}
+	for len(in) > 0 {
+		switch {
+		case in[0] == '.' && msg != nil:
+			in = in[1:]
+			switch {
+			case strings.HasPrefix(in, "?"):
+				p = append(p, UnknownAccess())
+				msg, in = nil, in[1:]
+			case strings.HasPrefix(in, "("):
+				name, rest, err := parseParens(in)
+				if err != nil {
+					return nil, errors.New("invalid path %q: %v", s, err)
+				}
+				in = rest
+				if msg.FullName() == genid.Any_message_fullname {
+					mt, err := o.Resolver.FindMessageByName(name)
+					if err != nil {
+						return nil, errors.New("invalid path %q: unable to resolve message %v: %v", s, name, err)
+					}
+					msg = mt.Descriptor()
+					p = append(p, AnyExpand(msg))
+					break
+				}
+				xt, err := o.Resolver.FindExtensionByName(name)
+				if err != nil {
+					return nil, errors.New("invalid path %q: unable to resolve extension %v: %v", s, name, err)
+				}
+				xd := xt.TypeDescriptor()
+				if xd.ContainingMessage().FullName() != msg.FullName() {
+					return nil, errors.New("invalid path %q: extension %v does not extend %v", s, name, msg.FullName())
+				}
+				access(xd)
+			default:
+				i := 0
+				for i < len(in) && isNameChar(in[i]) {
+					i++
+				}
+				f := msg.Fields().ByTextName(in[:i])
+				if f == nil {
+					return nil, errors.New("invalid path %q: message %v has no field %q", s, msg.FullName(), in[:i])
+				}
+				in = in[i:]
+				access(f)
+			}
+		case in[0] == '[' && fd != nil:
+			key, rest, err := parseIndex(in)
+			if err != nil {
+				return nil, errors.New("invalid path %q: %v", s, err)
+			}
+			in = rest
+			if fd.IsList() {
+				i, err := strconv.ParseUint(key, 10, 31)
+				if err != nil {
+					return nil, errors.New("invalid path %q: invalid list index %v", s, key)
+				}
+				p = append(p, ListIndex(int(i)))
+				msg, fd = fd.Message(), nil
+				break
+			}
+			k, err := parseMapKey(fd.MapKey(), key)
+			if err != nil {
+				return nil, errors.New("invalid path %q: invalid map key %v", s, key)
+			}
+			p = append(p, MapIndex(k))
+			msg, fd = fd.MapValue().Message(), nil
+		default:
+			return nil, errors.New("invalid path %q: unexpected %q at offset %d", s, in[0], len(s)-len(in))
+		}
+	}
+	return p, nil
+}
+
+// parseParens parses a full name enclosed in parentheses.
+func parseParens(in string) (protoreflect.FullName, string, error) {
+	i := strings.IndexByte(in, ')')
+	if i < 0 {
+		return "", "", errors.New("missing closing parenthesis")
+	}
+	name := protoreflect.FullName(in[1:i])
+	if !name.IsValid() {
+		return "", "", errors.New("invalid name %q", name)
+	}
+	return name, in[i+1:], nil
+}
+
+// parseIndex parses a list index or map key enclosed in brackets,
+// where a string map key may contain a closing bracket.
+func parseIndex(in string) (string, string, error) {
+	i := 1
+	if i < len(in) && (in[i] == '"' || in[i] == '\'') {
+		quote := in[i]
+		for i++; i < len(in) && in[i] != quote; i++ {
+			if in[i] == '\\' {
+				i++
+			}
+		}
+		i++
+	}
+	if i > len(in) {
+		return "", "", errors.New("unterminated string")
+	}
+	j := strings.IndexByte(in[i:], ']')
+	if j < 0 || (i > 1 && j > 0) {
+		return "", "", errors.New("missing closing bracket")
+	}
+	return in[1 : i+j], in[i+j+1:], nil
+}
+
+func parseMapKey(fd protoreflect.FieldDescriptor, s string) (protoreflect.MapKey, error) {
+	var v protoreflect.Value
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		if err != nil || (s != "true" && s != "false") {
+			return protoreflect.MapKey{}, errors.New("invalid bool")
+		}
+		v = protoreflect.ValueOfBool(b)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return protoreflect.MapKey{}, err
+		}
+		v = protoreflect.ValueOfInt32(int32(n))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return protoreflect.MapKey{}, err
+		}
+		v = protoreflect.ValueOfInt64(n)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return protoreflect.MapKey{}, err
+		}
+		v = protoreflect.ValueOfUint32(uint32(n))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return protoreflect.MapKey{}, err
+		}
+		v = protoreflect.ValueOfUint64(n)
+	case protoreflect.StringKind:
+		if len(s) == 0 || (s[0] != '"' && s[0] != '\'') {
+			return protoreflect.MapKey{}, errors.New("unquoted string")
+		}
+		str, err := text.UnmarshalString(s)
+		if err != nil {
+			return protoreflect.MapKey{}, err
+		}
+		v = protoreflect.ValueOfString(str)
+	default:
+		return protoreflect.MapKey{}, errors.New("invalid map key kind %v", fd.Kind())
+	}
+	return v.MapKey(), nil
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
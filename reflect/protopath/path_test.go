@@ -0,0 +1,406 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protopath_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/testing/protopack"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+	textpb "google.golang.org/protobuf/internal/testprotos/textpb2"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		md   protoreflect.MessageDescriptor
+		in   string
+		want string // defaults to in
+	}{
+		{md: (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(), in: "(goproto.proto.test.TestAllTypes)"},
+		{md: (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(), in: "", want: "(goproto.proto.test.TestAllTypes)"},
+		{md: (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(), in: ".optional_int32", want: "(goproto.proto.test.TestAllTypes).optional_int32"},
+		{md: (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(), in: "(goproto.proto.test.TestAllTypes).?"},
+		{md: (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(), in: "(goproto.proto.test.TestAllTypes).OptionalGroup.a"},
+		{md: (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(), in: "(goproto.proto.test.TestAllTypes).optional_nested_message.corecursive.repeated_int32[3]"},
+		{md: (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(), in: "(goproto.proto.test.TestAllTypes).repeated_nested_message[0].a"},
+		{md: (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(), in: "(goproto.proto.test.TestAllTypes).map_int32_int32[-5]"},
+		{md: (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(), in: "(goproto.proto.test.TestAllTypes).map_uint64_uint64[18446744073709551615]"},
+		{md: (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(), in: "(goproto.proto.test.TestAllTypes).map_bool_bool[true]"},
+		{md: (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(), in: `(goproto.proto.test.TestAllTypes).map_string_nested_message["a].\"b"].a`},
+		{md: (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(), in: `(goproto.proto.test.TestAllTypes).map_string_string['x']`, want: `(goproto.proto.test.TestAllTypes).map_string_string["x"]`},
+		{md: (*testpb.TestAllExtensions)(nil).ProtoReflect().Descriptor(), in: "(goproto.proto.test.TestAllExtensions).(goproto.proto.test.optional_nested_message).a"},
+		{md: (*textpb.KnownTypes)(nil).ProtoReflect().Descriptor(), in: "(pb2.KnownTypes).opt_any.(goproto.proto.test.TestAllTypes).map_string_string[\"k\"]"},
+		{md: (*textpb.KnownTypes)(nil).ProtoReflect().Descriptor(), in: "(pb2.KnownTypes).opt_any.type_url"},
+	}
+	for _, tt := range tests {
+		p, err := protopath.Parse(tt.md, tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		want := tt.want
+		if want == "" {
+			want = tt.in
+		}
+		if got := p.String(); got != want {
+			t.Errorf("Parse(%q).String() = %v, want %v", tt.in, got, want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	md := (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor()
+	for _, in := range []string{
+		"(goproto.proto.test.TestAllExtensions)",
+		"(goproto.proto.test.TestAllTypes",
+		"(goproto.proto.test.TestAllTypes)optional_int32",
+		".no_such_field",
+		".optional_int32.a",
+		".optional_int32[0]",
+		".?.a",
+		".optional_nested_message[0]",
+		".repeated_int32.a",
+		".repeated_int32[-1]",
+		".repeated_int32[a]",
+		".repeated_int32[0",
+		".map_string_string[a]",
+		`.map_string_string["a]`,
+		`.map_string_string["a"b]`,
+		".map_int32_int32[2147483648]",
+		".map_bool_bool[1]",
+		".(goproto.proto.test.optional_int32)",
+		".(goproto.proto.test.no_such_extension)",
+		".optional_nested_message.(goproto.proto.test.TestAllTypes)",
+		".optional_int32.",
+		".optional_int32 ",
+	} {
+		if p, err := protopath.Parse(md, in); err == nil {
+			t.Errorf("Parse(%q) = %v, want error", in, p)
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	m := &textpb.KnownTypes{
+		OptAny: mustMarshalAny(&testpb.TestAllTypes{
+			OptionalInt32:   proto.Int32(5),
+			RepeatedInt32:   []int32{1, 2},
+			MapStringString: map[string]string{"k": "v"},
+		}),
+	}
+	tests := []struct {
+		in      string
+		want    interface{}
+		wantErr bool
+	}{
+		{in: ".opt_any.type_url", want: "type.googleapis.com/goproto.proto.test.TestAllTypes"},
+		{in: ".opt_any.(goproto.proto.test.TestAllTypes).optional_int32", want: int32(5)},
+		{in: ".opt_any.(goproto.proto.test.TestAllTypes).default_int64", want: int64(82)},
+		{in: ".opt_any.(goproto.proto.test.TestAllTypes).repeated_int32[1]", want: int32(2)},
+		{in: `.opt_any.(goproto.proto.test.TestAllTypes).map_string_string["k"]`, want: "v"},
+		{in: ".opt_any.(goproto.proto.test.TestAllTypes).repeated_int32[2]", wantErr: true},
+		{in: `.opt_any.(goproto.proto.test.TestAllTypes).map_string_string["x"]`, wantErr: true},
+		{in: ".opt_any.(goproto.proto.test.TestAllExtensions).?", wantErr: true},
+		{in: ".opt_duration.seconds", want: int64(0)},
+	}
+	for _, tt := range tests {
+		p, err := protopath.Parse(m.ProtoReflect().Descriptor(), tt.in)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", tt.in, err)
+		}
+		got, err := p.Get(m.ProtoReflect())
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Get(%v) = %v, want error", p, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Get(%v) returned error: %v", p, err)
+			continue
+		}
+		if got.Interface() != tt.want {
+			t.Errorf("Get(%v) = %v, want %v", p, got, tt.want)
+		}
+	}
+}
+
+func TestSetClear(t *testing.T) {
+	md := (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor()
+	unknown := protopack.Message{
+		protopack.Tag{Number: 50000, Type: protopack.VarintType}, protopack.Uvarint(1),
+	}.Marshal()
+	tests := []struct {
+		in      string
+		set     protoreflect.Value
+		init    *testpb.TestAllTypes
+		want    *testpb.TestAllTypes
+		wantErr bool
+	}{{
+		in:   ".optional_int32",
+		set:  protoreflect.ValueOfInt32(1),
+		init: &testpb.TestAllTypes{},
+		want: &testpb.TestAllTypes{OptionalInt32: proto.Int32(1)},
+	}, {
+		in:   ".optional_nested_message.corecursive.optional_string",
+		set:  protoreflect.ValueOfString("a"),
+		init: &testpb.TestAllTypes{},
+		want: &testpb.TestAllTypes{
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				Corecursive: &testpb.TestAllTypes{OptionalString: proto.String("a")},
+			},
+		},
+	}, {
+		in:   ".repeated_int32[1]",
+		set:  protoreflect.ValueOfInt32(5),
+		init: &testpb.TestAllTypes{RepeatedInt32: []int32{1, 2, 3}},
+		want: &testpb.TestAllTypes{RepeatedInt32: []int32{1, 5, 3}},
+	}, {
+		in:   ".repeated_int32[3]",
+		set:  protoreflect.ValueOfInt32(4),
+		init: &testpb.TestAllTypes{RepeatedInt32: []int32{1, 2, 3}},
+		want: &testpb.TestAllTypes{RepeatedInt32: []int32{1, 2, 3, 4}},
+	}, {
+		in:      ".repeated_int32[4]",
+		set:     protoreflect.ValueOfInt32(4),
+		init:    &testpb.TestAllTypes{RepeatedInt32: []int32{1, 2, 3}},
+		wantErr: true,
+	}, {
+		in:   ".repeated_nested_message[0].a",
+		set:  protoreflect.ValueOfInt32(2),
+		init: &testpb.TestAllTypes{RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{{A: proto.Int32(1)}}},
+		want: &testpb.TestAllTypes{RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{{A: proto.Int32(2)}}},
+	}, {
+		in:   `.map_string_nested_message["x"].a`,
+		set:  protoreflect.ValueOfInt32(1),
+		init: &testpb.TestAllTypes{},
+		want: &testpb.TestAllTypes{MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{"x": {A: proto.Int32(1)}}},
+	}, {
+		in:   ".map_int32_int32[-1]",
+		set:  protoreflect.ValueOfInt32(1),
+		init: &testpb.TestAllTypes{MapInt32Int32: map[int32]int32{2: 2}},
+		want: &testpb.TestAllTypes{MapInt32Int32: map[int32]int32{-1: 1, 2: 2}},
+	}, {
+		in:   ".?",
+		set:  protoreflect.ValueOfBytes(unknown),
+		init: &testpb.TestAllTypes{},
+		want: func() *testpb.TestAllTypes {
+			m := &testpb.TestAllTypes{}
+			m.ProtoReflect().SetUnknown(unknown)
+			return m
+		}(),
+	}}
+	for _, tt := range tests {
+		p, err := protopath.Parse(md, tt.in)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", tt.in, err)
+		}
+		for _, dynamic := range []bool{false, true} {
+			var m proto.Message = proto.Clone(tt.init)
+			if dynamic {
+				m = dynamicpb.NewMessage(md)
+				proto.Merge(m, tt.init)
+			}
+			err := p.Set(m.ProtoReflect(), tt.set)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Set(%v) returned no error", p)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("Set(%v) returned error: %v", p, err)
+				continue
+			}
+			if diff := cmp.Diff(tt.want, m, protocmp.Transform()); diff != "" {
+				t.Errorf("Set(%v) mismatch (-want +got):\n%s", p, diff)
+			}
+			if got, err := p.Get(m.ProtoReflect()); err != nil || !got.Equal(tt.set) {
+				t.Errorf("Get(%v) after Set = %v, %v; want %v", p, got, err, tt.set)
+			}
+		}
+	}
+}
+
+func TestClear(t *testing.T) {
+	md := (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor()
+	tests := []struct {
+		in   string
+		init *testpb.TestAllTypes
+		want *testpb.TestAllTypes
+	}{{
+		in:   ".optional_int32",
+		init: &testpb.TestAllTypes{OptionalInt32: proto.Int32(1), OptionalInt64: proto.Int64(1)},
+		want: &testpb.TestAllTypes{OptionalInt64: proto.Int64(1)},
+	}, {
+		in:   ".optional_nested_message.corecursive.optional_string",
+		init: &testpb.TestAllTypes{},
+		want: &testpb.TestAllTypes{},
+	}, {
+		in:   ".repeated_int32[1]",
+		init: &testpb.TestAllTypes{RepeatedInt32: []int32{1, 2, 3}},
+		want: &testpb.TestAllTypes{RepeatedInt32: []int32{1, 3}},
+	}, {
+		in:   ".repeated_int32[5]",
+		init: &testpb.TestAllTypes{RepeatedInt32: []int32{1, 2, 3}},
+		want: &testpb.TestAllTypes{RepeatedInt32: []int32{1, 2, 3}},
+	}, {
+		in:   ".repeated_nested_message[3].a",
+		init: &testpb.TestAllTypes{},
+		want: &testpb.TestAllTypes{},
+	}, {
+		in:   `.map_string_string["a"]`,
+		init: &testpb.TestAllTypes{MapStringString: map[string]string{"a": "1", "b": "2"}},
+		want: &testpb.TestAllTypes{MapStringString: map[string]string{"b": "2"}},
+	}, {
+		in:   `.map_string_nested_message["x"].a`,
+		init: &testpb.TestAllTypes{},
+		want: &testpb.TestAllTypes{},
+	}}
+	for _, tt := range tests {
+		p, err := protopath.Parse(md, tt.in)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", tt.in, err)
+		}
+		m := proto.Clone(tt.init)
+		if err := p.Clear(m.ProtoReflect()); err != nil {
+			t.Errorf("Clear(%v) returned error: %v", p, err)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, m, protocmp.Transform()); diff != "" {
+			t.Errorf("Clear(%v) mismatch (-want +got):\n%s", p, diff)
+		}
+	}
+}
+
+func TestSetAny(t *testing.T) {
+	md := (*textpb.KnownTypes)(nil).ProtoReflect().Descriptor()
+	m := &textpb.KnownTypes{}
+
+	p, err := protopath.Parse(md, ".opt_any.(goproto.proto.test.TestAllTypes).optional_nested_message.a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Set(m.ProtoReflect(), protoreflect.ValueOfInt32(1)); err != nil {
+		t.Fatalf("Set(%v) returned error: %v", p, err)
+	}
+	want := &textpb.KnownTypes{
+		OptAny: mustMarshalAny(&testpb.TestAllTypes{
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(1)},
+		}),
+	}
+	if diff := cmp.Diff(want, m, protocmp.Transform()); diff != "" {
+		t.Errorf("Set(%v) mismatch (-want +got):\n%s", p, diff)
+	}
+
+	if err := p.Clear(m.ProtoReflect()); err != nil {
+		t.Fatalf("Clear(%v) returned error: %v", p, err)
+	}
+	want = &textpb.KnownTypes{
+		OptAny: mustMarshalAny(&testpb.TestAllTypes{
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{},
+		}),
+	}
+	if diff := cmp.Diff(want, m, protocmp.Transform()); diff != "" {
+		t.Errorf("Clear(%v) mismatch (-want +got):\n%s", p, diff)
+	}
+
+	// Setting a different message type through the Any message fails.
+	p, err = protopath.Parse(md, ".opt_any.(goproto.proto.test.TestAllExtensions).?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Set(m.ProtoReflect(), protoreflect.ValueOfBytes(nil)); err == nil {
+		t.Errorf("Set(%v) returned no error for mismatching message type", p)
+	}
+
+	// Setting the expanded message replaces the contents of the Any message.
+	p, err = protopath.Parse(md, ".opt_any.(goproto.proto.test.TestAllExtensions)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Set(m.ProtoReflect(), protoreflect.ValueOfMessage((&testpb.TestAllExtensions{}).ProtoReflect())); err != nil {
+		t.Fatalf("Set(%v) returned error: %v", p, err)
+	}
+	want = &textpb.KnownTypes{OptAny: mustMarshalAny(&testpb.TestAllExtensions{})}
+	if diff := cmp.Diff(want, m, protocmp.Transform()); diff != "" {
+		t.Errorf("Set(%v) mismatch (-want +got):\n%s", p, diff)
+	}
+}
+
+func TestPathMismatch(t *testing.T) {
+	p, err := protopath.Parse((*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor(), ".optional_int32")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &testpb.TestAllExtensions{}
+	if _, err := p.Get(m.ProtoReflect()); err == nil {
+		t.Errorf("Get(%v) returned no error for mismatching message", p)
+	}
+	if err := p.Set(m.ProtoReflect(), protoreflect.ValueOfInt32(1)); err == nil {
+		t.Errorf("Set(%v) returned no error for mismatching message", p)
+	}
+	if err := p.Clear(m.ProtoReflect()); err == nil {
+		t.Errorf("Clear(%v) returned no error for mismatching message", p)
+	}
+
+	// A path that was not constructed by Parse is validated.
+	fd := (*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor().Fields().ByName("repeated_int32")
+	p = protopath.Path{protopath.Root(fd.ContainingMessage()), protopath.FieldAccess(fd), protopath.MapIndex(protoreflect.ValueOfString("a").MapKey())}
+	if _, err := p.Get((&testpb.TestAllTypes{}).ProtoReflect()); err == nil {
+		t.Errorf("Get(%v) returned no error for invalid path", p)
+	}
+}
+
+func mustMarshalAny(m proto.Message) *anypb.Any {
+	a, err := anypb.New(m)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func TestAccessOptionsResolver(t *testing.T) {
+	md := (*textpb.KnownTypes)(nil).ProtoReflect().Descriptor()
+	m := &textpb.KnownTypes{
+		OptAny: mustMarshalAny(&testpb.TestAllTypes{
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(1)},
+		}),
+	}
+
+	types := new(protoregistry.Types)
+	if err := types.RegisterMessage(dynamicpb.NewMessageType((*testpb.TestAllTypes)(nil).ProtoReflect().Descriptor())); err != nil {
+		t.Fatal(err)
+	}
+	p, err := protopath.ParseOptions{Resolver: types}.Parse(md, ".opt_any.(goproto.proto.test.TestAllTypes).optional_nested_message")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := protopath.AccessOptions{Resolver: types}.Get(p, m.ProtoReflect())
+	if err != nil {
+		t.Fatalf("Get(%v) returned error: %v", p, err)
+	}
+	if _, ok := got.Message().Interface().(*dynamicpb.Message); !ok {
+		t.Errorf("Get(%v) = %T, want message of the type from the resolver", p, got.Message().Interface())
+	}
+
+	// The expanded type must be resolvable.
+	empty := protopath.AccessOptions{Resolver: new(protoregistry.Types)}
+	if _, err := empty.Get(p, m.ProtoReflect()); err == nil {
+		t.Errorf("Get(%v) returned no error for unresolvable message type", p)
+	}
+	if err := empty.Clear(p, m.ProtoReflect()); err == nil {
+		t.Errorf("Clear(%v) returned no error for unresolvable message type", p)
+	}
+}
@@ -6,10 +6,8 @@ package protopath
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
-	"google.golang.org/protobuf/internal/encoding/text"
+	"google.golang.org/protobuf/internal/pathfmt"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -193,47 +191,18 @@ func (s Step) String() string {
 func (s Step) appendString(b []byte) []byte {
 	switch s.kind {
 	case RootStep:
-		b = append(b, '(')
-		b = append(b, s.desc.FullName()...)
-		b = append(b, ')')
+		b = pathfmt.AppendRoot(b, s.desc.(protoreflect.MessageDescriptor))
 	case FieldAccessStep:
-		b = append(b, '.')
-		if fd := s.desc.(protoreflect.FieldDescriptor); fd.IsExtension() {
-			b = append(b, '(')
-			b = append(b, strings.Trim(fd.TextName(), "[]")...)
-			b = append(b, ')')
-		} else {
-			b = append(b, fd.TextName()...)
-		}
+		b = pathfmt.AppendField(b, s.desc.(protoreflect.FieldDescriptor))
 	case UnknownAccessStep:
 		b = append(b, '.')
 		b = append(b, '?')
 	case ListIndexStep:
-		b = append(b, '[')
-		b = strconv.AppendInt(b, s.key.Int(), 10)
-		b = append(b, ']')
+		b = pathfmt.AppendListIndex(b, int(s.key.Int()))
 	case MapIndexStep:
-		b = append(b, '[')
-		switch k := s.key.Interface().(type) {
-		case bool:
-			b = strconv.AppendBool(b, bool(k)) // e.g., "true" or "false"
-		case int32:
-			b = strconv.AppendInt(b, int64(k), 10) // e.g., "-32"
-		case int64:
-			b = strconv.AppendInt(b, int64(k), 10) // e.g., "-64"
-		case uint32:
-			b = strconv.AppendUint(b, uint64(k), 10) // e.g., "32"
-		case uint64:
-			b = strconv.AppendUint(b, uint64(k), 10) // e.g., "64"
-		case string:
-			b = text.AppendString(b, k) // e.g., `"hello, world"`
-		}
-		b = append(b, ']')
+		b = pathfmt.AppendMapIndex(b, s.key)
 	case AnyExpandStep:
-		b = append(b, '.')
-		b = append(b, '(')
-		b = append(b, s.desc.FullName()...)
-		b = append(b, ')')
+		b = pathfmt.AppendAnyExpand(b, s.desc.(protoreflect.MessageDescriptor))
 	default:
 		b = append(b, "<invalid>"...)
 	}
@@ -5,6 +5,8 @@
 package protoreflect
 
 import (
+	"reflect"
+
 	"google.golang.org/protobuf/internal/pragma"
 )
 
@@ -53,7 +55,11 @@ type (
 			FindExtensionByName(field FullName) (ExtensionType, error)
 			FindExtensionByNumber(message FullName, field FieldNumber) (ExtensionType, error)
 		}
-		Depth int
+		Depth     int
+		Allocator interface {
+			New(t reflect.Type) reflect.Value
+			MakeSlice(t reflect.Type, len, cap int) reflect.Value
+		}
 	}
 	unmarshalOutput = struct {
 		pragma.NoUnkeyedLiterals
@@ -0,0 +1,87 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protoarena provides an allocator for unmarshaling many messages
+// whose storage is allocated in large blocks and released in bulk.
+//
+// An Arena is used as the Allocator of proto.UnmarshalOptions:
+//
+//	a := new(protoarena.Arena)
+//	for _, b := range batch {
+//		m := new(foopb.Message)
+//		if err := (proto.UnmarshalOptions{Allocator: a}).Unmarshal(b, m); err != nil {
+//			return err
+//		}
+//		process(m)
+//	}
+//	a.Reset()
+package protoarena
+
+import (
+	"reflect"
+
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// blockSize is the size in bytes of the blocks of values of a type.
+const blockSize = 16 << 10
+
+// Arena is an allocator of the values of unmarshaled messages,
+// which allocates values of the same type from blocks of many values.
+//
+// Go memory is garbage collected, so rather than being freed individually,
+// the values of a block are freed together once none of them are referenced
+// anymore. A value which is retained keeps its whole block alive.
+//
+// The zero value is an empty Arena ready to use.
+// An Arena must not be used concurrently.
+type Arena struct {
+	// blocks holds the current block of each type, as a slice of
+	// the values used so far, with the capacity of the block.
+	blocks map[reflect.Type]reflect.Value
+}
+
+var _ protoiface.Allocator = (*Arena)(nil)
+
+// New returns a pointer to a new zero value of type t.
+func (a *Arena) New(t reflect.Type) reflect.Value {
+	return a.alloc(t, 1).Index(0).Addr()
+}
+
+// MakeSlice returns a new zero slice of slice type t
+// with the given length and capacity.
+func (a *Arena) MakeSlice(t reflect.Type, len, cap int) reflect.Value {
+	s := a.alloc(t.Elem(), cap).Slice(0, len)
+	if s.Type() != t {
+		s = s.Convert(t)
+	}
+	return s
+}
+
+// Reset releases the blocks of the Arena, so that values allocated after
+// Reset are stored in new blocks. The values allocated before Reset remain
+// valid and their blocks are freed once they are no longer referenced.
+func (a *Arena) Reset() {
+	a.blocks = nil
+}
+
+// alloc returns a slice of n new zero values of type t, with a capacity of n.
+func (a *Arena) alloc(t reflect.Type, n int) reflect.Value {
+	size := int(t.Size())
+	if size == 0 || n*size > blockSize/4 {
+		// Values that are large relative to a block are allocated on their own,
+		// to avoid wasting the remainder of blocks.
+		return reflect.MakeSlice(reflect.SliceOf(t), n, n)
+	}
+	b, ok := a.blocks[t]
+	if !ok || b.Cap()-b.Len() < n {
+		if a.blocks == nil {
+			a.blocks = make(map[reflect.Type]reflect.Value)
+		}
+		b = reflect.MakeSlice(reflect.SliceOf(t), 0, blockSize/size)
+	}
+	i := b.Len()
+	a.blocks[t] = b.Slice(0, i+n)
+	return b.Slice3(i, i+n, i+n)
+}
@@ -0,0 +1,172 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoarena_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/runtime/protoarena"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+	test3pb "google.golang.org/protobuf/internal/testprotos/test3"
+)
+
+func messages() []proto.Message {
+	return []proto.Message{
+		&testpb.TestAllTypes{
+			OptionalInt32:         proto.Int32(1),
+			OptionalString:        proto.String("string"),
+			OptionalBytes:         []byte("bytes"),
+			OptionalNestedEnum:    testpb.TestAllTypes_BAR.Enum(),
+			Optionalgroup:         &testpb.TestAllTypes_OptionalGroup{A: proto.Int32(2)},
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(3), Corecursive: &testpb.TestAllTypes{OptionalInt64: proto.Int64(4)}},
+			RepeatedInt32:         []int32{5, 6, 7},
+			RepeatedString:        []string{"a", "", "c"},
+			RepeatedBytes:         [][]byte{{}, {8}},
+			RepeatedNestedEnum:    []testpb.TestAllTypes_NestedEnum{testpb.TestAllTypes_FOO, testpb.TestAllTypes_BAZ},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{{A: proto.Int32(9)}, {}},
+			Repeatedgroup:         []*testpb.TestAllTypes_RepeatedGroup{{A: proto.Int32(10)}},
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"a": {A: proto.Int32(11)},
+			},
+			OneofField: &testpb.TestAllTypes_OneofNestedMessage{OneofNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(12)}},
+		},
+		&testpb.TestPackedTypes{
+			PackedInt32:  []int32{1, -2, 300},
+			PackedDouble: []float64{1.5},
+			PackedEnum:   []testpb.ForeignEnum{testpb.ForeignEnum_FOREIGN_BAR},
+		},
+		&test3pb.TestAllTypes{
+			SingularString:        "string",
+			SingularBytes:         []byte("bytes"),
+			SingularNestedMessage: &test3pb.TestAllTypes_NestedMessage{A: 1},
+			RepeatedString:        []string{"a", "b"},
+			OneofField:            &test3pb.TestAllTypes_OneofString{OneofString: "oneof"},
+		},
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	a := new(protoarena.Arena)
+	opts := proto.UnmarshalOptions{Allocator: a}
+	for _, want := range messages() {
+		md := want.ProtoReflect().Descriptor()
+		b, err := proto.Marshal(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, test := range []struct {
+			desc string
+			m    proto.Message
+		}{
+			{"generated", want.ProtoReflect().Type().New().Interface()},
+			{"dynamic", dynamicpb.NewMessage(md)},
+			{"struct", dynamicpb.NewStructMessageType(md).New().Interface()},
+		} {
+			t.Run(fmt.Sprintf("%v/%v", test.desc, md.FullName()), func(t *testing.T) {
+				// Unmarshal twice, so that values are allocated from blocks
+				// which already hold the values of another message.
+				for i := 0; i < 2; i++ {
+					if err := opts.Unmarshal(b, test.m); err != nil {
+						t.Fatalf("Unmarshal() error: %v", err)
+					}
+					if !proto.Equal(test.m, want) {
+						t.Errorf("Unmarshal() mismatch:\ngot:  %v\nwant: %v", test.m, want)
+					}
+				}
+			})
+		}
+	}
+	a.Reset()
+}
+
+// recordingAllocator records the types of the values it allocates.
+type recordingAllocator struct {
+	protoarena.Arena
+	types map[reflect.Type]bool
+}
+
+func (a *recordingAllocator) New(t reflect.Type) reflect.Value {
+	a.types[reflect.PtrTo(t)] = true
+	return a.Arena.New(t)
+}
+
+func (a *recordingAllocator) MakeSlice(t reflect.Type, len, cap int) reflect.Value {
+	a.types[t] = true
+	return a.Arena.MakeSlice(t, len, cap)
+}
+
+func TestAllocatedTypes(t *testing.T) {
+	a := &recordingAllocator{types: make(map[reflect.Type]bool)}
+	b, err := proto.Marshal(messages()[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &testpb.TestAllTypes{}
+	if err := (proto.UnmarshalOptions{Allocator: a}).Unmarshal(b, m); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []any{
+		(*int32)(nil),
+		(*testpb.TestAllTypes_NestedMessage)(nil),
+		(*testpb.TestAllTypes_OptionalGroup)(nil),
+		(*testpb.TestAllTypes_OneofNestedMessage)(nil),
+		[]byte(nil),
+		[]int32(nil),
+		[]string(nil),
+		[]*testpb.TestAllTypes_NestedMessage(nil),
+	} {
+		if typ := reflect.TypeOf(v); !a.types[typ] {
+			t.Errorf("%v was not allocated by the allocator", typ)
+		}
+	}
+
+	a.types = make(map[reflect.Type]bool)
+	dm := dynamicpb.NewMessage(m.ProtoReflect().Descriptor())
+	if err := (proto.UnmarshalOptions{Allocator: a}).Unmarshal(b, dm); err != nil {
+		t.Fatal(err)
+	}
+	for _, typ := range []reflect.Type{
+		reflect.TypeOf((*dynamicpb.Message)(nil)),
+		reflect.TypeOf([]byte(nil)),
+	} {
+		if !a.types[typ] {
+			t.Errorf("%v was not allocated by the allocator for a dynamic message", typ)
+		}
+	}
+}
+
+func TestArena(t *testing.T) {
+	a := new(protoarena.Arena)
+	p := a.New(reflect.TypeOf(int64(0))).Interface().(*int64)
+	if *p != 0 {
+		t.Errorf("New() = %v, want zero value", *p)
+	}
+
+	type ints []int32
+	s1 := a.MakeSlice(reflect.TypeOf(ints(nil)), 1, 2).Interface().(ints)
+	s2 := a.MakeSlice(reflect.TypeOf(ints(nil)), 2, 2).Interface().(ints)
+	if len(s1) != 1 || cap(s1) != 2 || len(s2) != 2 || cap(s2) != 2 {
+		t.Fatalf("MakeSlice() = len %v, cap %v and len %v, cap %v, want len 1, cap 2 and len 2, cap 2", len(s1), cap(s1), len(s2), cap(s2))
+	}
+	s1 = append(s1, 1, 2)
+	s2[0], s2[1] = 3, 4
+	if want := (ints{0, 1, 2}); !reflect.DeepEqual(s1, want) {
+		t.Errorf("s1 = %v, want %v", s1, want)
+	}
+	if want := (ints{3, 4}); !reflect.DeepEqual(s2, want) {
+		t.Errorf("s2 = %v, want %v", s2, want)
+	}
+
+	a.Reset()
+	large := a.MakeSlice(reflect.TypeOf([]byte(nil)), 0, 1<<20)
+	if got := large.Cap(); got != 1<<20 {
+		t.Errorf("MakeSlice() cap = %v, want %v", got, 1<<20)
+	}
+}
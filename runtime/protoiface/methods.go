@@ -10,6 +10,8 @@
 package protoiface
 
 import (
+	"reflect"
+
 	"google.golang.org/protobuf/internal/pragma"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
@@ -103,7 +105,22 @@ type UnmarshalInput = struct {
 		FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error)
 		FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error)
 	}
-	Depth int
+	Depth     int
+	Allocator Allocator // may be nil
+}
+
+// Allocator allocates the storage of values created by the Unmarshal method.
+// It allows the storage of many unmarshaled messages to be allocated
+// together, such as from an arena which is released in bulk.
+//
+// Unmarshal implementations may allocate any value without the Allocator.
+type Allocator = interface {
+	// New returns a pointer to a new zero value of type t.
+	New(t reflect.Type) reflect.Value
+
+	// MakeSlice returns a new zero slice of slice type t
+	// with the given length and capacity.
+	MakeSlice(t reflect.Type, len, cap int) reflect.Value
 }
 
 // UnmarshalOutput is output from the Unmarshal method.
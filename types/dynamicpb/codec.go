@@ -0,0 +1,777 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dynamicpb
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/internal/encoding/messageset"
+	"google.golang.org/protobuf/internal/errors"
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/internal/impl"
+	"google.golang.org/protobuf/internal/order"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+var (
+	errDecode         = errors.New("cannot parse invalid wire-format data")
+	errRecursionDepth = errors.New("exceeded maximum recursion depth")
+
+	// errUnknown is returned for fields which are to be treated
+	// as unknown fields, such as when the wire type does not match.
+	errUnknown = errors.New("unknown field")
+)
+
+// messageCoder is a table of the fields of a message type, which is built
+// from the message descriptor once and implements the fast-path methods
+// of all dynamic messages of the type.
+//
+// The fields are coded with the same value coders as generated messages.
+// Values of message fields are coded through the proto package, so that
+// they may be dynamic or generated messages.
+type messageCoder struct {
+	desc           protoreflect.MessageDescriptor
+	fields         []*fieldCoder // ordered by field number
+	denseFields    []*fieldCoder // indexed by field number
+	sparseFields   map[protowire.Number]*fieldCoder
+	required       []protoreflect.FieldDescriptor
+	needsInitCheck bool
+	methods        protoiface.Methods
+}
+
+// messageCoders is a cache of message coders by message descriptor.
+// Entries are never evicted, except by ReleaseFile, since a message type is
+// identified by its descriptor alone and messages of the type may be
+// created at any time.
+var messageCoders sync.Map // map[protoreflect.MessageDescriptor]*messageCoder
+
+// coderOf returns the message coder for md.
+func coderOf(md protoreflect.MessageDescriptor) *messageCoder {
+	if mc, ok := messageCoders.Load(md); ok {
+		return mc.(*messageCoder)
+	}
+	mc, _ := messageCoders.LoadOrStore(md, newMessageCoder(md))
+	return mc.(*messageCoder)
+}
+
+func newMessageCoder(md protoreflect.MessageDescriptor) *messageCoder {
+	mc := &messageCoder{
+		desc:           md,
+		sparseFields:   make(map[protowire.Number]*fieldCoder),
+		needsInitCheck: impl.NeedsInitCheck(md),
+	}
+	fds := md.Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		mc.fields = append(mc.fields, newFieldCoder(fd))
+		if fd.Cardinality() == protoreflect.Required {
+			mc.required = append(mc.required, fd)
+		}
+	}
+	sort.Slice(mc.fields, func(i, j int) bool {
+		return mc.fields[i].num < mc.fields[j].num
+	})
+
+	// Fields with small numbers are looked up in a slice,
+	// as long as it is not mostly empty.
+	var maxDense protowire.Number
+	for _, f := range mc.fields {
+		if f.num >= 16 && f.num >= 2*maxDense {
+			break
+		}
+		maxDense = f.num
+	}
+	mc.denseFields = make([]*fieldCoder, maxDense+1)
+	for _, f := range mc.fields {
+		if f.num <= maxDense {
+			mc.denseFields[f.num] = f
+		} else {
+			mc.sparseFields[f.num] = f
+		}
+	}
+
+	mc.methods = protoiface.Methods{
+		Flags:            protoiface.SupportMarshalDeterministic | protoiface.SupportUnmarshalDiscardUnknown,
+		Size:             mc.size,
+		Marshal:          mc.marshal,
+		Unmarshal:        mc.unmarshal,
+		Merge:            mc.merge,
+		CheckInitialized: mc.checkInitialized,
+	}
+	return mc
+}
+
+// field returns the coder of the field numbered num, or nil if there is none.
+func (mc *messageCoder) field(num protowire.Number) *fieldCoder {
+	if 0 <= num && int(num) < len(mc.denseFields) {
+		return mc.denseFields[num]
+	}
+	return mc.sparseFields[num]
+}
+
+// fieldKind is how the values of a field are coded.
+type fieldKind int
+
+const (
+	scalarField      fieldKind = iota // singular scalar, or list of scalars
+	messageField                      // singular message
+	groupField                        // singular group
+	messageListField                  // list of messages
+	groupListField                    // list of groups
+	mapField                          // map
+)
+
+// fieldCoder codes the values of a field.
+type fieldCoder struct {
+	fd      protoreflect.FieldDescriptor
+	num     protowire.Number
+	kind    fieldKind
+	wiretag uint64
+	tagsize int
+	value   impl.ValueCoder // for scalar fields
+	key     *fieldCoder     // for map fields
+	val     *fieldCoder     // for map fields
+}
+
+func newFieldCoder(fd protoreflect.FieldDescriptor) *fieldCoder {
+	f := &fieldCoder{
+		fd:  fd,
+		num: fd.Number(),
+	}
+	wtyp := protowire.BytesType
+	switch {
+	case fd.IsMap():
+		f.kind = mapField
+		f.key = newFieldCoder(fd.MapKey())
+		f.val = newFieldCoder(fd.MapValue())
+	case fd.Kind() == protoreflect.GroupKind:
+		f.kind = groupField
+		if fd.IsList() {
+			f.kind = groupListField
+		}
+		wtyp = protowire.StartGroupType
+	case fd.Kind() == protoreflect.MessageKind:
+		f.kind = messageField
+		if fd.IsList() {
+			f.kind = messageListField
+		}
+	default:
+		f.kind = scalarField
+		f.value = impl.NewValueCoder(fd)
+		if !fd.IsPacked() {
+			wtyp = wireTypes[fd.Kind()]
+		}
+	}
+	f.wiretag = protowire.EncodeTag(f.num, wtyp)
+	f.tagsize = protowire.SizeVarint(f.wiretag)
+	return f
+}
+
+var wireTypes = map[protoreflect.Kind]protowire.Type{
+	protoreflect.BoolKind:     protowire.VarintType,
+	protoreflect.EnumKind:     protowire.VarintType,
+	protoreflect.Int32Kind:    protowire.VarintType,
+	protoreflect.Sint32Kind:   protowire.VarintType,
+	protoreflect.Uint32Kind:   protowire.VarintType,
+	protoreflect.Int64Kind:    protowire.VarintType,
+	protoreflect.Sint64Kind:   protowire.VarintType,
+	protoreflect.Uint64Kind:   protowire.VarintType,
+	protoreflect.Sfixed32Kind: protowire.Fixed32Type,
+	protoreflect.Fixed32Kind:  protowire.Fixed32Type,
+	protoreflect.FloatKind:    protowire.Fixed32Type,
+	protoreflect.Sfixed64Kind: protowire.Fixed64Type,
+	protoreflect.Fixed64Kind:  protowire.Fixed64Type,
+	protoreflect.DoubleKind:   protowire.Fixed64Type,
+	protoreflect.StringKind:   protowire.BytesType,
+	protoreflect.BytesKind:    protowire.BytesType,
+}
+
+// extensionCoders is a cache of field coders by extension type descriptor.
+// As with messageCoders, entries are only evicted by ReleaseFile.
+var extensionCoders sync.Map // map[protoreflect.ExtensionTypeDescriptor]*fieldCoder
+
+// extensionCoderOf returns the field coder for xd.
+func extensionCoderOf(xd protoreflect.ExtensionTypeDescriptor) *fieldCoder {
+	if f, ok := extensionCoders.Load(xd); ok {
+		return f.(*fieldCoder)
+	}
+	f, _ := extensionCoders.LoadOrStore(xd, newFieldCoder(xd))
+	return f.(*fieldCoder)
+}
+
+// marshalOptions returns the options for marshaling nested messages.
+func marshalOptions(flags protoiface.MarshalInputFlags) proto.MarshalOptions {
+	return proto.MarshalOptions{
+		AllowPartial:  true,
+		Deterministic: flags&protoiface.MarshalDeterministic != 0,
+		UseCachedSize: flags&protoiface.MarshalUseCachedSize != 0,
+	}
+}
+
+func (mc *messageCoder) size(in protoiface.SizeInput) protoiface.SizeOutput {
+	m := in.Message.(*Message)
+	if m.known == nil {
+		return protoiface.SizeOutput{}
+	}
+	if in.Flags&protoiface.MarshalUseCachedSize != 0 {
+		if size := atomic.LoadInt32(&m.sizeCache); size > 0 {
+			return protoiface.SizeOutput{Size: int(size - 1)}
+		}
+	}
+	size := 0
+	for num, v := range m.known {
+		if xd, ok := m.ext[num]; ok {
+			size += extensionCoderOf(xd.(protoreflect.ExtensionTypeDescriptor)).size(v, in.Flags)
+		}
+	}
+	for num, v := range m.known {
+		if f := mc.field(num); f != nil && m.ext[num] == nil && isSet(f.fd, v) {
+			size += f.size(v, in.Flags)
+		}
+	}
+	size += len(m.unknown)
+	atomic.StoreInt32(&m.sizeCache, int32(size+1))
+	return protoiface.SizeOutput{Size: size}
+}
+
+func (f *fieldCoder) size(v protoreflect.Value, flags protoiface.MarshalInputFlags) int {
+	switch f.kind {
+	case messageField, groupField:
+		return f.sizeMessage(v.Message(), flags)
+	case messageListField, groupListField:
+		list := v.List()
+		size := 0
+		for i := 0; i < list.Len(); i++ {
+			size += f.sizeMessage(list.Get(i).Message(), flags)
+		}
+		return size
+	case mapField:
+		size := 0
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			size += f.tagsize + protowire.SizeBytes(f.key.size(k.Value(), flags)+f.val.size(v, flags))
+			return true
+		})
+		return size
+	default:
+		return f.value.Size(v, f.tagsize, flags)
+	}
+}
+
+// sizeMessage returns the size of the message or group m, including tags.
+func (f *fieldCoder) sizeMessage(m protoreflect.Message, flags protoiface.MarshalInputFlags) int {
+	size := marshalOptions(flags).Size(m.Interface())
+	if f.kind == groupField || f.kind == groupListField {
+		return 2*f.tagsize + size
+	}
+	return f.tagsize + protowire.SizeBytes(size)
+}
+
+func (mc *messageCoder) marshal(in protoiface.MarshalInput) (protoiface.MarshalOutput, error) {
+	m := in.Message.(*Message)
+	if m.known == nil {
+		return protoiface.MarshalOutput{Buf: in.Buf}, nil
+	}
+	b := in.Buf
+	var err error
+	if len(m.ext) > 0 {
+		nums := make([]protowire.Number, 0, len(m.ext))
+		for num := range m.ext {
+			nums = append(nums, num)
+		}
+		if in.Flags&protoiface.MarshalDeterministic != 0 {
+			sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+		}
+		for _, num := range nums {
+			xd := m.ext[num].(protoreflect.ExtensionTypeDescriptor)
+			if b, err = extensionCoderOf(xd).marshal(b, m.known[num], in.Flags); err != nil {
+				return protoiface.MarshalOutput{Buf: b}, err
+			}
+		}
+	}
+	// Fields are marshaled in order of their numbers, as generated messages
+	// are. Only the populated fields are visited, since a message usually
+	// has far fewer of them than its type declares.
+	var nums []protowire.Number
+	if len(m.known) > len(m.ext) {
+		nums = make([]protowire.Number, 0, len(m.known)-len(m.ext))
+		for num := range m.known {
+			if m.ext[num] == nil {
+				nums = append(nums, num)
+			}
+		}
+		sort.Sort(fieldNumbers(nums))
+	}
+	for _, num := range nums {
+		f, v := mc.field(num), m.known[num]
+		if f == nil || !isSet(f.fd, v) {
+			continue
+		}
+		if b, err = f.marshal(b, v, in.Flags); err != nil {
+			return protoiface.MarshalOutput{Buf: b}, err
+		}
+	}
+	b = append(b, m.unknown...)
+	return protoiface.MarshalOutput{Buf: b}, nil
+}
+
+// fieldNumbers sorts field numbers in increasing order.
+type fieldNumbers []protowire.Number
+
+func (x fieldNumbers) Len() int           { return len(x) }
+func (x fieldNumbers) Less(i, j int) bool { return x[i] < x[j] }
+func (x fieldNumbers) Swap(i, j int)      { x[i], x[j] = x[j], x[i] }
+
+func (f *fieldCoder) marshal(b []byte, v protoreflect.Value, flags protoiface.MarshalInputFlags) ([]byte, error) {
+	switch f.kind {
+	case messageField, groupField:
+		return f.marshalMessage(b, v.Message(), flags)
+	case messageListField, groupListField:
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			var err error
+			if b, err = f.marshalMessage(b, list.Get(i).Message(), flags); err != nil {
+				return b, err
+			}
+		}
+		return b, nil
+	case mapField:
+		var less order.KeyOrder
+		if flags&protoiface.MarshalDeterministic != 0 {
+			less = order.GenericKeyOrder
+		}
+		var err error
+		order.RangeEntries(v.Map(), less, func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			b, err = f.marshalMapEntry(b, k, v, flags)
+			return err == nil
+		})
+		return b, err
+	default:
+		return f.value.Marshal(b, v, f.wiretag, flags)
+	}
+}
+
+// marshalMessage appends the message or group m, including tags, to b.
+func (f *fieldCoder) marshalMessage(b []byte, m protoreflect.Message, flags protoiface.MarshalInputFlags) ([]byte, error) {
+	o := marshalOptions(flags)
+	b = protowire.AppendVarint(b, f.wiretag)
+	if f.kind == groupField || f.kind == groupListField {
+		b, err := o.MarshalAppend(b, m.Interface())
+		b = protowire.AppendTag(b, f.num, protowire.EndGroupType)
+		return b, err
+	}
+	size := o.Size(m.Interface())
+	b = protowire.AppendVarint(b, uint64(size))
+	before := len(b)
+	b, err := o.MarshalAppend(b, m.Interface())
+	if measuredSize := len(b) - before; size != measuredSize && err == nil {
+		return nil, errors.MismatchedSizeCalculation(size, measuredSize)
+	}
+	return b, err
+}
+
+func (f *fieldCoder) marshalMapEntry(b []byte, k protoreflect.MapKey, v protoreflect.Value, flags protoiface.MarshalInputFlags) ([]byte, error) {
+	size := f.key.size(k.Value(), flags) + f.val.size(v, flags)
+	b = protowire.AppendVarint(b, f.wiretag)
+	b = protowire.AppendVarint(b, uint64(size))
+	before := len(b)
+	b, err := f.key.marshal(b, k.Value(), flags)
+	if err != nil {
+		return b, err
+	}
+	b, err = f.val.marshal(b, v, flags)
+	if measuredSize := len(b) - before; size != measuredSize && err == nil {
+		return nil, errors.MismatchedSizeCalculation(size, measuredSize)
+	}
+	return b, err
+}
+
+func (mc *messageCoder) unmarshal(in protoiface.UnmarshalInput) (protoiface.UnmarshalOutput, error) {
+	m := in.Message.(*Message)
+	if m.known == nil {
+		panic(errors.New("%v: modification of read-only message", mc.desc.FullName()))
+	}
+	in.Depth--
+	if in.Depth < 0 {
+		return protoiface.UnmarshalOutput{}, errRecursionDepth
+	}
+	atomic.StoreInt32(&m.sizeCache, 0)
+	b := in.Buf
+	for len(b) > 0 {
+		num, wtyp, n := protowire.ConsumeTag(b)
+		if n < 0 || num > protowire.MaxValidNumber || wtyp == protowire.EndGroupType {
+			return protoiface.UnmarshalOutput{}, errDecode
+		}
+		b = b[n:]
+
+		var err error
+		if f := mc.field(num); f != nil {
+			var v protoreflect.Value
+			v, n, err = f.unmarshal(b, m.known[num], wtyp, in)
+			if err == nil {
+				m.clearOtherOneofFields(f.fd)
+				m.known[num] = v
+			}
+		} else {
+			n, err = mc.unmarshalExtension(m, b, num, wtyp, in)
+		}
+		if err == errUnknown {
+			n = protowire.ConsumeFieldValue(num, wtyp, b)
+			if n < 0 {
+				return protoiface.UnmarshalOutput{}, errDecode
+			}
+			if in.Flags&protoiface.UnmarshalDiscardUnknown == 0 {
+				m.unknown = protowire.AppendTag(m.unknown, num, wtyp)
+				m.unknown = append(m.unknown, b[:n]...)
+			}
+		} else if err != nil {
+			return protoiface.UnmarshalOutput{}, err
+		}
+		b = b[n:]
+	}
+	var out protoiface.UnmarshalOutput
+	if !mc.needsInitCheck {
+		out.Flags |= protoiface.UnmarshalInitialized
+	}
+	return out, nil
+}
+
+func (mc *messageCoder) unmarshalExtension(m *Message, b []byte, num protowire.Number, wtyp protowire.Type, in protoiface.UnmarshalInput) (int, error) {
+	if mc.desc.ExtensionRanges().Len() == 0 {
+		return 0, errUnknown
+	}
+	xt, err := in.Resolver.FindExtensionByNumber(mc.desc.FullName(), num)
+	if err == protoregistry.NotFound {
+		return 0, errUnknown
+	}
+	if err != nil {
+		return 0, errors.New("%v: unable to resolve extension %v: %v", mc.desc.FullName(), num, err)
+	}
+	xd := xt.TypeDescriptor()
+	v, ok := m.known[num]
+	if !ok || m.ext[num] != xd {
+		v = protoreflect.Value{}
+		if xd.IsList() || xd.Message() != nil {
+			v = xt.New()
+		}
+	}
+	v, n, err := extensionCoderOf(xd).unmarshal(b, v, wtyp, in)
+	if err != nil {
+		return 0, err
+	}
+	m.ext[num] = xd
+	m.known[num] = v
+	return n, nil
+}
+
+// unmarshal decodes a value of the field from b, which starts after the tag,
+// and returns the value of the field, which is v if the field is composite
+// and v is valid. It returns the number of bytes consumed.
+func (f *fieldCoder) unmarshal(b []byte, v protoreflect.Value, wtyp protowire.Type, in protoiface.UnmarshalInput) (protoreflect.Value, int, error) {
+	switch f.kind {
+	case messageField, groupField:
+		if !v.IsValid() {
+			v = protoreflect.ValueOfMessage(newMessage(f.fd.Message(), in.Allocator))
+		}
+		n, err := f.unmarshalMessage(b, v.Message(), wtyp, in)
+		return v, n, err
+	case messageListField, groupListField:
+		if !v.IsValid() {
+			v = protoreflect.ValueOfList(&dynamicList{desc: f.fd})
+		}
+		list := v.List()
+		var elem protoreflect.Value
+		if _, ok := list.(*dynamicList); ok {
+			elem = protoreflect.ValueOfMessage(newMessage(f.fd.Message(), in.Allocator))
+		} else {
+			// The list of an extension may hold messages of another type.
+			elem = list.NewElement()
+		}
+		n, err := f.unmarshalMessage(b, elem.Message(), wtyp, in)
+		if err != nil {
+			return v, 0, err
+		}
+		list.Append(elem)
+		return v, n, nil
+	case mapField:
+		if !v.IsValid() {
+			v = protoreflect.ValueOfMap(&dynamicMap{desc: f.fd, mapv: make(map[any]protoreflect.Value)})
+		}
+		n, err := f.unmarshalMapEntry(b, v.Map(), wtyp, in)
+		return v, n, err
+	default:
+		if v.IsValid() && !f.fd.IsList() {
+			v = protoreflect.Value{}
+		}
+		if !v.IsValid() && f.fd.IsList() {
+			v = protoreflect.ValueOfList(&dynamicList{desc: f.fd})
+		}
+		v, n, err := f.value.Unmarshal(b, v, f.num, wtyp, in)
+		if impl.IsUnknown(err) {
+			err = errUnknown
+		}
+		return v, n, err
+	}
+}
+
+// newMessage returns a new message of type md,
+// which is allocated by a if it is not nil.
+func newMessage(md protoreflect.MessageDescriptor, a protoiface.Allocator) *Message {
+	if a == nil {
+		return NewMessage(md)
+	}
+	m := a.New(dynamicMessageType.Elem()).Interface().(*Message)
+	m.typ = messageType{md}
+	m.known = make(map[protoreflect.FieldNumber]protoreflect.Value)
+	m.ext = make(map[protoreflect.FieldNumber]protoreflect.FieldDescriptor)
+	return m
+}
+
+// unmarshalMessage merges the message or group in b, which starts after the
+// tag, into m, and returns the number of bytes consumed.
+func (f *fieldCoder) unmarshalMessage(b []byte, m protoreflect.Message, wtyp protowire.Type, in protoiface.UnmarshalInput) (int, error) {
+	var body []byte
+	var n int
+	switch f.kind {
+	case messageField, messageListField:
+		if wtyp != protowire.BytesType {
+			return 0, errUnknown
+		}
+		body, n = protowire.ConsumeBytes(b)
+	default:
+		if wtyp != protowire.StartGroupType {
+			return 0, errUnknown
+		}
+		body, n = protowire.ConsumeGroup(f.num, b)
+	}
+	if n < 0 {
+		return 0, errDecode
+	}
+	if in.Depth == 0 {
+		// The proto package would treat a zero limit as the default.
+		return 0, errRecursionDepth
+	}
+	_, err := proto.UnmarshalOptions{
+		Merge:          true,
+		AllowPartial:   true,
+		DiscardUnknown: in.Flags&protoiface.UnmarshalDiscardUnknown != 0,
+		Resolver:       in.Resolver,
+		RecursionLimit: in.Depth,
+		Allocator:      in.Allocator,
+	}.UnmarshalState(protoiface.UnmarshalInput{
+		Message: m,
+		Buf:     body,
+	})
+	return n, err
+}
+
+func (f *fieldCoder) unmarshalMapEntry(b []byte, mapv protoreflect.Map, wtyp protowire.Type, in protoiface.UnmarshalInput) (int, error) {
+	if wtyp != protowire.BytesType {
+		return 0, errUnknown
+	}
+	b, n := protowire.ConsumeBytes(b)
+	if n < 0 {
+		return 0, errDecode
+	}
+	var (
+		key = f.key.fd.Default()
+		val protoreflect.Value
+	)
+	if f.val.kind == messageField {
+		val = protoreflect.ValueOfMessage(newMessage(f.val.fd.Message(), in.Allocator))
+	}
+	for len(b) > 0 {
+		num, wtyp, n := protowire.ConsumeTag(b)
+		if n < 0 || num > protowire.MaxValidNumber {
+			return 0, errDecode
+		}
+		b = b[n:]
+		err := errUnknown
+		switch num {
+		case genid.MapEntry_Key_field_number:
+			key, n, err = f.key.unmarshal(b, key, wtyp, in)
+		case genid.MapEntry_Value_field_number:
+			val, n, err = f.val.unmarshal(b, val, wtyp, in)
+		}
+		if err == errUnknown {
+			n = protowire.ConsumeFieldValue(num, wtyp, b)
+			if n < 0 {
+				return 0, errDecode
+			}
+		} else if err != nil {
+			return 0, err
+		}
+		b = b[n:]
+	}
+	if !val.IsValid() {
+		val = f.val.fd.Default()
+	}
+	mapv.Set(key.MapKey(), val)
+	return n, nil
+}
+
+func (mc *messageCoder) merge(in protoiface.MergeInput) protoiface.MergeOutput {
+	dst := in.Destination.(*Message)
+	if dst.known == nil {
+		return protoiface.MergeOutput{}
+	}
+	src, ok := in.Source.(*Message)
+	if !ok || src.typ.desc != dst.typ.desc {
+		return protoiface.MergeOutput{}
+	}
+	atomic.StoreInt32(&dst.sizeCache, 0)
+	for num, v := range src.known {
+		if xd, ok := src.ext[num]; ok {
+			if dst.ext[num] != xd {
+				dst.ext[num] = xd
+				delete(dst.known, num)
+			}
+			dst.known[num] = mergeValue(dst, xd, dst.known[num], v)
+			continue
+		}
+		f := mc.field(num)
+		if f == nil || !isSet(f.fd, v) {
+			continue
+		}
+		dst.clearOtherOneofFields(f.fd)
+		dst.known[num] = mergeValue(dst, f.fd, dst.known[num], v)
+	}
+	if len(src.unknown) > 0 {
+		dst.unknown = append(dst.unknown, src.unknown...)
+	}
+	return protoiface.MergeOutput{Flags: protoiface.MergeComplete}
+}
+
+// mergeValue merges the value src of the field fd of m into dst,
+// which may be invalid, and returns the result.
+func mergeValue(m *Message, fd protoreflect.FieldDescriptor, dst, src protoreflect.Value) protoreflect.Value {
+	if !dst.IsValid() && (fd.IsList() || fd.IsMap() || fd.Message() != nil) {
+		dst = m.NewField(fd)
+	}
+	switch {
+	case fd.IsList():
+		dstList, srcList := dst.List(), src.List()
+		for i := 0; i < srcList.Len(); i++ {
+			dstList.Append(cloneValue(fd, dstList.NewElement(), srcList.Get(i)))
+		}
+	case fd.IsMap():
+		dstMap := dst.Map()
+		src.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			dstMap.Set(k, cloneValue(fd.MapValue(), dstMap.NewValue(), v))
+			return true
+		})
+	case fd.Message() != nil:
+		proto.Merge(dst.Message().Interface(), src.Message().Interface())
+	default:
+		dst = cloneValue(fd, protoreflect.Value{}, src)
+	}
+	return dst
+}
+
+// cloneValue returns a copy of the singular value v of the field fd,
+// which is merged into the new message m if fd is a message field.
+func cloneValue(fd protoreflect.FieldDescriptor, m, v protoreflect.Value) protoreflect.Value {
+	switch {
+	case fd.Message() != nil:
+		proto.Merge(m.Message().Interface(), v.Message().Interface())
+		return m
+	case fd.Kind() == protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(append([]byte{}, v.Bytes()...))
+	default:
+		return v
+	}
+}
+
+func (mc *messageCoder) checkInitialized(in protoiface.CheckInitializedInput) (protoiface.CheckInitializedOutput, error) {
+	m := in.Message.(*Message)
+	if !mc.needsInitCheck && len(m.ext) == 0 {
+		return protoiface.CheckInitializedOutput{}, nil
+	}
+	for _, fd := range mc.required {
+		if v, ok := m.known[fd.Number()]; !ok || !isSet(fd, v) {
+			return protoiface.CheckInitializedOutput{}, errors.RequiredNotSet(string(fd.FullName()))
+		}
+	}
+	for _, f := range mc.fields {
+		if v, ok := m.known[f.num]; ok && m.ext[f.num] == nil {
+			if err := checkValueInitialized(f.fd, v); err != nil {
+				return protoiface.CheckInitializedOutput{}, err
+			}
+		}
+	}
+	for num, xd := range m.ext {
+		if err := checkValueInitialized(xd, m.known[num]); err != nil {
+			return protoiface.CheckInitializedOutput{}, err
+		}
+	}
+	return protoiface.CheckInitializedOutput{}, nil
+}
+
+// checkValueInitialized reports whether the messages in the value v
+// of the field fd have all of their required fields set.
+func checkValueInitialized(fd protoreflect.FieldDescriptor, v protoreflect.Value) error {
+	switch {
+	case fd.IsMap():
+		if fd.MapValue().Message() == nil {
+			return nil
+		}
+		var err error
+		v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+			err = proto.CheckInitialized(v.Message().Interface())
+			return err == nil
+		})
+		return err
+	case fd.Message() == nil:
+		return nil
+	case fd.IsList():
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			if err := proto.CheckInitialized(list.Get(i).Message().Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return proto.CheckInitialized(v.Message().Interface())
+	}
+}
+
+// ReleaseFile drops the tables that this package keeps to marshal and
+// unmarshal the messages and extensions declared in fd.
+//
+// The tables of a message type are built the first time they are needed and
+// are kept for the lifetime of the program, which retains the descriptors.
+// A program that creates new descriptors over time, such as one that
+// reloads its schemas, should call ReleaseFile for each file it no longer
+// uses. Messages of the types declared in fd remain usable afterwards,
+// at the cost of building the tables again.
+func ReleaseFile(fd protoreflect.FileDescriptor) {
+	messageCoders.Range(func(k, _ any) bool {
+		if k.(protoreflect.MessageDescriptor).ParentFile() == fd {
+			messageCoders.Delete(k)
+		}
+		return true
+	})
+	extensionCoders.Range(func(k, _ any) bool {
+		if k.(protoreflect.ExtensionTypeDescriptor).ParentFile() == fd {
+			extensionCoders.Delete(k)
+		}
+		return true
+	})
+}
+
+// methodsOf returns the fast-path methods of the messages of type md,
+// or nil if the messages are coded by the proto package.
+func methodsOf(md protoreflect.MessageDescriptor) *protoiface.Methods {
+	if messageset.IsMessageSet(md) {
+		return nil
+	}
+	return &coderOf(md).methods
+}
@@ -0,0 +1,343 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dynamicpb_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+	test3pb "google.golang.org/protobuf/internal/testprotos/test3"
+)
+
+func populatedMessages() []proto.Message {
+	ext := &testpb.TestAllExtensions{}
+	proto.SetExtension(ext, testpb.E_OptionalInt32, int32(1))
+	proto.SetExtension(ext, testpb.E_OptionalString, "ext")
+	proto.SetExtension(ext, testpb.E_OptionalNestedMessage, &testpb.TestAllExtensions_NestedMessage{A: proto.Int32(2)})
+	proto.SetExtension(ext, testpb.E_RepeatedFixed32, []uint32{3, 4})
+	proto.SetExtension(ext, testpb.E_Optionalgroup, &testpb.OptionalGroup{A: proto.Int32(5)})
+
+	return []proto.Message{
+		&testpb.TestAllTypes{
+			OptionalInt32:         proto.Int32(-1),
+			OptionalSint64:        proto.Int64(-2),
+			OptionalFixed32:       proto.Uint32(3),
+			OptionalDouble:        proto.Float64(4.5),
+			OptionalBool:          proto.Bool(true),
+			OptionalString:        proto.String("string"),
+			OptionalBytes:         []byte("bytes"),
+			Optionalgroup:         &testpb.TestAllTypes_OptionalGroup{A: proto.Int32(6)},
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(7), Corecursive: &testpb.TestAllTypes{OptionalInt32: proto.Int32(8)}},
+			OptionalNestedEnum:    testpb.TestAllTypes_BAR.Enum(),
+			RepeatedInt32:         []int32{1, -2, 3},
+			RepeatedString:        []string{"a", "", "c"},
+			RepeatedBytes:         [][]byte{{}, {1}},
+			Repeatedgroup:         []*testpb.TestAllTypes_RepeatedGroup{{A: proto.Int32(9)}, {}},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{{A: proto.Int32(10)}, {}},
+			RepeatedNestedEnum:    []testpb.TestAllTypes_NestedEnum{testpb.TestAllTypes_FOO, testpb.TestAllTypes_BAZ},
+			MapInt32Int32:         map[int32]int32{1: 2, 3: 4},
+			MapBoolBool:           map[bool]bool{true: false, false: true},
+			MapStringBytes:        map[string][]byte{"a": {}, "b": []byte("b")},
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"a": {A: proto.Int32(11)},
+				"b": {},
+			},
+			MapStringNestedEnum: map[string]testpb.TestAllTypes_NestedEnum{"a": testpb.TestAllTypes_NEG},
+			OneofField:          &testpb.TestAllTypes_OneofNestedMessage{OneofNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(12)}},
+		},
+		&testpb.TestPackedTypes{
+			PackedInt32:  []int32{1, -2, 300},
+			PackedSint64: []int64{-1, 2},
+			PackedFloat:  []float32{1.5},
+			PackedEnum:   []testpb.ForeignEnum{testpb.ForeignEnum_FOREIGN_BAR},
+		},
+		&test3pb.TestAllTypes{
+			SingularInt32:         1,
+			SingularString:        "string",
+			SingularNestedMessage: &test3pb.TestAllTypes_NestedMessage{A: 2},
+			OptionalInt64:         proto.Int64(0),
+			RepeatedDouble:        []float64{1, 2},
+			MapStringString:       map[string]string{"a": "b"},
+			OneofField:            &test3pb.TestAllTypes_OneofUint32{OneofUint32: 0},
+		},
+		ext,
+	}
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	for _, want := range populatedMessages() {
+		t.Run(fmt.Sprintf("%T", want), func(t *testing.T) {
+			opts := proto.MarshalOptions{Deterministic: true}
+			b, err := opts.Marshal(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m := dynamicpb.NewMessage(want.ProtoReflect().Descriptor())
+			if m.ProtoMethods() == nil {
+				t.Fatal("ProtoMethods() = nil, want fast-path methods")
+			}
+			if err := proto.Unmarshal(b, m); err != nil {
+				t.Fatalf("Unmarshal() error: %v", err)
+			}
+			if !proto.Equal(m, want) {
+				t.Errorf("Unmarshal() mismatch:\ngot:  %v\nwant: %v", m, want)
+			}
+			if got, want := proto.Size(m), len(b); got != want {
+				t.Errorf("Size() = %v, want %v", got, want)
+			}
+			got, err := opts.Marshal(m)
+			if err != nil {
+				t.Fatalf("Marshal() error: %v", err)
+			}
+			if !bytes.Equal(got, b) {
+				t.Errorf("Marshal() mismatch:\ngot:  %x\nwant: %x", got, b)
+			}
+
+			merged := dynamicpb.NewMessage(want.ProtoReflect().Descriptor())
+			proto.Merge(merged, m)
+			if !proto.Equal(merged, want) {
+				t.Errorf("Merge() mismatch:\ngot:  %v\nwant: %v", merged, want)
+			}
+		})
+	}
+}
+
+func TestMergeIsDeep(t *testing.T) {
+	src := dynamicpb.NewMessage((&testpb.TestAllTypes{}).ProtoReflect().Descriptor())
+	if err := proto.Unmarshal(mustMarshal(t, &testpb.TestAllTypes{
+		OptionalBytes:         []byte("a"),
+		OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(1)},
+		RepeatedInt32:         []int32{1},
+	}), src); err != nil {
+		t.Fatal(err)
+	}
+	dst := dynamicpb.NewMessage(src.Descriptor())
+	proto.Merge(dst, src)
+	proto.Merge(dst, src)
+
+	// Modifying the source does not modify the destination.
+	fields := src.Descriptor().Fields()
+	src.Get(fields.ByName("optional_bytes")).Bytes()[0] = 'b'
+	nested := src.Get(fields.ByName("optional_nested_message")).Message()
+	nested.Set(nested.Descriptor().Fields().ByName("a"), protoreflect.ValueOfInt32(2))
+
+	want := &testpb.TestAllTypes{
+		OptionalBytes:         []byte("a"),
+		OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{A: proto.Int32(1)},
+		RepeatedInt32:         []int32{1, 1},
+	}
+	if !proto.Equal(dst, want) {
+		t.Errorf("Merge() mismatch:\ngot:  %v\nwant: %v", dst, want)
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	md := (&test3pb.TestAllTypes{}).ProtoReflect().Descriptor()
+	b := protowire.AppendTag(nil, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, 1)
+	unknown := protowire.AppendTag(nil, 1000, protowire.BytesType)
+	unknown = protowire.AppendString(unknown, "unknown")
+	// A field with the wrong wire type is unknown.
+	unknown = protowire.AppendTag(unknown, 1, protowire.Fixed32Type)
+	unknown = protowire.AppendFixed32(unknown, 2)
+	b = append(b, unknown...)
+
+	m := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal(b, m); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if got := m.GetUnknown(); !bytes.Equal(got, unknown) {
+		t.Errorf("GetUnknown() = %x, want %x", got, unknown)
+	}
+	if got := m.Get(md.Fields().ByNumber(1)).Int(); got != 1 {
+		t.Errorf("field 1 = %v, want 1", got)
+	}
+	if got, err := proto.Marshal(m); err != nil || !bytes.Equal(got, b) {
+		t.Errorf("Marshal() = %x, %v, want %x", got, err, b)
+	}
+
+	m = dynamicpb.NewMessage(md)
+	if err := (proto.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(b, m); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if got := m.GetUnknown(); len(got) > 0 {
+		t.Errorf("GetUnknown() = %x, want none with DiscardUnknown", got)
+	}
+}
+
+func TestRequired(t *testing.T) {
+	md := (&testpb.TestRequiredForeign{}).ProtoReflect().Descriptor()
+	for _, partial := range []proto.Message{
+		&testpb.TestRequiredForeign{OptionalMessage: &testpb.TestRequired{}},
+		&testpb.TestRequiredForeign{RepeatedMessage: []*testpb.TestRequired{{RequiredField: proto.Int32(1)}, {}}},
+		&testpb.TestRequiredForeign{MapMessage: map[int32]*testpb.TestRequired{1: {}}},
+		&testpb.TestRequiredForeign{OneofField: &testpb.TestRequiredForeign_OneofMessage{OneofMessage: &testpb.TestRequired{}}},
+	} {
+		b, err := proto.MarshalOptions{AllowPartial: true}.Marshal(partial)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := dynamicpb.NewMessage(md)
+		if err := proto.Unmarshal(b, m); err == nil || !strings.Contains(err.Error(), "required field goproto.proto.test.TestRequired.required_field not set") {
+			t.Errorf("Unmarshal(%v) error = %v, want required field not set", partial, err)
+		}
+		if err := (proto.UnmarshalOptions{AllowPartial: true}).Unmarshal(b, m); err != nil {
+			t.Errorf("Unmarshal(%v) with AllowPartial error: %v", partial, err)
+		}
+		if _, err := proto.Marshal(m); err == nil {
+			t.Errorf("Marshal(%v) succeeded, want required field not set", partial)
+		}
+	}
+}
+
+func TestRecursionLimit(t *testing.T) {
+	m := &testpb.TestAllTypes{}
+	for i := 0; i < 10; i++ {
+		m = &testpb.TestAllTypes{OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{Corecursive: m}}
+	}
+	b := mustMarshal(t, m)
+	md := m.ProtoReflect().Descriptor()
+	if err := (proto.UnmarshalOptions{RecursionLimit: 20}).Unmarshal(b, dynamicpb.NewMessage(md)); err == nil {
+		t.Errorf("Unmarshal() with RecursionLimit 20 succeeded, want error")
+	}
+	if err := (proto.UnmarshalOptions{RecursionLimit: 21}).Unmarshal(b, dynamicpb.NewMessage(md)); err != nil {
+		t.Errorf("Unmarshal() with RecursionLimit 21 error: %v", err)
+	}
+}
+
+func TestInvalidUTF8(t *testing.T) {
+	md := (&test3pb.TestAllTypes{}).ProtoReflect().Descriptor()
+	for _, num := range []protowire.Number{
+		md.Fields().ByName("singular_string").Number(),
+		md.Fields().ByName("repeated_string").Number(),
+	} {
+		b := protowire.AppendTag(nil, num, protowire.BytesType)
+		b = protowire.AppendString(b, "\xff")
+		if err := proto.Unmarshal(b, dynamicpb.NewMessage(md)); err == nil {
+			t.Errorf("Unmarshal() of field %v with invalid UTF-8 succeeded, want error", num)
+		}
+	}
+}
+
+func mustMarshal(t *testing.T, m proto.Message) []byte {
+	t.Helper()
+	b, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestReleaseFile(t *testing.T) {
+	fd, err := protodesc.NewFile(protodesc.ToFileDescriptorProto(test3pb.File_internal_testprotos_test3_test_proto), protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := fd.Messages().ByName("TestAllTypes")
+	fd1 := md.Fields().ByNumber(1)
+	m := dynamicpb.NewMessage(md)
+	b := protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), 5)
+	if err := proto.Unmarshal(b, m); err != nil {
+		t.Fatal(err)
+	}
+
+	// Messages of a released file remain usable; the tables are rebuilt.
+	dynamicpb.ReleaseFile(fd)
+	if got := m.Get(fd1).Int(); got != 5 {
+		t.Errorf("field 1 = %v, want 5", got)
+	}
+	got, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal() after ReleaseFile error: %v", err)
+	}
+	if !bytes.Equal(got, b) {
+		t.Errorf("Marshal() after ReleaseFile = %x, want %x", got, b)
+	}
+	m2 := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal(b, m2); err != nil {
+		t.Fatalf("Unmarshal() after ReleaseFile error: %v", err)
+	}
+	if !proto.Equal(m, m2) {
+		t.Errorf("Unmarshal() after ReleaseFile = %v, want %v", m2, m)
+	}
+	if dynamicpb.NewMessageType(md) != dynamicpb.NewMessageType(md) {
+		t.Errorf("NewMessageType(md) != NewMessageType(md) after ReleaseFile")
+	}
+}
+
+// reflectMessage hides the fast-path methods of a dynamic message,
+// forcing the proto package to use the reflection-based implementation.
+type reflectMessage struct{ *dynamicpb.Message }
+
+func (m reflectMessage) ProtoReflect() protoreflect.Message   { return m }
+func (m reflectMessage) Interface() protoreflect.ProtoMessage { return m }
+func (m reflectMessage) ProtoMethods() *protoiface.Methods    { return nil }
+
+func benchmarkMessages(b *testing.B) (wire []byte, msgs map[string]func() proto.Message) {
+	src := &test3pb.TestAllTypes{
+		SingularInt32:   1,
+		SingularInt64:   2,
+		SingularFixed32: 3,
+		SingularDouble:  4,
+		SingularBool:    true,
+		SingularString:  "string",
+		SingularBytes:   []byte("bytes"),
+		RepeatedInt32:   []int32{1, 2, 3, 4, 5, 6, 7, 8},
+		RepeatedString:  []string{"a", "b", "c", "d"},
+	}
+	wire, err := proto.Marshal(src)
+	if err != nil {
+		b.Fatal(err)
+	}
+	md := src.ProtoReflect().Descriptor()
+	return wire, map[string]func() proto.Message{
+		"fast":    func() proto.Message { return dynamicpb.NewMessage(md) },
+		"reflect": func() proto.Message { return reflectMessage{dynamicpb.NewMessage(md)} },
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	wire, msgs := benchmarkMessages(b)
+	for _, name := range []string{"fast", "reflect"} {
+		m := msgs[name]()
+		if err := proto.Unmarshal(wire, m); err != nil {
+			b.Fatal(err)
+		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := proto.Marshal(m); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	wire, msgs := benchmarkMessages(b)
+	for _, name := range []string{"fast", "reflect"} {
+		newMessage := msgs[name]
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := proto.Unmarshal(wire, newMessage()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -68,6 +68,10 @@ type Message struct {
 	known   map[protoreflect.FieldNumber]protoreflect.Value
 	ext     map[protoreflect.FieldNumber]protoreflect.FieldDescriptor
 	unknown protoreflect.RawFields
+
+	// sizeCache is the size of the message plus one, or zero if the size
+	// has not been computed. It is only used by the fast-path methods.
+	sizeCache int32
 }
 
 var (
@@ -130,7 +134,7 @@ func (m *Message) Interface() protoreflect.ProtoMessage {
 // ProtoMethods is an internal detail of the [protoreflect.Message] interface.
 // Users should never call this directly.
 func (m *Message) ProtoMethods() *protoiface.Methods {
-	return nil
+	return methodsOf(m.typ.desc)
 }
 
 // Range visits every populated field in undefined order.
@@ -363,7 +367,12 @@ type messageType struct {
 //
 // MessageTypes created by this package are equal if their descriptors are equal.
 // That is, if md1 == md2, then NewMessageType(md1) == NewMessageType(md2).
+//
+// The tables used to marshal and unmarshal messages of the type
+// are built from the descriptor when the type is created,
+// and are kept until ReleaseFile is called with the file declaring it.
 func NewMessageType(desc protoreflect.MessageDescriptor) protoreflect.MessageType {
+	methodsOf(desc)
 	return messageType{desc}
 }
 
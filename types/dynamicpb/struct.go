@@ -0,0 +1,222 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dynamicpb
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"google.golang.org/protobuf/internal/encoding/tag"
+	"google.golang.org/protobuf/internal/genid"
+	"google.golang.org/protobuf/internal/impl"
+	"google.golang.org/protobuf/internal/strs"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NewStructMessageType creates a new MessageType with the provided descriptor,
+// whose messages are stored in Go structs rather than in a Message.
+//
+// The struct types are created with reflect.StructOf and are laid out like
+// the structs generated by protoc-gen-go, so that their messages are
+// accessed and serialized in the same way as generated messages.
+// Since the struct types are not named and have no methods, the messages
+// are only accessible through the [protoreflect.Message] interface,
+// and their Interface method returns a wrapper of the struct.
+//
+// Go struct types cannot refer to themselves, so message fields of types
+// which refer back to a message type that is being created are stored
+// as a *Message instead.
+//
+// MessageTypes created by this function are equal if their descriptors are
+// equal. That is, if md1 == md2, then
+// NewStructMessageType(md1) == NewStructMessageType(md2).
+func NewStructMessageType(desc protoreflect.MessageDescriptor) protoreflect.MessageType {
+	registerOnce.Do(func() {
+		impl.RegisterMessageTypeFunc(dynamicMessageType, NewMessageType)
+	})
+	structTypes.Lock()
+	defer structTypes.Unlock()
+	return structTypes.load(desc)
+}
+
+var (
+	structTypes = &structTypeCache{
+		types:    make(map[protoreflect.MessageDescriptor]*impl.MessageInfo),
+		building: make(map[protoreflect.MessageDescriptor]bool),
+	}
+
+	registerOnce sync.Once
+
+	anyType            = reflect.TypeOf((*any)(nil)).Elem()
+	dynamicMessageType = reflect.TypeOf((*Message)(nil))
+)
+
+// structTypeCache is a cache of the struct types of messages.
+type structTypeCache struct {
+	sync.Mutex
+	types    map[protoreflect.MessageDescriptor]*impl.MessageInfo
+	building map[protoreflect.MessageDescriptor]bool
+	count    int
+}
+
+// load returns the type of the messages of md, which is created if needed,
+// or nil if md is being created.
+func (c *structTypeCache) load(md protoreflect.MessageDescriptor) *impl.MessageInfo {
+	if mi, ok := c.types[md]; ok {
+		return mi
+	}
+	if c.building[md] {
+		return nil
+	}
+	c.building[md] = true
+	defer delete(c.building, md)
+
+	b := structBuilder{
+		cache:      c,
+		names:      make(map[string]bool),
+		fieldTypes: make(map[protoreflect.FieldNumber]any),
+	}
+
+	// Struct types with the same fields are identical, so every struct type
+	// has a distinct tag on a field of zero size.
+	c.count++
+	b.addField(reflect.StructField{
+		Name: "XXX_NoUnkeyedLiteral",
+		Type: reflect.TypeOf(struct{}{}),
+		Tag:  reflect.StructTag(fmt.Sprintf(`protobuf_message:"%v,%d"`, md.FullName(), c.count)),
+	})
+	b.addField(reflect.StructField{
+		Name: genid.SizeCacheA_goname,
+		Type: reflect.TypeOf(impl.SizeCache(0)),
+	})
+	b.addField(reflect.StructField{
+		Name: genid.UnknownFieldsA_goname,
+		Type: reflect.TypeOf(impl.UnknownFields(nil)),
+	})
+	if md.ExtensionRanges().Len() > 0 {
+		b.addField(reflect.StructField{
+			Name: genid.ExtensionFieldsA_goname,
+			Type: reflect.TypeOf(impl.ExtensionFields(nil)),
+		})
+	}
+
+	fds := md.Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		od := fd.ContainingOneof()
+		if od == nil || od.IsSynthetic() {
+			b.addField(reflect.StructField{
+				Name: strs.GoCamelCase(string(fd.Name())),
+				Type: b.goType(fd),
+				Tag:  b.tag(fd),
+			})
+			continue
+		}
+		if od.Fields().Get(0) != fd {
+			continue
+		}
+		// A oneof is stored in an interface field, whose value is a pointer
+		// to a wrapper struct of the field that is set.
+		b.addField(reflect.StructField{
+			Name: strs.GoCamelCase(string(od.Name())),
+			Type: anyType,
+			Tag:  reflect.StructTag(fmt.Sprintf(`protobuf_oneof:"%v"`, od.Name())),
+		})
+		for j := 0; j < od.Fields().Len(); j++ {
+			fd := od.Fields().Get(j)
+			wrapper := reflect.StructOf([]reflect.StructField{{
+				Name: strs.GoCamelCase(string(fd.Name())),
+				Type: b.goType(fd),
+				Tag:  b.tag(fd),
+			}})
+			b.oneofWrappers = append(b.oneofWrappers, reflect.New(wrapper).Interface())
+		}
+	}
+
+	t := reflect.PtrTo(reflect.StructOf(b.fields))
+	mi := impl.NewRuntimeMessageInfo(t, md, b.oneofWrappers, b.fieldTypes)
+	c.types[md] = mi
+	return mi
+}
+
+// structBuilder builds the struct type of a message.
+type structBuilder struct {
+	cache         *structTypeCache
+	fields        []reflect.StructField
+	names         map[string]bool
+	oneofWrappers []any
+	fieldTypes    map[protoreflect.FieldNumber]any
+}
+
+// addField adds f to the struct, renaming it if its name is already used.
+func (b *structBuilder) addField(f reflect.StructField) {
+	for b.names[f.Name] {
+		f.Name += "_"
+	}
+	b.names[f.Name] = true
+	b.fields = append(b.fields, f)
+}
+
+func (b *structBuilder) tag(fd protoreflect.FieldDescriptor) reflect.StructTag {
+	var enumName string
+	if fd.Enum() != nil {
+		enumName = string(fd.Enum().FullName())
+	}
+	return reflect.StructTag(fmt.Sprintf(`protobuf:%q`, tag.Marshal(fd, enumName)))
+}
+
+// goType returns the Go type of the field fd, which is the type
+// of the field in a oneof wrapper if fd is in a oneof.
+func (b *structBuilder) goType(fd protoreflect.FieldDescriptor) reflect.Type {
+	switch {
+	case fd.IsMap():
+		return reflect.MapOf(b.singularGoType(fd.Number(), fd.MapKey()), b.singularGoType(fd.Number(), fd.MapValue()))
+	case fd.IsList():
+		return reflect.SliceOf(b.singularGoType(fd.Number(), fd))
+	}
+	t := b.singularGoType(fd.Number(), fd)
+	od := fd.ContainingOneof()
+	if fd.HasPresence() && (od == nil || od.IsSynthetic()) && t.Kind() != reflect.Ptr && t.Kind() != reflect.Slice {
+		t = reflect.PtrTo(t)
+	}
+	return t
+}
+
+// singularGoType returns the Go type of a single value of fd,
+// which is a value of the field numbered num.
+func (b *structBuilder) singularGoType(num protoreflect.FieldNumber, fd protoreflect.FieldDescriptor) reflect.Type {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return reflect.TypeOf(false)
+	case protoreflect.EnumKind:
+		b.fieldTypes[num] = NewEnumType(fd.Enum()).New(0)
+		return reflect.TypeOf(int32(0))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return reflect.TypeOf(int32(0))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return reflect.TypeOf(uint32(0))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return reflect.TypeOf(int64(0))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return reflect.TypeOf(uint64(0))
+	case protoreflect.FloatKind:
+		return reflect.TypeOf(float32(0))
+	case protoreflect.DoubleKind:
+		return reflect.TypeOf(float64(0))
+	case protoreflect.StringKind:
+		return reflect.TypeOf("")
+	case protoreflect.BytesKind:
+		return reflect.TypeOf([]byte(nil))
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if mi := b.cache.load(fd.Message()); mi != nil {
+			b.fieldTypes[num] = mi.Zero().Interface()
+			return mi.GoReflectType
+		}
+		b.fieldTypes[num] = NewMessageType(fd.Message()).Zero().Interface()
+		return dynamicMessageType
+	}
+	panic(fmt.Sprintf("%v: invalid kind %v", fd.FullName(), fd.Kind()))
+}
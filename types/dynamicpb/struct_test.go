@@ -0,0 +1,101 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dynamicpb_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/prototest"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	testpb "google.golang.org/protobuf/internal/testprotos/test"
+	test3pb "google.golang.org/protobuf/internal/testprotos/test3"
+)
+
+func TestStructConformance(t *testing.T) {
+	for _, message := range []proto.Message{
+		(*testpb.TestAllTypes)(nil),
+		(*test3pb.TestAllTypes)(nil),
+		(*testpb.TestAllExtensions)(nil),
+		(*testpb.TestRequiredForeign)(nil),
+	} {
+		t.Run(fmt.Sprintf("%T", message), func(t *testing.T) {
+			mt := dynamicpb.NewStructMessageType(message.ProtoReflect().Descriptor())
+			prototest.Message{}.Test(t, mt)
+		})
+	}
+}
+
+func TestStructMarshalUnmarshal(t *testing.T) {
+	for _, want := range populatedMessages() {
+		t.Run(fmt.Sprintf("%T", want), func(t *testing.T) {
+			opts := proto.MarshalOptions{Deterministic: true}
+			b, err := opts.Marshal(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mt := dynamicpb.NewStructMessageType(want.ProtoReflect().Descriptor())
+			m := mt.New().Interface()
+			if err := proto.Unmarshal(b, m); err != nil {
+				t.Fatalf("Unmarshal() error: %v", err)
+			}
+			if !proto.Equal(m, want) {
+				t.Errorf("Unmarshal() mismatch:\ngot:  %v\nwant: %v", m, want)
+			}
+			got, err := opts.Marshal(m)
+			if err != nil {
+				t.Fatalf("Marshal() error: %v", err)
+			}
+			if !bytes.Equal(got, b) {
+				t.Errorf("Marshal() mismatch:\ngot:  %x\nwant: %x", got, b)
+			}
+		})
+	}
+}
+
+func TestStructTypes(t *testing.T) {
+	md := (&testpb.TestAllTypes{}).ProtoReflect().Descriptor()
+	mt := dynamicpb.NewStructMessageType(md)
+	if got := dynamicpb.NewStructMessageType(md); got != mt {
+		t.Errorf("NewStructMessageType() returned different types for the same descriptor")
+	}
+
+	fields := md.Fields()
+	for _, tt := range []struct {
+		field protoreflect.Name
+		want  protoreflect.FullName
+	}{
+		{"optional_nested_enum", "goproto.proto.test.TestAllTypes.NestedEnum"},
+		{"repeated_foreign_enum", "goproto.proto.test.ForeignEnum"},
+		{"map_string_nested_enum", "goproto.proto.test.TestAllTypes.NestedEnum"},
+	} {
+		fd := fields.ByName(tt.field)
+		ft := mt.(protoreflect.MessageFieldTypes)
+		var got protoreflect.FullName
+		if fd.IsMap() {
+			got = ft.Message(fd.Index()).(protoreflect.MessageFieldTypes).Enum(1).Descriptor().FullName()
+		} else {
+			got = ft.Enum(fd.Index()).Descriptor().FullName()
+		}
+		if got != tt.want {
+			t.Errorf("enum type of %v = %v, want %v", tt.field, got, tt.want)
+		}
+	}
+
+	// TestAllTypes.NestedMessage refers back to TestAllTypes,
+	// so its field is stored as a dynamic message.
+	nested := mt.New().NewField(fields.ByName("optional_nested_message")).Message()
+	corecursive := nested.NewField(nested.Descriptor().Fields().ByName("corecursive")).Message()
+	if _, ok := corecursive.Interface().(*dynamicpb.Message); !ok {
+		t.Errorf("recursive field has type %T, want *dynamicpb.Message", corecursive.Interface())
+	}
+	if got, want := corecursive.Descriptor(), md; got != want {
+		t.Errorf("recursive field has descriptor %v, want %v", got.FullName(), want.FullName())
+	}
+}
@@ -73,9 +73,22 @@
 // IsValid needs to be passed the target message type as an input since the
 // FieldMask message itself does not store the message type that the set of paths
 // are for.
+//
+// # Applying a FieldMask
+//
+// The Filter and Prune methods respectively keep or clear the fields of a
+// message that are selected by a FieldMask, while the Merge method updates
+// the selected fields of a message from another message:
+//
+//	var dst, src *descriptorpb.DescriptorProto
+//	fm.Merge(dst, src) // updates the name and number of every field and the options
+//
+// The Subtract function computes the paths of a FieldMask
+// that are not selected by other field masks.
 package fieldmaskpb
 
 import (
+	fieldmask "google.golang.org/protobuf/internal/fieldmask"
 	proto "google.golang.org/protobuf/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -465,6 +478,325 @@ func rangeFields(path string, f func(field string) bool) bool {
 	}
 }
 
+// Filter clears all fields of m that are not selected by the mask.
+// A path that traverses a repeated message field applies to every element
+// of the list and a path that traverses a map field applies to the entry
+// with the given key, which is formatted as in the text format and quoted
+// in backticks if it contains a dot or if it is the wildcard itself.
+// An extension field is named by its full name in parentheses.
+// The wildcard "*" selects every field or map entry at its position,
+// including those that other paths also traverse.
+// Unknown fields are cleared unless the mask contains the wildcard path.
+func (x *FieldMask) Filter(m proto.Message) {
+	filterMessage(m.ProtoReflect(), fieldmask.Parse(x.GetPaths()))
+}
+
+func filterMessage(m protoreflect.Message, t *fieldmask.Mask) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch sub, ok := t.Field(fd); {
+		case !ok:
+			m.Clear(fd)
+		case sub != nil:
+			filterValue(fd, v, sub)
+		}
+		return true
+	})
+	if sub, ok := t.Get(fieldmask.Name{Text: fieldmask.Wildcard, IsWildcard: true}); (!ok || sub != nil) && len(m.GetUnknown()) > 0 {
+		m.SetUnknown(nil)
+	}
+}
+
+func filterValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, t *fieldmask.Mask) {
+	switch {
+	case fd.IsMap():
+		mm := v.Map()
+		mm.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			switch sub, ok := t.MapKey(k); {
+			case !ok:
+				mm.Clear(k)
+			case sub != nil && fd.MapValue().Message() != nil:
+				filterMessage(v.Message(), sub)
+			}
+			return true
+		})
+	case fd.IsList():
+		if fd.Message() != nil {
+			l := v.List()
+			for i := 0; i < l.Len(); i++ {
+				filterMessage(l.Get(i).Message(), t)
+			}
+		}
+	case fd.Message() != nil:
+		filterMessage(v.Message(), t)
+	}
+}
+
+// Prune clears all fields of m that are selected by the mask.
+// It is the complement of Filter and interprets the paths in the same way.
+func (x *FieldMask) Prune(m proto.Message) {
+	pruneMessage(m.ProtoReflect(), fieldmask.Parse(x.GetPaths()))
+}
+
+func pruneMessage(m protoreflect.Message, t *fieldmask.Mask) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch sub, ok := t.Field(fd); {
+		case ok && sub == nil:
+			m.Clear(fd)
+		case ok:
+			pruneValue(fd, v, sub)
+		}
+		return true
+	})
+}
+
+func pruneValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, t *fieldmask.Mask) {
+	switch {
+	case fd.IsMap():
+		mm := v.Map()
+		mm.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			switch sub, ok := t.MapKey(k); {
+			case ok && sub == nil:
+				mm.Clear(k)
+			case ok && fd.MapValue().Message() != nil:
+				pruneMessage(v.Message(), sub)
+			}
+			return true
+		})
+	case fd.IsList():
+		if fd.Message() != nil {
+			l := v.List()
+			for i := 0; i < l.Len(); i++ {
+				pruneMessage(l.Get(i).Message(), t)
+			}
+		}
+	case fd.Message() != nil:
+		pruneMessage(v.Message(), t)
+	}
+}
+
+// Merge merges the fields of src that are selected by the mask into dst
+// following the update semantics of https://google.aip.dev/134.
+//
+// Every selected field in dst is replaced by a copy of its value in src,
+// such that a selected field that is not populated in src is cleared in dst.
+// In particular, repeated and map fields are replaced as a whole
+// rather than appended to or merged.
+// A path that traverses a singular message field updates only the selected
+// fields of the message in dst.
+// A path that traverses a map field replaces or updates the entry with the
+// given key, which is deleted from dst if it is not present in src.
+// A path that traverses a repeated message field resizes the list in dst to
+// the length of the list in src and updates the selected fields of every
+// element. The wildcard path "*" replaces every field of dst.
+//
+// It panics if dst and src do not have the same message descriptor.
+func (x *FieldMask) Merge(dst, src proto.Message) {
+	dstMsg, srcMsg := dst.ProtoReflect(), src.ProtoReflect()
+	if dstMsg.Descriptor().FullName() != srcMsg.Descriptor().FullName() {
+		panic("descriptor mismatch")
+	}
+	mergeMessage(dstMsg, srcMsg, fieldmask.Parse(x.GetPaths()))
+}
+
+func mergeMessage(dst, src protoreflect.Message, t *fieldmask.Mask) {
+	fds := dst.Descriptor().Fields()
+	for i := 0; i < fds.Len(); i++ {
+		mergeField(dst, src, fds.Get(i), t)
+	}
+
+	// Extension fields are not declared by the message descriptor,
+	// so merge those that are populated in either message.
+	xds := make(map[protoreflect.FieldNumber]protoreflect.FieldDescriptor)
+	addExtension := func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if fd.IsExtension() {
+			xds[fd.Number()] = fd
+		}
+		return true
+	}
+	dst.Range(addExtension)
+	src.Range(addExtension)
+	for _, xd := range xds {
+		mergeField(dst, src, xd, t)
+	}
+}
+
+func mergeField(dst, src protoreflect.Message, fd protoreflect.FieldDescriptor, t *fieldmask.Mask) {
+	sub, ok := t.Field(fd)
+	switch {
+	case !ok:
+	case sub == nil || (!fd.IsMap() && fd.Message() == nil):
+		replaceField(dst, src, fd)
+	case fd.IsMap():
+		if !dst.Has(fd) && !src.Has(fd) {
+			return
+		}
+		dm, sm := dst.Mutable(fd).Map(), src.Get(fd).Map()
+		isMessage := fd.MapValue().Message() != nil
+		dm.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			switch sub, ok := sub.MapKey(k); {
+			case !ok || sm.Has(k):
+			case sub == nil || !isMessage:
+				dm.Clear(k)
+			default:
+				mergeMessage(dm.Mutable(k).Message(), dm.NewValue().Message(), sub)
+			}
+			return true
+		})
+		sm.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			switch sub, ok := sub.MapKey(k); {
+			case !ok:
+			case sub == nil || !isMessage:
+				dm.Set(k, cloneValue(dm.NewValue, fd.MapValue(), v))
+			default:
+				mergeMessage(dm.Mutable(k).Message(), v.Message(), sub)
+			}
+			return true
+		})
+		if dm.Len() == 0 {
+			dst.Clear(fd)
+		}
+	case fd.IsList():
+		sl := src.Get(fd).List()
+		if sl.Len() == 0 {
+			dst.Clear(fd)
+			return
+		}
+		dl := dst.Mutable(fd).List()
+		if dl.Len() > sl.Len() {
+			dl.Truncate(sl.Len())
+		}
+		for dl.Len() < sl.Len() {
+			dl.AppendMutable()
+		}
+		for i := 0; i < sl.Len(); i++ {
+			mergeMessage(dl.Get(i).Message(), sl.Get(i).Message(), sub)
+		}
+	case dst.Has(fd) || src.Has(fd):
+		mergeMessage(dst.Mutable(fd).Message(), src.Get(fd).Message(), sub)
+	}
+}
+
+// replaceField replaces the value of the field fd in dst with a copy of
+// its value in src.
+func replaceField(dst, src protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	dst.Clear(fd)
+	if !src.Has(fd) {
+		return
+	}
+	switch v := src.Get(fd); {
+	case fd.IsMap():
+		dm := dst.Mutable(fd).Map()
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			dm.Set(k, cloneValue(dm.NewValue, fd.MapValue(), v))
+			return true
+		})
+	case fd.IsList():
+		dl, sl := dst.Mutable(fd).List(), v.List()
+		for i := 0; i < sl.Len(); i++ {
+			dl.Append(cloneValue(dl.NewElement, fd, sl.Get(i)))
+		}
+	default:
+		dst.Set(fd, cloneValue(func() protoreflect.Value { return dst.NewField(fd) }, fd, v))
+	}
+}
+
+// cloneValue returns a copy of the value v of the field fd, where newValue
+// returns an empty value to copy a message into.
+func cloneValue(newValue func() protoreflect.Value, fd protoreflect.FieldDescriptor, v protoreflect.Value) protoreflect.Value {
+	switch {
+	case fd.Message() != nil:
+		nv := newValue()
+		proto.Merge(nv.Message().Interface(), v.Message().Interface())
+		return nv
+	case fd.Kind() == protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(append([]byte{}, v.Bytes()...))
+	default:
+		return v
+	}
+}
+
+// Subtract returns the paths of mx that are not selected by any of the other
+// input field masks. A path of mx that is only partially subtracted is kept
+// as is; use SubtractOptions to expand it into the remaining fields.
+func Subtract(mx *FieldMask, my *FieldMask, ms ...*FieldMask) *FieldMask {
+	return SubtractOptions{}.Subtract(mx, my, ms...)
+}
+
+// SubtractOptions configures the subtraction of field masks.
+type SubtractOptions struct {
+	// Message is a message of the type the field masks are for.
+	// If set, a path of mx that is only partially subtracted is expanded
+	// into the fields of its message that remain, provided that the path
+	// refers to a singular message field of Message.
+	Message proto.Message
+}
+
+// Subtract returns the paths of mx that are not selected by any of the other
+// input field masks.
+func (o SubtractOptions) Subtract(mx *FieldMask, my *FieldMask, ms ...*FieldMask) *FieldMask {
+	var paths []string
+	paths = append(paths, my.GetPaths()...)
+	for _, m := range ms {
+		paths = append(paths, m.GetPaths()...)
+	}
+	t := fieldmask.Parse(paths)
+
+	var md0 protoreflect.MessageDescriptor
+	if o.Message != nil {
+		md0 = o.Message.ProtoReflect().Descriptor()
+	}
+	var out []string
+	for _, path := range mx.GetPaths() {
+		md, st, keep := md0, t, false
+		for _, name := range fieldmask.SplitPath(path) {
+			var fd protoreflect.FieldDescriptor
+			if md != nil && !name.IsWildcard {
+				fd = md.Fields().ByTextName(name.Text)
+			}
+			md = nil
+			if fd != nil && !fd.IsList() && !fd.IsMap() {
+				md = fd.Message()
+			}
+			var ok bool
+			st, ok = st.Get(name)
+			if keep = !ok; keep || st == nil {
+				break
+			}
+		}
+		switch {
+		case keep:
+			out = append(out, path)
+		case st == nil:
+			// The path is entirely subtracted.
+		case md == nil:
+			out = append(out, path)
+		default:
+			out = subtractFields(out, path, md, st)
+		}
+	}
+	return &FieldMask{Paths: normalizePaths(out)}
+}
+
+// subtractFields appends the paths of the fields of md that are not entirely
+// selected by t, where prefix is the path of the message.
+func subtractFields(out []string, prefix string, md protoreflect.MessageDescriptor, t *fieldmask.Mask) []string {
+	fds := md.Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		path := prefix + "." + fd.TextName()
+		switch sub, ok := t.Field(fd); {
+		case !ok:
+			out = append(out, path)
+		case sub == nil:
+		case fd.IsList() || fd.IsMap() || fd.Message() == nil:
+			out = append(out, path)
+		default:
+			out = subtractFields(out, path, fd.Message(), sub)
+		}
+	}
+	return out
+}
+
 func (x *FieldMask) Reset() {
 	*x = FieldMask{}
 	if protoimpl.UnsafeEnabled {
@@ -10,6 +10,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/testing/protopack"
+	"google.golang.org/protobuf/types/dynamicpb"
 
 	testpb "google.golang.org/protobuf/internal/testprotos/test"
 	fmpb "google.golang.org/protobuf/types/known/fieldmaskpb"
@@ -336,3 +339,530 @@ func TestIsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterPrune(t *testing.T) {
+	newMessage := func() *testpb.TestAllTypes {
+		return &testpb.TestAllTypes{
+			OptionalInt32:  proto.Int32(1),
+			OptionalString: proto.String("a"),
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A: proto.Int32(2),
+				Corecursive: &testpb.TestAllTypes{
+					OptionalInt64: proto.Int64(3),
+				},
+			},
+			Optionalgroup: &testpb.TestAllTypes_OptionalGroup{
+				A: proto.Int32(4),
+			},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(5), Corecursive: &testpb.TestAllTypes{}},
+				{A: proto.Int32(6)},
+			},
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"x": {A: proto.Int32(7), Corecursive: &testpb.TestAllTypes{}},
+				"y": {A: proto.Int32(8)},
+			},
+			MapInt32Int32: map[int32]int32{1: 1, 2: 2},
+			OneofField:    &testpb.TestAllTypes_OneofUint32{OneofUint32: 9},
+		}
+	}
+
+	tests := []struct {
+		desc       string
+		paths      []string
+		wantFilter *testpb.TestAllTypes
+		wantPrune  *testpb.TestAllTypes
+	}{{
+		desc:       "empty mask",
+		paths:      nil,
+		wantFilter: &testpb.TestAllTypes{},
+		wantPrune:  newMessage(),
+	}, {
+		desc:       "wildcard",
+		paths:      []string{"*"},
+		wantFilter: newMessage(),
+		wantPrune:  &testpb.TestAllTypes{},
+	}, {
+		desc:  "top-level fields",
+		paths: []string{"optional_int32", "OptionalGroup", "oneof_uint32", "map_int32_int32", "optional_bool"},
+		wantFilter: &testpb.TestAllTypes{
+			OptionalInt32: proto.Int32(1),
+			Optionalgroup: &testpb.TestAllTypes_OptionalGroup{
+				A: proto.Int32(4),
+			},
+			MapInt32Int32: map[int32]int32{1: 1, 2: 2},
+			OneofField:    &testpb.TestAllTypes_OneofUint32{OneofUint32: 9},
+		},
+		wantPrune: func() *testpb.TestAllTypes {
+			m := newMessage()
+			m.OptionalInt32 = nil
+			m.Optionalgroup = nil
+			m.MapInt32Int32 = nil
+			m.OneofField = nil
+			return m
+		}(),
+	}, {
+		desc:  "nested message fields",
+		paths: []string{"optional_nested_message.corecursive.optional_int64", "OptionalGroup.a"},
+		wantFilter: &testpb.TestAllTypes{
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				Corecursive: &testpb.TestAllTypes{
+					OptionalInt64: proto.Int64(3),
+				},
+			},
+			Optionalgroup: &testpb.TestAllTypes_OptionalGroup{
+				A: proto.Int32(4),
+			},
+		},
+		wantPrune: func() *testpb.TestAllTypes {
+			m := newMessage()
+			m.OptionalNestedMessage.Corecursive.OptionalInt64 = nil
+			m.Optionalgroup.A = nil
+			return m
+		}(),
+	}, {
+		desc:  "repeated and map fields",
+		paths: []string{"repeated_nested_message.a", "map_string_nested_message.x.corecursive", "map_int32_int32.2"},
+		wantFilter: &testpb.TestAllTypes{
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(5)},
+				{A: proto.Int32(6)},
+			},
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"x": {Corecursive: &testpb.TestAllTypes{}},
+			},
+			MapInt32Int32: map[int32]int32{2: 2},
+		},
+		wantPrune: func() *testpb.TestAllTypes {
+			m := newMessage()
+			m.RepeatedNestedMessage[0].A = nil
+			m.RepeatedNestedMessage[1].A = nil
+			m.MapStringNestedMessage["x"].Corecursive = nil
+			delete(m.MapInt32Int32, 2)
+			return m
+		}(),
+	}, {
+		desc:  "wildcard and named paths",
+		paths: []string{"map_string_nested_message.x.corecursive", "map_string_nested_message.*.a"},
+		wantFilter: &testpb.TestAllTypes{
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"x": {A: proto.Int32(7), Corecursive: &testpb.TestAllTypes{}},
+				"y": {A: proto.Int32(8)},
+			},
+		},
+		wantPrune: func() *testpb.TestAllTypes {
+			m := newMessage()
+			m.MapStringNestedMessage["x"] = &testpb.TestAllTypes_NestedMessage{}
+			m.MapStringNestedMessage["y"].A = nil
+			return m
+		}(),
+	}, {
+		desc:  "wildcard map key",
+		paths: []string{"map_string_nested_message.*.a"},
+		wantFilter: &testpb.TestAllTypes{
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"x": {A: proto.Int32(7)},
+				"y": {A: proto.Int32(8)},
+			},
+		},
+		wantPrune: func() *testpb.TestAllTypes {
+			m := newMessage()
+			m.MapStringNestedMessage["x"].A = nil
+			m.MapStringNestedMessage["y"].A = nil
+			return m
+		}(),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mask := &fmpb.FieldMask{Paths: tt.paths}
+
+			got := newMessage()
+			mask.Filter(got)
+			if diff := cmp.Diff(tt.wantFilter, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Filter() mismatch (-want +got):\n%s", diff)
+			}
+
+			got = newMessage()
+			mask.Prune(got)
+			if diff := cmp.Diff(tt.wantPrune, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Prune() mismatch (-want +got):\n%s", diff)
+			}
+
+			// The same operations apply to dynamic messages.
+			dyn := dynamicpb.NewMessage(got.ProtoReflect().Descriptor())
+			proto.Merge(dyn, newMessage())
+			mask.Filter(dyn)
+			if diff := cmp.Diff(tt.wantFilter, dyn, protocmp.Transform()); diff != "" {
+				t.Errorf("Filter() on dynamic message mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFilterPruneMapKeys(t *testing.T) {
+	newMessage := func() *testpb.TestAllTypes {
+		return &testpb.TestAllTypes{
+			MapStringString: map[string]string{"a.b": "1", "a": "2", "*": "3", "a`b": "4"},
+		}
+	}
+	mask := &fmpb.FieldMask{Paths: []string{"map_string_string.`a.b`", "map_string_string.`*`", "map_string_string.`a``b`"}}
+
+	got := newMessage()
+	mask.Filter(got)
+	want := &testpb.TestAllTypes{
+		MapStringString: map[string]string{"a.b": "1", "*": "3", "a`b": "4"},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Filter() mismatch (-want +got):\n%s", diff)
+	}
+
+	got = newMessage()
+	mask.Prune(got)
+	want = &testpb.TestAllTypes{
+		MapStringString: map[string]string{"a": "2"},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Prune() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	newMessage := func(a int32) *testpb.TestAllExtensions {
+		m := &testpb.TestAllExtensions{}
+		proto.SetExtension(m, testpb.E_OptionalInt32, a)
+		proto.SetExtension(m, testpb.E_OptionalInt64, int64(a))
+		proto.SetExtension(m, testpb.E_OptionalNestedMessage, &testpb.TestAllExtensions_NestedMessage{
+			A:           proto.Int32(a),
+			Corecursive: &testpb.TestAllExtensions{},
+		})
+		return m
+	}
+	mask := &fmpb.FieldMask{Paths: []string{
+		"(goproto.proto.test.optional_int32)",
+		"(goproto.proto.test.optional_nested_message).a",
+	}}
+
+	got := newMessage(1)
+	mask.Filter(got)
+	want := &testpb.TestAllExtensions{}
+	proto.SetExtension(want, testpb.E_OptionalInt32, int32(1))
+	proto.SetExtension(want, testpb.E_OptionalNestedMessage, &testpb.TestAllExtensions_NestedMessage{A: proto.Int32(1)})
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Filter() mismatch (-want +got):\n%s", diff)
+	}
+
+	got = newMessage(1)
+	mask.Prune(got)
+	want = &testpb.TestAllExtensions{}
+	proto.SetExtension(want, testpb.E_OptionalInt64, int64(1))
+	proto.SetExtension(want, testpb.E_OptionalNestedMessage, &testpb.TestAllExtensions_NestedMessage{Corecursive: &testpb.TestAllExtensions{}})
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Prune() mismatch (-want +got):\n%s", diff)
+	}
+
+	got = newMessage(1)
+	mask.Merge(got, newMessage(2))
+	want = newMessage(1)
+	proto.SetExtension(want, testpb.E_OptionalInt32, int32(2))
+	proto.GetExtension(want, testpb.E_OptionalNestedMessage).(*testpb.TestAllExtensions_NestedMessage).A = proto.Int32(2)
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Merge() mismatch (-want +got):\n%s", diff)
+	}
+
+	got = newMessage(1)
+	mask.Merge(got, &testpb.TestAllExtensions{})
+	want = newMessage(1)
+	proto.ClearExtension(want, testpb.E_OptionalInt32)
+	proto.GetExtension(want, testpb.E_OptionalNestedMessage).(*testpb.TestAllExtensions_NestedMessage).A = nil
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Merge() from empty message mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFilterUnknown(t *testing.T) {
+	m := &testpb.TestAllTypes{OptionalInt32: proto.Int32(1)}
+	m.ProtoReflect().SetUnknown(protopack.Message{
+		protopack.Tag{Number: 50000, Type: protopack.VarintType}, protopack.Uvarint(1),
+	}.Marshal())
+
+	(&fmpb.FieldMask{Paths: []string{"*"}}).Filter(m)
+	if len(m.ProtoReflect().GetUnknown()) == 0 {
+		t.Errorf("Filter() with wildcard cleared unknown fields")
+	}
+	(&fmpb.FieldMask{Paths: []string{"optional_int32"}}).Filter(m)
+	if len(m.ProtoReflect().GetUnknown()) != 0 {
+		t.Errorf("Filter() kept unknown fields")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	newDst := func() *testpb.TestAllTypes {
+		return &testpb.TestAllTypes{
+			OptionalInt32:  proto.Int32(1),
+			OptionalString: proto.String("dst"),
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A: proto.Int32(2),
+				Corecursive: &testpb.TestAllTypes{
+					OptionalInt64: proto.Int64(3),
+				},
+			},
+			RepeatedInt32: []int32{1, 2, 3},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(4), Corecursive: &testpb.TestAllTypes{}},
+				{A: proto.Int32(5)},
+			},
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"x": {A: proto.Int32(6), Corecursive: &testpb.TestAllTypes{}},
+				"y": {A: proto.Int32(7)},
+			},
+			OneofField: &testpb.TestAllTypes_OneofUint32{OneofUint32: 8},
+		}
+	}
+	newSrc := func() *testpb.TestAllTypes {
+		return &testpb.TestAllTypes{
+			OptionalInt32: proto.Int32(10),
+			OptionalBytes: []byte("src"),
+			OptionalNestedMessage: &testpb.TestAllTypes_NestedMessage{
+				A: proto.Int32(20),
+			},
+			RepeatedInt32: []int32{10},
+			RepeatedNestedMessage: []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(40)},
+			},
+			MapStringNestedMessage: map[string]*testpb.TestAllTypes_NestedMessage{
+				"x": {A: proto.Int32(60)},
+				"z": {A: proto.Int32(90)},
+			},
+			OneofField: &testpb.TestAllTypes_OneofString{OneofString: "src"},
+		}
+	}
+
+	tests := []struct {
+		desc  string
+		paths []string
+		want  *testpb.TestAllTypes
+	}{{
+		desc:  "empty mask",
+		paths: nil,
+		want:  newDst(),
+	}, {
+		desc:  "wildcard",
+		paths: []string{"*"},
+		want:  newSrc(),
+	}, {
+		desc:  "replace scalar fields",
+		paths: []string{"optional_int32", "optional_string", "optional_bytes"},
+		want: func() *testpb.TestAllTypes {
+			m := newDst()
+			m.OptionalInt32 = proto.Int32(10)
+			m.OptionalString = nil
+			m.OptionalBytes = []byte("src")
+			return m
+		}(),
+	}, {
+		desc:  "replace message field",
+		paths: []string{"optional_nested_message"},
+		want: func() *testpb.TestAllTypes {
+			m := newDst()
+			m.OptionalNestedMessage = &testpb.TestAllTypes_NestedMessage{A: proto.Int32(20)}
+			return m
+		}(),
+	}, {
+		desc:  "update message field",
+		paths: []string{"optional_nested_message.a"},
+		want: func() *testpb.TestAllTypes {
+			m := newDst()
+			m.OptionalNestedMessage.A = proto.Int32(20)
+			return m
+		}(),
+	}, {
+		desc:  "clear within message field",
+		paths: []string{"optional_nested_message.corecursive.optional_int64", "OptionalGroup.a"},
+		want: func() *testpb.TestAllTypes {
+			m := newDst()
+			m.OptionalNestedMessage.Corecursive.OptionalInt64 = nil
+			return m
+		}(),
+	}, {
+		desc:  "replace repeated fields",
+		paths: []string{"repeated_int32", "repeated_nested_message"},
+		want: func() *testpb.TestAllTypes {
+			m := newDst()
+			m.RepeatedInt32 = []int32{10}
+			m.RepeatedNestedMessage = []*testpb.TestAllTypes_NestedMessage{{A: proto.Int32(40)}}
+			return m
+		}(),
+	}, {
+		desc:  "update repeated message field",
+		paths: []string{"repeated_nested_message.a"},
+		want: func() *testpb.TestAllTypes {
+			m := newDst()
+			m.RepeatedNestedMessage = []*testpb.TestAllTypes_NestedMessage{
+				{A: proto.Int32(40), Corecursive: &testpb.TestAllTypes{}},
+			}
+			return m
+		}(),
+	}, {
+		desc:  "replace map field",
+		paths: []string{"map_string_nested_message"},
+		want: func() *testpb.TestAllTypes {
+			m := newDst()
+			m.MapStringNestedMessage = newSrc().MapStringNestedMessage
+			return m
+		}(),
+	}, {
+		desc:  "replace map entries",
+		paths: []string{"map_string_nested_message.x", "map_string_nested_message.y", "map_string_nested_message.z"},
+		want: func() *testpb.TestAllTypes {
+			m := newDst()
+			m.MapStringNestedMessage = newSrc().MapStringNestedMessage
+			return m
+		}(),
+	}, {
+		desc:  "update map entries",
+		paths: []string{"map_string_nested_message.*.a"},
+		want: func() *testpb.TestAllTypes {
+			m := newDst()
+			m.MapStringNestedMessage = map[string]*testpb.TestAllTypes_NestedMessage{
+				"x": {A: proto.Int32(60), Corecursive: &testpb.TestAllTypes{}},
+				"y": {},
+				"z": {A: proto.Int32(90)},
+			}
+			return m
+		}(),
+	}, {
+		desc:  "replace oneof",
+		paths: []string{"oneof_uint32", "oneof_string"},
+		want: func() *testpb.TestAllTypes {
+			m := newDst()
+			m.OneofField = &testpb.TestAllTypes_OneofString{OneofString: "src"}
+			return m
+		}(),
+	}, {
+		desc:  "clear oneof",
+		paths: []string{"oneof_uint32"},
+		want: func() *testpb.TestAllTypes {
+			m := newDst()
+			m.OneofField = nil
+			return m
+		}(),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mask := &fmpb.FieldMask{Paths: tt.paths}
+			src := newSrc()
+
+			got := newDst()
+			mask.Merge(got, src)
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Merge() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(newSrc(), src, protocmp.Transform()); diff != "" {
+				t.Errorf("Merge() modified src (-want +got):\n%s", diff)
+			}
+
+			// Merging must copy the values in src.
+			proto.Reset(src)
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Merge() result aliases src (-want +got):\n%s", diff)
+			}
+
+			// Merging a dynamic message into a generated message.
+			dyn := dynamicpb.NewMessage(src.ProtoReflect().Descriptor())
+			proto.Merge(dyn, newSrc())
+			got = newDst()
+			mask.Merge(got, dyn)
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Merge() from dynamic message mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	tests := []struct {
+		message proto.Message
+		in      [][]string
+		want    []string
+	}{{
+		message: (*testpb.TestAllTypes)(nil),
+		in: [][]string{
+			{"optional_int32", "optional_int64"},
+			{},
+		},
+		want: []string{"optional_int32", "optional_int64"},
+	}, {
+		message: (*testpb.TestAllTypes)(nil),
+		in: [][]string{
+			{"optional_int32", "optional_int64", "optional_nested_message.a"},
+			{"optional_int64"},
+			{"optional_nested_message"},
+		},
+		want: []string{"optional_int32"},
+	}, {
+		message: (*testpb.TestAllTypes)(nil),
+		in: [][]string{
+			{"optional_int32", "optional_nested_message"},
+			{"optional_nested_message.a"},
+		},
+		want: []string{"optional_int32", "optional_nested_message.corecursive"},
+	}, {
+		message: (*testpb.TestAllTypes)(nil),
+		in: [][]string{
+			{"OptionalGroup", "oneof_nested_message.corecursive"},
+			{"OptionalGroup.a", "OptionalGroup.optional_nested_message.a"},
+			{"oneof_nested_message.corecursive.*"},
+		},
+		want: []string{"OptionalGroup.optional_nested_message.corecursive", "OptionalGroup.same_field_number"},
+	}, {
+		message: (*testpb.TestAllTypes)(nil),
+		in: [][]string{
+			{"map_string_nested_message", "optional_int32"},
+			{"map_string_nested_message.x"},
+			{"optional_int32.a"},
+		},
+		want: []string{"map_string_nested_message", "optional_int32"},
+	}, {
+		message: (*testpb.TestAllTypes)(nil),
+		in: [][]string{
+			{"optional_int32", "optional_int64"},
+			{"*"},
+		},
+		want: []string{},
+	}, {
+		message: (*testpb.TestAllTypes)(nil),
+		in: [][]string{
+			{"optional_nested_message", "map_string_nested_message.`a.b`", "map_string_nested_message.`*`"},
+			{"*.a", "map_string_nested_message.`a.b`"},
+		},
+		want: []string{"map_string_nested_message.`*`", "optional_nested_message.corecursive"},
+	}, {
+		message: (*testpb.TestAllExtensions)(nil),
+		in: [][]string{
+			{"(goproto.proto.test.optional_int32)", "(goproto.proto.test.optional_int64)"},
+			{"(goproto.proto.test.optional_int32)"},
+		},
+		want: []string{"(goproto.proto.test.optional_int64)"},
+	}, {
+		in: [][]string{
+			{"optional_int32", "optional_nested_message"},
+			{"optional_int32", "optional_nested_message.a"},
+		},
+		want: []string{"optional_nested_message"},
+	}}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			var masks []*fmpb.FieldMask
+			for _, paths := range tt.in {
+				masks = append(masks, &fmpb.FieldMask{Paths: paths})
+			}
+			got := fmpb.SubtractOptions{Message: tt.message}.Subtract(masks[0], masks[1], masks[2:]...).GetPaths()
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Subtract() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}